@@ -22,17 +22,17 @@ import (
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/e2e-framework/pkg/envconf"
 	"sigs.k8s.io/e2e-framework/pkg/features"
+	"sigs.k8s.io/e2e-framework/pkg/workloads"
 )
 
 func TestKindCluster(t *testing.T) {
 	deploymentFeature := features.New("appsv1/deployment").
 		Setup(func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
 			// start a deployment
-			deployment := newDeployment(cfg.Namespace(), "test-deployment", 1)
+			deployment := workloads.NewDeployment(cfg.Namespace(), "test-deployment", "nginx", 1,
+				workloads.WithLabels(map[string]string{"app": "test-app"}), workloads.WithContainerName("nginx"))
 			if err := cfg.Client().Resources().Create(ctx, deployment); err != nil {
 				t.Fatal(err)
 			}
@@ -59,19 +59,3 @@ func TestKindCluster(t *testing.T) {
 
 	testenv.Test(t, deploymentFeature)
 }
-
-func newDeployment(namespace string, name string, replicaCount int32) *appsv1.Deployment {
-	return &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": "test-app"}},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicaCount,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{"app": "test-app"},
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test-app"}},
-				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "nginx", Image: "nginx"}}},
-			},
-		},
-	}
-}
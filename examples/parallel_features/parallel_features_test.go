@@ -22,8 +22,6 @@ import (
 	"testing"
 
 	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"sigs.k8s.io/e2e-framework/klient/k8s"
 	"sigs.k8s.io/e2e-framework/klient/wait"
@@ -32,6 +30,7 @@ import (
 	"sigs.k8s.io/e2e-framework/pkg/envconf"
 	"sigs.k8s.io/e2e-framework/pkg/envfuncs"
 	"sigs.k8s.io/e2e-framework/pkg/features"
+	"sigs.k8s.io/e2e-framework/pkg/workloads"
 	"sigs.k8s.io/e2e-framework/support/kind"
 )
 
@@ -60,26 +59,10 @@ func TestMain(m *testing.M) {
 	os.Exit(testEnv.Run(m))
 }
 
-func newDeployment(namespace string, name string, replicaCount int32) *appsv1.Deployment {
-	return &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": name}},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicaCount,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{"app": name},
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
-				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: name, Image: "nginx"}}},
-			},
-		},
-	}
-}
-
 func TestPodBringUp(t *testing.T) {
 	featureOne := features.New("Feature One").
 		Assess("Create Nginx Deployment 1", func(ctx context.Context, t *testing.T, config *envconf.Config) context.Context {
-			deployment := newDeployment(namespace, "deployment-1", 2)
+			deployment := workloads.NewDeployment(namespace, "deployment-1", "nginx", 2)
 			err := config.Client().Resources().Create(ctx, deployment)
 			if err != nil {
 				t.Error("failed to create test pod for deployment-1")
@@ -100,7 +83,7 @@ func TestPodBringUp(t *testing.T) {
 
 	featureTwo := features.New("Feature Two").
 		Assess("Create Nginx Deployment 2", func(ctx context.Context, t *testing.T, config *envconf.Config) context.Context {
-			deployment := newDeployment(namespace, "deployment-2", 2)
+			deployment := workloads.NewDeployment(namespace, "deployment-2", "nginx", 2)
 			err := config.Client().Resources().Create(ctx, deployment)
 			if err != nil {
 				t.Error("failed to create test pod for deployment-2")
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+	"sigs.k8s.io/e2e-framework/third_party/certmanager"
+)
+
+func TestCertificateIssuance(t *testing.T) {
+	cert := certmanager.Certificate("web", namespace, issuerName,
+		certmanager.WithSecretName("web-tls"),
+		certmanager.WithDNSNames("web."+namespace+".svc"))
+
+	feature := features.New("Self-signed certificate").
+		Setup(func(ctx context.Context, t *testing.T, c *envconf.Config) context.Context {
+			if _, err := certmanager.Create(cert)(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+			return ctx
+		}).
+		Assess("certificate is issued", func(ctx context.Context, t *testing.T, c *envconf.Config) context.Context {
+			err := wait.For(certmanager.CertificateReady(c.Client().Resources(), "web", namespace), wait.WithContext(ctx), wait.WithTimeout(time.Minute))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var secret v1.Secret
+			if err := c.Client().Resources().Get(ctx, "web-tls", namespace, &secret); err != nil {
+				t.Fatal(err)
+			}
+			if len(secret.Data[v1.TLSCertKey]) == 0 || len(secret.Data[v1.TLSPrivateKeyKey]) == 0 {
+				t.Fatal("certificate secret does not contain a key pair")
+			}
+			return ctx
+		}).
+		Teardown(func(ctx context.Context, t *testing.T, c *envconf.Config) context.Context {
+			if _, err := certmanager.Delete(cert)(ctx, c); err != nil {
+				t.Error(err)
+			}
+			return ctx
+		}).Feature()
+
+	testEnv.Test(t, feature)
+}
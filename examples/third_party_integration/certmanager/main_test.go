@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/envfuncs"
+	"sigs.k8s.io/e2e-framework/support/kind"
+	"sigs.k8s.io/e2e-framework/third_party/certmanager"
+)
+
+var (
+	testEnv         env.Environment
+	namespace       string
+	kindClusterName string
+)
+
+const issuerName = "selfsigned"
+
+func TestMain(m *testing.M) {
+	cfg, _ := envconf.NewFromFlags()
+	testEnv = env.NewWithConfig(cfg)
+	kindClusterName = envconf.RandomName("cert-manager", 16)
+	namespace = envconf.RandomName("cert-manager", 16)
+
+	issuer := certmanager.SelfSignedIssuer(issuerName, namespace)
+	testEnv.Setup(
+		envfuncs.CreateCluster(kind.NewProvider(), kindClusterName),
+		envfuncs.CreateNamespace(namespace),
+		certmanager.Install(),
+		certmanager.Create(issuer),
+		certmanager.WaitForIssuerReady(issuerName, namespace, time.Minute),
+	)
+
+	testEnv.Finish(
+		certmanager.Delete(issuer),
+		certmanager.Uninstall(),
+		envfuncs.DeleteNamespace(namespace),
+		envfuncs.DestroyCluster(kindClusterName),
+	)
+	os.Exit(testEnv.Run(m))
+}
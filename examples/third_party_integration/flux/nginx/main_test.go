@@ -19,6 +19,7 @@ package nginx
 import (
 	"os"
 	"testing"
+	"time"
 
 	"sigs.k8s.io/e2e-framework/pkg/env"
 	"sigs.k8s.io/e2e-framework/pkg/envconf"
@@ -48,6 +49,7 @@ func TestMain(m *testing.M) {
 			"GitRepository/"+gitRepoName+".flux-system",
 			flux.WithPath("examples/third_party_integration/flux/template"),
 			flux.WithArgs("--target-namespace", namespace, "--prune")),
+		flux.WaitForKustomizationReady(ksName, "", 5*time.Minute),
 	)
 
 	testEnv.Finish(
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// gen-kubetest2-flags generates the list of the framework flags that the kubetest2 tester passes
+// through to the tests from the definitions of pkg/flags, so that the tester supports every flag
+// of the framework. It is run from the root of the repository:
+//
+//	go run ./hack/gen-kubetest2-flags
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+
+	"sigs.k8s.io/e2e-framework/pkg/flags"
+)
+
+// DefaultOutput is the path of the generated file, relative to the root of the repository
+const DefaultOutput = "third_party/kubetest2/pkg/tester/zz_generated.flags.go"
+
+const header = `/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by hack/gen-kubetest2-flags. DO NOT EDIT.
+
+package tester
+
+// frameworkFlags are the flags of the e2e-framework passed through to the tests,
+// as defined by sigs.k8s.io/e2e-framework/pkg/flags
+var frameworkFlags = []frameworkFlag{
+`
+
+func main() {
+	output := flag.String("output", DefaultOutput, "Path of the generated file")
+	flag.Parse()
+
+	src, err := generate(flags.Definitions())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the formatted source of the generated file
+func generate(defs []flags.Definition) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(header)
+	for _, def := range defs {
+		fmt.Fprintf(&b, "\t{name: %q, usage: %q, isBool: %t},\n", def.Name, def.Usage, def.Bool)
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apicalls records the requests sent to the API server by the clients of a rest.Config, so the
+// number of API calls, their latency and the time spent in client-side throttling can be reported for
+// each feature and checked against a budget.
+package apicalls
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+type recorderKey struct{}
+
+// Stats are the statistics of the API calls sharing the same verb and resource
+type Stats struct {
+	// Verb is the HTTP method of the calls, such as GET or POST
+	Verb string `json:"verb"`
+	// Resource is the resource targeted by the calls, such as pods or deployments.apps, and the path
+	// of the request for non resource URLs
+	Resource string `json:"resource"`
+	// Count is the number of calls
+	Count int `json:"count"`
+	// Errors is the number of calls that failed or returned a status code of 400 or above
+	Errors int `json:"errors"`
+	// Latency is the cumulated latency of the calls
+	Latency time.Duration `json:"latency"`
+	// MaxLatency is the highest latency of the calls
+	MaxLatency time.Duration `json:"maxLatency"`
+}
+
+// Summary is the summary of the API calls recorded by a Recorder
+type Summary struct {
+	// Total is the number of API calls
+	Total int `json:"total"`
+	// Throttled is the time the calls waited for the client-side rate limiter
+	Throttled time.Duration `json:"throttled"`
+	// Calls are the statistics of the calls, by verb and resource
+	Calls []Stats `json:"calls,omitempty"`
+}
+
+// String returns a short description of the summary, listing the most frequent calls first
+func (s Summary) String() string {
+	calls := make([]string, 0, len(s.Calls))
+	for _, c := range s.Calls {
+		calls = append(calls, fmt.Sprintf("%s %s: %d", c.Verb, c.Resource, c.Count))
+	}
+	return fmt.Sprintf("%d API calls, throttled for %s [%s]", s.Total, s.Throttled, strings.Join(calls, ", "))
+}
+
+// Recorder records the API calls sent with a context returned by WithRecorder. It is safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	calls     map[string]*Stats
+	throttled time.Duration
+}
+
+// NewRecorder returns an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{calls: make(map[string]*Stats)}
+}
+
+// WithRecorder returns a copy of ctx carrying the recorder. The API calls sent with the context, or any context
+// derived from it, by a client instrumented with Instrument are recorded.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+// FromContext returns the recorder carried by ctx, or nil
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return r
+}
+
+func (r *Recorder) recordCall(verb, resource string, latency time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := verb + " " + resource
+	stats, ok := r.calls[key]
+	if !ok {
+		stats = &Stats{Verb: verb, Resource: resource}
+		r.calls[key] = stats
+	}
+	stats.Count++
+	if failed {
+		stats.Errors++
+	}
+	stats.Latency += latency
+	if latency > stats.MaxLatency {
+		stats.MaxLatency = latency
+	}
+}
+
+func (r *Recorder) recordThrottling(wait time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.throttled += wait
+}
+
+// Summary returns the summary of the calls recorded so far, sorted by decreasing number of calls
+func (r *Recorder) Summary() Summary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summary := Summary{Throttled: r.throttled}
+	for _, stats := range r.calls {
+		summary.Total += stats.Count
+		summary.Calls = append(summary.Calls, *stats)
+	}
+	sort.Slice(summary.Calls, func(i, j int) bool {
+		a, b := summary.Calls[i], summary.Calls[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Verb+" "+a.Resource < b.Verb+" "+b.Resource
+	})
+	return summary
+}
+
+// Instrument makes the clients created from cfg record their API calls, and the time they wait for the
+// client-side rate limiter, in the Recorder carried by the context of each request. Requests sent with a
+// context without a recorder are not affected. Unless cfg has a custom rate limiter, whose wait time is not
+// recorded, it gets a rate limiter of its own created from its QPS and Burst, so each instrumented copy of a
+// config is throttled independently as it would be without instrumentation.
+func Instrument(cfg *rest.Config) {
+	instrumented := false
+	if limiter, ok := cfg.RateLimiter.(*rateLimiter); ok {
+		instrumented = true
+		cfg.RateLimiter = nil
+		cfg.QPS, cfg.Burst = limiter.qps, limiter.burst
+	}
+	if cfg.RateLimiter == nil {
+		cfg.RateLimiter = newRateLimiter(cfg.QPS, cfg.Burst)
+	}
+	if !instrumented {
+		cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return &roundTripper{next: rt}
+		})
+	}
+}
+
+// rateLimiter is a token bucket rate limiter recording the time requests wait for it
+type rateLimiter struct {
+	flowcontrol.RateLimiter
+	qps   float32
+	burst int
+}
+
+func newRateLimiter(qps float32, burst int) *rateLimiter {
+	limiter := &rateLimiter{qps: qps, burst: burst}
+	switch {
+	case qps < 0:
+		limiter.RateLimiter = flowcontrol.NewFakeAlwaysRateLimiter()
+	case qps == 0:
+		limiter.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(rest.DefaultQPS, rest.DefaultBurst)
+	default:
+		limiter.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(qps, burst)
+	}
+	return limiter
+}
+
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	start := time.Now()
+	err := l.RateLimiter.Wait(ctx)
+	if r := FromContext(ctx); r != nil {
+		r.recordThrottling(time.Since(start))
+	}
+	return err
+}
+
+// roundTripper records the requests sent with a context carrying a recorder
+type roundTripper struct {
+	next http.RoundTripper
+}
+
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := FromContext(req.Context())
+	if r == nil {
+		return t.next.RoundTrip(req)
+	}
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	verb := req.Method
+	if verb == http.MethodGet && req.URL.Query().Get("watch") == "true" {
+		verb = "WATCH"
+	}
+	r.recordCall(verb, resourceFromPath(req.URL.Path), time.Since(start), err != nil || resp.StatusCode >= http.StatusBadRequest)
+	return resp, err
+}
+
+// resourceFromPath returns the resource targeted by a request path, such as pods for
+// /api/v1/namespaces/default/pods/web or deployments.apps for /apis/apps/v1/deployments, or the
+// path itself for non resource URLs
+func resourceFromPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	var group string
+	switch {
+	case len(parts) >= 3 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 4 && parts[0] == "apis":
+		group, parts = parts[1], parts[3:]
+	default:
+		return path
+	}
+	if len(parts) >= 2 && parts[0] == "namespaces" {
+		if len(parts) == 2 {
+			// a namespace itself
+			parts = parts[:1]
+		} else {
+			parts = parts[2:]
+		}
+	}
+	resource := parts[0]
+	if len(parts) >= 3 {
+		// a subresource of a named resource
+		resource += "/" + parts[2]
+	}
+	if group != "" {
+		resource += "." + group
+	}
+	return resource
+}
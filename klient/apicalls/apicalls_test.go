@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apicalls
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestResourceFromPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/v1/pods", want: "pods"},
+		{path: "/api/v1/namespaces/default/pods/web-0", want: "pods"},
+		{path: "/api/v1/namespaces/default/pods/web-0/log", want: "pods/log"},
+		{path: "/api/v1/namespaces/default", want: "namespaces"},
+		{path: "/api/v1/nodes/node-1", want: "nodes"},
+		{path: "/apis/apps/v1/namespaces/default/deployments", want: "deployments.apps"},
+		{path: "/apis/apps/v1/namespaces/default/deployments/web/scale", want: "deployments/scale.apps"},
+		{path: "/apis/rbac.authorization.k8s.io/v1/clusterroles", want: "clusterroles.rbac.authorization.k8s.io"},
+		{path: "/version", want: "/version"},
+		{path: "/apis", want: "/apis"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			if got := resourceFromPath(tc.path); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/namespaces/default/pods" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"kind":"PodList","apiVersion":"v1","items":[]}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	cfg := &rest.Config{Host: srv.URL, QPS: 20, Burst: 1, ContentConfig: rest.ContentConfig{ContentType: "application/json"}}
+	Instrument(cfg)
+	// instrumenting twice does not record the calls twice
+	Instrument(cfg)
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := NewRecorder()
+	ctx := WithRecorder(context.TODO(), recorder)
+	for i := 0; i < 3; i++ {
+		if _, err := clientset.CoreV1().Pods("default").List(ctx, metav1.ListOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := clientset.CoreV1().Nodes().Get(ctx, "node-1", metav1.GetOptions{}); err == nil {
+		t.Fatal("expected a not found error")
+	}
+	// calls without a recorder are not recorded
+	if _, err := clientset.CoreV1().Pods("default").List(context.TODO(), metav1.ListOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	summary := recorder.Summary()
+	if summary.Total != 4 || len(summary.Calls) != 2 {
+		t.Fatalf("unexpected summary %s", summary)
+	}
+	if c := summary.Calls[0]; c.Verb != "GET" || c.Resource != "pods" || c.Count != 3 || c.Errors != 0 {
+		t.Errorf("unexpected pod calls %+v", c)
+	}
+	if c := summary.Calls[1]; c.Resource != "nodes" || c.Count != 1 || c.Errors != 1 {
+		t.Errorf("unexpected node calls %+v", c)
+	}
+	// a burst of 1 at 20 QPS throttles the calls following the first one
+	if summary.Throttled == 0 {
+		t.Error("expected the calls to be throttled")
+	}
+}
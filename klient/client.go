@@ -17,7 +17,12 @@ limitations under the License.
 package klient
 
 import (
+	"errors"
+
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	klog "k8s.io/klog/v2"
 	cr "sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,11 +40,20 @@ type Client interface {
 	// This method takes zero or at most 1 namespace (more will panic) that
 	// can be used in List operations.
 	Resources(...string) *resources.Resources
+	// Clientset returns the typed clientset of the API server, to use the APIs not
+	// covered by Resources such as the logs of the pods.
+	Clientset() kubernetes.Interface
+	// Discovery returns the discovery client of the API server.
+	Discovery() discovery.DiscoveryInterface
+	// Dynamic returns the dynamic client of the API server.
+	Dynamic() dynamic.Interface
 }
 
 type client struct {
 	cfg       *rest.Config
 	resources *resources.Resources
+	clientset kubernetes.Interface
+	dynamic   dynamic.Interface
 }
 
 // NewControllerRuntimeClient provides an instance of the Controller runtime client with
@@ -54,7 +68,38 @@ func New(cfg *rest.Config) (Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &client{cfg: cfg, resources: res}, nil
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	dyn, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &client{cfg: cfg, resources: res, clientset: clientset, dynamic: dyn}, nil
+}
+
+// NewWithImpersonation returns a new Client whose requests impersonate the given user and
+// groups, which can be used to verify the RBAC rules of restricted users. cfg is not modified
+// and its user must be allowed to impersonate them.
+func NewWithImpersonation(cfg *rest.Config, user string, groups ...string) (Client, error) {
+	if cfg == nil {
+		return nil, errors.New("must provide rest.Config")
+	}
+	impersonated := rest.CopyConfig(cfg)
+	impersonated.Impersonate = rest.ImpersonationConfig{UserName: user, Groups: groups}
+	return New(impersonated)
+}
+
+// NewWithToken returns a new Client authenticating with the bearer token, such as the token of
+// a service account, instead of the credentials of cfg. cfg is not modified.
+func NewWithToken(cfg *rest.Config, token string) (Client, error) {
+	if cfg == nil {
+		return nil, errors.New("must provide rest.Config")
+	}
+	tokenCfg := rest.AnonymousClientConfig(cfg)
+	tokenCfg.BearerToken = token
+	return New(tokenCfg)
 }
 
 // NewWithKubeConfigFile creates a client using the kubeconfig filePath
@@ -66,6 +111,17 @@ func NewWithKubeConfigFile(filePath string) (Client, error) {
 	return New(cfg)
 }
 
+// NewWithKubeConfigContext creates a client using the named context of the kubeconfig filePath,
+// which may list several files to merge as KUBECONFIG does. The current context is used when
+// contextName is empty.
+func NewWithKubeConfigContext(filePath, contextName string) (Client, error) {
+	cfg, err := conf.NewFromContext(filePath, contextName)
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg)
+}
+
 // RESTConfig returns the *rest.Config value associated
 // with this client.
 func (c *client) RESTConfig() *rest.Config {
@@ -74,17 +130,34 @@ func (c *client) RESTConfig() *rest.Config {
 
 // Resources returns *Resources value to access CRUD object
 // operations. It takes 0 or, at most, 1 namespace, or panics.
+// Each call returns a copy, which can be modified with WithNamespace
+// without affecting the other callers.
 func (c *client) Resources(namespace ...string) *resources.Resources {
 	switch len(namespace) {
 	case 0:
-		return c.resources.WithNamespace("")
+		return c.resources.InNamespace("")
 	case 1:
-		return c.resources.WithNamespace(namespace[0])
+		return c.resources.InNamespace(namespace[0])
 	default:
 		panic("too many namespaces provided")
 	}
 }
 
+// Clientset returns the typed clientset created along with the client
+func (c *client) Clientset() kubernetes.Interface {
+	return c.clientset
+}
+
+// Discovery returns the discovery client of the clientset
+func (c *client) Discovery() discovery.DiscoveryInterface {
+	return c.clientset.Discovery()
+}
+
+// Dynamic returns the dynamic client created along with the client
+func (c *client) Dynamic() dynamic.Interface {
+	return c.dynamic
+}
+
 func init() {
 	log.SetLogger(klog.NewKlogr())
 }
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package klient
+
+import (
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestNewWithImpersonationAndToken(t *testing.T) {
+	cfg := &rest.Config{Host: "https://127.0.0.1:6443", Username: "admin", Password: "secret"}
+
+	impersonated, err := NewWithImpersonation(cfg, "jane", "developers", "qa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	imp := impersonated.RESTConfig().Impersonate
+	if imp.UserName != "jane" || len(imp.Groups) != 2 || imp.Groups[0] != "developers" {
+		t.Errorf("unexpected impersonation config %+v", imp)
+	}
+	if impersonated.RESTConfig().Username != "admin" {
+		t.Error("expected the credentials of the impersonating user to be kept")
+	}
+
+	withToken, err := NewWithToken(cfg, "sa-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenCfg := withToken.RESTConfig()
+	if tokenCfg.BearerToken != "sa-token" || tokenCfg.Username != "" || tokenCfg.Password != "" || tokenCfg.Host != cfg.Host {
+		t.Errorf("unexpected token config %+v", tokenCfg)
+	}
+
+	if cfg.Impersonate.UserName != "" || cfg.BearerToken != "" {
+		t.Error("expected the original config not to be modified")
+	}
+	if _, err := NewWithImpersonation(nil, "jane"); err == nil {
+		t.Error("expected an error for a nil config")
+	}
+}
+
+func TestClientAccessors(t *testing.T) {
+	c, err := New(&rest.Config{Host: "https://127.0.0.1:6443"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Clientset() == nil || c.Discovery() == nil || c.Dynamic() == nil {
+		t.Fatal("expected the clients to be created along with the client")
+	}
+	if c.Clientset() != c.Clientset() || c.Dynamic() != c.Dynamic() {
+		t.Error("expected the clients to be cached")
+	}
+}
+
+func TestClientResources(t *testing.T) {
+	c, err := New(&rest.Config{Host: "https://127.0.0.1:6443"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// scoping the resources of a caller with WithNamespace must not affect the other callers
+	if c.Resources("apps") == c.Resources("apps") {
+		t.Error("expected each call to return a copy of the resources")
+	}
+}
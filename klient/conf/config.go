@@ -20,9 +20,13 @@ package conf
 import (
 	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"os/user"
 	"path"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -72,12 +76,85 @@ func NewWithContextName(fileName, context string) (*rest.Config, error) {
 		}).ClientConfig()
 }
 
+// NewFromContext returns k8s config value of type *rest.Config for the named context of the kubeconfig.
+// kubeconfig may list several files separated by the OS path list separator, as KUBECONFIG does, in which
+// case they are merged. The kubeconfig is resolved with ResolveKubeConfigFile when empty, and the current
+// context is used when contextName is empty.
+func NewFromContext(kubeconfig, contextName string) (*rest.Config, error) {
+	rules, err := loadingRules(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules,
+		&clientcmd.ConfigOverrides{CurrentContext: contextName}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("kubeconfig context %q: %w", contextName, err)
+	}
+	return cfg, nil
+}
+
+// ListContexts returns the sorted names of the contexts defined in the kubeconfig, which may list several
+// files as with NewFromContext. The kubeconfig is resolved with ResolveKubeConfigFile when empty.
+func ListContexts(kubeconfig string) ([]string, error) {
+	rules, err := loadingRules(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	config, err := rules.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load kubeconfig: %w", err)
+	}
+	contexts := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return contexts, nil
+}
+
+// loadingRules returns the rules to load the kubeconfig, which may be a list of files to merge
+func loadingRules(kubeconfig string) (*clientcmd.ClientConfigLoadingRules, error) {
+	if kubeconfig == "" {
+		kubeconfig = ResolveKubeConfigFile()
+	}
+	if kubeconfig == "" {
+		return nil, errors.New("no kubeconfig file found")
+	}
+	if files := filepath.SplitList(kubeconfig); len(files) > 1 {
+		return &clientcmd.ClientConfigLoadingRules{Precedence: files}, nil
+	}
+	return &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig}, nil
+}
+
 // NewInCluster for clients that expect to be
 // running inside a pod on kubernetes
 func NewInCluster() (*rest.Config, error) {
 	return rest.InClusterConfig()
 }
 
+// serviceAccountDir is where the credentials of the service account of a pod are mounted
+var serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// IsInCluster reports whether the process runs inside a pod of a kubernetes cluster with the
+// credentials of its service account mounted, which is when NewInCluster can be used.
+func IsInCluster() bool {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" || os.Getenv("KUBERNETES_SERVICE_PORT") == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(serviceAccountDir, "token"))
+	return err == nil
+}
+
+// InClusterNamespace returns the namespace of the pod the process runs in, as mounted with the
+// credentials of its service account, or an empty string when it is not running in a pod.
+func InClusterNamespace() string {
+	data, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
 // ResolveKubeConfigFile returns the kubeconfig file from
 // either flag --kubeconfig or env KUBECONFIG.
 // If flag.Parsed() is true then lookup for --kubeconfig flag.
@@ -127,13 +204,15 @@ func ResolveKubeConfigFile() string {
 	return ""
 }
 
-// ResolveClusterContext returns cluster context name based on --context flag.
+// ResolveClusterContext returns cluster context name based on --context flag, or its
+// --kube-context alias.
 func ResolveClusterContext() string {
 	// If a flag --context is specified use that
 	if flag.Parsed() {
-		f := flag.Lookup("context")
-		if f != nil {
-			return f.Value.String()
+		for _, name := range []string{"context", "kube-context"} {
+			if f := flag.Lookup(name); f != nil && f.Value.String() != "" {
+				return f.Value.String()
+			}
 		}
 	}
 
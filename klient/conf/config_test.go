@@ -17,7 +17,9 @@ limitations under the License.
 package conf
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"k8s.io/client-go/util/homedir"
@@ -55,3 +57,83 @@ func TestNewWithContextName(t *testing.T) {
 		t.Errorf("client config is nill")
 	}
 }
+
+func TestNewFromContext(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first")
+	second := filepath.Join(dir, "second")
+	if err := createFile(first, genKubeconfig("https://cluster-a", "https://cluster-b")); err != nil {
+		t.Fatal(err)
+	}
+	if err := createFile(second, genKubeconfig("https://cluster-c")); err != nil {
+		t.Fatal(err)
+	}
+	merged := strings.Join([]string{first, second}, string(os.PathListSeparator))
+
+	contexts, err := ListContexts(merged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(contexts, ",") != "https://cluster-a,https://cluster-b,https://cluster-c" {
+		t.Errorf("unexpected contexts %v", contexts)
+	}
+
+	tests := []struct {
+		name       string
+		kubeconfig string
+		context    string
+		wantHost   string
+		wantErr    bool
+	}{
+		{name: "current context", kubeconfig: first, wantHost: "https://cluster-a"},
+		{name: "named context", kubeconfig: first, context: "https://cluster-b", wantHost: "https://cluster-b"},
+		{name: "context of a merged file", kubeconfig: merged, context: "https://cluster-c", wantHost: "https://cluster-c"},
+		{name: "unknown context", kubeconfig: first, context: "missing", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewFromContext(tt.kubeconfig, tt.context)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Host != tt.wantHost {
+				t.Errorf("expected host %q, got %q", tt.wantHost, cfg.Host)
+			}
+		})
+	}
+}
+
+func TestIsInCluster(t *testing.T) {
+	saDir := t.TempDir()
+	if err := createFile(filepath.Join(saDir, "namespace"), "e2e-runner\n"); err != nil {
+		t.Fatal(err)
+	}
+	defer func(dir string) { serviceAccountDir = dir }(serviceAccountDir)
+	serviceAccountDir = saDir
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.96.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "443")
+	if IsInCluster() {
+		t.Error("expected not to be in cluster without a service account token")
+	}
+	if err := createFile(filepath.Join(saDir, "token"), "token"); err != nil {
+		t.Fatal(err)
+	}
+	if !IsInCluster() {
+		t.Error("expected to be in cluster")
+	}
+	if ns := InClusterNamespace(); ns != "e2e-runner" {
+		t.Errorf("expected namespace e2e-runner, got %q", ns)
+	}
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	if IsInCluster() {
+		t.Error("expected not to be in cluster without the service environment variables")
+	}
+}
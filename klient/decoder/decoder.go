@@ -26,9 +26,10 @@ import (
 	"io/fs"
 	"os"
 	"strings"
+	"text/template"
 
-	"github.com/vladimirvivien/gexe/http"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -36,10 +37,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/kubernetes/scheme"
 	klog "k8s.io/klog/v2"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/e2e-framework/klient/k8s"
 	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	sigsyaml "sigs.k8s.io/yaml"
 )
 
 // Options are a set of configurations used to instruct the decoding process and otherwise
@@ -47,6 +48,18 @@ import (
 type Options struct {
 	DefaultGVK  *schema.GroupVersionKind
 	MutateFuncs []MutateFunc
+	// Checksum is the expected SHA-256 checksum of the manifests fetched by DecodeURL
+	Checksum string
+	// CacheDir is the directory the manifests fetched by DecodeURL are cached in
+	CacheDir string
+	// TemplateFuncs are the additional functions available to the templates rendered by DecodeWithTemplate
+	TemplateFuncs template.FuncMap
+	// Strict makes decoding fail on unknown or duplicate fields
+	Strict bool
+	// ValidateFuncs are run against each decoded object before it is passed to the HandlerFunc
+	ValidateFuncs []ValidateFunc
+	// ContinueOnError makes decoding carry on after a failed document and return all the errors at the end
+	ContinueOnError bool
 }
 
 // DecodeOption is a function that alters the configuration Options used to decode and optionally mutate objects via MutateFuncs
@@ -60,28 +73,38 @@ type MutateFunc func(obj k8s.Object) error
 type HandlerFunc func(ctx context.Context, obj k8s.Object) error
 
 // DecodeEachFile resolves files at the filesystem matching the pattern, decoding JSON or YAML files. Supports multi-document files.
+// Errors identify the file, the position of the document in the file and the object that failed.
 //
-// If handlerFn returns an error, decoding is halted.
+// If handlerFn returns an error, decoding is halted, unless the ContinueOnError option is provided.
 // Options may be provided to configure the behavior of the decoder.
 func DecodeEachFile(ctx context.Context, fsys fs.FS, pattern string, handlerFn HandlerFunc, options ...DecodeOption) error {
+	decodeOpt := &Options{}
+	for _, opt := range options {
+		opt(decodeOpt)
+	}
 	files, err := fs.Glob(fsys, pattern)
 	if err != nil {
 		return err
 	}
+	var errs []error
 	for _, file := range files {
 		f, err := fsys.Open(file)
 		if err != nil {
-			return err
+			return errors.Join(append(errs, err)...)
 		}
 		defer f.Close()
 		if err := DecodeEach(ctx, f, handlerFn, options...); err != nil {
-			return fmt.Errorf("failed to decode file %q: %w", file, err)
+			err = fmt.Errorf("failed to decode file %q: %w", file, err)
+			if !decodeOpt.ContinueOnError {
+				return err
+			}
+			errs = append(errs, err)
 		}
 		if err := f.Close(); err != nil {
-			return err
+			return errors.Join(append(errs, err)...)
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // DecodeAllFiles resolves files at the filesystem matching the pattern, decoding JSON or YAML files. Supports multi-document files.
@@ -112,33 +135,76 @@ func DeleteWithManifestDir(ctx context.Context, r *resources.Resources, dirPath,
 
 // DecodeEach a stream of documents of any Kind using either the innate typing of the scheme.
 // Falls back to the unstructured.Unstructured type if a matching type cannot be found for the Kind.
+// Documents are handled in the order they appear in the stream, and errors identify the position of
+// the document, starting at 1, and the object that failed.
 //
-// If handlerFn returns an error, decoding is halted.
+// If handlerFn returns an error, decoding is halted, unless the ContinueOnError option is provided.
 // Options may be provided to configure the behavior of the decoder.
 func DecodeEach(ctx context.Context, manifest io.Reader, handlerFn HandlerFunc, options ...DecodeOption) error {
+	decodeOpt := &Options{}
+	for _, opt := range options {
+		opt(decodeOpt)
+	}
 	decoder := yaml.NewYAMLReader(bufio.NewReader(manifest))
-	for {
+	var errs []error
+	for index := 1; ; index++ {
 		b, err := decoder.Read()
 		if errors.Is(err, io.EOF) {
 			break
 		} else if err != nil {
-			return err
+			return errors.Join(append(errs, fmt.Errorf("document %d: %w", index, err))...)
 		}
-		obj, err := DecodeAny(bytes.NewReader(b), options...)
-		if err != nil {
-			// Skip the Missing Kind entries. This will avoid unwanted failures of the yaml apply workflow in cases
-			// if the file has an empty item with just comments in it.
-			if runtime.IsMissingKind(err) {
-				klog.V(2).InfoS("Skipping document with missing Kind", "document", strings.TrimSpace(string(b)))
-				continue
+		if err := decodeDocument(ctx, b, handlerFn, decodeOpt, options); err != nil {
+			err = fmt.Errorf("document %d%s: %w", index, describeDocument(b), err)
+			if !decodeOpt.ContinueOnError {
+				return err
 			}
-			return err
+			errs = append(errs, err)
 		}
-		if err := handlerFn(ctx, obj); err != nil {
-			return err
+	}
+	return errors.Join(errs...)
+}
+
+// decodeDocument decodes, validates and handles a single document
+func decodeDocument(ctx context.Context, document []byte, handlerFn HandlerFunc, decodeOpt *Options, options []DecodeOption) error {
+	obj, err := DecodeAny(bytes.NewReader(document), options...)
+	if err != nil {
+		// Skip the Missing Kind entries. This will avoid unwanted failures of the yaml apply workflow in cases
+		// if the file has an empty item with just comments in it.
+		if runtime.IsMissingKind(err) {
+			klog.V(2).InfoS("Skipping document with missing Kind", "document", strings.TrimSpace(string(document)))
+			return nil
 		}
+		return err
+	}
+	for _, validate := range decodeOpt.ValidateFuncs {
+		if err := validate(ctx, obj); err != nil {
+			return fmt.Errorf("invalid object: %w", err)
+		}
+	}
+	return handlerFn(ctx, obj)
+}
+
+// describeDocument identifies the object of a document by its kind and name, it returns an empty string
+// if the document can't be parsed
+func describeDocument(document []byte) string {
+	var partial metav1.PartialObjectMetadata
+	if err := sigsyaml.Unmarshal(document, &partial); err != nil || partial.Kind == "" {
+		return ""
+	}
+	name := partial.Name
+	if partial.Namespace != "" {
+		name = partial.Namespace + "/" + name
+	}
+	return fmt.Sprintf(" (%s %s %s)", partial.APIVersion, partial.Kind, name)
+}
+
+// ContinueOnError makes decoding carry on with the next documents and files when decoding, validating or
+// handling a document fails. All the errors are returned together once every document has been processed.
+func ContinueOnError() DecodeOption {
+	return func(options *Options) {
+		options.ContinueOnError = true
 	}
-	return nil
 }
 
 // DecodeAll is a stream of documents of any Kind using either the innate typing of the scheme.
@@ -162,7 +228,11 @@ func DecodeAny(manifest io.Reader, options ...DecodeOption) (k8s.Object, error)
 		opt(decodeOpt)
 	}
 
-	k8sDecoder := serializer.NewCodecFactory(scheme.Scheme).UniversalDeserializer().Decode
+	var codecOpts []serializer.CodecFactoryOptionsMutator
+	if decodeOpt.Strict {
+		codecOpts = append(codecOpts, serializer.EnableStrict)
+	}
+	k8sDecoder := serializer.NewCodecFactory(scheme.Scheme, codecOpts...).UniversalDeserializer().Decode
 	b, err := io.ReadAll(manifest)
 	if err != nil {
 		return nil, err
@@ -196,7 +266,15 @@ func Decode(manifest io.Reader, obj k8s.Object, options ...DecodeOption) error {
 	for _, opt := range options {
 		opt(decodeOpt)
 	}
-	if err := yaml.NewYAMLOrJSONDecoder(manifest, 1024).Decode(obj); err != nil {
+	if decodeOpt.Strict {
+		b, err := io.ReadAll(manifest)
+		if err != nil {
+			return err
+		}
+		if err := sigsyaml.UnmarshalStrict(b, obj); err != nil {
+			return err
+		}
+	} else if err := yaml.NewYAMLOrJSONDecoder(manifest, 1024).Decode(obj); err != nil {
 		return err
 	}
 	for _, patch := range decodeOpt.MutateFuncs {
@@ -220,14 +298,29 @@ func DecodeFile(fsys fs.FS, manifestPath string, obj k8s.Object, options ...Deco
 
 // DecodeURL decodes a document from the URL of any Kind using either the innate typing of the scheme.
 // Falls back to the unstructured.Unstructured type if a matching type cannot be found for the Kind.
+//
+// The content can be pinned with WithChecksum and cached locally with WithCacheDir.
 func DecodeURL(ctx context.Context, url string, handlerFn HandlerFunc, options ...DecodeOption) error {
-	resp := http.Get(url).Do()
-	if resp.Err() != nil {
-		return resp.Err()
+	opts := Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	data, err := fetchURL(ctx, url, &opts)
+	if err != nil {
+		return err
 	}
-	defer resp.Body().Close()
 
-	return DecodeEach(ctx, resp.Body(), handlerFn, options...)
+	return DecodeEach(ctx, bytes.NewReader(data), handlerFn, options...)
+}
+
+// ApplyWithManifestURL fetches the manifest at url and creates a kubernetes resource for each of the resources it contains.
+func ApplyWithManifestURL(ctx context.Context, r *resources.Resources, url string, createOptions []resources.CreateOption, options ...DecodeOption) error {
+	return DecodeURL(ctx, url, CreateHandler(r, createOptions...), options...)
+}
+
+// DeleteWithManifestURL does the reverse of ApplyWithManifestURL and deletes the resources found in the manifest at url.
+func DeleteWithManifestURL(ctx context.Context, r *resources.Resources, url string, deleteOptions []resources.DeleteOption, options ...DecodeOption) error {
+	return DecodeURL(ctx, url, DeleteHandler(r, deleteOptions...), options...)
 }
 
 // DecodeString decodes a single-document YAML or JSON string into the provided object. Patches are applied
@@ -283,10 +376,10 @@ func MutateAnnotations(overrides map[string]string) DecodeOption {
 }
 
 // MutateOwnerAnnotations is an optional parameter to decoding functions that will patch objects using the given owner object
+//
+// Deprecated: despite its name this adds an owner reference, use MutateOwnerReferences instead.
 func MutateOwnerAnnotations(owner k8s.Object) DecodeOption {
-	return MutateOption(func(obj k8s.Object) error {
-		return controllerutil.SetOwnerReference(owner, obj, scheme.Scheme)
-	})
+	return MutateOwnerReferences(owner)
 }
 
 // MutateNamespace is an optional parameter to decoding functions that will patch objects with the given namespace name
@@ -18,14 +18,19 @@ package decoder_test
 
 import (
 	"context"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -250,6 +255,243 @@ func TestDecodeURL(t *testing.T) {
 	})
 }
 
+func TestDecodeURLWithChecksumAndCache(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, testDataExampleMultiDoc)
+	}))
+	defer ts.Close()
+	sum := sha256.Sum256([]byte(testDataExampleMultiDoc))
+	checksum := hex.EncodeToString(sum[:])
+	noop := func(ctx context.Context, obj k8s.Object) error { return nil }
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		err := decoder.DecodeURL(context.TODO(), ts.URL, noop, decoder.WithChecksum("sha256:0000"))
+		if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Fatalf("expected a checksum mismatch, got: %v", err)
+		}
+	})
+
+	t.Run("cached download", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		requests = 0
+		for i := 0; i < 2; i++ {
+			count := 0
+			err := decoder.DecodeURL(context.TODO(), ts.URL, func(ctx context.Context, obj k8s.Object) error {
+				count++
+				return nil
+			}, decoder.WithChecksum("sha256:"+checksum), decoder.WithCacheDir(cacheDir))
+			if err != nil {
+				t.Fatal(err)
+			} else if count != 2 {
+				t.Fatalf("expected 2 documents, got: %d", count)
+			}
+		}
+		if requests != 1 {
+			t.Fatalf("expected the manifest to be downloaded once, got %d requests", requests)
+		}
+	})
+
+	t.Run("unexpected status", func(t *testing.T) {
+		notFound := httptest.NewServer(http.NotFoundHandler())
+		defer notFound.Close()
+		if err := decoder.DecodeURL(context.TODO(), notFound.URL, noop); err == nil {
+			t.Fatal("expected an error for a missing manifest")
+		}
+	})
+}
+
+func TestDecodeWithTemplate(t *testing.T) {
+	values := map[string]any{"Name": "nginx", "Namespace": "", "Replicas": 3, "Image": "nginx:1.27"}
+	var deployments []*appsv1.Deployment
+	err := decoder.DecodeWithTemplate(context.TODO(), os.DirFS("testdata/templates"), "*.yaml", values, func(ctx context.Context, obj k8s.Object) error {
+		deployment, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			t.Fatalf("unexpected type returned not Deployment: %T", obj)
+		}
+		deployments = append(deployments, deployment)
+		return nil
+	}, decoder.MutateLabels(map[string]string{"test": "template"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deployments) != 1 {
+		t.Fatalf("expected 1 deployment, got: %d", len(deployments))
+	}
+	d := deployments[0]
+	if d.Name != "nginx" || d.Namespace != "default" || *d.Spec.Replicas != 3 ||
+		d.Spec.Template.Spec.Containers[0].Image != "nginx:1.27" || d.Labels["test"] != "template" {
+		t.Errorf("unexpected deployment rendered: %+v", d)
+	}
+
+	delete(values, "Image")
+	err = decoder.DecodeWithTemplate(context.TODO(), os.DirFS("testdata/templates"), "*.yaml", values, decoder.NoopHandler(nil))
+	if err == nil {
+		t.Error("expected an error for a missing template value")
+	}
+}
+
+func TestStrictDecoding(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: strict
+data:
+  foo: bar
+unknownField: value
+`
+	if _, err := decoder.DecodeAny(strings.NewReader(manifest)); err != nil {
+		t.Fatalf("unexpected error without strict decoding: %v", err)
+	}
+	if _, err := decoder.DecodeAny(strings.NewReader(manifest), decoder.WithStrictDecoding()); err == nil {
+		t.Error("expected DecodeAny to fail on an unknown field")
+	}
+	if err := decoder.DecodeString(manifest, &v1.ConfigMap{}, decoder.WithStrictDecoding()); err == nil {
+		t.Error("expected DecodeString to fail on an unknown field")
+	}
+}
+
+func TestOpenAPIValidation(t *testing.T) {
+	res, err := resources.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the selector of the deployment does not match the labels of its template
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: invalid
+  namespace: default
+spec:
+  selector:
+    matchLabels:
+      app: foo
+  template:
+    metadata:
+      labels:
+        app: bar
+    spec:
+      containers:
+      - name: nginx
+        image: nginx
+`
+	handled := false
+	err = decoder.DecodeEach(ctx, strings.NewReader(manifest), func(ctx context.Context, obj k8s.Object) error {
+		handled = true
+		return nil
+	}, decoder.WithOpenAPIValidation(res))
+	if !apierrors.IsInvalid(err) {
+		t.Errorf("expected an invalid error, got: %v", err)
+	}
+	if handled {
+		t.Error("expected the invalid object not to be handled")
+	}
+	var deployment appsv1.Deployment
+	if err := res.Get(ctx, "invalid", "default", &deployment); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the validation not to create the deployment, got: %v", err)
+	}
+}
+
+func TestDecodeEachErrorContext(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+  namespace: default
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: third
+`
+	failing := func(ctx context.Context, obj k8s.Object) error {
+		if obj.GetName() == "first" {
+			return nil
+		}
+		return errors.New("handler failed")
+	}
+
+	err := decoder.DecodeEach(context.TODO(), strings.NewReader(manifest), failing)
+	if err == nil || err.Error() != "document 2 (v1 ConfigMap default/second): handler failed" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var handled []string
+	err = decoder.DecodeEach(context.TODO(), strings.NewReader(manifest), func(ctx context.Context, obj k8s.Object) error {
+		handled = append(handled, obj.GetName())
+		return failing(ctx, obj)
+	}, decoder.ContinueOnError())
+	if err == nil || !strings.Contains(err.Error(), "document 2 ") || !strings.Contains(err.Error(), "document 3 (v1 ConfigMap third)") {
+		t.Fatalf("expected the errors of both failing documents, got: %v", err)
+	}
+	if strings.Join(handled, ",") != "first,second,third" {
+		t.Errorf("expected the documents to be handled in order, got: %v", handled)
+	}
+}
+
+func TestMutateWorkload(t *testing.T) {
+	manifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: controller
+  template:
+    metadata:
+      labels:
+        app: controller
+    spec:
+      initContainers:
+      - name: init
+        image: registry.k8s.io/controller:v1.0.0
+      containers:
+      - name: controller
+        image: registry.k8s.io/controller@sha256:0123
+        args: ["--v=2"]
+      - name: sidecar
+        image: registry.k8s.io/controller-sidecar:v1.0.0
+`
+	deployment := &appsv1.Deployment{}
+	err := decoder.DecodeString(manifest, deployment,
+		decoder.MutateName("test-", "-1"),
+		decoder.MutateImage("registry.k8s.io/controller", "controller:dev"),
+		decoder.MutateField("spec.replicas", 3),
+		decoder.MutateField("{.spec.template.spec.containers[0].args}", []string{"--v=4"}),
+		decoder.MutateField(".metadata.annotations.owner", "e2e"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := deployment.Spec.Template.Spec
+	if deployment.Name != "test-controller-1" {
+		t.Errorf("unexpected name %q", deployment.Name)
+	}
+	if spec.InitContainers[0].Image != "controller:dev" || spec.Containers[0].Image != "controller:dev" {
+		t.Errorf("expected the controller images to be replaced, got %q and %q", spec.InitContainers[0].Image, spec.Containers[0].Image)
+	}
+	if spec.Containers[1].Image != "registry.k8s.io/controller-sidecar:v1.0.0" {
+		t.Errorf("expected the sidecar image to be kept, got %q", spec.Containers[1].Image)
+	}
+	if *deployment.Spec.Replicas != 3 || strings.Join(spec.Containers[0].Args, " ") != "--v=4" || deployment.Annotations["owner"] != "e2e" {
+		t.Errorf("expected the fields to be set, got %+v", deployment)
+	}
+
+	for _, path := range []string{"", "spec.template.spec.containers[5].image", "spec.missing[0]", "spec..replicas"} {
+		if err := decoder.DecodeString(manifest, &appsv1.Deployment{}, decoder.MutateField(path, "value")); err == nil {
+			t.Errorf("expected an error setting field %q", path)
+		}
+	}
+}
+
 func TestDecodeAll(t *testing.T) {
 	for _, file := range []string{"example-multidoc-1.yaml", "example-multidoc-emptyitemcomment.yaml"} {
 		t.Run(fmt.Sprintf("Testing multi doc with %s", file), func(t *testing.T) {
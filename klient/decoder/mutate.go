@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+)
+
+// containerFields are the fields holding containers in a pod spec
+var containerFields = []string{"containers", "initContainers", "ephemeralContainers"}
+
+// MutateOwnerReferences is an optional parameter to decoding functions that will add an owner reference to
+// the given owner object, so that the decoded objects are garbage collected once owner is deleted
+func MutateOwnerReferences(owner k8s.Object) DecodeOption {
+	return MutateOption(func(obj k8s.Object) error {
+		return controllerutil.SetOwnerReference(owner, obj, scheme.Scheme)
+	})
+}
+
+// MutateName is an optional parameter to decoding functions that will add a prefix and a suffix to the
+// name of the objects, for instance to avoid name clashes between features sharing manifests
+func MutateName(prefix, suffix string) DecodeOption {
+	return MutateOption(func(obj k8s.Object) error {
+		obj.SetName(prefix + obj.GetName() + suffix)
+		return nil
+	})
+}
+
+// MutateImage is an optional parameter to decoding functions that will replace the image of the containers,
+// init containers and ephemeral containers of workloads. An image is replaced if it is equal to oldImage, or
+// if oldImage has no tag nor digest and matches the repository of the image.
+//
+//	decoder.MutateImage("registry.k8s.io/my-controller", "my-controller:dev")
+func MutateImage(oldImage, newImage string) DecodeOption {
+	return MutateOption(func(obj k8s.Object) error {
+		return mutateContent(obj, func(content map[string]interface{}) error {
+			replaceImages(content, oldImage, newImage)
+			return nil
+		})
+	})
+}
+
+// MutateField is an optional parameter to decoding functions that will set the field at path to value.
+// The path is a JSONPath-like expression made of field names separated by dots, where list items are
+// selected by their index, for instance "spec.replicas" or "{.spec.template.spec.containers[0].args}".
+// Missing intermediate fields are created, list items must exist. The value must be serializable to JSON.
+func MutateField(path string, value interface{}) DecodeOption {
+	return MutateOption(func(obj k8s.Object) error {
+		segments, err := parseFieldPath(path)
+		if err != nil {
+			return err
+		}
+		jsonValue, err := toJSONValue(value)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", path, err)
+		}
+		return mutateContent(obj, func(content map[string]interface{}) error {
+			if err := setField(content, segments, jsonValue); err != nil {
+				return fmt.Errorf("field %s: %w", path, err)
+			}
+			return nil
+		})
+	})
+}
+
+// mutateContent applies fn to the serialized form of obj and updates obj with the result
+func mutateContent(obj k8s.Object, fn func(content map[string]interface{}) error) error {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return fn(u.Object)
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	if err := fn(content); err != nil {
+		return err
+	}
+	// reset the object so that fields removed from the content don't linger
+	value := reflect.ValueOf(obj).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(content, obj)
+}
+
+// replaceImages walks the content and replaces the images of the container lists it finds
+func replaceImages(content interface{}, oldImage, newImage string) {
+	switch value := content.(type) {
+	case map[string]interface{}:
+		for _, field := range containerFields {
+			containers, ok := value[field].([]interface{})
+			if !ok {
+				continue
+			}
+			for _, c := range containers {
+				container, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if image, ok := container["image"].(string); ok && imageMatches(image, oldImage) {
+					container["image"] = newImage
+				}
+			}
+		}
+		for _, v := range value {
+			replaceImages(v, oldImage, newImage)
+		}
+	case []interface{}:
+		for _, v := range value {
+			replaceImages(v, oldImage, newImage)
+		}
+	}
+}
+
+// imageMatches checks if image is reference, or if it is reference with a tag or a digest
+func imageMatches(image, reference string) bool {
+	if image == reference {
+		return true
+	}
+	rest, found := strings.CutPrefix(image, reference)
+	return found && (strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, "@")) && !strings.Contains(rest, "/")
+}
+
+// fieldPathSegment is a field name or, when name is empty, a list index
+type fieldPathSegment struct {
+	name  string
+	index int
+}
+
+// parseFieldPath splits a path such as "spec.containers[0].image" in segments
+func parseFieldPath(path string) ([]fieldPathSegment, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSuffix(strings.TrimPrefix(path, "{"), "}"), ".")
+	if trimmed == "" {
+		return nil, fmt.Errorf("invalid field path %q", path)
+	}
+	var segments []fieldPathSegment
+	for _, part := range strings.Split(trimmed, ".") {
+		name, indexes, _ := strings.Cut(part, "[")
+		if name == "" {
+			return nil, fmt.Errorf("invalid field path %q", path)
+		}
+		segments = append(segments, fieldPathSegment{name: name})
+		if indexes == "" {
+			continue
+		}
+		for _, index := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid index %q in field path %q", index, path)
+			}
+			segments = append(segments, fieldPathSegment{index: i})
+		}
+	}
+	return segments, nil
+}
+
+// setField sets the field at the path made of segments to value, creating missing maps along the way
+func setField(content interface{}, segments []fieldPathSegment, value interface{}) error {
+	segment := segments[0]
+	last := len(segments) == 1
+	if segment.name != "" {
+		fields, ok := content.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s is not in an object", segment.name)
+		}
+		if last {
+			fields[segment.name] = value
+			return nil
+		}
+		next, found := fields[segment.name]
+		if !found || next == nil {
+			if segments[1].name == "" {
+				return fmt.Errorf("list %s does not exist", segment.name)
+			}
+			next = map[string]interface{}{}
+			fields[segment.name] = next
+		}
+		return setField(next, segments[1:], value)
+	}
+
+	items, ok := content.([]interface{})
+	if !ok {
+		return fmt.Errorf("index %d is not in a list", segment.index)
+	}
+	if segment.index >= len(items) {
+		return fmt.Errorf("index %d is out of range, the list has %d items", segment.index, len(items))
+	}
+	if last {
+		items[segment.index] = value
+		return nil
+	}
+	return setField(items[segment.index], segments[1:], value)
+}
+
+// toJSONValue converts value to the types used by the serialized form of objects
+func toJSONValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var result interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	return convertNumbers(result), nil
+}
+
+// convertNumbers replaces the json.Number values with int64 or float64 values
+func convertNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = convertNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertNumbers(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decoder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"text/template"
+
+	sprig "github.com/go-task/slim-sprig/v3"
+)
+
+// WithTemplateFuncs adds functions to the templates rendered by DecodeWithTemplate, in addition to the sprig
+// functions available by default. Functions added this way take precedence over the sprig functions.
+func WithTemplateFuncs(funcs template.FuncMap) DecodeOption {
+	return func(options *Options) {
+		if options.TemplateFuncs == nil {
+			options.TemplateFuncs = template.FuncMap{}
+		}
+		for name, fn := range funcs {
+			options.TemplateFuncs[name] = fn
+		}
+	}
+}
+
+// DecodeWithTemplate resolves files at the filesystem matching the pattern, renders each of them as a Go
+// text/template with the given values and decodes the result, JSON or YAML. Supports multi-document files.
+// The sprig functions are available in the templates, and referencing a missing map key is an error.
+//
+//	err := decoder.DecodeWithTemplate(ctx, os.DirFS("testdata"), "*.yaml", map[string]any{
+//		"Namespace": namespace,
+//		"Image":     "nginx:1.27",
+//		"Replicas":  3,
+//	}, decoder.CreateHandler(r))
+//
+// If handlerFn returns an error, decoding is halted, unless the ContinueOnError option is provided.
+// Options may be provided to configure the behavior of the decoder.
+func DecodeWithTemplate(ctx context.Context, fsys fs.FS, pattern string, values any, handlerFn HandlerFunc, options ...DecodeOption) error {
+	decodeOpt := &Options{}
+	for _, opt := range options {
+		opt(decodeOpt)
+	}
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if err := DecodeTemplate(ctx, file, bytes.NewReader(data), values, handlerFn, options...); err != nil {
+			err = fmt.Errorf("failed to decode file %q: %w", file, err)
+			if !decodeOpt.ContinueOnError {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DecodeTemplate renders the manifest as a Go text/template named name with the given values and decodes
+// the result like DecodeEach does.
+func DecodeTemplate(ctx context.Context, name string, manifest io.Reader, values any, handlerFn HandlerFunc, options ...DecodeOption) error {
+	opts := Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	raw, err := io.ReadAll(manifest)
+	if err != nil {
+		return err
+	}
+	funcs := sprig.TxtFuncMap()
+	for name, fn := range opts.TemplateFuncs {
+		funcs[name] = fn
+	}
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(string(raw))
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, values); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return DecodeEach(ctx, &rendered, handlerFn, options...)
+}
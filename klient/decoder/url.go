@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decoder
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	klog "k8s.io/klog/v2"
+)
+
+// WithChecksum pins the content of a manifest fetched by DecodeURL to the given SHA-256 checksum, hex encoded
+// and optionally prefixed with "sha256:". Decoding fails if the content does not match the checksum.
+func WithChecksum(checksum string) DecodeOption {
+	return func(options *Options) {
+		options.Checksum = strings.ToLower(strings.TrimPrefix(checksum, "sha256:"))
+	}
+}
+
+// WithCacheDir caches the manifests fetched by DecodeURL in dir, so that they are only downloaded once across
+// test runs. When a checksum is pinned with WithChecksum, a cached manifest that does not match it is
+// downloaded again.
+func WithCacheDir(dir string) DecodeOption {
+	return func(options *Options) {
+		options.CacheDir = dir
+	}
+}
+
+// fetchURL returns the content at url, from the cache directory if one is configured and holds it
+func fetchURL(ctx context.Context, url string, options *Options) ([]byte, error) {
+	var cachePath string
+	if options.CacheDir != "" {
+		key := sha256.Sum256([]byte(url))
+		cachePath = filepath.Join(options.CacheDir, hex.EncodeToString(key[:]))
+		if data, err := os.ReadFile(cachePath); err == nil {
+			if verifyChecksum(data, options.Checksum) == nil {
+				klog.V(4).InfoS("Using cached manifest", "url", url, "path", cachePath)
+				return data, nil
+			}
+			klog.V(4).InfoS("Cached manifest does not match the checksum, downloading it again", "url", url, "path", cachePath)
+		}
+	}
+
+	data, err := download(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyChecksum(data, options.Checksum); err != nil {
+		return nil, fmt.Errorf("manifest %s: %w", url, err)
+	}
+
+	if cachePath != "" {
+		if err := writeCache(cachePath, data); err != nil {
+			return nil, fmt.Errorf("caching manifest %s: %w", url, err)
+		}
+	}
+	return data, nil
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching manifest %s: unexpected status %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func verifyChecksum(data []byte, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != checksum {
+		return fmt.Errorf("checksum mismatch: expected sha256:%s, got sha256:%s", checksum, actual)
+	}
+	return nil
+}
+
+// writeCache writes the cache file atomically so that concurrent test processes never read a partial manifest
+func writeCache(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decoder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	klog "k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+)
+
+// validationFieldManager is the field manager of the dry-run requests made by WithOpenAPIValidation
+const validationFieldManager = "e2e-framework-validation"
+
+// ValidateFunc is a function executed after an object has been decoded and mutated, before it is passed to the
+// HandlerFunc. Returning an error halts decoding of any further objects.
+type ValidateFunc func(ctx context.Context, obj k8s.Object) error
+
+// WithStrictDecoding makes decoding fail on unknown or duplicate fields instead of silently dropping them.
+// Objects of a Kind that is not registered in the scheme are decoded as unstructured.Unstructured and can't
+// be checked this way, WithOpenAPIValidation can be used for those.
+func WithStrictDecoding() DecodeOption {
+	return func(options *Options) {
+		options.Strict = true
+	}
+}
+
+// WithValidation adds a function validating each decoded object before it is passed to the HandlerFunc.
+func WithValidation(fn ValidateFunc) DecodeOption {
+	return func(options *Options) {
+		options.ValidateFuncs = append(options.ValidateFuncs, fn)
+	}
+}
+
+// WithOpenAPIValidation validates each decoded object against the OpenAPI schema of the cluster r talks to,
+// before it is passed to the HandlerFunc. The object is submitted as a server-side apply dry-run request
+// with strict field validation, so that unknown fields and invalid values are reported with the name of
+// the offending object. Failures unrelated to the schema, such as a missing namespace, are not reported.
+func WithOpenAPIValidation(r *resources.Resources) DecodeOption {
+	return WithValidation(func(ctx context.Context, obj k8s.Object) error {
+		candidate, ok := obj.DeepCopyObject().(k8s.Object)
+		if !ok {
+			return fmt.Errorf("unexpected type %T, does not satisfy k8s.Object", obj)
+		}
+		gvk, err := apiutil.GVKForObject(candidate, r.GetScheme())
+		if err != nil {
+			return err
+		}
+		candidate.GetObjectKind().SetGroupVersionKind(gvk)
+		candidate.SetResourceVersion("")
+		candidate.SetManagedFields(nil)
+		data, err := json.Marshal(candidate)
+		if err != nil {
+			return err
+		}
+
+		err = r.Patch(ctx, candidate, k8s.Patch{PatchType: types.ApplyPatchType, Data: data}, func(po *metav1.PatchOptions) {
+			force := true
+			po.DryRun = []string{metav1.DryRunAll}
+			po.FieldValidation = metav1.FieldValidationStrict
+			po.FieldManager = validationFieldManager
+			po.Force = &force
+		})
+		if apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) {
+			return err
+		} else if err != nil {
+			klog.V(4).InfoS("Could not validate object against the cluster schema", "kind", gvk.Kind, "name", obj.GetName(), "error", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DefaultIgnoredFields are the fields that are always left out of Diff, as they are maintained
+// by the API server and rarely relevant when comparing objects.
+var DefaultIgnoredFields = []string{
+	"metadata.managedFields",
+	"metadata.resourceVersion",
+}
+
+// Diff compares two objects field by field and returns a human-readable description of their
+// differences, one line per differing field, or an empty string if they are semantically equal.
+// Missing fields and empty values are treated as equal, and numbers are compared by value.
+//
+// Fields are addressed by their dot separated path in the serialized object, for instance
+// "metadata.labels" or "status". The fields in DefaultIgnoredFields and in ignoreFields are
+// left out of the comparison.
+//
+//	diff, err := k8s.Diff(expected, actual, "status")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	if diff != "" {
+//		t.Errorf("unexpected deployment (-expected +actual):\n%s", diff)
+//	}
+func Diff(expected, actual Object, ignoreFields ...string) (string, error) {
+	e, err := toUnstructured(expected)
+	if err != nil {
+		return "", fmt.Errorf("diff: expected object: %w", err)
+	}
+	a, err := toUnstructured(actual)
+	if err != nil {
+		return "", fmt.Errorf("diff: actual object: %w", err)
+	}
+
+	for _, field := range append(append([]string{}, DefaultIgnoredFields...), ignoreFields...) {
+		path := strings.Split(field, ".")
+		unstructured.RemoveNestedField(e, path...)
+		unstructured.RemoveNestedField(a, path...)
+	}
+
+	var lines []string
+	diffValues("", e, a, &lines)
+	return strings.Join(lines, "\n"), nil
+}
+
+// toUnstructured converts an object to its serialized form
+func toUnstructured(obj Object) (map[string]interface{}, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return runtime.DeepCopyJSON(u.Object), nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
+
+// diffValues appends a line to lines for each difference found between expected and actual
+func diffValues(path string, expected, actual interface{}, lines *[]string) {
+	if isEmpty(expected) && isEmpty(actual) {
+		return
+	}
+	em, expectedMap := expected.(map[string]interface{})
+	am, actualMap := actual.(map[string]interface{})
+	if (expectedMap || expected == nil) && (actualMap || actual == nil) {
+		diffMaps(path, em, am, lines)
+		return
+	}
+	es, expectedSlice := expected.([]interface{})
+	as, actualSlice := actual.([]interface{})
+	if (expectedSlice || expected == nil) && (actualSlice || actual == nil) {
+		diffSlices(path, es, as, lines)
+		return
+	}
+
+	switch {
+	case isEmpty(actual):
+		*lines = append(*lines, fmt.Sprintf("- %s: %v", path, expected))
+	case isEmpty(expected):
+		*lines = append(*lines, fmt.Sprintf("+ %s: %v", path, actual))
+	case !equalScalars(expected, actual):
+		*lines = append(*lines, fmt.Sprintf("- %s: %v", path, expected), fmt.Sprintf("+ %s: %v", path, actual))
+	}
+}
+
+func diffMaps(path string, expected, actual map[string]interface{}, lines *[]string) {
+	keys := make(map[string]struct{}, len(expected)+len(actual))
+	for k := range expected {
+		keys[k] = struct{}{}
+	}
+	for k := range actual {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		diffValues(p, expected[k], actual[k], lines)
+	}
+}
+
+func diffSlices(path string, expected, actual []interface{}, lines *[]string) {
+	n := len(expected)
+	if len(actual) > n {
+		n = len(actual)
+	}
+	for i := 0; i < n; i++ {
+		var e, a interface{}
+		if i < len(expected) {
+			e = expected[i]
+		}
+		if i < len(actual) {
+			a = actual[i]
+		}
+		diffValues(fmt.Sprintf("%s[%d]", path, i), e, a, lines)
+	}
+}
+
+// isEmpty checks if a value is absent or the zero value of a serialized field
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return len(val) == 0
+	case []interface{}:
+		return len(val) == 0
+	case string:
+		return val == ""
+	}
+	return false
+}
+
+// equalScalars compares two serialized values, numbers are compared by value regardless of their type
+func equalScalars(expected, actual interface{}) bool {
+	if ef, ok := toFloat(expected); ok {
+		if af, ok := toFloat(actual); ok {
+			return ef == af
+		}
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	}
+	return 0, false
+}
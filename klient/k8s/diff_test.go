@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDiff(t *testing.T) {
+	replicas := func(n int32) *int32 { return &n }
+	deployment := func(mutate func(d *appsv1.Deployment)) *appsv1.Deployment {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default", Labels: map[string]string{"app": "nginx"}},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: replicas(1),
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.27"}},
+				}},
+			},
+		}
+		if mutate != nil {
+			mutate(d)
+		}
+		return d
+	}
+
+	tests := []struct {
+		name         string
+		expected     Object
+		actual       Object
+		ignoreFields []string
+		want         string
+	}{
+		{
+			name:     "equal",
+			expected: deployment(nil),
+			actual:   deployment(nil),
+		},
+		{
+			name:     "server maintained fields are ignored",
+			expected: deployment(nil),
+			actual: deployment(func(d *appsv1.Deployment) {
+				d.ResourceVersion = "42"
+				d.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+			}),
+		},
+		{
+			name:     "changed fields",
+			expected: deployment(nil),
+			actual: deployment(func(d *appsv1.Deployment) {
+				d.Spec.Replicas = replicas(3)
+				d.Spec.Template.Spec.Containers[0].Image = "nginx:1.28"
+			}),
+			want: "- spec.replicas: 1\n+ spec.replicas: 3\n" +
+				"- spec.template.spec.containers[0].image: nginx:1.27\n+ spec.template.spec.containers[0].image: nginx:1.28",
+		},
+		{
+			name:     "added and removed fields",
+			expected: deployment(nil),
+			actual: deployment(func(d *appsv1.Deployment) {
+				d.Labels = map[string]string{"tier": "web"}
+			}),
+			want: "- metadata.labels.app: nginx\n+ metadata.labels.tier: web",
+		},
+		{
+			name:     "ignored fields",
+			expected: deployment(nil),
+			actual: deployment(func(d *appsv1.Deployment) {
+				d.Status.ReadyReplicas = 1
+				d.Labels = nil
+			}),
+			ignoreFields: []string{"status", "metadata.labels"},
+		},
+		{
+			name:     "numbers from unstructured objects",
+			expected: deployment(nil),
+			actual: &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "nginx", "namespace": "default", "labels": map[string]interface{}{"app": "nginx"}},
+				"spec": map[string]interface{}{
+					"replicas": float64(1),
+					"template": map[string]interface{}{"spec": map[string]interface{}{
+						"containers": []interface{}{map[string]interface{}{"name": "nginx", "image": "nginx:1.27"}},
+					}},
+				},
+			}},
+			ignoreFields: []string{"spec.template.metadata", "spec.strategy", "status"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Diff(test.expected, test.actual, test.ignoreFields...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("Diff() =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+// Sprint returns the object as YAML for debug output, such as test failure messages. The kind and
+// API version of typed objects are filled from the client-go scheme when they are not set, the
+// managed fields are left out and the values of secrets are redacted by DefaultRedactor. The
+// objects of lists are printed the same way. When the object cannot be serialized, a description
+// of the error is returned instead.
+func Sprint(obj runtime.Object) string {
+	return DefaultRedactor.Sprint(obj)
+}
+
+// Dump returns the objects printed with Sprint as a multi-document YAML stream
+func Dump(objs ...runtime.Object) string {
+	return DefaultRedactor.Dump(objs...)
+}
+
+// Sprint returns the object as YAML like the Sprint function, with the rules of the redactor applied
+func (r *Redactor) Sprint(obj runtime.Object) string {
+	if obj == nil {
+		return "<nil>\n"
+	}
+	u, err := r.printable(obj)
+	if err != nil {
+		return fmt.Sprintf("<%T: %v>\n", obj, err)
+	}
+	data, err := yaml.Marshal(u)
+	if err != nil {
+		return fmt.Sprintf("<%T: %v>\n", obj, err)
+	}
+	return r.RedactText(string(data))
+}
+
+// Dump returns the objects printed with Sprint as a multi-document YAML stream
+func (r *Redactor) Dump(objs ...runtime.Object) string {
+	docs := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		docs = append(docs, r.Sprint(obj))
+	}
+	return strings.Join(docs, "---\n")
+}
+
+// printable converts the object to its serialized form, cleaned up for printing
+func (r *Redactor) printable(obj runtime.Object) (map[string]interface{}, error) {
+	var u map[string]interface{}
+	if un, ok := obj.(*unstructured.Unstructured); ok {
+		u = runtime.DeepCopyJSON(un.Object)
+	} else {
+		var err error
+		if u, err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+			return nil, err
+		}
+		if obj.GetObjectKind().GroupVersionKind().Empty() {
+			if gvks, _, err := scheme.Scheme.ObjectKinds(obj); err == nil && len(gvks) > 0 {
+				u["apiVersion"], u["kind"] = gvks[0].ToAPIVersionAndKind()
+			}
+		}
+	}
+
+	kind, _ := u["kind"].(string)
+	r.clean(u, kind)
+	if items, ok := u["items"].([]interface{}); ok {
+		// the items of typed lists have no kind, it is derived from the kind of the list
+		itemKind := strings.TrimSuffix(kind, "List")
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); ok {
+				if k, ok := m["kind"].(string); ok {
+					r.clean(m, k)
+				} else {
+					r.clean(m, itemKind)
+				}
+			}
+		}
+	}
+	return u, nil
+}
+
+// clean removes the managed fields of a serialized object of the given kind and redacts it
+func (r *Redactor) clean(u map[string]interface{}, kind string) {
+	unstructured.RemoveNestedField(u, "metadata", "managedFields")
+	r.redactObject(u, kind)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSprint(t *testing.T) {
+	managed := []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "creds",
+			Namespace:     "default",
+			ManagedFields: managed,
+			Annotations:   map[string]string{lastAppliedAnnotation: `{"data":{"password":"aHVudGVyMg=="}}`},
+		},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+		StringData: map[string]string{"token": "s3cr3t"},
+	}
+
+	tests := []struct {
+		name    string
+		obj     runtime.Object
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "typed object",
+			obj:     &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", ManagedFields: managed}, Data: map[string]string{"key": "value"}},
+			want:    []string{"apiVersion: v1\n", "kind: ConfigMap\n", "name: config\n", "key: value\n"},
+			notWant: []string{"managedFields"},
+		},
+		{
+			name:    "secret",
+			obj:     secret,
+			want:    []string{"kind: Secret\n", "password: " + Redacted, "token: " + Redacted, lastAppliedAnnotation + ": " + Redacted},
+			notWant: []string{"aHVudGVyMg", "s3cr3t", "managedFields"},
+		},
+		{
+			name:    "typed list",
+			obj:     &corev1.SecretList{Items: []corev1.Secret{*secret}},
+			want:    []string{"kind: SecretList\n", "password: " + Redacted},
+			notWant: []string{"aHVudGVyMg", "managedFields"},
+		},
+		{
+			name: "unstructured secret",
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata":   map[string]interface{}{"name": "creds"},
+				"data":       map[string]interface{}{"password": "aHVudGVyMg=="},
+			}},
+			want:    []string{"password: " + Redacted},
+			notWant: []string{"aHVudGVyMg"},
+		},
+		{
+			name: "nil",
+			want: []string{"<nil>"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Sprint(test.obj)
+			for _, want := range test.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected %q in:\n%s", want, got)
+				}
+			}
+			for _, notWant := range test.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("unexpected %q in:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+	if len(secret.ManagedFields) == 0 || string(secret.Data["password"]) != "hunter2" {
+		t.Error("expected the printed object to be left unchanged")
+	}
+}
+
+func TestDump(t *testing.T) {
+	got := Dump(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a"}}, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "b"}})
+	docs := strings.Split(got, "---\n")
+	if len(docs) != 2 || !strings.Contains(docs[0], "name: a") || !strings.Contains(docs[1], "name: b") {
+		t.Errorf("unexpected dump:\n%s", got)
+	}
+}
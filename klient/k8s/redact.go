@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Redacted replaces the redacted values in the output of Sprint, Dump and of a Redactor
+const Redacted = "<redacted>"
+
+// lastAppliedAnnotation holds a copy of the object as last applied by kubectl, which includes
+// the redacted data
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// DefaultRedactor is the redactor used by Sprint and Dump, it redacts the data of secrets
+var DefaultRedactor = NewRedactor(RedactKinds("Secret"))
+
+// Redactor removes sensitive values, such as credentials, from objects and text before they are
+// printed or written to artifacts. The rules of a redactor are set with RedactorOption values.
+type Redactor struct {
+	kinds    map[string]struct{}
+	fields   []fieldRule
+	patterns []*regexp.Regexp
+}
+
+type fieldRule struct {
+	kind string
+	path []string
+}
+
+// RedactorOption adds a redaction rule to a Redactor
+type RedactorOption func(*Redactor)
+
+// NewRedactor creates a Redactor applying the given rules. A redactor without rules leaves the
+// values unchanged, only the managed fields of the printed objects are left out.
+func NewRedactor(opts ...RedactorOption) *Redactor {
+	r := &Redactor{kinds: map[string]struct{}{}}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// RedactKinds redacts the values of the data, stringData and binaryData fields, and the
+// last-applied-configuration annotation, of the objects of the given kinds, such as Secret
+func RedactKinds(kinds ...string) RedactorOption {
+	return func(r *Redactor) {
+		for _, kind := range kinds {
+			r.kinds[kind] = struct{}{}
+		}
+	}
+}
+
+// RedactFields redacts the values at the dot separated field paths, such as "data.password" or
+// "spec.credentials", of the objects of the given kind, or of any kind when kind is empty. Maps and
+// lists found at a path are replaced as a whole.
+func RedactFields(kind string, paths ...string) RedactorOption {
+	return func(r *Redactor) {
+		for _, path := range paths {
+			r.fields = append(r.fields, fieldRule{kind: kind, path: strings.Split(path, ".")})
+		}
+	}
+}
+
+// RedactPatterns replaces the matches of the regular expressions in printed objects and in
+// redacted text and files, for instance to remove bearer tokens from exported logs
+func RedactPatterns(patterns ...*regexp.Regexp) RedactorOption {
+	return func(r *Redactor) {
+		r.patterns = append(r.patterns, patterns...)
+	}
+}
+
+// redactObject applies the kind and field rules to a serialized object of the given kind
+func (r *Redactor) redactObject(u map[string]interface{}, kind string) {
+	if _, ok := r.kinds[kind]; ok {
+		for _, field := range []string{"data", "stringData", "binaryData"} {
+			if values, ok := u[field].(map[string]interface{}); ok {
+				for key := range values {
+					values[key] = Redacted
+				}
+			}
+		}
+		if _, found, _ := unstructured.NestedString(u, "metadata", "annotations", lastAppliedAnnotation); found {
+			_ = unstructured.SetNestedField(u, Redacted, "metadata", "annotations", lastAppliedAnnotation)
+		}
+	}
+	for _, rule := range r.fields {
+		if rule.kind != "" && rule.kind != kind {
+			continue
+		}
+		if _, found, _ := unstructured.NestedFieldNoCopy(u, rule.path...); found {
+			_ = unstructured.SetNestedField(u, Redacted, rule.path...)
+		}
+	}
+}
+
+// RedactText replaces the matches of the patterns of the redactor in s
+func (r *Redactor) RedactText(s string) string {
+	for _, pattern := range r.patterns {
+		s = pattern.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// RedactDir rewrites the text files found under dir with the matches of the patterns of the
+// redactor replaced, such as cluster logs exported as artifacts. Binary files are left unchanged.
+func (r *Redactor) RedactDir(dir string) error {
+	if len(r.patterns) == 0 {
+		return nil
+	}
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !d.Type().IsRegular() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("redact %s: %w", path, err)
+		}
+		if bytes.IndexByte(data, 0) >= 0 {
+			return nil
+		}
+		redacted := r.RedactText(string(data))
+		if redacted == string(data) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("redact %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, []byte(redacted), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("redact %s: %w", path, err)
+		}
+		return nil
+	})
+}
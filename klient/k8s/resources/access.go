@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+)
+
+// AccessOption refines the request checked by CanI
+type AccessOption func(*authorizationv1.ResourceAttributes)
+
+// WithSubresource checks the access to a subresource, such as "log" or "scale"
+func WithSubresource(subresource string) AccessOption {
+	return func(attrs *authorizationv1.ResourceAttributes) {
+		attrs.Subresource = subresource
+	}
+}
+
+// WithResourceName checks the access to a single named resource instead of all the resources of the type
+func WithResourceName(name string) AccessOption {
+	return func(attrs *authorizationv1.ResourceAttributes) {
+		attrs.Name = name
+	}
+}
+
+// CanI reports whether the user of r is allowed to perform verb on the resources identified by gvr in
+// namespace, using a SelfSubjectAccessReview. An empty namespace checks the access across all namespaces,
+// or to a cluster scoped resource. Combined with WithImpersonation or WithServiceAccount, it checks the
+// permissions of another user:
+//
+//	restricted, err := r.WithServiceAccount("apps", "deployer")
+//	allowed, err := restricted.CanI(ctx, "delete", schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, "apps")
+func (r *Resources) CanI(ctx context.Context, verb string, gvr schema.GroupVersionResource, namespace string, opts ...AccessOption) (bool, error) {
+	attrs := &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      verb,
+		Group:     gvr.Group,
+		Version:   gvr.Version,
+		Resource:  gvr.Resource,
+	}
+	for _, fn := range opts {
+		fn(attrs)
+	}
+
+	clientset, err := r.clientset()
+	if err != nil {
+		return false, err
+	}
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attrs},
+	}
+	review, err = clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to review access to %s %s: %w", verb, gvr.GroupResource(), err)
+	}
+	klog.V(4).InfoS("Access reviewed", "verb", verb, "resource", gvr.GroupResource(), "namespace", namespace,
+		"allowed", review.Status.Allowed, "reason", review.Status.Reason)
+	return review.Status.Allowed, nil
+}
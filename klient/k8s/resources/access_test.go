@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+func TestCanI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews" {
+			http.NotFound(w, r)
+			return
+		}
+		review := &authorizationv1.SelfSubjectAccessReview{}
+		if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		attrs := review.Spec.ResourceAttributes
+		// the impersonated user may only read pod logs in the apps namespace
+		if r.Header.Get("Impersonate-User") == "" {
+			review.Status.Allowed = true
+		} else {
+			review.Status.Allowed = attrs.Verb == "get" && attrs.Resource == "pods" && attrs.Subresource == "log" && attrs.Namespace == "apps"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(review)
+	}))
+	defer srv.Close()
+
+	cfg := &rest.Config{Host: srv.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}}
+	admin := &Resources{config: cfg}
+	janeCfg := rest.CopyConfig(cfg)
+	janeCfg.Impersonate = rest.ImpersonationConfig{UserName: "jane"}
+	jane := &Resources{config: janeCfg}
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	tests := []struct {
+		name      string
+		resources *Resources
+		verb      string
+		namespace string
+		opts      []AccessOption
+		want      bool
+	}{
+		{name: "admin", resources: admin, verb: "delete", namespace: "apps", want: true},
+		{name: "allowed subresource", resources: jane, verb: "get", namespace: "apps", opts: []AccessOption{WithSubresource("log"), WithResourceName("web-0")}, want: true},
+		{name: "denied resource", resources: jane, verb: "get", namespace: "apps"},
+		{name: "denied namespace", resources: jane, verb: "get", namespace: "kube-system", opts: []AccessOption{WithSubresource("log")}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			allowed, err := tc.resources.CanI(context.TODO(), tc.verb, pods, tc.namespace, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if allowed != tc.want {
+				t.Errorf("expected allowed %v, got %v", tc.want, allowed)
+			}
+		})
+	}
+
+	if _, err := (&Resources{config: &rest.Config{Host: srv.URL + "/missing", ContentConfig: cfg.ContentConfig}}).CanI(context.TODO(), "get", pods, ""); err == nil {
+		t.Error("expected an error when the review fails")
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// JobResult is the outcome of a job run with RunJobAndWait
+type JobResult struct {
+	// Job is the last observed state of the job
+	Job *batchv1.Job
+	// Succeeded reports whether the job completed, as opposed to failed
+	Succeeded bool
+	// Logs holds the logs of the pods of the job, keyed by pod name. The logs of pods with several
+	// containers are concatenated in the order of the containers of the pod spec.
+	Logs map[string]string
+}
+
+// Output returns the logs of all the pods of the job, in pod name order
+func (j *JobResult) Output() string {
+	names := make([]string, 0, len(j.Logs))
+	for name := range j.Logs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(j.Logs[name])
+	}
+	return sb.String()
+}
+
+type RunJobOption func(*runJobOptions)
+
+type runJobOptions struct {
+	timeout      time.Duration
+	pollInterval time.Duration
+	container    string
+	keep         bool
+}
+
+// WithJobTimeout sets how long RunJobAndWait waits for the job to complete or fail. Defaults to 5 minutes
+func WithJobTimeout(timeout time.Duration) RunJobOption {
+	return func(o *runJobOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithJobPollInterval sets how often the job status is checked. Defaults to 2 seconds
+func WithJobPollInterval(interval time.Duration) RunJobOption {
+	return func(o *runJobOptions) {
+		o.pollInterval = interval
+	}
+}
+
+// WithJobContainer only captures the logs of the named container of the job pods
+func WithJobContainer(name string) RunJobOption {
+	return func(o *runJobOptions) {
+		o.container = name
+	}
+}
+
+// WithKeepJob skips the deletion of the job and its pods once RunJobAndWait returns, which can help
+// troubleshooting a failing job
+func WithKeepJob() RunJobOption {
+	return func(o *runJobOptions) {
+		o.keep = true
+	}
+}
+
+// RunJobAndWait creates the job, waits for it to either complete or fail and captures the logs of its pods.
+// The job and its pods are deleted before returning unless WithKeepJob is used. A failed job is not an error,
+// its outcome is reported by JobResult.Succeeded, and an error is only returned when the job could not be
+// created or did not finish in time, in which case the result still holds the logs captured so far.
+//
+//	job := &batchv1.Job{...}
+//	result, err := r.RunJobAndWait(ctx, job, resources.WithJobTimeout(time.Minute))
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	if !result.Succeeded || !strings.Contains(result.Output(), "PASS") {
+//		t.Fatalf("job failed: %s", result.Output())
+//	}
+func (r *Resources) RunJobAndWait(ctx context.Context, job *batchv1.Job, opts ...RunJobOption) (*JobResult, error) {
+	o := &runJobOptions{timeout: 5 * time.Minute, pollInterval: 2 * time.Second}
+	for _, fn := range opts {
+		fn(o)
+	}
+	if job.GetNamespace() == "" {
+		job.SetNamespace(r.namespace)
+	}
+
+	if err := r.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("create job %s/%s: %w", job.Namespace, job.Name, err)
+	}
+	if !o.keep {
+		defer func() {
+			// the job is cleaned up even when ctx is done
+			if err := r.Delete(context.WithoutCancel(ctx), job, WithDeletePropagation(string(metav1.DeletePropagationBackground))); err != nil {
+				klog.ErrorS(err, "failed to delete job", "namespace", job.Namespace, "name", job.Name)
+			}
+		}()
+	}
+
+	result := &JobResult{Job: job}
+	waitErr := apimachinerywait.PollUntilContextTimeout(ctx, o.pollInterval, o.timeout, true, func(ctx context.Context) (bool, error) {
+		if err := r.Get(ctx, job.Name, job.Namespace, result.Job); err != nil {
+			return false, nil
+		}
+		for _, cond := range result.Job.Status.Conditions {
+			if cond.Status != v1.ConditionTrue {
+				continue
+			}
+			switch cond.Type {
+			case batchv1.JobComplete:
+				result.Succeeded = true
+				return true, nil
+			case batchv1.JobFailed:
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+
+	logs, err := r.jobLogs(context.WithoutCancel(ctx), result.Job, o.container)
+	result.Logs = logs
+	if waitErr != nil {
+		return result, fmt.Errorf("job %s/%s did not finish: %w", job.Namespace, job.Name, waitErr)
+	}
+	if err != nil {
+		return result, fmt.Errorf("job %s/%s logs: %w", job.Namespace, job.Name, err)
+	}
+	return result, nil
+}
+
+// jobLogs fetches the logs of the pods selected by the job
+func (r *Resources) jobLogs(ctx context.Context, job *batchv1.Job, container string) (map[string]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	var pods v1.PodList
+	if err := r.InNamespace(job.Namespace).List(ctx, &pods, WithLabelSelector(selector.String())); err != nil {
+		return nil, err
+	}
+	clientset, err := r.clientset()
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make(map[string]string, len(pods.Items))
+	for _, pod := range pods.Items {
+		var sb strings.Builder
+		for _, c := range pod.Spec.Containers {
+			if container != "" && c.Name != container {
+				continue
+			}
+			data, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{Container: c.Name}).DoRaw(ctx)
+			if err != nil {
+				// pods that never started have no logs
+				klog.V(4).InfoS("Unable to fetch job pod logs", "pod", pod.Name, "container", c.Name, "error", err)
+				continue
+			}
+			sb.Write(data)
+		}
+		logs[pod.Name] = sb.String()
+	}
+	return logs, nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestDurationOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		want     int64
+	}{
+		{name: "zero", duration: 0, want: 0},
+		{name: "whole seconds", duration: 30 * time.Second, want: 30},
+		{name: "partial second", duration: 1500 * time.Millisecond, want: 2},
+		{name: "sub second", duration: 10 * time.Millisecond, want: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lo := &metav1.ListOptions{}
+			WithTimeout(test.duration)(lo)
+			if *lo.TimeoutSeconds != test.want {
+				t.Errorf("WithTimeout(%s) = %ds, want %ds", test.duration, *lo.TimeoutSeconds, test.want)
+			}
+			do := &metav1.DeleteOptions{}
+			WithGracePeriod(test.duration)(do)
+			if *do.GracePeriodSeconds != test.want {
+				t.Errorf("WithGracePeriod(%s) = %ds, want %ds", test.duration, *do.GracePeriodSeconds, test.want)
+			}
+		})
+	}
+}
+
+func TestListOptions(t *testing.T) {
+	lo := &metav1.ListOptions{}
+	for _, opt := range []ListOption{WithLimit(10), WithContinue("token"), WithResourceVersion("42")} {
+		opt(lo)
+	}
+	if lo.Limit != 10 || lo.Continue != "token" || lo.ResourceVersion != "42" {
+		t.Errorf("unexpected list options %+v", lo)
+	}
+}
+
+func TestDeleteAllOfOptions(t *testing.T) {
+	r := &Resources{namespace: "test-ns"}
+	lo := &metav1.ListOptions{}
+	do := &metav1.DeleteOptions{}
+	for _, opt := range []DeleteAllOfOption{
+		WithListOptions(WithLabelSelector("app=nginx")),
+		WithDeleteOptions(WithGracePeriod(5 * time.Second)),
+	} {
+		opt(lo, do)
+	}
+
+	listOpts, err := r.toListOptions(lo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if listOpts.Namespace != "test-ns" || listOpts.LabelSelector.String() != "app=nginx" {
+		t.Errorf("unexpected list options %+v", listOpts)
+	}
+	deleteOpts, err := toDeleteOptions(do)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *deleteOpts.GracePeriodSeconds != 5 {
+		t.Errorf("unexpected grace period %ds", *deleteOpts.GracePeriodSeconds)
+	}
+
+	WithDeleteOptions(WithGracePeriod(-time.Second))(lo, do)
+	if _, err := toDeleteOptions(do); err == nil {
+		t.Error("expected an error for a negative grace period")
+	}
+}
+
+func TestWithNamespace(t *testing.T) {
+	r := &Resources{namespace: "shared"}
+	if scoped := r.WithNamespace("feature"); scoped != r || r.namespace != "feature" {
+		t.Errorf("expected WithNamespace to update the resources, got namespace %q", r.namespace)
+	}
+}
+
+func TestInNamespace(t *testing.T) {
+	r := &Resources{namespace: "shared"}
+	scoped := r.InNamespace("feature")
+	if scoped.namespace != "feature" {
+		t.Errorf("expected namespace feature, got %q", scoped.namespace)
+	}
+	if r.namespace != "shared" {
+		t.Errorf("expected the original resources to keep namespace shared, got %q", r.namespace)
+	}
+}
+
+func TestClientsetCached(t *testing.T) {
+	r, err := New(&rest.Config{Host: "https://127.0.0.1:6443"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := r.clientset()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := r.InNamespace("other").clientset()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("expected the copies of the resources to share the clientset")
+	}
+}
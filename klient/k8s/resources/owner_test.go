@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestSetOwnerReference(t *testing.T) {
+	r := &Resources{scheme: scheme.Scheme}
+	owner := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "default", UID: "1234"}}
+
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "dependent", Namespace: "default"}}
+	if err := r.SetOwnerReference(owner, obj); err != nil {
+		t.Fatal(err)
+	}
+	refs := obj.GetOwnerReferences()
+	if len(refs) != 1 || refs[0].UID != "1234" || refs[0].Kind != "Deployment" || refs[0].Controller != nil {
+		t.Errorf("unexpected owner references %+v", refs)
+	}
+
+	other := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", UID: "5678"}}
+	if err := r.SetControllerReference(owner, obj); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetControllerReference(other, obj); err == nil {
+		t.Error("expected an error when setting a second controller reference")
+	}
+
+	clusterScoped := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+	if err := r.SetOwnerReference(owner, clusterScoped); err == nil {
+		t.Error("expected an error when a cluster scoped object is owned by a namespaced object")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestProxyGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/services/http:web:8080/proxy/healthz":
+			_, _ = w.Write([]byte("service ok " + r.URL.Query().Get("verbose")))
+		case "/api/v1/namespaces/default/pods/https:web-0:metrics/proxy/metrics":
+			_, _ = w.Write([]byte("pod ok"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	r := &Resources{config: &rest.Config{Host: srv.URL}}
+	tests := []struct {
+		name    string
+		scheme  string
+		target  string
+		port    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "service by name", scheme: "http", target: "web", port: "8080", path: "/healthz?verbose=1", want: "service ok 1"},
+		{name: "service with prefix", scheme: "http", target: "service/web", port: "8080", path: "healthz", want: "service ok "},
+		{name: "pod", scheme: "https", target: "pod/web-0", port: "metrics", path: "/metrics", want: "pod ok"},
+		{name: "not found", scheme: "http", target: "pod/web-0", port: "8080", path: "/", wantErr: true},
+		{name: "unsupported target", target: "deployment/web", port: "8080", path: "/", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := r.ProxyGet(context.TODO(), tc.scheme, "default", tc.target, tc.port, tc.path)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, string(data))
+			}
+		})
+	}
+}
+
+func TestWithServiceAccount(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Impersonate-User") + " " + strings.Join(r.Header.Values("Impersonate-Group"), ",")))
+	}))
+	defer srv.Close()
+
+	r, err := New(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	restricted, err := r.WithNamespace("apps").WithServiceAccount("apps", "deployer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := restricted.ProxyGet(context.TODO(), "http", "apps", "web", "80", "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "system:serviceaccount:apps:deployer system:serviceaccounts,system:serviceaccounts:apps"
+	if string(data) != want {
+		t.Errorf("expected impersonation headers %q, got %q", want, string(data))
+	}
+	if restricted.namespace != "apps" {
+		t.Errorf("expected the namespace to be kept, got %q", restricted.namespace)
+	}
+	if r.GetConfig().Impersonate.UserName != "" {
+		t.Error("expected the original resources not to impersonate")
+	}
+}
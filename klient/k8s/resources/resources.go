@@ -20,6 +20,12 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -30,9 +36,12 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
 	klog "k8s.io/klog/v2"
 	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"sigs.k8s.io/e2e-framework/klient/k8s"
@@ -51,6 +60,28 @@ type Resources struct {
 
 	// namespace for namespaced object requests
 	namespace string
+
+	// kube caches the clientset used by the operations not supported by the controller runtime
+	// client, such as ExecInPod, it is shared by the copies of the Resources
+	kube *lazyClientset
+}
+
+// lazyClientset creates a clientset on first use
+type lazyClientset struct {
+	once      sync.Once
+	clientset kubernetes.Interface
+	err       error
+}
+
+// clientset returns the clientset of the config of r, created once and reused afterwards
+func (r *Resources) clientset() (kubernetes.Interface, error) {
+	if r.kube == nil {
+		return kubernetes.NewForConfig(r.config)
+	}
+	r.kube.once.Do(func() {
+		r.kube.clientset, r.kube.err = kubernetes.NewForConfig(r.config)
+	})
+	return r.kube.clientset, r.kube.err
 }
 
 // New instantiates the controller runtime client
@@ -71,6 +102,7 @@ func New(cfg *rest.Config) (*Resources, error) {
 		config: cfg,
 		scheme: scheme.Scheme,
 		client: cl,
+		kube:   &lazyClientset{},
 	}
 
 	return res, nil
@@ -86,6 +118,43 @@ func (r *Resources) WithNamespace(ns string) *Resources {
 	return r
 }
 
+// InNamespace returns a copy of r using the namespace ns. Unlike WithNamespace, r is not modified, so a
+// Resources shared by concurrent features can be scoped to the namespace of each feature.
+func (r *Resources) InNamespace(ns string) *Resources {
+	scoped := *r
+	scoped.namespace = ns
+	return &scoped
+}
+
+// WithImpersonation returns a copy of r whose requests impersonate the given user and groups, which can be used
+// to verify that the RBAC rules of a restricted user allow or deny operations. The user of the rest.Config of r
+// must be allowed to impersonate them. The namespace of r is kept.
+//
+//	restricted, err := r.WithImpersonation("jane", "developers")
+//	err = restricted.Delete(ctx, deployment) // expect a forbidden error
+func (r *Resources) WithImpersonation(user string, groups ...string) (*Resources, error) {
+	cfg := rest.CopyConfig(r.config)
+	cfg.Impersonate = rest.ImpersonationConfig{UserName: user, Groups: groups}
+	cl, err := cr.New(cfg, cr.Options{Scheme: r.scheme})
+	if err != nil {
+		return nil, err
+	}
+	return &Resources{
+		config:    cfg,
+		scheme:    r.scheme,
+		client:    cl,
+		namespace: r.namespace,
+		kube:      &lazyClientset{},
+	}, nil
+}
+
+// WithServiceAccount returns a copy of r whose requests impersonate the named service account, with the groups
+// the API server grants to service accounts, see WithImpersonation.
+func (r *Resources) WithServiceAccount(namespace, name string) (*Resources, error) {
+	return r.WithImpersonation(fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name),
+		"system:serviceaccounts", "system:serviceaccounts:"+namespace)
+}
+
 func (r *Resources) Get(ctx context.Context, name, namespace string, obj k8s.Object) error {
 	return r.client.Get(ctx, cr.ObjectKey{Namespace: namespace, Name: name}, obj)
 }
@@ -147,19 +216,31 @@ func (r *Resources) Delete(ctx context.Context, obj k8s.Object, opts ...DeleteOp
 	for _, fn := range opts {
 		fn(deleteOptions)
 	}
+	o, err := toDeleteOptions(deleteOptions)
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+	return r.client.Delete(ctx, obj, o)
+}
 
-	o := &cr.DeleteOptions{
+// toDeleteOptions validates the delete options and converts them for the controller-runtime client
+func toDeleteOptions(deleteOptions *metav1.DeleteOptions) (*cr.DeleteOptions, error) {
+	if deleteOptions.GracePeriodSeconds != nil && *deleteOptions.GracePeriodSeconds < 0 {
+		return nil, fmt.Errorf("grace period must not be negative, got %ds", *deleteOptions.GracePeriodSeconds)
+	}
+	return &cr.DeleteOptions{
 		Raw:                deleteOptions,
 		GracePeriodSeconds: deleteOptions.GracePeriodSeconds,
 		Preconditions:      deleteOptions.Preconditions,
 		PropagationPolicy:  deleteOptions.PropagationPolicy,
 		DryRun:             deleteOptions.DryRun,
-	}
-	return r.client.Delete(ctx, obj, o)
+	}, nil
 }
 
+// WithGracePeriod sets the duration the object is given to terminate before it is deleted.
+// The duration is rounded up to the second, a zero duration deletes the object immediately.
 func WithGracePeriod(gpt time.Duration) DeleteOption {
-	t := gpt.Milliseconds()
+	t := durationSeconds(gpt)
 	return func(do *metav1.DeleteOptions) { do.GracePeriodSeconds = &t }
 }
 
@@ -176,14 +257,30 @@ func (r *Resources) List(ctx context.Context, objs k8s.ObjectList, opts ...ListO
 	for _, fn := range opts {
 		fn(listOptions)
 	}
+	o, err := r.toListOptions(listOptions)
+	if err != nil {
+		return fmt.Errorf("list: %w", err)
+	}
+
+	return r.client.List(ctx, objs, o)
+}
+
+// toListOptions validates the list options and converts them for the controller-runtime client
+func (r *Resources) toListOptions(listOptions *metav1.ListOptions) (*cr.ListOptions, error) {
+	if listOptions.TimeoutSeconds != nil && *listOptions.TimeoutSeconds < 0 {
+		return nil, fmt.Errorf("timeout must not be negative, got %ds", *listOptions.TimeoutSeconds)
+	}
+	if listOptions.Limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative, got %d", listOptions.Limit)
+	}
 
 	ls, err := labels.Parse(listOptions.LabelSelector)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	fs, err := fields.ParseSelector(listOptions.FieldSelector)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	o := &cr.ListOptions{
@@ -196,8 +293,57 @@ func (r *Resources) List(ctx context.Context, objs k8s.ObjectList, opts ...ListO
 	if r.namespace != "" {
 		o.Namespace = r.namespace
 	}
+	return o, nil
+}
 
-	return r.client.List(ctx, objs, o)
+// DeleteAllOfOption is used to provide additional arguments to the DeleteAllOf call. It is built
+// from ListOption and DeleteOption values with WithListOptions and WithDeleteOptions.
+type DeleteAllOfOption func(*metav1.ListOptions, *metav1.DeleteOptions)
+
+// WithListOptions selects the objects deleted by DeleteAllOf, for instance with WithLabelSelector
+func WithListOptions(opts ...ListOption) DeleteAllOfOption {
+	return func(lo *metav1.ListOptions, _ *metav1.DeleteOptions) {
+		for _, fn := range opts {
+			fn(lo)
+		}
+	}
+}
+
+// WithDeleteOptions configures how DeleteAllOf deletes the objects, for instance with WithGracePeriod
+func WithDeleteOptions(opts ...DeleteOption) DeleteAllOfOption {
+	return func(_ *metav1.ListOptions, do *metav1.DeleteOptions) {
+		for _, fn := range opts {
+			fn(do)
+		}
+	}
+}
+
+// DeleteAllOf deletes all the objects of the type of obj matching the options in a single call. The
+// objects are deleted from the namespace set with WithNamespace, or the namespace of obj otherwise.
+//
+//	err := res.WithNamespace(ns).DeleteAllOf(ctx, &corev1.Pod{},
+//		resources.WithListOptions(resources.WithLabelSelector("app=nginx")),
+//		resources.WithDeleteOptions(resources.WithGracePeriod(0)))
+func (r *Resources) DeleteAllOf(ctx context.Context, obj k8s.Object, opts ...DeleteAllOfOption) error {
+	listOptions := &metav1.ListOptions{}
+	deleteOptions := &metav1.DeleteOptions{}
+	for _, fn := range opts {
+		fn(listOptions, deleteOptions)
+	}
+
+	lo, err := r.toListOptions(listOptions)
+	if err != nil {
+		return fmt.Errorf("delete all of: %w", err)
+	}
+	if lo.Namespace == "" {
+		lo.Namespace = obj.GetNamespace()
+	}
+	do, err := toDeleteOptions(deleteOptions)
+	if err != nil {
+		return fmt.Errorf("delete all of: %w", err)
+	}
+
+	return r.client.DeleteAllOf(ctx, obj, &cr.DeleteAllOfOptions{ListOptions: *lo, DeleteOptions: *do})
 }
 
 func WithLabelSelector(sel string) ListOption {
@@ -208,11 +354,40 @@ func WithFieldSelector(sel string) ListOption {
 	return func(lo *metav1.ListOptions) { lo.FieldSelector = sel }
 }
 
+// WithTimeout sets the server side timeout of the List call. The duration is rounded up to the second.
 func WithTimeout(to time.Duration) ListOption {
-	t := to.Milliseconds()
+	t := durationSeconds(to)
 	return func(lo *metav1.ListOptions) { lo.TimeoutSeconds = &t }
 }
 
+// WithLimit sets the maximum number of objects returned by the List call. When more objects are
+// available, the continue token of the returned list can be passed to WithContinue to get the next ones.
+func WithLimit(limit int64) ListOption {
+	return func(lo *metav1.ListOptions) { lo.Limit = limit }
+}
+
+// WithContinue sets the continue token returned by a previous List call, to get the next objects
+// of a list paginated with WithLimit.
+func WithContinue(token string) ListOption {
+	return func(lo *metav1.ListOptions) { lo.Continue = token }
+}
+
+// WithResourceVersion sets the resource version the objects are listed at, see the resourceVersion
+// semantics of the Kubernetes API. An empty value lists the most recent objects.
+func WithResourceVersion(version string) ListOption {
+	return func(lo *metav1.ListOptions) { lo.ResourceVersion = version }
+}
+
+// durationSeconds converts the duration to a number of seconds, rounding partial seconds up so
+// that a positive duration never results in 0
+func durationSeconds(d time.Duration) int64 {
+	seconds := int64(d / time.Second)
+	if d%time.Second > 0 {
+		seconds++
+	}
+	return seconds
+}
+
 // PatchOption is used to provide additional arguments to the Patch call.
 type PatchOption func(*metav1.PatchOptions)
 
@@ -227,10 +402,11 @@ func (r *Resources) Patch(ctx context.Context, obj k8s.Object, patch k8s.Patch,
 	p := cr.RawPatch(patch.PatchType, patch.Data)
 
 	o := &cr.PatchOptions{
-		Raw:          patchOptions,
-		DryRun:       patchOptions.DryRun,
-		Force:        patchOptions.Force,
-		FieldManager: patchOptions.FieldManager,
+		Raw:             patchOptions,
+		DryRun:          patchOptions.DryRun,
+		Force:           patchOptions.Force,
+		FieldManager:    patchOptions.FieldManager,
+		FieldValidation: patchOptions.FieldValidation,
 	}
 	return r.client.Patch(ctx, obj, p, o)
 }
@@ -265,6 +441,25 @@ func (r *Resources) Label(obj k8s.Object, label map[string]string) {
 	obj.SetLabels(label)
 }
 
+// SetOwnerReference adds an owner reference to owner on obj, so that obj is garbage collected once owner
+// is deleted. The object is only changed locally, it has to be created or updated afterwards for the
+// reference to take effect. The GroupVersionKind of owner is resolved using the scheme of the Resources.
+func (r *Resources) SetOwnerReference(owner, obj k8s.Object) error {
+	if err := controllerutil.SetOwnerReference(owner, obj, r.scheme); err != nil {
+		return fmt.Errorf("set owner reference: %w", err)
+	}
+	return nil
+}
+
+// SetControllerReference behaves like SetOwnerReference but marks owner as the managing controller of obj.
+// It fails if obj is already controlled by another object.
+func (r *Resources) SetControllerReference(owner, obj k8s.Object) error {
+	if err := controllerutil.SetControllerReference(owner, obj, r.scheme); err != nil {
+		return fmt.Errorf("set controller reference: %w", err)
+	}
+	return nil
+}
+
 func (r *Resources) GetScheme() *runtime.Scheme {
 	return r.scheme
 }
@@ -274,6 +469,8 @@ func (r *Resources) GetControllerRuntimeClient() cr.Client {
 	return r.client
 }
 
+// Watch returns a watcher for the objects of the type of object in the namespace of the Resources, or in all
+// namespaces if none is set. The label and field selectors of the list options are applied to the watch.
 func (r *Resources) Watch(object k8s.ObjectList, opts ...ListOption) *watcher.EventHandlerFuncs {
 	listOptions := &metav1.ListOptions{}
 
@@ -281,7 +478,7 @@ func (r *Resources) Watch(object k8s.ObjectList, opts ...ListOption) *watcher.Ev
 		fn(listOptions)
 	}
 
-	o := &cr.ListOptions{Raw: listOptions}
+	o := &cr.ListOptions{Raw: listOptions, Namespace: r.namespace}
 
 	return &watcher.EventHandlerFuncs{
 		ListOptions: o,
@@ -291,7 +488,7 @@ func (r *Resources) Watch(object k8s.ObjectList, opts ...ListOption) *watcher.Ev
 }
 
 func (r *Resources) ExecInPod(ctx context.Context, namespaceName, podName, containerName string, command []string, stdout, stderr *bytes.Buffer) error {
-	clientset, err := kubernetes.NewForConfig(r.config)
+	clientset, err := r.clientset()
 	if err != nil {
 		return err
 	}
@@ -329,6 +526,116 @@ func (r *Resources) ExecInPod(ctx context.Context, namespaceName, podName, conta
 	return nil
 }
 
+// PortForward forwards a random local port to remotePort of a pod, as kubectl port-forward does, and returns the
+// local port once the forwarding is ready. Forwarding stops when stop is called or when ctx is done.
+//
+//	localPort, stop, err := r.PortForward(ctx, namespace, podName, 8080)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer stop()
+//	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/healthz", localPort))
+func (r *Resources) PortForward(ctx context.Context, namespaceName, podName string, remotePort int) (localPort int, stop func(), err error) {
+	clientset, err := r.clientset()
+	if err != nil {
+		return 0, nil, err
+	}
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespaceName).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(r.config)
+	if err != nil {
+		return 0, nil, err
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	var once sync.Once
+	stop = func() { once.Do(func() { close(stopCh) }) }
+
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", remotePort)}, stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return 0, nil, fmt.Errorf("port forward to %s/%s: %w", namespaceName, podName, err)
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- forwarder.ForwardPorts()
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			stop()
+		case <-stopCh:
+		}
+	}()
+
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		stop()
+		return 0, nil, fmt.Errorf("port forward to %s/%s: %w", namespaceName, podName, err)
+	case <-ctx.Done():
+		stop()
+		return 0, nil, ctx.Err()
+	}
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		stop()
+		return 0, nil, fmt.Errorf("port forward to %s/%s: %w", namespaceName, podName, err)
+	}
+	if len(ports) == 0 {
+		stop()
+		return 0, nil, fmt.Errorf("port forward to %s/%s: no forwarded port", namespaceName, podName)
+	}
+	return int(ports[0].Local), stop, nil
+}
+
+// ProxyGet sends a GET request for path to a pod or a service through the API server proxy and returns the
+// response body, which lets tests reach workloads without a NodePort, a LoadBalancer or a port forward.
+// serviceOrPod is either a service name, or a name prefixed with "service/" or "pod/" as with kubectl. port is
+// a port number or name, and scheme is http or https, http when empty. A query string in path is sent along.
+//
+//	body, err := r.ProxyGet(ctx, "https", "webhook-system", "service/webhook", "443", "/healthz")
+func (r *Resources) ProxyGet(ctx context.Context, scheme, namespaceName, serviceOrPod, port, path string) ([]byte, error) {
+	clientset, err := r.clientset()
+	if err != nil {
+		return nil, err
+	}
+	path, query, _ := strings.Cut(path, "?")
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("proxy get %s: invalid query: %w", path, err)
+	}
+	params := make(map[string]string, len(values))
+	for key := range values {
+		params[key] = values.Get(key)
+	}
+	path = strings.TrimPrefix(path, "/")
+
+	kind, name, found := strings.Cut(serviceOrPod, "/")
+	if !found {
+		kind, name = "service", serviceOrPod
+	}
+	var req rest.ResponseWrapper
+	switch kind {
+	case "service", "services", "svc":
+		req = clientset.CoreV1().Services(namespaceName).ProxyGet(scheme, name, port, path, params)
+	case "pod", "pods", "po":
+		req = clientset.CoreV1().Pods(namespaceName).ProxyGet(scheme, name, port, path, params)
+	default:
+		return nil, fmt.Errorf("proxy get: unsupported target %q, expected a service or a pod", serviceOrPod)
+	}
+	data, err := req.DoRaw(ctx)
+	if err != nil {
+		return data, fmt.Errorf("proxy get %s %s/%s:%s/%s: %w", kind, namespaceName, name, port, path, err)
+	}
+	return data, nil
+}
+
 func init() {
 	log.SetLogger(klog.NewKlogr())
 }
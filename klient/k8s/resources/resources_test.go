@@ -26,6 +26,7 @@ import (
 
 	"github.com/vladimirvivien/gexe"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -435,3 +436,50 @@ func TestExecInPod(t *testing.T) {
 		t.Fatal("Couldn't find proper env")
 	}
 }
+
+func TestRunJobAndWait(t *testing.T) {
+	res, err := resources.New(cfg)
+	if err != nil {
+		t.Fatalf("Error initiating runtime controller: %v", err)
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-job-ns"}}
+	if err := res.Create(context.TODO(), namespace); err != nil {
+		t.Fatalf("Error while creating namespace resource: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		command   []string
+		succeeded bool
+	}{
+		{name: "job-succeeds", command: []string{"sh", "-c", "echo hello from job"}, succeeded: true},
+		{name: "job-fails", command: []string{"sh", "-c", "echo hello from job; exit 1"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var backoffLimit int32
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: tc.name, Namespace: namespace.Name},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: &backoffLimit,
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							Containers:    []corev1.Container{{Name: "main", Image: "busybox", Command: tc.command}},
+						},
+					},
+				},
+			}
+			result, err := res.RunJobAndWait(ctx, job, resources.WithJobTimeout(3*time.Minute))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Succeeded != tc.succeeded {
+				t.Errorf("expected job success to be %v, got %v", tc.succeeded, result.Succeeded)
+			}
+			if !strings.Contains(result.Output(), "hello from job") {
+				t.Errorf("expected job output to be captured, got %q", result.Output())
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+)
+
+// DefaultCleanupTimeout is how long CleanupRegistry.Cleanup waits for the tracked objects to be deleted
+// when its context has no deadline
+const DefaultCleanupTimeout = 2 * time.Minute
+
+type cleanupRegistryKey struct{}
+
+// trackedObject is an object created by a Tracker, with the resources used to create it
+type trackedObject struct {
+	resources *Resources
+	obj       k8s.Object
+}
+
+// CleanupRegistry records the objects created by Trackers so that they can be deleted at once. The
+// framework attaches a registry to the context of each feature and cleans it up after the teardown
+// steps of the feature. It is safe for concurrent use.
+type CleanupRegistry struct {
+	mu      sync.Mutex
+	objects []trackedObject
+}
+
+// NewCleanupRegistry returns an empty CleanupRegistry
+func NewCleanupRegistry() *CleanupRegistry {
+	return &CleanupRegistry{}
+}
+
+// WithCleanupRegistry returns a copy of ctx carrying the registry, in which the objects created by
+// Trackers with the context are recorded
+func WithCleanupRegistry(ctx context.Context, registry *CleanupRegistry) context.Context {
+	return context.WithValue(ctx, cleanupRegistryKey{}, registry)
+}
+
+// CleanupRegistryFromContext returns the registry carried by ctx, or nil
+func CleanupRegistryFromContext(ctx context.Context) *CleanupRegistry {
+	registry, _ := ctx.Value(cleanupRegistryKey{}).(*CleanupRegistry)
+	return registry
+}
+
+func (c *CleanupRegistry) track(r *Resources, obj k8s.Object) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects = append(c.objects, trackedObject{resources: r, obj: obj.DeepCopyObject().(k8s.Object)})
+}
+
+// Len returns the number of objects waiting to be cleaned up
+func (c *CleanupRegistry) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.objects)
+}
+
+// Cleanup deletes the tracked objects in the reverse order of their creation, with background
+// propagation, and waits for each of them to be gone before deleting the next one, so that for
+// instance the objects of a namespace are deleted before the namespace. Objects that were already
+// deleted are ignored. All the objects are processed even when some of them fail to be deleted,
+// the errors being aggregated, and the registry is empty once Cleanup returns.
+func (c *CleanupRegistry) Cleanup(ctx context.Context) error {
+	c.mu.Lock()
+	objects := c.objects
+	c.objects = nil
+	c.mu.Unlock()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultCleanupTimeout)
+		defer cancel()
+	}
+
+	var errs []error
+	for i := len(objects) - 1; i >= 0; i-- {
+		if err := objects[i].delete(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t trackedObject) delete(ctx context.Context) error {
+	name, namespace := t.obj.GetName(), t.obj.GetNamespace()
+	klog.V(4).InfoS("Deleting tracked object", "kind", fmt.Sprintf("%T", t.obj), "namespace", namespace, "name", name)
+	err := t.resources.Delete(ctx, t.obj, WithDeletePropagation(string(metav1.DeletePropagationBackground)))
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("delete %T %s/%s: %w", t.obj, namespace, name, err)
+	}
+	err = apimachinerywait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
+		err := t.resources.Get(ctx, name, namespace, t.obj.DeepCopyObject().(k8s.Object))
+		return apierrors.IsNotFound(err), nil
+	})
+	if err != nil {
+		return fmt.Errorf("wait for %T %s/%s to be deleted: %w", t.obj, namespace, name, err)
+	}
+	return nil
+}
+
+// Tracker creates objects like Resources does and records them in the CleanupRegistry carried by the
+// context, so that they are deleted automatically once the feature is done instead of in teardown steps.
+type Tracker struct {
+	resources *Resources
+}
+
+// Tracker returns a Tracker creating the objects with r
+//
+//	func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+//		// deleted after the teardown steps of the feature
+//		if err := cfg.Client().Resources().Tracker().Create(ctx, deployment); err != nil {
+//			t.Fatal(err)
+//		}
+//		return ctx
+//	}
+func (r *Resources) Tracker() *Tracker {
+	return &Tracker{resources: r}
+}
+
+// Create creates the object and records it in the cleanup registry of ctx. An error is returned, without
+// creating the object, when ctx does not carry a registry, as the object would never be cleaned up.
+func (t *Tracker) Create(ctx context.Context, obj k8s.Object, opts ...CreateOption) error {
+	registry := CleanupRegistryFromContext(ctx)
+	if registry == nil {
+		return fmt.Errorf("create %s/%s: no cleanup registry in context", obj.GetNamespace(), obj.GetName())
+	}
+	if err := t.resources.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	registry.track(t.resources, obj)
+	return nil
+}
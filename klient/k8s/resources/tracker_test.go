@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+)
+
+func TestTracker(t *testing.T) {
+	var deleted []string
+	client := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Delete: func(ctx context.Context, client cr.WithWatch, obj cr.Object, opts ...cr.DeleteOption) error {
+			deleted = append(deleted, obj.GetName())
+			return client.Delete(ctx, obj, opts...)
+		},
+	}).Build()
+	r := &Resources{client: client}
+
+	if err := r.Tracker().Create(context.TODO(), &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "untracked"}}); err == nil {
+		t.Fatal("expected an error without a cleanup registry")
+	}
+
+	registry := NewCleanupRegistry()
+	ctx := WithCleanupRegistry(context.TODO(), registry)
+	objects := []k8s.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "apps"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps"}},
+		&v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "gone", Namespace: "apps"}},
+	}
+	for _, obj := range objects {
+		if err := r.Tracker().Create(ctx, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// an object deleted by the test itself is ignored by the cleanup
+	if err := client.Delete(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "gone", Namespace: "apps"}}); err != nil {
+		t.Fatal(err)
+	}
+	deleted = nil
+	if registry.Len() != 4 {
+		t.Fatalf("expected 4 tracked objects, got %d", registry.Len())
+	}
+
+	if err := registry.Cleanup(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"gone", "web", "config", "apps"}; len(deleted) != len(want) || deleted[0] != want[0] || deleted[1] != want[1] || deleted[2] != want[2] || deleted[3] != want[3] {
+		t.Errorf("expected the objects to be deleted in reverse order %v, got %v", want, deleted)
+	}
+	for _, obj := range objects {
+		if err := r.Get(context.TODO(), obj.GetName(), obj.GetNamespace(), obj); !apierrors.IsNotFound(err) {
+			t.Errorf("expected %s to be deleted, got %v", obj.GetName(), err)
+		}
+	}
+	if registry.Len() != 0 {
+		t.Error("expected the registry to be empty after the cleanup")
+	}
+}
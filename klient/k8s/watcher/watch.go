@@ -18,7 +18,13 @@ package watcher
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/rest"
 	klog "k8s.io/klog/v2"
@@ -27,18 +33,39 @@ import (
 	"sigs.k8s.io/e2e-framework/klient/k8s"
 )
 
+// reconnectInterval is the time waited between two attempts to re-establish a dropped watch
+var reconnectInterval = time.Second
+
+// ErrWatcherStopped is returned by WaitFor when the watcher stops before a matching event is received
+var ErrWatcherStopped = errors.New("watcher stopped")
+
 // EventHandlerFuncs is an adaptor to let you easily specify as many or
 // as few of functions to invoke while getting notification from watcher
 type EventHandlerFuncs struct {
-	addFunc     func(obj interface{})
-	updateFunc  func(newObj interface{})
-	deleteFunc  func(obj interface{})
-	watcher     watch.Interface
+	addFunc         func(obj interface{})
+	updateFunc      func(newObj interface{})
+	typedUpdateFunc func(oldObj, newObj k8s.Object)
+	deleteFunc      func(obj interface{})
+	errorFunc       func(err error)
+
+	mu      sync.Mutex
+	watcher watch.Interface
+	stopped bool
+	done    chan struct{}
+	waiters map[*waiter]struct{}
+
 	ListOptions *cr.ListOptions
 	K8sObject   k8s.ObjectList
 	Cfg         *rest.Config
 }
 
+// waiter is a pending WaitFor call
+type waiter struct {
+	predicate func(eventType watch.EventType, obj k8s.Object) bool
+	matched   chan struct{}
+	once      sync.Once
+}
+
 // EventHandler can handle notifications for events that happen to a resource.
 // Start will be waiting for the events notification which is responsible
 // for invoking the registered user defined functions.
@@ -49,7 +76,10 @@ type EventHandler interface {
 }
 
 // Start triggers the registered methods based on the event received for
-// particular k8s resources
+// particular k8s resources. When the watch connection drops, it is
+// re-established from the last received resource version, or from the
+// current state of the resources if that version has expired. Failures
+// are reported to the function registered with WithErrorFunc.
 func (e *EventHandlerFuncs) Start(ctx context.Context) error {
 	// check if context is valid and that it has not been cancelled.
 	if ctx.Err() != nil {
@@ -61,52 +91,234 @@ func (e *EventHandlerFuncs) Start(ctx context.Context) error {
 		return err
 	}
 
-	w, err := cl.Watch(ctx, e.K8sObject, e.ListOptions)
+	w, err := cl.Watch(ctx, e.K8sObject, e.listOptions(""))
 	if err != nil {
 		return err
 	}
 
 	// set watcher object
+	e.mu.Lock()
 	e.watcher = w
+	e.stopped = false
+	e.done = make(chan struct{})
+	done := e.done
+	e.mu.Unlock()
 
 	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				if ctx.Err() != nil {
+		defer close(done)
+		e.run(ctx, cl, w)
+	}()
+
+	return nil
+}
+
+// run dispatches the events received from the watcher until the context is
+// done or the watcher is stopped
+func (e *EventHandlerFuncs) run(ctx context.Context, cl cr.WithWatch, w watch.Interface) {
+	objects := map[types.UID]k8s.Object{}
+	var resourceVersion string
+	for {
+		select {
+		case <-ctx.Done():
+			w.Stop()
+			return
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				if w = e.rewatch(ctx, cl, resourceVersion); w == nil {
 					return
 				}
-			case event := <-e.watcher.ResultChan():
-				// retrieve the event type
-				eventType := event.Type
-
-				switch eventType {
-				case watch.Added:
-					// calls AddFunc if it's not nil.
-					if e.addFunc != nil {
-						e.addFunc(event.Object)
-					}
-				case watch.Modified:
-					// calls UpdateFunc if it's not nil.
-					if e.updateFunc != nil {
-						e.updateFunc(event.Object)
-					}
-				case watch.Deleted:
-					// calls DeleteFunc if it's not nil.
-					if e.deleteFunc != nil {
-						e.deleteFunc(event.Object)
-					}
+				continue
+			}
+
+			switch event.Type {
+			case watch.Error:
+				err := apierrors.FromObject(event.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					// the watch can't be resumed, start over from the current state
+					resourceVersion = ""
+					w.Stop()
+					continue
+				}
+				e.handleError(fmt.Errorf("watch error: %w", err))
+			case watch.Bookmark:
+				if obj, ok := event.Object.(k8s.Object); ok {
+					resourceVersion = obj.GetResourceVersion()
+				}
+			default:
+				obj, ok := event.Object.(k8s.Object)
+				if !ok {
+					e.handleError(fmt.Errorf("watch: unexpected type %T in event, does not satisfy k8s.Object", event.Object))
+					continue
 				}
+				resourceVersion = obj.GetResourceVersion()
+				e.dispatch(event.Type, obj, objects)
 			}
 		}
-	}()
+	}
+}
 
-	return nil
+// rewatch re-establishes a dropped watch from the given resource version, it
+// returns nil if the context is done or the watcher is stopped in the meantime
+func (e *EventHandlerFuncs) rewatch(ctx context.Context, cl cr.WithWatch, resourceVersion string) watch.Interface {
+	for {
+		if e.isStopped() || ctx.Err() != nil {
+			return nil
+		}
+		klog.V(4).InfoS("Re-establishing watch", "resourceVersion", resourceVersion)
+		w, err := cl.Watch(ctx, e.K8sObject, e.listOptions(resourceVersion))
+		if err == nil {
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			if e.stopped {
+				w.Stop()
+				return nil
+			}
+			e.watcher = w
+			return w
+		}
+		if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+			resourceVersion = ""
+		}
+		e.handleError(fmt.Errorf("re-establishing watch: %w", err))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconnectInterval):
+		}
+	}
+}
+
+// listOptions returns the options of the watch starting at the given resource version
+func (e *EventHandlerFuncs) listOptions(resourceVersion string) *cr.ListOptions {
+	opts := &cr.ListOptions{}
+	if e.ListOptions != nil {
+		*opts = *e.ListOptions
+	}
+	if opts.Raw != nil {
+		raw := *opts.Raw
+		opts.Raw = &raw
+	}
+	if resourceVersion != "" {
+		opts.AsListOptions().ResourceVersion = resourceVersion
+	}
+	return opts
+}
+
+// dispatch invokes the registered functions for an event and notifies the
+// pending WaitFor calls. objects holds the last known state of the objects
+// and is used to provide the previous state to the typed update function.
+func (e *EventHandlerFuncs) dispatch(eventType watch.EventType, obj k8s.Object, objects map[types.UID]k8s.Object) {
+	old, known := objects[obj.GetUID()]
+	switch eventType {
+	case watch.Added:
+		objects[obj.GetUID()] = obj
+		if !known {
+			// calls AddFunc if it's not nil.
+			if e.addFunc != nil {
+				e.addFunc(obj)
+			}
+			break
+		}
+		// a re-established watch replays the current state of known objects
+		if old.GetResourceVersion() == obj.GetResourceVersion() {
+			return
+		}
+		eventType = watch.Modified
+		e.update(old, obj)
+	case watch.Modified:
+		objects[obj.GetUID()] = obj
+		e.update(old, obj)
+	case watch.Deleted:
+		delete(objects, obj.GetUID())
+		// calls DeleteFunc if it's not nil.
+		if e.deleteFunc != nil {
+			e.deleteFunc(obj)
+		}
+	}
+	e.notifyWaiters(eventType, obj)
+}
+
+// update calls UpdateFunc and the typed update function if they are not nil.
+func (e *EventHandlerFuncs) update(old, obj k8s.Object) {
+	if e.updateFunc != nil {
+		e.updateFunc(obj)
+	}
+	if e.typedUpdateFunc != nil {
+		e.typedUpdateFunc(old, obj)
+	}
+}
+
+func (e *EventHandlerFuncs) handleError(err error) {
+	klog.V(4).ErrorS(err, "Watch failure")
+	if e.errorFunc != nil {
+		e.errorFunc(err)
+	}
+}
+
+func (e *EventHandlerFuncs) notifyWaiters(eventType watch.EventType, obj k8s.Object) {
+	e.mu.Lock()
+	waiters := make([]*waiter, 0, len(e.waiters))
+	for w := range e.waiters {
+		waiters = append(waiters, w)
+	}
+	e.mu.Unlock()
+
+	for _, w := range waiters {
+		if w.predicate(eventType, obj) {
+			w.once.Do(func() { close(w.matched) })
+		}
+	}
+}
+
+func (e *EventHandlerFuncs) isStopped() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.stopped
 }
 
 // Stop triggers stopping a particular k8s watch resources
 func (e *EventHandlerFuncs) Stop() {
-	e.watcher.Stop()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stopped = true
+	if e.watcher != nil {
+		e.watcher.Stop()
+	}
+}
+
+// WaitFor blocks until the watcher receives an event for which predicate returns
+// true. It returns an error if no such event is received within timeout or if the
+// watcher stops first. Only events received after WaitFor is called are considered.
+//
+//	err := w.WaitFor(func(eventType watch.EventType, obj k8s.Object) bool {
+//		return eventType == watch.Deleted && obj.GetName() == "nginx"
+//	}, time.Minute)
+func (e *EventHandlerFuncs) WaitFor(predicate func(eventType watch.EventType, obj k8s.Object) bool, timeout time.Duration) error {
+	w := &waiter{predicate: predicate, matched: make(chan struct{})}
+	e.mu.Lock()
+	if e.waiters == nil {
+		e.waiters = map[*waiter]struct{}{}
+	}
+	e.waiters[w] = struct{}{}
+	done := e.done
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		delete(e.waiters, w)
+		e.mu.Unlock()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-w.matched:
+		return nil
+	case <-done:
+		return ErrWatcherStopped
+	case <-timer.C:
+		return fmt.Errorf("no matching event received within %s", timeout)
+	}
 }
 
 // WithAddFunc used to set action on create event
@@ -121,12 +333,27 @@ func (e *EventHandlerFuncs) WithUpdateFunc(updatefn func(updated interface{})) *
 	return e
 }
 
+// WithTypedUpdateFunc sets action for any update events, the function receives
+// the previous and the updated state of the object. The previous state is nil
+// if the object was not seen by the watcher before.
+func (e *EventHandlerFuncs) WithTypedUpdateFunc(updatefn func(oldObj, newObj k8s.Object)) *EventHandlerFuncs {
+	e.typedUpdateFunc = updatefn
+	return e
+}
+
 // WithDeleteFunc sets action for delete events
 func (e *EventHandlerFuncs) WithDeleteFunc(deletefn func(obj interface{})) *EventHandlerFuncs {
 	e.deleteFunc = deletefn
 	return e
 }
 
+// WithErrorFunc sets action for watch failures, such as error events or
+// failed attempts to re-establish a dropped watch
+func (e *EventHandlerFuncs) WithErrorFunc(errorfn func(err error)) *EventHandlerFuncs {
+	e.errorFunc = errorfn
+	return e
+}
+
 func init() {
 	log.SetLogger(klog.NewKlogr())
 }
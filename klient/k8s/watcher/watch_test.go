@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watcher
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+)
+
+func pod(name, uid, resourceVersion string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: name, Namespace: "default", UID: types.UID(uid), ResourceVersion: resourceVersion,
+	}}
+}
+
+func TestEventHandlerFuncs_Run(t *testing.T) {
+	var mu sync.Mutex
+	var added []string
+	var updates [][2]string
+	e := &EventHandlerFuncs{K8sObject: &corev1.PodList{}}
+	e.WithAddFunc(func(obj interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		added = append(added, obj.(k8s.Object).GetName())
+	}).WithTypedUpdateFunc(func(oldObj, newObj k8s.Object) {
+		mu.Lock()
+		defer mu.Unlock()
+		updates = append(updates, [2]string{oldObj.GetResourceVersion(), newObj.GetResourceVersion()})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cl := fake.NewClientBuilder().Build()
+	w := watch.NewFake()
+	e.watcher = w
+	e.done = make(chan struct{})
+	go func() {
+		defer close(e.done)
+		e.run(ctx, cl, w)
+	}()
+
+	w.Add(pod("a", "1", "1"))
+	w.Modify(pod("a", "1", "2"))
+
+	// dropping the connection re-establishes the watch
+	reconnectInterval = 10 * time.Millisecond
+	w.Stop()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		if err := cl.Create(ctx, pod("b", "2", "")); err != nil {
+			t.Error(err)
+		}
+	}()
+	err := e.WaitFor(func(eventType watch.EventType, obj k8s.Object) bool {
+		return eventType == watch.Added && obj.GetName() == "b"
+	}, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	if len(added) != 2 || added[0] != "a" || added[1] != "b" {
+		t.Errorf("unexpected added objects %v", added)
+	}
+	if len(updates) != 1 || updates[0] != [2]string{"1", "2"} {
+		t.Errorf("unexpected updates %v", updates)
+	}
+	mu.Unlock()
+
+	e.Stop()
+	if err := e.WaitFor(func(watch.EventType, k8s.Object) bool { return false }, 5*time.Second); err != ErrWatcherStopped {
+		t.Errorf("expected ErrWatcherStopped, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics provides access to the resource usage reported by the metrics.k8s.io API and to the
+// Prometheus metrics exposed by pods, so tests can assert on them without deploying a Prometheus server.
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// metricsAPIPath is the path of the resource metrics API, served by metrics-server
+const metricsAPIPath = "/apis/metrics.k8s.io/v1beta1"
+
+// ContainerMetrics is the resource usage of a container
+type ContainerMetrics struct {
+	Name  string          `json:"name"`
+	Usage v1.ResourceList `json:"usage"`
+}
+
+// PodMetrics is the resource usage of the containers of a pod, as reported by the metrics.k8s.io API
+type PodMetrics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Timestamp  metav1.Time        `json:"timestamp"`
+	Window     metav1.Duration    `json:"window"`
+	Containers []ContainerMetrics `json:"containers"`
+}
+
+// Usage returns the resource usage of the pod, that is the sum of the usage of its containers
+func (p *PodMetrics) Usage() v1.ResourceList {
+	usage := v1.ResourceList{}
+	for _, c := range p.Containers {
+		for name, quantity := range c.Usage {
+			total := usage[name]
+			total.Add(quantity)
+			usage[name] = total
+		}
+	}
+	return usage
+}
+
+// NodeMetrics is the resource usage of a node, as reported by the metrics.k8s.io API
+type NodeMetrics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Timestamp metav1.Time     `json:"timestamp"`
+	Window    metav1.Duration `json:"window"`
+	Usage     v1.ResourceList `json:"usage"`
+}
+
+// CPU returns the CPU usage of the node
+func (n *NodeMetrics) CPU() resource.Quantity {
+	return n.Usage[v1.ResourceCPU]
+}
+
+// Memory returns the memory usage of the node
+func (n *NodeMetrics) Memory() resource.Quantity {
+	return n.Usage[v1.ResourceMemory]
+}
+
+// Client fetches metrics from the cluster
+type Client struct {
+	clientset kubernetes.Interface
+}
+
+// New returns a metrics Client for the cluster of cfg. The metrics.k8s.io API is only served when
+// metrics-server, or another implementation of the API, is installed in the cluster.
+func New(cfg *rest.Config) (*Client, error) {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{clientset: clientset}, nil
+}
+
+// PodMetrics returns the resource usage of the named pod
+func (c *Client) PodMetrics(ctx context.Context, namespace, name string) (*PodMetrics, error) {
+	var metrics PodMetrics
+	if err := c.getMetrics(ctx, &metrics, "namespaces", namespace, "pods", name); err != nil {
+		return nil, fmt.Errorf("pod metrics %s/%s: %w", namespace, name, err)
+	}
+	return &metrics, nil
+}
+
+// NodeMetrics returns the resource usage of the named node
+func (c *Client) NodeMetrics(ctx context.Context, name string) (*NodeMetrics, error) {
+	var metrics NodeMetrics
+	if err := c.getMetrics(ctx, &metrics, "nodes", name); err != nil {
+		return nil, fmt.Errorf("node metrics %s: %w", name, err)
+	}
+	return &metrics, nil
+}
+
+func (c *Client) getMetrics(ctx context.Context, into any, segments ...string) error {
+	data, err := c.clientset.CoreV1().RESTClient().Get().
+		AbsPath(append([]string{metricsAPIPath}, segments...)...).
+		DoRaw(ctx)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, into)
+}
+
+// ScrapePod fetches the Prometheus metrics exposed by a pod on port, a port number or name, and path,
+// /metrics when empty, and returns the parsed samples. The request goes through the API server proxy, so the
+// pod does not need to be reachable from where the tests run.
+func (c *Client) ScrapePod(ctx context.Context, namespace, name, port, path string) (Samples, error) {
+	if path == "" {
+		path = "/metrics"
+	}
+	data, err := c.clientset.CoreV1().Pods(namespace).
+		ProxyGet("", name, port, strings.TrimPrefix(path, "/"), nil).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("scrape pod %s/%s: %w", namespace, name, err)
+	}
+	samples, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("scrape pod %s/%s: %w", namespace, name, err)
+	}
+	return samples, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods/web":
+			_, _ = w.Write([]byte(`{"kind":"PodMetrics","apiVersion":"metrics.k8s.io/v1beta1","metadata":{"name":"web","namespace":"default"},
+"window":"15s","containers":[{"name":"app","usage":{"cpu":"150m","memory":"64Mi"}},{"name":"sidecar","usage":{"cpu":"50m","memory":"16Mi"}}]}`))
+		case "/apis/metrics.k8s.io/v1beta1/nodes/worker":
+			_, _ = w.Write([]byte(`{"kind":"NodeMetrics","apiVersion":"metrics.k8s.io/v1beta1","metadata":{"name":"worker"},"window":"20s","usage":{"cpu":"1","memory":"2Gi"}}`))
+		case "/api/v1/namespaces/default/pods/web:metrics/proxy/metrics":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("# TYPE up gauge\nup 1\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := New(&rest.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.TODO()
+
+	pod, err := client.PodMetrics(ctx, "default", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	usage := pod.Usage()
+	if cpu := usage.Cpu().MilliValue(); cpu != 200 {
+		t.Errorf("expected pod cpu usage 200m, got %dm", cpu)
+	}
+	if mem := usage.Memory().Value(); mem != 80*1024*1024 {
+		t.Errorf("expected pod memory usage 80Mi, got %d", mem)
+	}
+
+	node, err := client.NodeMetrics(ctx, "worker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cpu := node.CPU(); cpu.MilliValue() != 1000 {
+		t.Errorf("expected node cpu usage 1, got %s", cpu.String())
+	}
+
+	if _, err := client.NodeMetrics(ctx, "missing"); err == nil {
+		t.Error("expected an error for a missing node")
+	}
+
+	samples, err := client.ScrapePod(ctx, "default", "web", "metrics", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up, ok := samples.Value("up", nil); !ok || up != 1 {
+		t.Errorf("expected up to be 1, got %v (found %v)", up, ok)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Sample is a single value of the Prometheus text exposition format. Histograms and summaries are
+// exposed as several samples, such as <name>_bucket, <name>_sum and <name>_count.
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Samples is a list of parsed samples
+type Samples []Sample
+
+// Find returns the samples with the given name whose labels include all the given labels
+func (s Samples) Find(name string, labels map[string]string) Samples {
+	var found Samples
+	for _, sample := range s {
+		if sample.Name != name || !hasLabels(sample, labels) {
+			continue
+		}
+		found = append(found, sample)
+	}
+	return found
+}
+
+// Value returns the value of the first sample matching name and labels, and whether there is one
+func (s Samples) Value(name string, labels map[string]string) (float64, bool) {
+	found := s.Find(name, labels)
+	if len(found) == 0 {
+		return 0, false
+	}
+	return found[0].Value, true
+}
+
+// Sum returns the sum of the values of the samples matching name and labels
+func (s Samples) Sum(name string, labels map[string]string) float64 {
+	var sum float64
+	for _, sample := range s.Find(name, labels) {
+		sum += sample.Value
+	}
+	return sum
+}
+
+func hasLabels(sample Sample, labels map[string]string) bool {
+	for k, v := range labels {
+		if sample.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse parses metrics in the Prometheus text exposition format. Comments, including HELP and TYPE
+// metadata, and timestamps are ignored.
+func Parse(r io.Reader) (Samples, error) {
+	var samples Samples
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		sample, err := parseSample(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		samples = append(samples, sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+func parseSample(text string) (Sample, error) {
+	sample := Sample{Labels: map[string]string{}}
+	end := strings.IndexAny(text, "{ \t")
+	if end <= 0 {
+		return sample, fmt.Errorf("invalid sample %q", text)
+	}
+	sample.Name = text[:end]
+	rest := text[end:]
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		rest, err = parseLabels(rest[1:], sample.Labels)
+		if err != nil {
+			return sample, fmt.Errorf("sample %s: %w", sample.Name, err)
+		}
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return sample, fmt.Errorf("sample %s: invalid value %q", sample.Name, strings.TrimSpace(rest))
+	}
+	value, err := parseValue(fields[0])
+	if err != nil {
+		return sample, fmt.Errorf("sample %s: %w", sample.Name, err)
+	}
+	sample.Value = value
+	return sample, nil
+}
+
+// parseLabels parses the labels following the opening brace into labels and returns the text after the closing brace
+func parseLabels(text string, labels map[string]string) (string, error) {
+	for {
+		text = strings.TrimLeft(text, " \t,")
+		if strings.HasPrefix(text, "}") {
+			return text[1:], nil
+		}
+		eq := strings.Index(text, "=")
+		if eq <= 0 {
+			return "", fmt.Errorf("invalid labels")
+		}
+		name := strings.TrimSpace(text[:eq])
+		text = strings.TrimLeft(text[eq+1:], " \t")
+		if !strings.HasPrefix(text, `"`) {
+			return "", fmt.Errorf("label %s: value is not quoted", name)
+		}
+		var value strings.Builder
+		i := 1
+		for ; i < len(text) && text[i] != '"'; i++ {
+			if text[i] == '\\' && i+1 < len(text) {
+				i++
+				switch text[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(text[i])
+				}
+				continue
+			}
+			value.WriteByte(text[i])
+		}
+		if i == len(text) {
+			return "", fmt.Errorf("label %s: unterminated value", name)
+		}
+		labels[name] = value.String()
+		text = text[i+1:]
+	}
+}
+
+func parseValue(s string) (float64, error) {
+	switch s {
+	case "+Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
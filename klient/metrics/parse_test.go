@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+const exposition = `# HELP http_requests_total The total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="post",code="200"} 1027 1395066363000
+http_requests_total{method="post",code="400"}    3 1395066363000
+http_requests_total{method="get",code="200"} 14
+
+# A comment with "quotes"
+msdos_file_access_time_seconds{path="C:\\DIR\\FILE.TXT",error="Cannot find file:\n\"FILE.TXT\""} 1.458255915e9
+process_open_fds 12
+request_duration_seconds_bucket{le="+Inf"} 144320
+go_gc_pause{quantile="0.5"} NaN
+`
+
+func TestParse(t *testing.T) {
+	samples, err := Parse(strings.NewReader(exposition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 7 {
+		t.Fatalf("expected 7 samples, got %d: %v", len(samples), samples)
+	}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		value  float64
+	}{
+		{name: "http_requests_total", labels: map[string]string{"method": "post", "code": "400"}, value: 3},
+		{name: "msdos_file_access_time_seconds", labels: map[string]string{"path": `C:\DIR\FILE.TXT`, "error": "Cannot find file:\n\"FILE.TXT\""}, value: 1.458255915e9},
+		{name: "process_open_fds", value: 12},
+		{name: "request_duration_seconds_bucket", labels: map[string]string{"le": "+Inf"}, value: 144320},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			value, ok := samples.Value(tc.name, tc.labels)
+			if !ok {
+				t.Fatalf("sample %s%v not found", tc.name, tc.labels)
+			}
+			if value != tc.value {
+				t.Errorf("expected value %v, got %v", tc.value, value)
+			}
+		})
+	}
+
+	if sum := samples.Sum("http_requests_total", map[string]string{"code": "200"}); sum != 1041 {
+		t.Errorf("expected sum 1041, got %v", sum)
+	}
+	if value, _ := samples.Value("go_gc_pause", nil); !math.IsNaN(value) {
+		t.Errorf("expected NaN, got %v", value)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "missing value", input: "process_open_fds"},
+		{name: "invalid value", input: "process_open_fds twelve"},
+		{name: "unquoted label", input: `http_requests_total{code=200} 1`},
+		{name: "unterminated label", input: `http_requests_total{code="200} 1`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tc.input)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
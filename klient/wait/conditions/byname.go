@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+// The conditions below look resources up by name and namespace, like DeploymentAvailable, so waiting on them does
+// not require building a partially filled object first.
+
+// PodReadyByName is a helper function used to check if the pod condition v1.PodReady of the named pod has reached
+// v1.ConditionTrue state, see PodReady
+func (c *Condition) PodReadyByName(name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return c.PodReady(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})
+}
+
+// ContainersReadyByName is a helper function used to check if the pod condition v1.ContainersReady of the named
+// pod has reached v1.ConditionTrue state, see ContainersReady
+func (c *Condition) ContainersReadyByName(name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return c.ContainersReady(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})
+}
+
+// PodRunningByName is a helper function used to check if the named pod has reached the v1.PodRunning phase, see
+// PodRunning
+func (c *Condition) PodRunningByName(name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return c.PodRunning(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})
+}
+
+// JobCompletedByName is a helper function used to check if the named Job has been completed successfully, see
+// JobCompleted
+func (c *Condition) JobCompletedByName(name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return c.JobCompleted(&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})
+}
+
+// JobFailedByName is a helper function used to check if the named Job has failed, see JobFailed
+func (c *Condition) JobFailedByName(name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return c.JobFailed(&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})
+}
+
+// DaemonSetReadyByName is a helper function used to check if the pods of the named daemonset are scheduled and
+// ready, see DaemonSetReady
+func (c *Condition) DaemonSetReadyByName(name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return c.DaemonSetReady(&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})
+}
+
+// ServiceHasEndpointsByName is a helper function used to check if the EndpointSlices of the named service list at
+// least minReady ready endpoints, see ServiceHasEndpoints
+func (c *Condition) ServiceHasEndpointsByName(name, namespace string, minReady int) apimachinerywait.ConditionWithContextFunc {
+	return c.ServiceHasEndpoints(&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}, minReady)
+}
+
+// IngressHasAddressByName is a helper function used to check if the named ingress has been assigned an IP address
+// or a hostname, see IngressHasAddress
+func (c *Condition) IngressHasAddressByName(name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return c.IngressHasAddress(&networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})
+}
@@ -19,19 +19,26 @@ package conditions
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	log "k8s.io/klog/v2"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
 
 	"sigs.k8s.io/e2e-framework/klient/k8s"
 	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
 )
 
 type Condition struct {
@@ -44,6 +51,15 @@ func New(r *resources.Resources) *Condition {
 	return &Condition{resources: r}
 }
 
+// get fetches obj and reports it as the state last observed by the condition, see wait.Observe
+func (c *Condition) get(ctx context.Context, obj k8s.Object) error {
+	if err := c.resources.Get(ctx, obj.GetName(), obj.GetNamespace(), obj); err != nil {
+		return err
+	}
+	wait.Observe(ctx, obj)
+	return nil
+}
+
 func (c *Condition) namespacedName(obj k8s.Object) string {
 	return fmt.Sprintf("%s [%s/%s]", obj.GetObjectKind().GroupVersionKind().String(), obj.GetNamespace(), obj.GetName())
 }
@@ -52,24 +68,24 @@ func (c *Condition) namespacedName(obj k8s.Object) string {
 // replicas. This can be leveraged for checking cases such as scaling up and down a deployment or STS and any
 // other scalable resources.
 func (c *Condition) ResourceScaled(obj k8s.Object, scaleFetcher func(object k8s.Object) int32, replica int32) apimachinerywait.ConditionWithContextFunc {
-	return func(ctx context.Context) (done bool, err error) {
+	return wait.Described(fmt.Sprintf("%s to have %d replicas", wait.ObjectRef(obj), replica), func(ctx context.Context) (done bool, err error) {
 		log.V(4).InfoS("Checking for resource to be scaled", "resource", c.namespacedName(obj), "replica", replica)
-		if err := c.resources.Get(ctx, obj.GetName(), obj.GetNamespace(), obj); err != nil {
+		if err := c.get(ctx, obj); err != nil {
 			return false, nil
 		}
 		return scaleFetcher(obj) == replica, nil
-	}
+	})
 }
 
 // ResourceMatch is a helper function used to check if the resource under question has met a pre-defined state. This can
 // be leveraged for checking fields on a resource that may not be immediately present upon creation.
 func (c *Condition) ResourceMatch(obj k8s.Object, matchFetcher func(object k8s.Object) bool) apimachinerywait.ConditionWithContextFunc {
-	return func(ctx context.Context) (done bool, err error) {
-		if err := c.resources.Get(ctx, obj.GetName(), obj.GetNamespace(), obj); err != nil {
+	return wait.Described(fmt.Sprintf("%s to match", wait.ObjectRef(obj)), func(ctx context.Context) (done bool, err error) {
+		if err := c.get(ctx, obj); err != nil {
 			return false, nil
 		}
 		return matchFetcher(obj), nil
-	}
+	})
 }
 
 // ResourceListN is a helper function that can be used to check for a minimum number of returned objects in a list. This function
@@ -81,10 +97,11 @@ func (c *Condition) ResourceListN(list k8s.ObjectList, n int, listOptions ...res
 // ResourceListMatchN is a helper function that can be used to check for a minimum number of returned objects in a list. This function
 // accepts list options and a match function that can be used to adjust the set of objects queried for in the List resource operation.
 func (c *Condition) ResourceListMatchN(list k8s.ObjectList, n int, matchFetcher func(object k8s.Object) bool, listOptions ...resources.ListOption) apimachinerywait.ConditionWithContextFunc {
-	return func(ctx context.Context) (done bool, err error) {
+	return wait.Described(fmt.Sprintf("%s to list at least %d matching objects", wait.ObjectRef(list), n), func(ctx context.Context) (done bool, err error) {
 		if err = c.resources.List(ctx, list, listOptions...); err != nil {
 			return false, nil
 		}
+		wait.Observe(ctx, list)
 		var found int
 		metaList, err := meta.ExtractList(list)
 		if err != nil {
@@ -98,7 +115,52 @@ func (c *Condition) ResourceListMatchN(list k8s.ObjectList, n int, matchFetcher
 			}
 		}
 		return found >= n, nil
+	})
+}
+
+// ResourceListMatchPercent is a helper function that can be used to check that at least percent percents of the
+// objects returned in a list pass the match function, such as 95% of the pods of a large deployment being ready.
+// Unlike ResourceListMatchN, the threshold follows the number of listed objects, which makes it resilient to the
+// disruptions of large scale tests. An empty list never passes the check. This function accepts list options that
+// can be used to adjust the set of objects queried for in the List resource operation.
+func (c *Condition) ResourceListMatchPercent(list k8s.ObjectList, percent float64, matchFetcher func(object k8s.Object) bool, listOptions ...resources.ListOption) apimachinerywait.ConditionWithContextFunc {
+	return wait.Described(fmt.Sprintf("%g%% of the objects of %s to match", percent, wait.ObjectRef(list)), func(ctx context.Context) (done bool, err error) {
+		if err = c.resources.List(ctx, list, listOptions...); err != nil {
+			return false, nil
+		}
+		wait.Observe(ctx, list)
+		metaList, err := meta.ExtractList(list)
+		if err != nil {
+			return false, err
+		}
+		if len(metaList) == 0 {
+			return false, nil
+		}
+		var found int
+		for _, obj := range metaList {
+			o, ok := obj.(k8s.Object)
+			if !ok {
+				return false, fmt.Errorf("condition: unexpected type %T in list, does not satisfy k8s.Object", obj)
+			}
+			if matchFetcher(o) {
+				found++
+			}
+		}
+		log.V(4).InfoS("Current matching objects", "list", wait.ObjectRef(list), "matching", found, "total", len(metaList), "percent", percent)
+		return float64(found)*100 >= percent*float64(len(metaList)), nil
+	})
+}
+
+// AtLeastNReady is a helper function that can be used to check that at least n of the objects returned in a list
+// report a Ready condition with status True in status.conditions, as pods, nodes and many custom resources do.
+// Stale conditions are ignored, see ResourceConditionMatch. This function accepts list options that can be used
+// to adjust the set of objects queried for in the List resource operation.
+func (c *Condition) AtLeastNReady(list k8s.ObjectList, n int, listOptions ...resources.ListOption) apimachinerywait.ConditionWithContextFunc {
+	ready := func(obj k8s.Object) bool {
+		ok, _ := c.hasCondition(obj, "Ready", string(v1.ConditionTrue))
+		return ok
 	}
+	return wait.Described(fmt.Sprintf("%s to list at least %d ready objects", wait.ObjectRef(list), n), c.ResourceListMatchN(list, n, ready, listOptions...))
 }
 
 // ResourcesFound is a helper function that can be used to check for a set of objects. This function accepts a list
@@ -126,11 +188,11 @@ func (c *Condition) ResourcesMatch(list k8s.ObjectList, matchFetcher func(object
 			objects[obj] = false
 		}
 	}
-	return func(ctx context.Context) (done bool, err error) {
+	return wait.Described(fmt.Sprintf("%d objects to exist and match", len(objects)), func(ctx context.Context) (done bool, err error) {
 		found := 0
 		for obj, created := range objects {
 			if !created {
-				if err := c.resources.Get(ctx, obj.GetName(), obj.GetNamespace(), obj); errors.IsNotFound(err) {
+				if err := c.get(ctx, obj); errors.IsNotFound(err) {
 					continue
 				} else if err != nil {
 					return false, err
@@ -143,7 +205,7 @@ func (c *Condition) ResourcesMatch(list k8s.ObjectList, matchFetcher func(object
 			found++
 		}
 		return len(objects) == found, nil
-	}
+	})
 }
 
 // ResourcesDeleted is a helper function that can be used to check for if a set of objects has been deleted. This function
@@ -165,11 +227,11 @@ func (c *Condition) ResourcesDeleted(list k8s.ObjectList) apimachinerywait.Condi
 			objects[obj] = true
 		}
 	}
-	return func(ctx context.Context) (done bool, err error) {
+	return wait.Described(fmt.Sprintf("%d objects to be deleted", len(objects)), func(ctx context.Context) (done bool, err error) {
 		for obj, created := range objects {
 			if created {
 				log.V(4).InfoS("Checking for resource to be garbage collected", "resource", c.namespacedName(obj))
-				if err := c.resources.Get(ctx, obj.GetName(), obj.GetNamespace(), obj); errors.IsNotFound(err) {
+				if err := c.get(ctx, obj); errors.IsNotFound(err) {
 					delete(objects, obj)
 				} else if err != nil {
 					return false, err
@@ -177,7 +239,7 @@ func (c *Condition) ResourcesDeleted(list k8s.ObjectList) apimachinerywait.Condi
 			}
 		}
 		return len(objects) == 0, nil
-	}
+	})
 }
 
 // ResourceDeleted is a helper function used to check if a resource under question has been deleted. This will enable
@@ -187,25 +249,105 @@ func (c *Condition) ResourcesDeleted(list k8s.ObjectList) apimachinerywait.Condi
 // This method can be leveraged against any Kubernetes resource to check the deletion workflow and it does so by
 // checking the resource and waiting until it obtains a v1.StatusReasonNotFound error from the API
 func (c *Condition) ResourceDeleted(obj k8s.Object) apimachinerywait.ConditionWithContextFunc {
-	return func(ctx context.Context) (done bool, err error) {
+	return wait.Described(fmt.Sprintf("%s to be deleted", wait.ObjectRef(obj)), func(ctx context.Context) (done bool, err error) {
 		log.V(4).InfoS("Checking for resource to be garbage collected", "resource", c.namespacedName(obj))
-		if err := c.resources.Get(context.Background(), obj.GetName(), obj.GetNamespace(), obj); err != nil {
+		if err := c.get(ctx, obj); err != nil {
 			if errors.IsNotFound(err) {
 				return true, nil
 			}
 			return false, err
 		}
 		return false, nil
+	})
+}
+
+// OwnedResourcesDeleted is a helper function that can be used to check that the dependents of owner have been
+// garbage collected. The list determines the kind of dependents to look for, and the optional list options narrow
+// the query further. Dependents are looked up in the namespace of owner, or in all namespaces for cluster scoped
+// owners, and the check passes once none of the listed objects references owner anymore.
+func (c *Condition) OwnedResourcesDeleted(owner k8s.Object, list k8s.ObjectList, listOptions ...resources.ListOption) apimachinerywait.ConditionWithContextFunc {
+	return wait.Described(fmt.Sprintf("dependents of %s to be deleted", wait.ObjectRef(owner)), func(ctx context.Context) (done bool, err error) {
+		log.V(4).InfoS("Checking for owned resources to be garbage collected", "owner", c.namespacedName(owner))
+		if err := c.resources.InNamespace(owner.GetNamespace()).List(ctx, list, listOptions...); err != nil {
+			return false, err
+		}
+		metaList, err := meta.ExtractList(list)
+		if err != nil {
+			return false, err
+		}
+		for _, o := range metaList {
+			obj, ok := o.(k8s.Object)
+			if !ok {
+				return false, fmt.Errorf("condition: unexpected type %T in list, does not satisfy k8s.Object", o)
+			}
+			if isOwnedBy(obj, owner) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// isOwnedBy checks if one of the owner references of obj points to owner
+func isOwnedBy(obj, owner k8s.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
 	}
+	return false
+}
+
+// ResourceConditionMatch is a helper function used to check if any resource reporting a list of conditions in
+// status.conditions, such as custom resources, has a condition of the given type with the given status. The
+// conditions are read through the unstructured representation of the object, so it works with typed and
+// unstructured objects alike. A condition reporting an observedGeneration older than the generation of the
+// object is considered stale and does not match.
+//
+//	wait.For(conditions.New(client.Resources()).ResourceConditionMatch(certificate, "Ready", "True"))
+func (c *Condition) ResourceConditionMatch(obj k8s.Object, conditionType, conditionStatus string) apimachinerywait.ConditionWithContextFunc {
+	return wait.Described(fmt.Sprintf("%s to have condition %s=%s", wait.ObjectRef(obj), conditionType, conditionStatus), func(ctx context.Context) (done bool, err error) {
+		log.V(4).InfoS("Checking for condition match", "resource", c.namespacedName(obj), "condition", conditionType, "status", conditionStatus)
+		if err := c.get(ctx, obj); err != nil {
+			return false, nil
+		}
+		return c.hasCondition(obj, conditionType, conditionStatus)
+	})
+}
+
+// hasCondition checks if obj reports a condition of the given type with the given status in status.conditions,
+// ignoring stale conditions, see ResourceConditionMatch
+func (c *Condition) hasCondition(obj k8s.Object, conditionType, conditionStatus string) (bool, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return false, err
+	}
+	conditions, _, err := unstructured.NestedSlice(content, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	for _, item := range conditions {
+		cond, ok := item.(map[string]interface{})
+		if !ok || cond["type"] != conditionType {
+			continue
+		}
+		if generation, found, _ := unstructured.NestedInt64(cond, "observedGeneration"); found && generation < obj.GetGeneration() {
+			log.V(4).InfoS("Condition is stale", "resource", c.namespacedName(obj), "condition", conditionType, "observedGeneration", generation)
+			return false, nil
+		}
+		log.V(4).InfoS("Current condition", "condition", conditionType, "status", cond["status"], "reason", cond["reason"])
+		return cond["status"] == conditionStatus, nil
+	}
+	return false, nil
 }
 
 // JobConditionMatch is a helper function that can be used to check the Job Completion or runtime status against a
 // specific condition. This function accepts both conditionType and conditionState as argument and hence you can use this
 // to match both positive or negative cases with suitable values passed to the arguments.
 func (c *Condition) JobConditionMatch(job k8s.Object, conditionType batchv1.JobConditionType, conditionState v1.ConditionStatus) apimachinerywait.ConditionWithContextFunc {
-	return func(ctx context.Context) (done bool, err error) {
+	return wait.Described(fmt.Sprintf("%s to have condition %s=%s", wait.ObjectRef(job), conditionType, conditionState), func(ctx context.Context) (done bool, err error) {
 		log.V(4).InfoS("Checking for condition match", "resource", c.namespacedName(job), "state", conditionState, "conditionType", conditionType)
-		if err := c.resources.Get(ctx, job.GetName(), job.GetNamespace(), job); err != nil {
+		if err := c.get(ctx, job); err != nil {
 			return false, err
 		}
 		status := job.(*batchv1.Job).Status // nolint: errcheck
@@ -216,13 +358,13 @@ func (c *Condition) JobConditionMatch(job k8s.Object, conditionType batchv1.JobC
 			}
 		}
 		return
-	}
+	})
 }
 
 // DeploymentConditionMatch is a helper function that can be used to check a specific condition match for the Deployment in question.
 func (c *Condition) DeploymentConditionMatch(deployment k8s.Object, conditionType appsv1.DeploymentConditionType, conditionState v1.ConditionStatus) apimachinerywait.ConditionWithContextFunc {
-	return func(ctx context.Context) (done bool, err error) {
-		if err := c.resources.Get(ctx, deployment.GetName(), deployment.GetNamespace(), deployment); err != nil {
+	return wait.Described(fmt.Sprintf("%s to have condition %s=%s", wait.ObjectRef(deployment), conditionType, conditionState), func(ctx context.Context) (done bool, err error) {
+		if err := c.get(ctx, deployment); err != nil {
 			return false, err
 		}
 		for _, cond := range deployment.(*appsv1.Deployment).Status.Conditions { // nolint: errcheck
@@ -231,15 +373,15 @@ func (c *Condition) DeploymentConditionMatch(deployment k8s.Object, conditionTyp
 			}
 		}
 		return
-	}
+	})
 }
 
 // PodConditionMatch is a helper function that can be used to check a specific condition match for the Pod in question.
 // This is extended into a few simplified match helpers such as PodReady and ContainersReady as well.
 func (c *Condition) PodConditionMatch(pod k8s.Object, conditionType v1.PodConditionType, conditionState v1.ConditionStatus) apimachinerywait.ConditionWithContextFunc {
-	return func(ctx context.Context) (done bool, err error) {
+	return wait.Described(fmt.Sprintf("%s to have condition %s=%s", wait.ObjectRef(pod), conditionType, conditionState), func(ctx context.Context) (done bool, err error) {
 		log.V(4).InfoS("Checking for condition match", "resource", c.namespacedName(pod), "state", conditionState, "conditionType", conditionType)
-		if err := c.resources.Get(ctx, pod.GetName(), pod.GetNamespace(), pod); err != nil {
+		if err := c.get(ctx, pod); err != nil {
 			return false, err
 		}
 		status := pod.(*v1.Pod).Status // nolint: errcheck
@@ -250,21 +392,21 @@ func (c *Condition) PodConditionMatch(pod k8s.Object, conditionType v1.PodCondit
 			}
 		}
 		return
-	}
+	})
 }
 
 // PodPhaseMatch is a helper function that is used to check and see if the Pod Has reached a specific Phase of the
 // runtime. This can be combined with PodConditionMatch to check if a specific condition and phase has been met.
 // This will enable validation such as checking against CLB of a POD.
 func (c *Condition) PodPhaseMatch(pod k8s.Object, phase v1.PodPhase) apimachinerywait.ConditionWithContextFunc {
-	return func(ctx context.Context) (done bool, err error) {
+	return wait.Described(fmt.Sprintf("%s to be in phase %s", wait.ObjectRef(pod), phase), func(ctx context.Context) (done bool, err error) {
 		log.V(4).InfoS("Checking for phase match", "resource", c.namespacedName(pod), "phase", phase)
-		if err := c.resources.Get(context.Background(), pod.GetName(), pod.GetNamespace(), pod); err != nil {
+		if err := c.get(ctx, pod); err != nil {
 			return false, err
 		}
 		log.V(4).InfoS("Current phase", "phase", pod.(*v1.Pod).Status.Phase) // nolint: errcheck
 		return pod.(*v1.Pod).Status.Phase == phase, nil                      // nolint: errcheck
-	}
+	})
 }
 
 // PodReady is a helper function used to check if the pod condition v1.PodReady has reached v1.ConditionTrue state
@@ -306,8 +448,8 @@ func (c *Condition) DeploymentAvailable(name, namespace string) apimachinerywait
 
 // DaemonSetReady is a helper function used to check if a daemonset's pods are scheduled and ready
 func (c *Condition) DaemonSetReady(daemonset k8s.Object) apimachinerywait.ConditionWithContextFunc {
-	return func(ctx context.Context) (done bool, err error) {
-		if err := c.resources.Get(ctx, daemonset.GetName(), daemonset.GetNamespace(), daemonset); err != nil {
+	return wait.Described(fmt.Sprintf("%s to be ready", wait.ObjectRef(daemonset)), func(ctx context.Context) (done bool, err error) {
+		if err := c.get(ctx, daemonset); err != nil {
 			return false, err
 		}
 		status := daemonset.(*appsv1.DaemonSet).Status // nolint: errcheck
@@ -315,5 +457,87 @@ func (c *Condition) DaemonSetReady(daemonset k8s.Object) apimachinerywait.Condit
 			done = true
 		}
 		return
+	})
+}
+
+// ServiceHasEndpoints is a helper function used to check if a service is routable, that is if the EndpointSlices of
+// the service list at least minReady ready endpoints. Unlike waiting for the backing workload to be available, this
+// accounts for the delay before the endpoints of the service are programmed. Endpoints listed in several slices,
+// as happens with dual-stack services, are counted once.
+func (c *Condition) ServiceHasEndpoints(service k8s.Object, minReady int) apimachinerywait.ConditionWithContextFunc {
+	return wait.Described(fmt.Sprintf("%s to have %d ready endpoints", wait.ObjectRef(service), minReady), func(ctx context.Context) (done bool, err error) {
+		log.V(4).InfoS("Checking for service endpoints", "resource", c.namespacedName(service), "minReady", minReady)
+		var slices discoveryv1.EndpointSliceList
+		err = c.resources.InNamespace(service.GetNamespace()).List(ctx, &slices,
+			resources.WithLabelSelector(discoveryv1.LabelServiceName+"="+service.GetName()))
+		if err != nil {
+			return false, nil
+		}
+		wait.Observe(ctx, &slices)
+		ready := map[string]struct{}{}
+		for _, slice := range slices.Items {
+			for _, endpoint := range slice.Endpoints {
+				if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+					continue
+				}
+				ready[endpointKey(endpoint)] = struct{}{}
+			}
+		}
+		log.V(4).InfoS("Current ready endpoints", "resource", c.namespacedName(service), "ready", len(ready))
+		return len(ready) >= minReady, nil
+	})
+}
+
+// endpointKey identifies the backend of an endpoint, regardless of the address family of its slice
+func endpointKey(endpoint discoveryv1.Endpoint) string {
+	if ref := endpoint.TargetRef; ref != nil {
+		return fmt.Sprintf("%s/%s/%s", ref.Kind, ref.Namespace, ref.Name)
 	}
+	return strings.Join(endpoint.Addresses, ",")
+}
+
+// IngressHasAddress is a helper function used to check if an ingress has been assigned an IP address or a hostname
+// by its ingress controller, which is when it can start routing traffic
+func (c *Condition) IngressHasAddress(ingress k8s.Object) apimachinerywait.ConditionWithContextFunc {
+	return wait.Described(fmt.Sprintf("%s to have an address", wait.ObjectRef(ingress)), func(ctx context.Context) (done bool, err error) {
+		log.V(4).InfoS("Checking for ingress address", "resource", c.namespacedName(ingress))
+		ing := &networkingv1.Ingress{}
+		if err := c.resources.Get(ctx, ingress.GetName(), ingress.GetNamespace(), ing); err != nil {
+			return false, nil
+		}
+		wait.Observe(ctx, ing)
+		for _, lb := range ing.Status.LoadBalancer.Ingress {
+			if lb.IP != "" || lb.Hostname != "" {
+				log.V(4).InfoS("Ingress has an address", "resource", c.namespacedName(ingress), "ip", lb.IP, "hostname", lb.Hostname)
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// AccessGranted is a helper function used to check that the user of the resources of the condition is allowed
+// to perform verb on the resources identified by gvr in namespace, see resources.Resources.CanI. It is
+// typically combined with resources.Resources.WithImpersonation to assert the RBAC rules of restricted users,
+// which may take a moment to be enforced after the roles are bound.
+func (c *Condition) AccessGranted(verb string, gvr schema.GroupVersionResource, namespace string, opts ...resources.AccessOption) apimachinerywait.ConditionWithContextFunc {
+	return wait.Described(fmt.Sprintf("%s of %s in namespace %q to be allowed", verb, gvr.Resource, namespace), func(ctx context.Context) (done bool, err error) {
+		allowed, err := c.resources.CanI(ctx, verb, gvr, namespace, opts...)
+		if err != nil {
+			return false, err
+		}
+		return allowed, nil
+	})
+}
+
+// AccessDenied is a helper function used to check that the user of the resources of the condition is not
+// allowed to perform verb on the resources identified by gvr in namespace, see AccessGranted.
+func (c *Condition) AccessDenied(verb string, gvr schema.GroupVersionResource, namespace string, opts ...resources.AccessOption) apimachinerywait.ConditionWithContextFunc {
+	return wait.Described(fmt.Sprintf("%s of %s in namespace %q to be denied", verb, gvr.Resource, namespace), func(ctx context.Context) (done bool, err error) {
+		allowed, err := c.resources.CanI(ctx, verb, gvr, namespace, opts...)
+		if err != nil {
+			return false, err
+		}
+		return !allowed, nil
+	})
 }
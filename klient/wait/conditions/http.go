@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+)
+
+// HTTPOption configures the requests sent by the HTTP conditions
+type HTTPOption func(*httpOptions)
+
+type httpOptions struct {
+	client       *http.Client
+	header       http.Header
+	host         string
+	bodyContains string
+	expectStatus int
+}
+
+// WithHTTPClient sets the client used to send the requests. Defaults to a client with a 5 seconds timeout
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(o *httpOptions) {
+		o.client = client
+	}
+}
+
+// WithHTTPHeader adds a header to the requests
+func WithHTTPHeader(key, value string) HTTPOption {
+	return func(o *httpOptions) {
+		o.header.Add(key, value)
+	}
+}
+
+// WithHTTPHost sets the Host of the requests, which selects the virtual host served by ingress controllers and
+// gateways independently of the address the requests are sent to
+func WithHTTPHost(host string) HTTPOption {
+	return func(o *httpOptions) {
+		o.host = host
+	}
+}
+
+// WithBodyContains additionally requires the response body to contain s
+func WithBodyContains(s string) HTTPOption {
+	return func(o *httpOptions) {
+		o.bodyContains = s
+	}
+}
+
+// WithExpectedStatus sets the status code the response is expected to have. Defaults to 200 for ProbeService
+func WithExpectedStatus(code int) HTTPOption {
+	return func(o *httpOptions) {
+		o.expectStatus = code
+	}
+}
+
+func newHTTPOptions(expectStatus int, opts []HTTPOption) *httpOptions {
+	o := &httpOptions{
+		client:       &http.Client{Timeout: 5 * time.Second},
+		header:       http.Header{},
+		expectStatus: expectStatus,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// HTTPGetSucceeds is a helper function used to check that a GET request to url answers with expectStatus and,
+// when WithBodyContains is used, with the expected content. Connection errors and unexpected answers are retried,
+// which makes it suitable to wait for a service to come up.
+//
+//	err := wait.For(conditions.HTTPGetSucceeds("http://localhost:8080/healthz", http.StatusOK), wait.WithTimeout(time.Minute))
+func HTTPGetSucceeds(url string, expectStatus int, opts ...HTTPOption) apimachinerywait.ConditionWithContextFunc {
+	o := newHTTPOptions(expectStatus, opts)
+	return func(ctx context.Context) (done bool, err error) {
+		return httpGet(ctx, url, o), nil
+	}
+}
+
+func httpGet(ctx context.Context, url string, o *httpOptions) bool {
+	log.V(4).InfoS("Probing HTTP endpoint", "url", url, "expectStatus", o.expectStatus)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		log.V(4).InfoS("Invalid HTTP probe request", "url", url, "error", err)
+		return false
+	}
+	for key, values := range o.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if o.host != "" {
+		req.Host = o.host
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		log.V(4).InfoS("HTTP probe failed", "url", url, "error", err)
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != o.expectStatus {
+		log.V(4).InfoS("Unexpected HTTP probe status", "url", url, "status", resp.StatusCode)
+		return false
+	}
+	if o.bodyContains == "" {
+		return true
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(body), o.bodyContains)
+}
+
+// ProbeService is a helper function used to check that an in-cluster HTTP service answers a GET request to path on
+// its port with the expected status, 200 unless WithExpectedStatus is used. The request is sent through a port
+// forward to one of the ready pods backing the service, so the service does not need to be reachable from where
+// the tests run.
+func (c *Condition) ProbeService(namespace, service string, port int, path string, opts ...HTTPOption) apimachinerywait.ConditionWithContextFunc {
+	o := newHTTPOptions(http.StatusOK, opts)
+	return func(ctx context.Context) (done bool, err error) {
+		podName, targetPort, err := c.serviceBackend(ctx, namespace, service, port)
+		if err != nil {
+			log.V(4).InfoS("No backend to probe", "service", namespace+"/"+service, "port", port, "error", err)
+			return false, nil
+		}
+		localPort, stop, err := c.resources.PortForward(ctx, namespace, podName, targetPort)
+		if err != nil {
+			log.V(4).InfoS("Port forward failed", "pod", namespace+"/"+podName, "port", targetPort, "error", err)
+			return false, nil
+		}
+		defer stop()
+		return httpGet(ctx, fmt.Sprintf("http://127.0.0.1:%d/%s", localPort, strings.TrimPrefix(path, "/")), o), nil
+	}
+}
+
+// serviceBackend returns a ready pod backing the service and the container port the service port maps to in it
+func (c *Condition) serviceBackend(ctx context.Context, namespace, service string, port int) (string, int, error) {
+	var svc v1.Service
+	if err := c.resources.Get(ctx, service, namespace, &svc); err != nil {
+		return "", 0, err
+	}
+	portName, found := "", false
+	for _, p := range svc.Spec.Ports {
+		if int(p.Port) == port {
+			portName, found = p.Name, true
+			break
+		}
+	}
+	if !found {
+		return "", 0, fmt.Errorf("service %s/%s has no port %d", namespace, service, port)
+	}
+
+	var slices discoveryv1.EndpointSliceList
+	err := c.resources.InNamespace(namespace).List(ctx, &slices,
+		resources.WithLabelSelector(discoveryv1.LabelServiceName+"="+service))
+	if err != nil {
+		return "", 0, err
+	}
+	for _, slice := range slices.Items {
+		targetPort := 0
+		for _, p := range slice.Ports {
+			if p.Name != nil && *p.Name == portName && p.Port != nil {
+				targetPort = int(*p.Port)
+			}
+		}
+		if targetPort == 0 {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+			if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+				return endpoint.TargetRef.Name, targetPort, nil
+			}
+		}
+	}
+	return "", 0, fmt.Errorf("service %s/%s has no ready pod endpoint for port %d", namespace, service, port)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPGetSucceeds(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" && r.URL.Path == "/private" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/vhost" && r.Host != "web.example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("status: ok"))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		url    string
+		status int
+		opts   []HTTPOption
+		done   bool
+	}{
+		{name: "expected status", url: srv.URL + "/healthz", status: http.StatusOK, done: true},
+		{name: "unexpected status", url: srv.URL + "/private", status: http.StatusOK},
+		{name: "expected error status", url: srv.URL + "/private", status: http.StatusUnauthorized, done: true},
+		{name: "with header", url: srv.URL + "/private", status: http.StatusOK, opts: []HTTPOption{WithHTTPHeader("Authorization", "Bearer token")}, done: true},
+		{name: "unknown host", url: srv.URL + "/vhost", status: http.StatusOK},
+		{name: "with host", url: srv.URL + "/vhost", status: http.StatusOK, opts: []HTTPOption{WithHTTPHost("web.example.com")}, done: true},
+		{name: "body contains", url: srv.URL, status: http.StatusOK, opts: []HTTPOption{WithBodyContains("ok")}, done: true},
+		{name: "body does not contain", url: srv.URL, status: http.StatusOK, opts: []HTTPOption{WithBodyContains("degraded")}},
+		{name: "connection refused", url: "http://127.0.0.1:1", status: http.StatusOK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			done, err := HTTPGetSucceeds(tc.url, tc.status, tc.opts...)(context.TODO())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if done != tc.done {
+				t.Errorf("expected done to be %v, got %v", tc.done, done)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+// TimeoutError is returned by For when the condition is not met before the timeout expires or the
+// context of the wait is cancelled. It wraps the error of the context, so errors.Is matches
+// context.DeadlineExceeded or context.Canceled, and carries the state of the object as last
+// observed by the condition, when the condition reports it with Observe. Its message describes the
+// condition, when it is wrapped with Described, and the phase and conditions of the last observed
+// object, as the pre-defined conditions do. The observations recorded during the wait are listed
+// after the message when the wait keeps a history, see WithHistory.
+//
+//	var timeoutErr *wait.TimeoutError
+//	if errors.As(err, &timeoutErr) && timeoutErr.LastObserved != nil {
+//		t.Logf("last observed:\n%s", k8s.Sprint(timeoutErr.LastObserved))
+//	}
+type TimeoutError struct {
+	// Timeout is the timeout of the wait, 0 when the wait had none
+	Timeout time.Duration
+	// Condition is the description of the condition set with Described, empty if none was
+	Condition string
+	// LastObserved is a copy of the object last reported with Observe, nil if none was
+	LastObserved runtime.Object
+	// History is the timeline of the objects reported with Observe, empty unless the wait was
+	// configured WithHistory
+	History History
+	// Err is the error of the context of the wait
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	condition := e.Condition
+	if condition == "" {
+		condition = "the condition"
+	}
+	msg := fmt.Sprintf("stopped waiting for %s", condition)
+	if e.Timeout > 0 {
+		msg = fmt.Sprintf("timed out after %s waiting for %s", e.Timeout, condition)
+	}
+	if e.LastObserved != nil {
+		msg = fmt.Sprintf("%s, last observed %s", msg, ObjectRef(e.LastObserved))
+		if state := e.LastState(); state != "" {
+			msg = fmt.Sprintf("%s (%s)", msg, state)
+		}
+	}
+	msg = fmt.Sprintf("%s: %v", msg, e.Err)
+	if len(e.History) > 0 {
+		msg = fmt.Sprintf("%s\nobserved states:\n%s", msg, e.History)
+	}
+	return msg
+}
+
+// LastState summarizes the key status fields of the last observed object: its phase and its
+// conditions for objects, the number of items for lists. It is empty when there is no last
+// observed object or when it reports none of these fields.
+func (e *TimeoutError) LastState() string {
+	if e.LastObserved == nil {
+		return ""
+	}
+	return stateOf(e.LastObserved)
+}
+
+// stateOf summarizes the phase and conditions of obj, or the number of items of a list
+func stateOf(obj runtime.Object) string {
+	if meta.IsListType(obj) {
+		if items, err := meta.ExtractList(obj); err == nil {
+			return fmt.Sprintf("%d items", len(items))
+		}
+		return ""
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return ""
+	}
+	var state []string
+	if phase, _, _ := unstructured.NestedString(content, "status", "phase"); phase != "" {
+		state = append(state, "phase: "+phase)
+	}
+	conditions, _, _ := unstructured.NestedSlice(content, "status", "conditions")
+	var described []string
+	for _, item := range conditions {
+		cond, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		desc := fmt.Sprintf("%v=%v", cond["type"], cond["status"])
+		if reason, ok := cond["reason"].(string); ok && reason != "" {
+			desc = fmt.Sprintf("%s (%s)", desc, reason)
+		}
+		described = append(described, desc)
+	}
+	if len(described) > 0 {
+		state = append(state, "conditions: "+strings.Join(described, ", "))
+	}
+	return strings.Join(state, "; ")
+}
+
+// ObjectRef describes obj by its kind, namespace and name, such as "Pod default/web", for use in the
+// descriptions of conditions. The kind of typed objects without type metadata is taken from their type.
+func ObjectRef(obj runtime.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if kind == "" {
+		kind = strings.TrimPrefix(path.Ext(fmt.Sprintf("%T", obj)), ".")
+	}
+	o, ok := obj.(metav1.Object)
+	if !ok {
+		return kind
+	}
+	if o.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", kind, o.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", kind, o.GetNamespace(), o.GetName())
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+type observerKey struct{}
+
+// observer holds the description of the condition polled by For, the object it last reported and,
+// when the wait keeps a history, the timeline of the objects it reported
+type observer struct {
+	mu          sync.Mutex
+	obj         runtime.Object
+	description string
+	history     History
+	maxHistory  int
+}
+
+// Observe records a copy of obj as the state last observed by the condition polled with ctx by For,
+// which is reported by the TimeoutError returned when the condition is not met in time. The
+// pre-defined conditions report the objects they fetch. The observation is also appended to the
+// history of the wait when it keeps one. It does nothing when ctx does not come from For.
+func Observe(ctx context.Context, obj runtime.Object) {
+	o, ok := ctx.Value(observerKey{}).(*observer)
+	if !ok || obj == nil {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.obj = obj.DeepCopyObject()
+	if o.maxHistory > 0 {
+		o.history = append(o.history, Observation{Time: time.Now(), Object: ObjectRef(obj), State: stateOf(obj)})
+		if len(o.history) > o.maxHistory {
+			o.history = o.history[len(o.history)-o.maxHistory:]
+		}
+	}
+}
+
+// Described returns a condition that reports description, such as "Pod default/web to be ready", in the
+// TimeoutError returned by For when the condition is not met in time. The pre-defined conditions are
+// described already, the outermost description wins when conditions are wrapped.
+func Described(description string, condition apimachinerywait.ConditionWithContextFunc) apimachinerywait.ConditionWithContextFunc {
+	return func(ctx context.Context) (bool, error) {
+		if o, ok := ctx.Value(observerKey{}).(*observer); ok {
+			o.mu.Lock()
+			if o.description == "" {
+				o.description = description
+			}
+			o.mu.Unlock()
+		}
+		return condition(ctx)
+	}
+}
+
+// timeoutError returns the TimeoutError of a wait interrupted by err
+func (o *observer) timeoutError(timeout time.Duration, err error) *TimeoutError {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return &TimeoutError{Timeout: timeout, Condition: o.description, LastObserved: o.obj, History: o.history, Err: err}
+}
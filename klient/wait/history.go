@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultHistorySize is the number of observations kept by WithHistoryFile when WithHistory does not
+// configure another size
+const DefaultHistorySize = 100
+
+// Observation is an object reported by a condition with Observe during a wait that keeps a history
+type Observation struct {
+	// Time is when the object was observed
+	Time time.Time
+	// Object identifies the observed object, see ObjectRef
+	Object string
+	// State summarizes the phase and conditions of the observed object, or the number of items of a list
+	State string
+}
+
+func (o Observation) String() string {
+	return o.format("15:04:05.000")
+}
+
+// format describes the observation with its time formatted with layout
+func (o Observation) format(layout string) string {
+	if o.State == "" {
+		return fmt.Sprintf("%s %s", o.Time.Format(layout), o.Object)
+	}
+	return fmt.Sprintf("%s %s (%s)", o.Time.Format(layout), o.Object, o.State)
+}
+
+// History is the timeline of the observations of a wait, oldest first
+type History []Observation
+
+// String lists the observations one per line
+func (h History) String() string {
+	lines := make([]string, len(h))
+	for i, o := range h {
+		lines[i] = "  " + o.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// WriteFile writes the observations to path, one per line with their full timestamp, creating its parent directories as needed
+func (h History) WriteFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create the directory of the wait history: %w", err)
+	}
+	var b strings.Builder
+	for _, o := range h {
+		fmt.Fprintln(&b, o.format(time.RFC3339Nano))
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write the wait history: %w", err)
+	}
+	return nil
+}
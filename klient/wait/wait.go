@@ -18,6 +18,7 @@ package wait
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
@@ -28,6 +29,9 @@ const (
 	defaultPollInterval = 5 * time.Second
 )
 
+// ErrConditionNotMet is returned by the functions created with Check while their condition is not met
+var ErrConditionNotMet = errors.New("condition not met")
+
 type Options struct {
 	// Interval is used to specify the poll interval while waiting for a condition to be met
 	Interval time.Duration
@@ -39,6 +43,12 @@ type Options struct {
 	// Immediate is used to indicate if the apimachinerywait's immediate wait method are to be
 	// called instead of the regular one
 	Immediate bool
+	// History is the maximum number of observations kept in the history of the wait, no history is
+	// kept when 0
+	History int
+	// HistoryFile is the path of the file the history of the wait is written to when the condition
+	// is not met in time
+	HistoryFile string
 }
 
 type Option func(*Options)
@@ -78,12 +88,32 @@ func WithImmediate() Option {
 	}
 }
 
+// WithHistory records the objects reported with Observe by the condition, which includes the objects fetched
+// by the pre-defined conditions, along with the time and a summary of their state. The timeline is reported by
+// the TimeoutError returned when the condition is not met in time. Only the last max observations are kept.
+func WithHistory(max int) Option {
+	return func(options *Options) {
+		options.History = max
+	}
+}
+
+// WithHistoryFile writes the history of the wait to path when the condition is not met in time, for instance
+// in the artifacts directory of the test with envconf.Config.ArtifactPath. It keeps the last DefaultHistorySize
+// observations unless WithHistory configures another size.
+func WithHistoryFile(path string) Option {
+	return func(options *Options) {
+		options.HistoryFile = path
+	}
+}
+
 // For provides a way to perform poll checks against the kubernetes resource to make sure the resource under
 // test has reached a suitable state before moving to the next action or fail with an error message.
 //
 // The conditions sub-packages provides a series of pre-defined wait functions that can be used by the developers
 // or a custom wait function can be passed as an argument to get a similar functionality if the check required
 // for your test is not already provided by the helper utility.
+//
+// When the condition is not met in time, the returned error is a *TimeoutError.
 func For(conditionFunc apimachinerywait.ConditionWithContextFunc, opts ...Option) error {
 	options := &Options{
 		Interval:  defaultPollInterval,
@@ -105,5 +135,39 @@ func For(conditionFunc apimachinerywait.ConditionWithContextFunc, opts ...Option
 		defer cancel()
 	}
 
-	return apimachinerywait.PollUntilContextCancel(options.Ctx, options.Interval, options.Immediate, conditionFunc)
+	obs := &observer{maxHistory: options.History}
+	if options.HistoryFile != "" && obs.maxHistory == 0 {
+		obs.maxHistory = DefaultHistorySize
+	}
+	ctx := context.WithValue(options.Ctx, observerKey{}, obs)
+	err := apimachinerywait.PollUntilContextCancel(ctx, options.Interval, options.Immediate, conditionFunc)
+	if err != nil && apimachinerywait.Interrupted(err) {
+		timeoutErr := obs.timeoutError(options.Timeout, err)
+		if options.HistoryFile != "" {
+			if err := timeoutErr.History.WriteFile(options.HistoryFile); err != nil {
+				return errors.Join(timeoutErr, err)
+			}
+		}
+		return timeoutErr
+	}
+	return err
+}
+
+// Check adapts a condition to a function that returns nil once the condition is met, and ErrConditionNotMet or
+// the error of the condition otherwise. This allows the pre-defined conditions to be polled by other libraries,
+// for instance with Gomega:
+//
+//	Eventually(wait.Check(conditions.New(client.Resources()).PodReady(pod))).
+//		WithContext(ctx).WithTimeout(time.Minute).Should(Succeed())
+func Check(conditionFunc apimachinerywait.ConditionWithContextFunc) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		done, err := conditionFunc(ctx)
+		if err != nil {
+			return err
+		}
+		if !done {
+			return ErrConditionNotMet
+		}
+		return nil
+	}
 }
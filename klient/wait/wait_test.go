@@ -18,6 +18,10 @@ package wait_test
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,6 +30,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/e2e-framework/klient/k8s"
@@ -61,6 +66,49 @@ func TestPodReady(t *testing.T) {
 	}
 }
 
+func TestPodReadyByName(t *testing.T) {
+	createPod("p-by-name", t)
+	err := wait.For(conditions.New(getResourceManager()).PodReadyByName("p-by-name", namespace), wait.WithInterval(2*time.Second))
+	if err != nil {
+		t.Error("failed to wait for pod to reach Ready condition", err)
+	}
+}
+
+func TestResourceConditionMatch(t *testing.T) {
+	pod := createPod("p-conditions", t)
+	err := wait.For(conditions.New(getResourceManager()).ResourceConditionMatch(pod, "Ready", "True"), wait.WithInterval(2*time.Second))
+	if err != nil {
+		t.Error("failed to wait for pod to report a Ready condition", err)
+	}
+
+	deployment := &unstructured.Unstructured{}
+	deployment.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	deployment.SetName(createDeployment("d-conditions", 1, t).Name)
+	deployment.SetNamespace(namespace)
+	err = wait.For(conditions.New(getResourceManager()).ResourceConditionMatch(deployment, "Available", "True"), wait.WithInterval(2*time.Second))
+	if err != nil {
+		t.Error("failed to wait for deployment to report an Available condition", err)
+	}
+}
+
+func TestServiceHasEndpoints(t *testing.T) {
+	deployment := createDeployment("d-endpoints", 2, t)
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: namespace},
+		Spec: v1.ServiceSpec{
+			Selector: deployment.Spec.Selector.MatchLabels,
+			Ports:    []v1.ServicePort{{Port: 80}},
+		},
+	}
+	if err := getResourceManager().Create(context.TODO(), service); err != nil {
+		t.Fatal("failed to create service due to an error", err)
+	}
+	err := wait.For(conditions.New(getResourceManager()).ServiceHasEndpoints(service, 2), wait.WithInterval(2*time.Second))
+	if err != nil {
+		t.Error("failed to wait for service to have ready endpoints", err)
+	}
+}
+
 func TestContainersReady(t *testing.T) {
 	var err error
 	pod := createPod("p4", t)
@@ -160,6 +208,26 @@ func TestResourceListMatchN(t *testing.T) {
 	log.Info("Done")
 }
 
+func TestResourceListMatchPercent(t *testing.T) {
+	createDeployment("d8", 4, t)
+	pods := &v1.PodList{}
+	err := wait.For(conditions.New(getResourceManager()).ResourceListMatchPercent(pods, 75, func(object k8s.Object) bool {
+		return object.(*v1.Pod).Status.Phase == v1.PodRunning // nolint: errcheck
+	}, resources.WithLabelSelector(labels.FormatLabels(map[string]string{"app": "d8"}))))
+	if err != nil {
+		t.Error("failed waiting for 75% of the deployment pods to be running", err)
+	}
+}
+
+func TestAtLeastNReady(t *testing.T) {
+	createDeployment("d9", 3, t)
+	pods := &v1.PodList{}
+	err := wait.For(conditions.New(getResourceManager()).AtLeastNReady(pods, 2, resources.WithLabelSelector(labels.FormatLabels(map[string]string{"app": "d9"}))))
+	if err != nil {
+		t.Error("failed waiting for 2 of the deployment pods to be ready", err)
+	}
+}
+
 func TestResourcesMatch(t *testing.T) {
 	var err error
 	go func() {
@@ -290,3 +358,170 @@ func TestForCancel(t *testing.T) {
 		t.Error("expected error")
 	}
 }
+
+func TestCheck(t *testing.T) {
+	failure := errors.New("failure")
+	tests := []struct {
+		name string
+		done bool
+		err  error
+		want error
+	}{
+		{name: "met", done: true},
+		{name: "not met", want: wait.ErrConditionNotMet},
+		{name: "error", err: failure, want: failure},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := wait.Check(func(ctx context.Context) (bool, error) {
+				return test.done, test.err
+			})
+			if err := check(context.Background()); !errors.Is(err, test.want) {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestForTimeoutError(t *testing.T) {
+	cm := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "observed", Namespace: "default"}, Data: map[string]string{"state": "pending"}}
+	err := wait.For(func(ctx context.Context) (bool, error) {
+		wait.Observe(ctx, cm)
+		return false, nil
+	}, wait.WithTimeout(50*time.Millisecond), wait.WithInterval(10*time.Millisecond))
+
+	var timeoutErr *wait.TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the timeout error to wrap the context error, got %v", timeoutErr.Err)
+	}
+	observed, ok := timeoutErr.LastObserved.(*v1.ConfigMap)
+	if !ok || observed == cm || observed.Data["state"] != "pending" {
+		t.Errorf("expected a copy of the last observed object, got %v", timeoutErr.LastObserved)
+	}
+	if want := "timed out after 50ms waiting for the condition, last observed ConfigMap default/observed: context deadline exceeded"; err.Error() != want {
+		t.Errorf("expected error %q, got %q", want, err.Error())
+	}
+}
+
+func TestTimeoutErrorDescribesState(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Status: v1.PodStatus{
+			Phase: v1.PodPending,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodScheduled, Status: v1.ConditionTrue},
+				{Type: v1.PodReady, Status: v1.ConditionFalse, Reason: "ContainersNotReady"},
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		condition func(context.Context) (bool, error)
+		want      string
+	}{
+		{
+			name: "described condition",
+			condition: wait.Described("Pod default/web to be ready", func(ctx context.Context) (bool, error) {
+				wait.Observe(ctx, pod)
+				return false, nil
+			}),
+			want: "timed out after 30ms waiting for Pod default/web to be ready, last observed Pod default/web " +
+				"(phase: Pending; conditions: PodScheduled=True, Ready=False (ContainersNotReady)): context deadline exceeded",
+		},
+		{
+			name: "outermost description",
+			condition: wait.Described("the web pod", wait.Described("Pod default/web to be ready", func(ctx context.Context) (bool, error) {
+				return false, nil
+			})),
+			want: "timed out after 30ms waiting for the web pod: context deadline exceeded",
+		},
+		{
+			name: "observed list",
+			condition: func(ctx context.Context) (bool, error) {
+				wait.Observe(ctx, &v1.PodList{Items: []v1.Pod{*pod}})
+				return false, nil
+			},
+			want: "timed out after 30ms waiting for the condition, last observed PodList (1 items): context deadline exceeded",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := wait.For(test.condition, wait.WithTimeout(30*time.Millisecond), wait.WithInterval(10*time.Millisecond))
+			if err == nil || err.Error() != test.want {
+				t.Errorf("expected error %q, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestForHistory(t *testing.T) {
+	phases := []v1.PodPhase{v1.PodPending, v1.PodRunning, v1.PodFailed}
+	tests := []struct {
+		name       string
+		opts       []wait.Option
+		maxHistory int
+	}{
+		{name: "no history"},
+		{name: "history", opts: []wait.Option{wait.WithHistory(100)}, maxHistory: 100},
+		{name: "last observations", opts: []wait.Option{wait.WithHistory(2)}, maxHistory: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			polls := 0
+			condition := func(ctx context.Context) (bool, error) {
+				pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+				pod.Status.Phase = phases[polls%len(phases)]
+				polls++
+				wait.Observe(ctx, pod)
+				return false, nil
+			}
+			opts := append([]wait.Option{wait.WithTimeout(50 * time.Millisecond), wait.WithInterval(10 * time.Millisecond), wait.WithImmediate()}, test.opts...)
+			err := wait.For(condition, opts...)
+			var timeoutErr *wait.TimeoutError
+			if !errors.As(err, &timeoutErr) {
+				t.Fatalf("expected a timeout error, got %v", err)
+			}
+			if want := min(polls, test.maxHistory); len(timeoutErr.History) != want {
+				t.Fatalf("expected %d observations, got %d:\n%s", want, len(timeoutErr.History), timeoutErr.History)
+			}
+			if test.maxHistory == 0 {
+				if strings.Contains(err.Error(), "observed states") {
+					t.Errorf("expected no history in the error, got %q", err)
+				}
+				return
+			}
+			last := timeoutErr.History[len(timeoutErr.History)-1]
+			if want := "phase: " + string(phases[(polls-1)%len(phases)]); last.Object != "Pod default/web" || last.State != want {
+				t.Errorf("expected the last observation to be the pod in %q, got %s", want, last)
+			}
+			if !strings.Contains(err.Error(), "observed states:\n  "+timeoutErr.History[0].String()) {
+				t.Errorf("expected the history in the error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestForHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "waits", "history.log")
+	err := wait.For(func(ctx context.Context) (bool, error) {
+		wait.Observe(ctx, &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}, Status: v1.PodStatus{Phase: v1.PodPending}})
+		return false, nil
+	}, wait.WithTimeout(30*time.Millisecond), wait.WithInterval(10*time.Millisecond), wait.WithHistoryFile(path))
+	if err == nil {
+		t.Fatal("expected the wait to time out")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the history: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) == 0 || !strings.HasSuffix(lines[0], " Pod default/web (phase: Pending)") {
+		t.Errorf("unexpected history:\n%s", data)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, strings.Fields(lines[0])[0]); err != nil {
+		t.Errorf("expected the observations to start with their timestamp: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package assert provides assertion helpers for assessments. Each helper fetches or waits for the
+// resources in question and reports a failure describing what was observed, so that an assessment
+// does not need to repeat the Get, wait and compare boilerplate.
+//
+// The helpers report failures with t.Errorf and return whether the assertion held, the wait options
+// can be used to tune how long the eventual assertions poll for. Assessments written with testify can
+// use WithRequire to get require assertions bound to the step they run in.
+package assert
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// DeploymentAvailable asserts that the deployment identified by name and namespace eventually reports
+// the Available condition. On failure, the replica counts and conditions of the deployment are reported.
+func DeploymentAvailable(ctx context.Context, t *testing.T, cfg *envconf.Config, name, namespace string, opts ...wait.Option) bool {
+	t.Helper()
+
+	client, err := cfg.NewClient()
+	if err != nil {
+		t.Errorf("deployment %s/%s: %v", namespace, name, err)
+		return false
+	}
+	err = wait.For(conditions.New(client.Resources()).DeploymentAvailable(name, namespace), waitOptions(ctx, opts)...)
+	if err == nil {
+		return true
+	}
+
+	var dep appsv1.Deployment
+	if getErr := client.Resources().Get(ctx, name, namespace, &dep); getErr != nil {
+		t.Errorf("deployment %s/%s is not available: %v: %v", namespace, name, err, getErr)
+		return false
+	}
+	t.Errorf("deployment %s/%s is not available: %v\n%s", namespace, name, err, describeDeployment(&dep))
+	return false
+}
+
+// PodLogsContain asserts that the logs of the pod identified by name and namespace eventually contain
+// the expected text. The logs of the default container are used. On failure, the last lines of the logs
+// are reported.
+func PodLogsContain(ctx context.Context, t *testing.T, cfg *envconf.Config, name, namespace, expected string, opts ...wait.Option) bool {
+	t.Helper()
+
+	client, err := cfg.NewClient()
+	if err != nil {
+		t.Errorf("pod %s/%s: %v", namespace, name, err)
+		return false
+	}
+	clientset := client.Clientset()
+
+	var logs string
+	err = wait.For(func(ctx context.Context) (bool, error) {
+		data, err := clientset.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{}).DoRaw(ctx)
+		if err != nil {
+			return false, nil
+		}
+		logs = string(data)
+		return strings.Contains(logs, expected), nil
+	}, waitOptions(ctx, opts)...)
+	if err != nil {
+		t.Errorf("logs of pod %s/%s do not contain %q: %v\nlast log lines:\n%s", namespace, name, expected, err, tailLines(logs, 20))
+		return false
+	}
+	return true
+}
+
+// EventuallyResource asserts that the object eventually satisfies the match function. The object is
+// fetched using its name and namespace and is updated in place. On failure, the object as last observed
+// is reported.
+func EventuallyResource(ctx context.Context, t *testing.T, cfg *envconf.Config, obj k8s.Object, match func(object k8s.Object) bool, opts ...wait.Option) bool {
+	t.Helper()
+
+	client, err := cfg.NewClient()
+	if err != nil {
+		t.Errorf("%s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+		return false
+	}
+	err = wait.For(conditions.New(client.Resources()).ResourceMatch(obj, match), waitOptions(ctx, opts)...)
+	if err != nil {
+		t.Errorf("%T %s/%s did not match: %v\nlast observed:\n%s", obj, obj.GetNamespace(), obj.GetName(), err, cfg.Redactor().Sprint(obj))
+		return false
+	}
+	return true
+}
+
+// ResourceEquals asserts that the object stored in the cluster with the name and namespace of expected
+// matches expected. Fields maintained by the API server and ignoreFields are left out of the comparison,
+// see k8s.Diff. On failure, the differing fields are reported.
+func ResourceEquals(ctx context.Context, t *testing.T, cfg *envconf.Config, expected k8s.Object, ignoreFields ...string) bool {
+	t.Helper()
+
+	client, err := cfg.NewClient()
+	if err != nil {
+		t.Errorf("%s/%s: %v", expected.GetNamespace(), expected.GetName(), err)
+		return false
+	}
+	actual, ok := expected.DeepCopyObject().(k8s.Object)
+	if !ok {
+		t.Errorf("%T does not satisfy k8s.Object", expected)
+		return false
+	}
+	if err := client.Resources().Get(ctx, expected.GetName(), expected.GetNamespace(), actual); err != nil {
+		t.Errorf("%T %s/%s: %v", expected, expected.GetNamespace(), expected.GetName(), err)
+		return false
+	}
+	diff, err := k8s.Diff(expected, actual, ignoreFields...)
+	if err != nil {
+		t.Errorf("%T %s/%s: %v", expected, expected.GetNamespace(), expected.GetName(), err)
+		return false
+	}
+	if diff != "" {
+		t.Errorf("%T %s/%s does not match (-expected +actual):\n%s", expected, expected.GetNamespace(), expected.GetName(), diff)
+		return false
+	}
+	return true
+}
+
+// waitOptions makes the wait honor the context of the assessment, options passed by the caller take precedence
+func waitOptions(ctx context.Context, opts []wait.Option) []wait.Option {
+	return append([]wait.Option{wait.WithContext(ctx), wait.WithImmediate()}, opts...)
+}
+
+// describeDeployment summarizes the replica counts and conditions of a deployment
+func describeDeployment(dep *appsv1.Deployment) string {
+	var b bytes.Buffer
+	var desired int32 = 1
+	if dep.Spec.Replicas != nil {
+		desired = *dep.Spec.Replicas
+	}
+	fmt.Fprintf(&b, "replicas: %d desired, %d updated, %d ready, %d available",
+		desired, dep.Status.UpdatedReplicas, dep.Status.ReadyReplicas, dep.Status.AvailableReplicas)
+	for _, cond := range dep.Status.Conditions {
+		fmt.Fprintf(&b, "\ncondition %s=%s", cond.Type, cond.Status)
+		if cond.Reason != "" {
+			fmt.Fprintf(&b, " (%s)", cond.Reason)
+		}
+		if cond.Message != "" {
+			fmt.Fprintf(&b, ": %s", cond.Message)
+		}
+	}
+	return b.String()
+}
+
+// tailLines returns the last n lines of text
+func tailLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assert
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+func TestDescribeDeployment(t *testing.T) {
+	replicas := int32(3)
+	dep := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas: 3,
+			ReadyReplicas:   1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, Reason: "MinimumReplicasUnavailable", Message: "Deployment does not have minimum availability."},
+			},
+		},
+	}
+	want := "replicas: 3 desired, 3 updated, 1 ready, 0 available\n" +
+		"condition Available=False (MinimumReplicasUnavailable): Deployment does not have minimum availability."
+	if got := describeDeployment(dep); got != want {
+		t.Errorf("describeDeployment() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		n    int
+		want string
+	}{
+		{name: "empty", text: "", n: 2, want: ""},
+		{name: "fewer lines", text: "a\nb\n", n: 3, want: "a\nb"},
+		{name: "more lines", text: "a\nb\nc\n", n: 2, want: "b\nc"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tailLines(test.text, test.n); got != test.want {
+				t.Errorf("tailLines() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWithRequire(t *testing.T) {
+	type key struct{}
+	var bound bool
+	step := WithRequire(func(ctx context.Context, require *require.Assertions, cfg *envconf.Config) context.Context {
+		require.NotNil(cfg)
+		bound = true
+		return context.WithValue(ctx, key{}, "value")
+	})
+	ctx := step(context.Background(), t, envconf.New())
+	if !bound || ctx.Value(key{}) != "value" {
+		t.Error("expected the step to run and return its context")
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assert
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+// RequireStepFunc is a step function that receives testify require assertions bound to the *testing.T of the step
+type RequireStepFunc func(ctx context.Context, require *require.Assertions, cfg *envconf.Config) context.Context
+
+// WithRequire adapts a RequireStepFunc to a step function. The assertions are created for each run of the step,
+// so a failed assertion stops the step that made it and fails the feature that owns it, even when features are
+// tested in parallel. Assertions must be made from the goroutine running the step, as required by t.FailNow.
+//
+//	feature.Assess("pods are listed", assert.WithRequire(func(ctx context.Context, require *require.Assertions, cfg *envconf.Config) context.Context {
+//		var pods corev1.PodList
+//		require.NoError(cfg.Client().Resources("kube-system").List(ctx, &pods))
+//		require.NotEmpty(pods.Items)
+//		return ctx
+//	}))
+func WithRequire(fn RequireStepFunc) types.StepFunc {
+	return func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		t.Helper()
+		return fn(ctx, require.New(t), cfg)
+	}
+}
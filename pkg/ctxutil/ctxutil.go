@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ctxutil provides typed helpers to store and load the values that the
+// framework and the tests pass along in the context of the environment.
+package ctxutil
+
+import (
+	"context"
+	"fmt"
+)
+
+// Load returns the value stored in ctx under key, when there is one and it has type T.
+//
+//	cluster, ok := ctxutil.Load[*kind.Cluster](ctx, support.ClusterNameContextKey("my-cluster"))
+func Load[T any](ctx context.Context, key any) (T, bool) {
+	val, ok := ctx.Value(key).(T)
+	return val, ok
+}
+
+// MustLoad returns the value stored in ctx under key and panics when there is none
+// or when it does not have type T.
+func MustLoad[T any](ctx context.Context, key any) T {
+	val, ok := Load[T](ctx, key)
+	if !ok {
+		panic(fmt.Sprintf("ctxutil: no value of type %T found in context for key %v", val, key))
+	}
+	return val
+}
+
+// Store returns a copy of ctx in which val is stored under key. It is a typed
+// shorthand for context.WithValue.
+func Store[T any](ctx context.Context, key any, val T) context.Context {
+	return context.WithValue(ctx, key, val)
+}
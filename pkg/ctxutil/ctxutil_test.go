@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctxutil
+
+import (
+	"context"
+	"testing"
+)
+
+type testKey string
+
+func TestLoad(t *testing.T) {
+	ctx := Store(context.Background(), testKey("name"), "value")
+
+	if val, ok := Load[string](ctx, testKey("name")); !ok || val != "value" {
+		t.Errorf("unexpected value %q, found %v", val, ok)
+	}
+	if _, ok := Load[int](ctx, testKey("name")); ok {
+		t.Error("expected a value of another type not to be loaded")
+	}
+	if _, ok := Load[string](ctx, testKey("missing")); ok {
+		t.Error("expected a missing value not to be loaded")
+	}
+	if _, ok := Load[string](ctx, "name"); ok {
+		t.Error("expected a key of another type not to match")
+	}
+}
+
+func TestMustLoad(t *testing.T) {
+	ctx := Store(context.Background(), testKey("name"), 42)
+	if val := MustLoad[int](ctx, testKey("name")); val != 42 {
+		t.Errorf("unexpected value %d", val)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustLoad to panic for a missing value")
+		}
+	}()
+	MustLoad[int](ctx, testKey("missing"))
+}
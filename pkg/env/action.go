@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	klog "k8s.io/klog/v2"
 	"sigs.k8s.io/e2e-framework/pkg/envconf"
@@ -33,8 +34,12 @@ const (
 	roleBeforeTest
 	roleBeforeFeature
 	roleAfterFeature
+	roleBeforeAssessment
+	roleAfterAssessment
 	roleAfterTest
 	roleFinish
+	roleFixtureSetup
+	roleFixtureTeardown
 )
 
 func (r actionRole) String() string {
@@ -47,10 +52,18 @@ func (r actionRole) String() string {
 		return "BeforeEachFeature"
 	case roleAfterFeature:
 		return "AfterEachFeature"
+	case roleBeforeAssessment:
+		return "BeforeEachAssessment"
+	case roleAfterAssessment:
+		return "AfterEachAssessment"
 	case roleAfterTest:
 		return "AfterEachTest"
 	case roleFinish:
 		return "Finish"
+	case roleFixtureSetup:
+		return "Fixture setup"
+	case roleFixtureTeardown:
+		return "Fixture teardown"
 	default:
 		panic("unknown role") // this should never happen
 	}
@@ -68,6 +81,9 @@ type action struct {
 
 	// testFuncs store the TestEnvFunc for before/after feature.
 	testFuncs []types.TestEnvFunc
+
+	// assessmentFuncs store the AssessmentEnvFunc for before/after assessment.
+	assessmentFuncs []types.AssessmentEnvFunc
 }
 
 // runWithT will run the action and inject *testing.T into the callback function.
@@ -87,7 +103,7 @@ func (a *action) runWithT(ctx context.Context, cfg *envconf.Config, t *testing.T
 			var err error
 			ctx, err = f(ctx, cfg, t)
 			if err != nil {
-				return ctx, err
+				return ctx, newActionError(a.role, f, err)
 			}
 		}
 	default:
@@ -112,9 +128,9 @@ func (a *action) runWithFeature(ctx context.Context, cfg *envconf.Config, t *tes
 			}
 
 			var err error
-			ctx, err = f(ctx, cfg, t, fi)
+			ctx, err = f(ctx, featureConfig(ctx, cfg), t, fi)
 			if err != nil {
-				return ctx, err
+				return ctx, newActionError(a.role, f, err)
 			}
 		}
 	default:
@@ -123,22 +139,66 @@ func (a *action) runWithFeature(ctx context.Context, cfg *envconf.Config, t *tes
 	return ctx, nil
 }
 
-func (a *action) run(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+// runWithAssessment will run the action and inject the names of the feature and of the assessment
+// into the callback function.
+func (a *action) runWithAssessment(ctx context.Context, cfg *envconf.Config, t *testing.T, feature, assessment string) (context.Context, error) {
+	t.Helper()
+	switch a.role {
+	case roleBeforeAssessment, roleAfterAssessment:
+		if cfg.DryRunMode() {
+			klog.V(2).Info("Skipping execution of roleBeforeAssessment and roleAfterAssessment due to framework being in dry-run mode")
+			return ctx, nil
+		}
+		for _, f := range a.assessmentFuncs {
+			if f == nil {
+				continue
+			}
+
+			var err error
+			ctx, err = f(ctx, featureConfig(ctx, cfg), t, feature, assessment)
+			if err != nil {
+				return ctx, newActionError(a.role, f, err)
+			}
+		}
+	default:
+		return ctx, fmt.Errorf("runWithAssessment() is only valid for actions roleBeforeAssessment and roleAfterAssessment")
+	}
+	return ctx, nil
+}
+
+// run runs the env functions of the action and records their duration in results, when it is set
+func (a *action) run(ctx context.Context, cfg *envconf.Config, results *resultCollector) (context.Context, error) {
 	if cfg.DryRunMode() {
 		klog.V(2).InfoS("Skipping processing of action due to framework being in dry-run mode")
 		return ctx, nil
 	}
+	timeout := a.timeout(cfg)
 	for _, f := range a.funcs {
 		if f == nil {
 			continue
 		}
 
 		var err error
-		ctx, err = f(ctx, cfg)
+		start := time.Now()
+		if timeout > 0 {
+			ctx, err = runFuncWithTimeout(ctx, cfg, f, a.role, timeout)
+		} else {
+			ctx, err = f(ctx, cfg)
+		}
+		recordEnvFuncTiming(results, cfg.SlowThreshold(), a.role, f, start)
 		if err != nil {
-			return ctx, err
+			return ctx, newActionError(a.role, f, err)
 		}
 	}
 
 	return ctx, nil
 }
+
+// featureConfig returns the config set for the feature by a BeforeEachFeature func with
+// envconf.WithFeatureConfig, or cfg when there is none
+func featureConfig(ctx context.Context, cfg *envconf.Config) *envconf.Config {
+	if featureCfg := envconf.FeatureConfigFromContext(ctx); featureCfg != nil {
+		return featureCfg
+	}
+	return cfg
+}
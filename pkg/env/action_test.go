@@ -18,7 +18,10 @@ package env
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"sigs.k8s.io/e2e-framework/pkg/envconf"
 	"sigs.k8s.io/e2e-framework/pkg/types"
@@ -44,7 +47,7 @@ func TestAction_Run(t *testing.T) {
 						return ctx, nil
 					},
 				}
-				_, err = (&action{role: roleSetup, funcs: funcs}).run(ctx, cfg)
+				_, err = (&action{role: roleSetup, funcs: funcs}).run(ctx, cfg, nil)
 				return
 			},
 			expected: 12,
@@ -64,7 +67,7 @@ func TestAction_Run(t *testing.T) {
 						return ctx, nil
 					},
 				}
-				_, err = (&action{role: roleSetup, funcs: funcs}).run(ctx, cfg)
+				_, err = (&action{role: roleSetup, funcs: funcs}).run(ctx, cfg, nil)
 				return
 			},
 			expected: 24,
@@ -85,7 +88,7 @@ func TestAction_Run(t *testing.T) {
 						return ctx, nil
 					},
 				}
-				_, err = (&action{role: roleSetup, funcs: funcs}).run(ctx, cfg)
+				_, err = (&action{role: roleSetup, funcs: funcs}).run(ctx, cfg, nil)
 				return
 			},
 			expected: 6,
@@ -105,6 +108,40 @@ func TestAction_Run(t *testing.T) {
 	}
 }
 
+func TestAction_RunWithTimeout(t *testing.T) {
+	blocked := func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		<-ctx.Done()
+		return ctx, ctx.Err()
+	}
+	storeValue := func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		return context.WithValue(ctx, &ctxTestKeyString{}, 42), nil
+	}
+
+	cfg := envconf.New().WithSetupTimeout(20 * time.Millisecond).WithTeardownTimeout(20 * time.Millisecond)
+	for _, role := range []actionRole{roleSetup, roleFinish} {
+		t.Run(role.String(), func(t *testing.T) {
+			_, err := (&action{role: role, funcs: []types.EnvFunc{blocked}}).run(context.Background(), cfg, nil)
+			if err == nil || !strings.Contains(err.Error(), "timed out after 20ms") || !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected timeout error, got %v", err)
+			}
+			if IsSetupError(err) != (role == roleSetup) {
+				t.Errorf("unexpected setup error classification of %v", err)
+			}
+
+			ctx, err := (&action{role: role, funcs: []types.EnvFunc{storeValue}}).run(context.Background(), cfg, nil)
+			if err != nil {
+				t.Fatalf("unexpected failure: %v", err)
+			}
+			if ctx.Value(&ctxTestKeyString{}) != 42 {
+				t.Error("expected value stored by the action to be kept")
+			}
+			if _, ok := ctx.Deadline(); ok {
+				t.Error("expected the timeout not to leak into the returned context")
+			}
+		})
+	}
+}
+
 func TestActionRole_String(t *testing.T) {
 	tests := []struct {
 		name string
@@ -131,6 +168,16 @@ func TestActionRole_String(t *testing.T) {
 			r:    roleAfterFeature,
 			want: "AfterEachFeature",
 		},
+		{
+			name: "RoleBeforeAssessment",
+			r:    roleBeforeAssessment,
+			want: "BeforeEachAssessment",
+		},
+		{
+			name: "RoleAfterAssessment",
+			r:    roleAfterAssessment,
+			want: "AfterEachAssessment",
+		},
 		{
 			name: "RoleAfterTest",
 			r:    roleAfterTest,
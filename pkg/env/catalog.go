@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	klog "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/pkg/features"
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+// catalogFeature describes a feature passed to Test or TestInParallel in the catalog of the features,
+// which is used to trace the features in test management systems
+type catalogFeature struct {
+	Test           string              `json:"test"`
+	Name           string              `json:"name"`
+	Description    string              `json:"description,omitempty"`
+	Labels         map[string][]string `json:"labels,omitempty"`
+	Dependencies   []string            `json:"dependencies,omitempty"`
+	Fixtures       []string            `json:"fixtures,omitempty"`
+	MinKubeVersion string              `json:"minKubeVersion,omitempty"`
+	MaxKubeVersion string              `json:"maxKubeVersion,omitempty"`
+	Assessments    []plannedStep       `json:"assessments,omitempty"`
+	types.FeatureMetadata
+}
+
+// addToCatalog records all the features passed to the test named testName, regardless of the
+// filters and sharding, as the catalog describes the features registered by the suite
+func (p *planCollector) addToCatalog(testName string, testFeatures []types.Feature) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, f := range testFeatures {
+		p.catalog = append(p.catalog, catalogEntry(testName, i, f))
+	}
+}
+
+// catalogEntry describes the i-th feature of a test and all its assessments
+func catalogEntry(testName string, i int, f types.Feature) catalogFeature {
+	entry := catalogFeature{Test: testName, Name: f.Name(), Labels: f.Labels()}
+	if entry.Name == "" {
+		entry.Name = fmt.Sprintf("Feature-%d", i+1)
+	}
+	if df, ok := f.(types.DescribableFeature); ok {
+		entry.Description = df.Description()
+	}
+	if mf, ok := f.(types.MetadataFeature); ok {
+		entry.FeatureMetadata = mf.Metadata()
+	}
+	if df, ok := f.(types.DependentFeature); ok {
+		entry.Dependencies = df.Dependencies()
+	}
+	if ff, ok := f.(types.FixtureFeature); ok {
+		entry.Fixtures = ff.Fixtures()
+	}
+	if vf, ok := f.(types.VersionedFeature); ok {
+		entry.MinKubeVersion, entry.MaxKubeVersion = vf.KubeVersionRange()
+	}
+	for j, assess := range features.GetStepsByLevel(f.Steps(), types.LevelAssess) {
+		entry.Assessments = append(entry.Assessments, planStep(assess, j))
+	}
+	return entry
+}
+
+// writeFeatureCatalog writes the catalog of the features as JSON to the destination set with the
+// --list-features flag
+func (e *testEnv) writeFeatureCatalog() error {
+	path := e.cfg.ListFeatures()
+	if path == "" || e.plan == nil {
+		return nil
+	}
+	e.plan.mu.Lock()
+	catalog := append([]catalogFeature{}, e.plan.catalog...)
+	e.plan.mu.Unlock()
+
+	data, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding feature catalog: %w", err)
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	klog.V(2).InfoS("Writing feature catalog", "path", path, "features", len(catalog))
+	return os.WriteFile(path, data, 0o644)
+}
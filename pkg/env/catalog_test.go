@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+)
+
+func TestTestEnv_WriteFeatureCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	env := &testEnv{
+		ctx:     context.Background(),
+		cfg:     envconf.New().WithListFeatures(path),
+		results: &resultCollector{},
+		plan:    &planCollector{},
+	}
+	// the catalog lists the features and assessments filtered out of the run
+	env.cfg.WithSkipFeatureRegex("skipped").WithSkipAssessmentRegex("slow")
+
+	var executed bool
+	noop := func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+		executed = true
+		return ctx
+	}
+	traced := features.New("traced").
+		WithDescription("traced feature").
+		WithID("TC-1").
+		WithOwner("qa").
+		WithLink("https://example.com/TC-1").
+		WithLabel("type", "catalog").
+		DependsOn("skipped").
+		WithMinKubeVersion("1.29").
+		Assess("fast", noop).
+		AssessWithDescription("slow", "takes a while", noop).
+		Feature()
+	skipped := features.New("skipped").Assess("check", noop).Feature()
+	env.Test(t, skipped, traced)
+	if executed {
+		t.Error("expected assessments not to be executed when listing the features")
+	}
+
+	if err := env.writeFeatureCatalog(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var catalog []catalogFeature
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		t.Fatal(err)
+	}
+	if len(catalog) != 2 || catalog[0].Name != "skipped" || catalog[1].Name != "traced" {
+		t.Fatalf("unexpected catalog: %+v", catalog)
+	}
+	feature := catalog[1]
+	if feature.Test != t.Name() || feature.Description != "traced feature" || feature.ID != "TC-1" ||
+		feature.Owners[0] != "qa" || feature.Links[0] != "https://example.com/TC-1" || feature.Labels["type"][0] != "catalog" ||
+		feature.Dependencies[0] != "skipped" || feature.MinKubeVersion != "1.29" {
+		t.Errorf("unexpected feature: %+v", feature)
+	}
+	if len(feature.Assessments) != 2 || feature.Assessments[1].Name != "slow" || feature.Assessments[1].Description != "takes a while" {
+		t.Errorf("unexpected assessments: %+v", feature.Assessments)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"flag"
+	"time"
+)
+
+// defaultTimeoutGrace is how long before the go test timeout the tests are cancelled and the
+// Finish actions run, when no teardown timeout is configured
+const defaultTimeoutGrace = time.Minute
+
+// testTimeout returns the value of the -test.timeout flag of the test binary, set by go test
+// -timeout, or 0 when the tests run without timeout
+func testTimeout() time.Duration {
+	// m.Run parses the flags the same way when they are not parsed yet
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+	f := flag.Lookup("test.timeout")
+	if f == nil {
+		return 0
+	}
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return 0
+	}
+	timeout, _ := getter.Get().(time.Duration)
+	return timeout
+}
+
+// timeoutGrace returns how long before the go test timeout the Finish actions start: the
+// teardown timeout when it is set, defaultTimeoutGrace otherwise, and at most half the timeout
+func timeoutGrace(timeout, teardown time.Duration) time.Duration {
+	grace := defaultTimeoutGrace
+	if teardown > 0 {
+		grace = teardown
+	}
+	return min(grace, timeout/2)
+}
+
+// withTestTimeout returns a copy of ctx whose deadline expires the grace period before the go test
+// timeout, which starts when m.Run is called, and calls onDeadline once the deadline expires. The
+// go test timeout panics and exits without running any deferred function, onDeadline gives the
+// Finish actions a chance to clean up before. The returned function releases the timer.
+func withTestTimeout(ctx context.Context, timeout, teardown time.Duration, onDeadline func()) (context.Context, func()) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	deadline := time.Now().Add(timeout - timeoutGrace(timeout, teardown))
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	timer := time.AfterFunc(time.Until(deadline), onDeadline)
+	return ctx, func() {
+		timer.Stop()
+		cancel()
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTimeoutGrace(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		teardown time.Duration
+		want     time.Duration
+	}{
+		{name: "default", timeout: 10 * time.Minute, want: defaultTimeoutGrace},
+		{name: "teardown timeout", timeout: 10 * time.Minute, teardown: 3 * time.Minute, want: 3 * time.Minute},
+		{name: "short timeout", timeout: time.Minute, want: 30 * time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := timeoutGrace(test.timeout, test.teardown); got != test.want {
+				t.Errorf("expected a grace period of %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestWithTestTimeout(t *testing.T) {
+	ctx, stop := withTestTimeout(context.Background(), 0, 0, func() { t.Error("unexpected deadline") })
+	stop()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline without go test timeout")
+	}
+
+	expired := make(chan struct{})
+	start := time.Now()
+	ctx, stop = withTestTimeout(context.Background(), 200*time.Millisecond, 50*time.Millisecond, func() { close(expired) })
+	defer stop()
+	deadline, ok := ctx.Deadline()
+	if elapsed := deadline.Sub(start); !ok || elapsed < 150*time.Millisecond || elapsed >= 200*time.Millisecond {
+		t.Errorf("expected a deadline the grace period before the timeout, got %s", elapsed)
+	}
+	select {
+	case <-expired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the deadline callback to be called")
+	}
+	if ctx.Err() == nil {
+		t.Error("expected the context to be cancelled once the deadline expired")
+	}
+}
+
+func TestTimeoutExpired(t *testing.T) {
+	expired := func(parent context.Context, timeout time.Duration) context.Context {
+		ctx, cancel := withStepTimeout(parent, timeout)
+		t.Cleanup(cancel)
+		<-ctx.Done()
+		return ctx
+	}
+	suite, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	<-suite.Done()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		parent  context.Context
+		timeout time.Duration
+		want    bool
+	}{
+		{name: "own timeout", ctx: expired(context.Background(), time.Millisecond), parent: context.Background(), timeout: time.Millisecond, want: true},
+		{name: "running", ctx: context.Background(), parent: context.Background(), timeout: time.Minute},
+		{name: "suite deadline without timeout", ctx: suite, parent: suite},
+		{name: "suite deadline before the timeout", ctx: expired(suite, time.Minute), parent: suite, timeout: time.Minute},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := timeoutExpired(test.ctx, test.parent, test.timeout); got != test.want {
+				t.Errorf("timeoutExpired() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
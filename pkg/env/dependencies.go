@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	klog "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+// featureDependencies returns the names of the features f depends on
+func featureDependencies(f types.Feature) []string {
+	if d, ok := f.(types.DependentFeature); ok {
+		return d.Dependencies()
+	}
+	return nil
+}
+
+// sortByDependencies returns the features ordered so that each feature comes after the features it
+// depends on. The relative order of the features is preserved otherwise. Dependencies on features
+// that are not part of testFeatures are ignored. An error is returned if the dependencies form a cycle.
+func sortByDependencies(testFeatures []types.Feature) ([]types.Feature, error) {
+	remaining := make(map[string]int, len(testFeatures))
+	for _, f := range testFeatures {
+		remaining[f.Name()]++
+	}
+
+	sorted := make([]types.Feature, 0, len(testFeatures))
+	pending := append([]types.Feature{}, testFeatures...)
+	for len(pending) > 0 {
+		next := -1
+		for i, f := range pending {
+			ready := true
+			for _, dep := range featureDependencies(f) {
+				if remaining[dep] > 0 {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			var names []string
+			for _, f := range pending {
+				names = append(names, f.Name())
+			}
+			return nil, fmt.Errorf("feature dependency cycle detected between features: %s", strings.Join(names, ", "))
+		}
+		f := pending[next]
+		sorted = append(sorted, f)
+		remaining[f.Name()]--
+		pending = append(pending[:next], pending[next+1:]...)
+	}
+	return sorted, nil
+}
+
+// dependencyTracker keeps track of the outcome of the features passed to a single Test or
+// TestInParallel call so that dependent features can wait for their dependencies to be done.
+type dependencyTracker struct {
+	mu      sync.Mutex
+	done    *sync.Cond
+	pending map[string]int
+	failed  map[string]bool
+}
+
+func newDependencyTracker(testFeatures []types.Feature) *dependencyTracker {
+	d := &dependencyTracker{
+		pending: make(map[string]int, len(testFeatures)),
+		failed:  make(map[string]bool),
+	}
+	d.done = sync.NewCond(&d.mu)
+	for _, f := range testFeatures {
+		d.pending[f.Name()]++
+	}
+	return d
+}
+
+// finish records the outcome of the feature identified by name
+func (d *dependencyTracker) finish(name string, succeeded bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pending[name]--
+	if !succeeded {
+		d.failed[name] = true
+	}
+	d.done.Broadcast()
+}
+
+// wait blocks until all the dependencies are done and returns the name of the first one that
+// failed or was skipped, if any
+func (d *dependencyTracker) wait(dependencies []string) (string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, dep := range dependencies {
+		if _, ok := d.pending[dep]; !ok {
+			klog.V(2).InfoS("Ignoring feature dependency that is not part of the same test", "dependency", dep)
+			continue
+		}
+		for d.pending[dep] > 0 {
+			d.done.Wait()
+		}
+		if d.failed[dep] {
+			return dep, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+func TestSortByDependencies(t *testing.T) {
+	tests := []struct {
+		name     string
+		features []types.Feature
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "no dependencies",
+			features: []types.Feature{
+				features.New("a").Feature(),
+				features.New("b").Feature(),
+			},
+			want: "a,b",
+		},
+		{
+			name: "dependency declared after dependent",
+			features: []types.Feature{
+				features.New("c").DependsOn("b").Feature(),
+				features.New("a").Feature(),
+				features.New("b").DependsOn("a").Feature(),
+			},
+			want: "a,b,c",
+		},
+		{
+			name: "dependency outside of the batch",
+			features: []types.Feature{
+				features.New("b").DependsOn("unknown").Feature(),
+				features.New("a").Feature(),
+			},
+			want: "b,a",
+		},
+		{
+			name: "cycle",
+			features: []types.Feature{
+				features.New("a").DependsOn("b").Feature(),
+				features.New("b").DependsOn("a").Feature(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := sortByDependencies(tt.features)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sortByDependencies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, f := range sorted {
+				names = append(names, f.Name())
+			}
+			if got := strings.Join(names, ","); !tt.wantErr && got != tt.want {
+				t.Errorf("sortByDependencies() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependencyTracker(t *testing.T) {
+	tracker := newDependencyTracker([]types.Feature{features.New("a").Feature(), features.New("b").Feature()})
+	tracker.finish("a", false)
+	if dep, failed := tracker.wait([]string{"unknown", "a"}); !failed || dep != "a" {
+		t.Errorf("expected dependency a to be reported as failed, got %q, %v", dep, failed)
+	}
+
+	go tracker.finish("b", true)
+	if _, failed := tracker.wait([]string{"b"}); failed {
+		t.Error("expected dependency b to succeed")
+	}
+}
+
+func TestTestEnv_TestInParallelWithDependencies(t *testing.T) {
+	env := NewParallel()
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) features.Func {
+		return func(ctx context.Context, t *testing.T, config *envconf.Config) context.Context {
+			time.Sleep(50 * time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return ctx
+		}
+	}
+
+	_ = env.TestInParallel(t,
+		features.New("dependent").DependsOn("dependency").Assess("record", record("dependent")).Feature(),
+		features.New("dependency").Assess("record", record("dependency")).Feature(),
+	)
+	if got := strings.Join(order, ","); got != "dependency,dependent" {
+		t.Errorf("expected dependency to complete before the dependent feature, got %s", got)
+	}
+}
+
+func TestTestEnv_TestWithSkippedDependency(t *testing.T) {
+	env := newTestEnv()
+	ran := false
+	env.Test(t,
+		features.New("dependency").Setup(func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+			t.Skip("not applicable to this cluster")
+			return ctx
+		}).Feature(),
+		features.New("dependent").DependsOn("dependency").Assess("run", func(ctx context.Context, _ *testing.T, _ *envconf.Config) context.Context {
+			ran = true
+			return ctx
+		}).Feature(),
+	)
+	if ran {
+		t.Error("expected the features depending on a skipped feature to be skipped")
+	}
+	if failed := env.results.failed(); len(failed) != 0 {
+		t.Errorf("expected the skipped features not to be recorded as failed, got %v", failed)
+	}
+}
@@ -20,33 +20,50 @@ package env
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"os"
+	"path"
 	"regexp"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"sync"
 	"testing"
+	"time"
 
+	"k8s.io/client-go/rest"
 	klog "k8s.io/klog/v2"
 
 	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/klient/apicalls"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
 	"sigs.k8s.io/e2e-framework/pkg/envconf"
 	"sigs.k8s.io/e2e-framework/pkg/featuregate"
 	"sigs.k8s.io/e2e-framework/pkg/features"
 	"sigs.k8s.io/e2e-framework/pkg/types"
 )
 
+// ErrSuiteFailed can be wrapped by the errors of the Finish functions to make the test suite fail.
+// The other errors of the Finish functions are logged and do not change the outcome of the suite.
+var ErrSuiteFailed = errors.New("test suite failed")
+
 type (
-	Environment = types.Environment
-	Func        = types.EnvFunc
-	FeatureFunc = types.FeatureEnvFunc
-	TestFunc    = types.TestEnvFunc
+	Environment    = types.Environment
+	Func           = types.EnvFunc
+	FeatureFunc    = types.FeatureEnvFunc
+	AssessmentFunc = types.AssessmentEnvFunc
+	TestFunc       = types.TestEnvFunc
 )
 
 type testEnv struct {
-	ctx     context.Context
-	cfg     *envconf.Config
-	actions []action
+	ctx      context.Context
+	cfg      *envconf.Config
+	actions  []action
+	results  *resultCollector
+	plan     *planCollector
+	fixtures *fixtureRegistry
 }
 
 // New creates a test environment with no config attached.
@@ -100,20 +117,83 @@ func NewWithContext(ctx context.Context, cfg *envconf.Config) (types.Environment
 	if cfg == nil {
 		return nil, fmt.Errorf("environment config is nil")
 	}
-	return &testEnv{ctx: ctx, cfg: cfg}, nil
+	return &testEnv{ctx: ctx, cfg: cfg, results: &resultCollector{}, plan: &planCollector{}, fixtures: newFixtureRegistry()}, nil
 }
 
 func newTestEnv() *testEnv {
 	return &testEnv{
-		ctx: context.Background(),
-		cfg: envconf.New(),
+		ctx:      context.Background(),
+		cfg:      envconf.New(),
+		results:  &resultCollector{},
+		plan:     &planCollector{},
+		fixtures: newFixtureRegistry(),
 	}
 }
 
 func newTestEnvWithParallel() *testEnv {
 	return &testEnv{
-		ctx: context.Background(),
-		cfg: envconf.New().WithParallelTestEnabled(),
+		ctx:      context.Background(),
+		cfg:      envconf.New().WithParallelTestEnabled(),
+		results:  &resultCollector{},
+		plan:     &planCollector{},
+		fixtures: newFixtureRegistry(),
+	}
+}
+
+// WithRepeat returns an environment function that makes Test and TestInParallel run each
+// selected feature n times and report the pass rate of each feature at the end of the run.
+// It is meant to be registered using Setup and overrides the value of the --repeat flag.
+func WithRepeat(n int) Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		if n < 0 {
+			return ctx, fmt.Errorf("feature repeat count must not be negative: %d", n)
+		}
+		cfg.WithRepeat(n)
+		return ctx, nil
+	}
+}
+
+// RunForDuration returns an environment function that makes Test and TestInParallel run their
+// selected features in a loop until the wall-clock duration d elapses, for stability testing.
+// Each iteration runs as a subtest and shares the context of the previous one. The run stops
+// once more iterations failed than tolerated by the --soak-max-failures flag. It is meant to
+// be registered using Setup and overrides the value of the --soak-duration flag.
+func RunForDuration(d time.Duration) Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		if d < 0 {
+			return ctx, fmt.Errorf("soak duration must not be negative: %s", d)
+		}
+		cfg.WithSoakDuration(d)
+		return ctx, nil
+	}
+}
+
+// WithHookErrorPolicy returns an environment function that sets what a failing hook function,
+// such as a BeforeEachFeature function, aborts: the whole test, only the affected feature, or
+// nothing as the error is logged. It is meant to be registered using Setup and overrides the
+// value of the --hook-error-policy flag.
+func WithHookErrorPolicy(policy envconf.HookErrorPolicy) Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		switch policy {
+		case envconf.HookErrorFailTest, envconf.HookErrorFailFeature, envconf.HookErrorContinue:
+		default:
+			return ctx, fmt.Errorf("unknown hook error policy %q", policy)
+		}
+		cfg.WithHookErrorPolicy(policy)
+		return ctx, nil
+	}
+}
+
+// WithMaxParallel returns an environment function that limits the number of test features
+// run concurrently by TestInParallel to n. It is meant to be registered using Setup and
+// overrides the value of the --parallel-max flag.
+func WithMaxParallel(n int) Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		if n < 0 {
+			return ctx, fmt.Errorf("max parallel features must not be negative: %d", n)
+		}
+		cfg.WithMaxParallelTests(n)
+		return ctx, nil
 	}
 }
 
@@ -126,9 +206,12 @@ type ctxName string
 func newChildTestEnv(e *testEnv) *testEnv {
 	childCtx := context.WithValue(e.ctx, ctxName("parent"), fmt.Sprintf("%s", e.ctx))
 	return &testEnv{
-		ctx:     childCtx,
-		cfg:     e.deepCopyConfig(),
-		actions: append([]action{}, e.actions...),
+		ctx:      childCtx,
+		cfg:      e.deepCopyConfig(),
+		actions:  append([]action{}, e.actions...),
+		results:  e.results,
+		plan:     e.plan,
+		fixtures: e.fixtures,
 	}
 }
 
@@ -139,8 +222,11 @@ func (e *testEnv) WithContext(ctx context.Context) types.Environment {
 		panic("nil context") // this should never happen
 	}
 	env := &testEnv{
-		ctx: ctx,
-		cfg: e.cfg,
+		ctx:      ctx,
+		cfg:      e.cfg,
+		results:  e.results,
+		plan:     e.plan,
+		fixtures: e.fixtures,
 	}
 	env.actions = append(env.actions, e.actions...)
 	return env
@@ -186,6 +272,29 @@ func (e *testEnv) AfterEachFeature(funcs ...FeatureFunc) types.Environment {
 	return e
 }
 
+// BeforeEachAssessment registers functions that are executed before each assessment of a
+// feature during an env.Test call, for instance to reset a mock server between assessments.
+// The functions run within the subtest of the assessment, after the assessment filters are
+// applied.
+func (e *testEnv) BeforeEachAssessment(funcs ...AssessmentFunc) types.Environment {
+	if len(funcs) == 0 {
+		return e
+	}
+	e.actions = append(e.actions, action{role: roleBeforeAssessment, assessmentFuncs: funcs})
+	return e
+}
+
+// AfterEachAssessment registers functions that are executed after each assessment of a
+// feature during an env.Test call. They also run when the assessment fails or calls
+// t.FailNow().
+func (e *testEnv) AfterEachAssessment(funcs ...AssessmentFunc) types.Environment {
+	if len(funcs) == 0 {
+		return e
+	}
+	e.actions = append(e.actions, action{role: roleAfterAssessment, assessmentFuncs: funcs})
+	return e
+}
+
 // AfterEachTest registers environment funcs that are executed
 // after each Env.Test(...).
 func (e *testEnv) AfterEachTest(funcs ...types.TestEnvFunc) types.Environment {
@@ -213,7 +322,11 @@ func (e *testEnv) processTestActions(ctx context.Context, t *testing.T, actions
 	for _, action := range actions {
 		out, err = action.runWithT(out, e.cfg, t)
 		if err != nil {
-			t.Fatalf("%s failure: %s", action.role, err)
+			if e.cfg.HookErrorPolicy() == envconf.HookErrorContinue {
+				t.Logf("%s, ignored", err)
+				continue
+			}
+			t.Fatal(err)
 		}
 	}
 	return out
@@ -221,33 +334,134 @@ func (e *testEnv) processTestActions(ctx context.Context, t *testing.T, actions
 
 // processTestFeature is used to trigger the execution of the actual feature. This function wraps the entire
 // workflow of orchestrating the feature execution be running the action configured by BeforeEachFeature /
-// AfterEachFeature.
-func (e *testEnv) processTestFeature(ctx context.Context, t *testing.T, featureName string, feature types.Feature) context.Context {
+// AfterEachFeature. It reports whether the feature passed and whether it skipped itself.
+func (e *testEnv) processTestFeature(ctx context.Context, t *testing.T, featureName string, feature types.Feature) (context.Context, bool, bool) {
 	t.Helper()
 	skipped, message := e.requireFeatureProcessing(feature)
 	if skipped {
 		t.Skip(message)
 	}
+	if e.cfg.DryRunMode() {
+		e.plan.add(t.Name(), e.planFeature(featureName, feature))
+	}
+	// the fixtures are ready before the beforeEachFeature actions run
+	fixtures, err := e.fixtures.acquire(ctx, e.cfg, e.results, featureFixtures(feature))
+	if err != nil {
+		t.Run(featureName, func(newT *testing.T) {
+			newT.Fatal(err)
+		})
+		return ctx, false, false
+	}
+	defer e.fixtures.release(fixtures)
+	ctx, dropFixtureValues := withFixtureValues(ctx, fixtures)
+	defer dropFixtureValues()
+
+	// the config a beforeEachFeature action may set for the feature does not outlive it
+	parentCfg := envconf.FeatureConfigFromContext(ctx)
+
 	// execute beforeEachFeature actions
-	ctx = e.processFeatureActions(ctx, t, feature, e.getBeforeFeatureActions())
+	ctx, err = e.processFeatureActions(ctx, t, feature, e.getBeforeFeatureActions())
 
-	// execute feature test
-	ctx = e.execFeature(ctx, t, featureName, feature)
+	// execute feature test, unless a beforeEachFeature action failed the feature
+	var passed, selfSkipped bool
+	if err != nil {
+		t.Run(featureName, func(newT *testing.T) {
+			newT.Fatal(err)
+		})
+	} else {
+		ctx, passed, selfSkipped = e.execFeature(ctx, t, featureName, feature)
+	}
 
 	// execute afterEachFeature actions
-	return e.processFeatureActions(ctx, t, feature, e.getAfterFeatureActions())
+	ctx, err = e.processFeatureActions(ctx, t, feature, e.getAfterFeatureActions())
+	if err != nil {
+		t.Errorf("feature %s: %s", featureName, err)
+		passed = false
+	}
+	if envconf.FeatureConfigFromContext(ctx) != parentCfg {
+		ctx = envconf.WithFeatureConfig(ctx, parentCfg)
+	}
+	return ctx, passed, selfSkipped
+}
+
+// processDependentFeature runs the feature once the features it depends on are done, or skips it if
+// any of them failed or was skipped. The outcome of the feature is recorded in the tracker, a feature
+// that skips itself counts as skipped so that the features depending on it are skipped too.
+func (e *testEnv) processDependentFeature(ctx context.Context, t *testing.T, tracker *dependencyTracker, featureName string, feature types.Feature) context.Context {
+	t.Helper()
+	passed, skipped, unsupported := false, false, false
+	defer func() {
+		tracker.finish(feature.Name(), passed && !skipped)
+		// features skipped using the filtering flags skip the whole test and are not recorded,
+		// neither are features that are skipped, by themselves or with a dependency, or do not
+		// support the Kubernetes version of the cluster
+		if !unsupported && !skipped && (passed || !t.Skipped()) {
+			e.results.record(feature.Name(), passed)
+		}
+	}()
+
+	if dep, failed := tracker.wait(featureDependencies(feature)); failed {
+		skipped = true
+		t.Run(featureName, func(newT *testing.T) {
+			newT.Skipf("skipping feature %s: dependency %s failed or was skipped", featureName, dep)
+		})
+		return ctx
+	}
+
+	skip, message, err := e.requireKubeVersion(feature)
+	if err != nil || skip {
+		unsupported = skip
+		t.Run(featureName, func(newT *testing.T) {
+			if err != nil {
+				newT.Fatal(err)
+			}
+			newT.Skip(message)
+		})
+		return ctx
+	}
+	ctx, passed, skipped = e.processTestFeature(ctx, t, featureName, feature)
+	return ctx
 }
 
 // processFeatureActions is used to run a series of feature action that were configured as
-// BeforeEachFeature or AfterEachFeature
-func (e *testEnv) processFeatureActions(ctx context.Context, t *testing.T, feature types.Feature, actions []action) context.Context {
+// BeforeEachFeature or AfterEachFeature. The failure of an action is returned when it only fails
+// the feature according to the hook error policy, and the remaining actions are not run.
+func (e *testEnv) processFeatureActions(ctx context.Context, t *testing.T, feature types.Feature, actions []action) (context.Context, error) {
 	t.Helper()
 	var err error
 	out := ctx
 	for _, action := range actions {
 		out, err = action.runWithFeature(out, e.cfg, t, deepCopyFeature(feature))
 		if err != nil {
-			t.Fatalf("%s failure: %s", action.role, err)
+			switch e.cfg.HookErrorPolicy() {
+			case envconf.HookErrorContinue:
+				t.Logf("%s, ignored", err)
+			case envconf.HookErrorFailFeature:
+				return out, err
+			default:
+				t.Fatal(err)
+			}
+		}
+	}
+	return out, nil
+}
+
+// processAssessmentActions is used to run a series of assessment action that were configured as
+// BeforeEachAssessment or AfterEachAssessment. A failing action fails the assessment, unless the
+// hook error policy is to continue.
+func (e *testEnv) processAssessmentActions(ctx context.Context, t *testing.T, featName, assessName string, actions []action) context.Context {
+	t.Helper()
+	var err error
+	out := ctx
+	for _, action := range actions {
+		out, err = action.runWithAssessment(out, e.cfg, t, featName, assessName)
+		if err != nil {
+			if e.cfg.HookErrorPolicy() == envconf.HookErrorContinue {
+				t.Logf("%s, ignored", err)
+				continue
+			}
+			// the failure is scoped to the subtest of the assessment
+			t.Fatal(err)
 		}
 	}
 	return out
@@ -272,20 +486,61 @@ func (e *testEnv) processTests(ctx context.Context, t *testing.T, enableParallel
 		t.Log("No test testFeatures provided, skipping test")
 		return ctx
 	}
+	if dedicatedTestEnv.cfg.ListFeatures() != "" {
+		dedicatedTestEnv.plan.addToCatalog(t.Name(), testFeatures)
+	}
+	testFeatures = dedicatedTestEnv.shardFeatures(testFeatures)
+	if len(testFeatures) == 0 {
+		t.Log("No test features belong to this shard, skipping test")
+		return ctx
+	}
+	testFeatures = dedicatedTestEnv.rerunFeatures(testFeatures)
+	if len(testFeatures) == 0 {
+		t.Log("No previously failed test features to rerun, skipping test")
+		return ctx
+	}
+	testFeatures = dedicatedTestEnv.repeatFeatures(testFeatures)
 	beforeTestActions := dedicatedTestEnv.getBeforeTestActions()
 	afterTestActions := dedicatedTestEnv.getAfterTestActions()
 
 	runInParallel := dedicatedTestEnv.cfg.ParallelTestEnabled() && enableParallelRun
 
+	// workers bounds the number of features that run concurrently when a limit is configured
+	var workers chan struct{}
 	if runInParallel {
 		klog.V(4).Info("Running test features in parallel")
+		if maxParallel := dedicatedTestEnv.cfg.MaxParallelTests(); maxParallel > 0 {
+			klog.V(4).InfoS("Limiting the number of test features run concurrently", "max", maxParallel)
+			workers = make(chan struct{}, maxParallel)
+		}
+	}
+
+	orderedFeatures, err := sortByDependencies(dedicatedTestEnv.orderFeatures(testFeatures))
+	if err != nil {
+		t.Fatal(err)
 	}
 
 	ctx = dedicatedTestEnv.processTestActions(ctx, t, beforeTestActions)
 
+	if soak := dedicatedTestEnv.cfg.SoakDuration(); soak > 0 && !dedicatedTestEnv.cfg.DryRunMode() {
+		ctx = dedicatedTestEnv.soakFeatures(ctx, t, soak, func(ctx context.Context, t *testing.T) context.Context {
+			return dedicatedTestEnv.runFeatures(ctx, t, orderedFeatures, runInParallel, workers)
+		})
+	} else {
+		ctx = dedicatedTestEnv.runFeatures(ctx, t, orderedFeatures, runInParallel, workers)
+	}
+	return dedicatedTestEnv.processTestActions(ctx, t, afterTestActions)
+}
+
+// runFeatures runs the ordered features, in parallel when enabled with at most cap(workers)
+// features running concurrently when workers is not nil
+func (e *testEnv) runFeatures(ctx context.Context, t *testing.T, orderedFeatures []types.Feature, runInParallel bool, workers chan struct{}) context.Context {
+	t.Helper()
+	tracker := newDependencyTracker(orderedFeatures)
+
 	var wg sync.WaitGroup
-	for i, feature := range testFeatures {
-		featureTestEnv := newChildTestEnv(dedicatedTestEnv)
+	for i, feature := range orderedFeatures {
+		featureTestEnv := newChildTestEnv(e)
 		featureCopy := feature
 		featName := feature.Name()
 		if featName == "" {
@@ -293,12 +548,18 @@ func (e *testEnv) processTests(ctx context.Context, t *testing.T, enableParallel
 		}
 		if runInParallel {
 			wg.Add(1)
+			if workers != nil {
+				workers <- struct{}{}
+			}
 			go func(ctx context.Context, w *sync.WaitGroup, featName string, f types.Feature) {
 				defer w.Done()
-				_ = featureTestEnv.processTestFeature(ctx, t, featName, f)
+				if workers != nil {
+					defer func() { <-workers }()
+				}
+				_ = featureTestEnv.processDependentFeature(ctx, t, tracker, featName, f)
 			}(ctx, &wg, featName, featureCopy)
 		} else {
-			ctx = featureTestEnv.processTestFeature(ctx, t, featName, featureCopy)
+			ctx = featureTestEnv.processDependentFeature(ctx, t, tracker, featName, featureCopy)
 			// In case if the feature under test has failed, skip reset of the features
 			// that are part of the same test
 			if featureTestEnv.cfg.FailFast() && t.Failed() {
@@ -309,7 +570,91 @@ func (e *testEnv) processTests(ctx context.Context, t *testing.T, enableParallel
 	if runInParallel {
 		wg.Wait()
 	}
-	return dedicatedTestEnv.processTestActions(ctx, t, afterTestActions)
+	return ctx
+}
+
+// shardFeatures returns the features that belong to the shard of the test suite run by this process
+func (e *testEnv) shardFeatures(testFeatures []types.Feature) []types.Feature {
+	index, total := e.cfg.Shard()
+	if total <= 1 {
+		return testFeatures
+	}
+	strategy := e.cfg.ShardStrategy()
+	sharded := make([]types.Feature, 0, len(testFeatures))
+	for _, f := range testFeatures {
+		if strategy.Contains(f.Name(), f.Labels(), index, total) {
+			sharded = append(sharded, f)
+			continue
+		}
+		klog.V(2).InfoS("Skipping feature that belongs to another shard", "feature", f.Name(), "shard", index, "shards", total)
+	}
+	return sharded
+}
+
+// rerunFeatures returns the features that failed in a previous run when the environment is
+// configured to only rerun those
+func (e *testEnv) rerunFeatures(testFeatures []types.Feature) []types.Feature {
+	names, ok := e.cfg.RerunFeatures()
+	if !ok {
+		return testFeatures
+	}
+	rerun := make([]types.Feature, 0, len(testFeatures))
+	for _, f := range testFeatures {
+		if slices.Contains(names, f.Name()) {
+			rerun = append(rerun, f)
+			continue
+		}
+		klog.V(2).InfoS("Skipping feature that did not fail in the previous run", "feature", f.Name())
+	}
+	return rerun
+}
+
+// repeatFeatures returns each feature as many times as the configured repeat count. The
+// copies of a feature are run one after the other or in parallel, like distinct features.
+func (e *testEnv) repeatFeatures(testFeatures []types.Feature) []types.Feature {
+	n := e.cfg.Repeat()
+	if n <= 1 {
+		return testFeatures
+	}
+	repeated := make([]types.Feature, 0, len(testFeatures)*n)
+	for _, f := range testFeatures {
+		for i := 0; i < n; i++ {
+			repeated = append(repeated, f)
+		}
+	}
+	return repeated
+}
+
+// orderFeatures returns the features in the order they are executed based on the configuration
+// of the environment. The features are shuffled first, if enabled, and then stable sorted by
+// their order so that the shuffle only applies to the features sharing the same order.
+func (e *testEnv) orderFeatures(testFeatures []types.Feature) []types.Feature {
+	seed, shuffle := e.cfg.ShuffledFeatures()
+	if !shuffle && !e.cfg.SortedFeatures() {
+		return testFeatures
+	}
+	ordered := append([]types.Feature{}, testFeatures...)
+	if shuffle {
+		klog.V(2).InfoS("Shuffling test features", "seed", seed)
+		r := rand.New(rand.NewSource(seed))
+		r.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+	}
+	if e.cfg.SortedFeatures() {
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return featureOrder(ordered[i]) < featureOrder(ordered[j])
+		})
+	}
+	return ordered
+}
+
+// featureOrder returns the execution order of the feature, 0 for features that do not declare one
+func featureOrder(f types.Feature) int {
+	if o, ok := f.(types.OrderedFeature); ok {
+		return o.Order()
+	}
+	return 0
 }
 
 // TestInParallel executes a series a feature tests from within a
@@ -365,8 +710,7 @@ func (e *testEnv) Finish(funcs ...Func) types.Environment {
 
 // EnvConf returns the test environment's environment configuration
 func (e *testEnv) EnvConf() *envconf.Config {
-	cfg := *e.cfg
-	return &cfg
+	return e.cfg.Clone()
 }
 
 // Run is to launch the test suite from a TestMain function.
@@ -376,13 +720,80 @@ func (e *testEnv) EnvConf() *envconf.Config {
 // before completing the suite.
 func (e *testEnv) Run(m *testing.M) (exitCode int) {
 	e.panicOnMissingContext()
-	ctx := e.ctx
+	ctx, cancel := context.WithCancel(e.ctx)
+	defer cancel()
+	current := &runContext{ctx: ctx}
 
 	setups := e.getSetupActions()
 	// fail fast on setup, upon err exit
 	var err error
 
+	// finish runs the Finish actions only once, either when Run returns or when an
+	// interrupt signal is received, whichever comes first.
+	var finishOnce sync.Once
+	suiteFailed := false
+	finish := func() {
+		finishOnce.Do(func() {
+			// the run context may have been cancelled by an interrupt signal, the finish
+			// actions still need a live context to clean up
+			ctx := context.WithoutCancel(current.get())
+			// shared informers are not used past the tests, stop them before the cluster goes away
+			e.cfg.StopInformers()
+			// the fixtures may depend on resources released by the finish actions, such as the cluster
+			e.fixtures.tearDown(e.cfg, e.results)
+			finishes := e.getFinishActions()
+			// attempt to gracefully clean up.
+			// Upon error, log and continue.
+			for _, fin := range finishes {
+				var err error
+				// context passed down to each finish step
+				if ctx, err = fin.run(ctx, e.cfg, e.results); err != nil {
+					if errors.Is(err, ErrSuiteFailed) {
+						klog.ErrorS(err, "Test suite failed", "action", fin.role)
+						suiteFailed = true
+						continue
+					}
+					klog.V(2).ErrorS(err, "Cleanup failed", "action", fin.role)
+				}
+			}
+			current.set(ctx)
+
+			if e.cfg.Repeat() > 1 || e.cfg.SoakDuration() > 0 {
+				e.results.logPassRates()
+			}
+			if err := e.writeFailedFeatures(); err != nil {
+				klog.ErrorS(err, "Failed to persist the names of the failed features")
+			}
+			if err := e.writeAPICalls(); err != nil {
+				klog.ErrorS(err, "Failed to write the API calls of the features")
+			}
+			if err := e.writeDryRunPlan(); err != nil {
+				klog.ErrorS(err, "Failed to write the dry-run plan")
+			}
+			if err := e.writeFeatureCatalog(); err != nil {
+				klog.ErrorS(err, "Failed to write the catalog of the features")
+			}
+			if err := e.writeTimings(); err != nil {
+				klog.ErrorS(err, "Failed to write the timings of the run")
+			}
+			if err := e.results.printTimings(os.Stdout); err != nil {
+				klog.ErrorS(err, "Failed to print the slowest features and steps")
+			}
+		})
+	}
+
+	stopSignals := notifyInterrupt(func(sig os.Signal) {
+		klog.InfoS("Received signal, cancelling the test run and running finish actions", "signal", sig)
+		cancel()
+		finish()
+		if err := e.writeSonobuoyResults(signalExitCode(sig)); err != nil {
+			klog.ErrorS(err, "Failed to write the Sonobuoy results")
+		}
+		os.Exit(signalExitCode(sig))
+	})
+
 	defer func() {
+		stopSignals()
 		// Recover and see if the panic handler is disabled. If it is disabled, panic and stop the workflow.
 		// Otherwise, log and continue with running the Finish steps of the Test suite
 		rErr := recover()
@@ -396,25 +807,32 @@ func (e *testEnv) Run(m *testing.M) (exitCode int) {
 			exitCode = 1
 		}
 
-		finishes := e.getFinishActions()
-		// attempt to gracefully clean up.
-		// Upon error, log and continue.
-		for _, fin := range finishes {
-			// context passed down to each finish step
-			if ctx, err = fin.run(ctx, e.cfg); err != nil {
-				klog.V(2).ErrorS(err, "Cleanup failed", "action", fin.role)
-			}
+		finish()
+		if suiteFailed && exitCode == 0 {
+			exitCode = 1
+		}
+		if err := e.writeSonobuoyResults(exitCode); err != nil {
+			klog.ErrorS(err, "Failed to write the Sonobuoy results")
 		}
-		e.ctx = ctx
+		e.ctx = current.get()
 	}()
 
 	for _, setup := range setups {
 		// context passed down to each setup
-		if ctx, err = setup.run(ctx, e.cfg); err != nil {
-			klog.Errorf("%s failure: %s", setup.role, err)
+		if ctx, err = setup.run(ctx, e.cfg, e.results); err != nil {
+			klog.Error(err)
 			return 1
 		}
+		current.set(ctx)
 	}
+
+	// the tests are cancelled shortly before the go test timeout expires, and the finish actions
+	// run while the tests wind down, as the timeout aborts the process without any cleanup
+	ctx, stopTimeout := withTestTimeout(ctx, testTimeout(), e.cfg.TeardownTimeout(), func() {
+		klog.InfoS("The go test timeout is about to expire, cancelling the tests and running finish actions")
+		finish()
+	})
+	defer stopTimeout()
 	e.ctx = ctx
 
 	// Execute the test suite
@@ -452,6 +870,14 @@ func (e *testEnv) getAfterFeatureActions() []action {
 	return e.getActionsByRole(roleAfterFeature)
 }
 
+func (e *testEnv) getBeforeAssessmentActions() []action {
+	return e.getActionsByRole(roleBeforeAssessment)
+}
+
+func (e *testEnv) getAfterAssessmentActions() []action {
+	return e.getActionsByRole(roleAfterAssessment)
+}
+
 func (e *testEnv) getAfterTestActions() []action {
 	return e.getActionsByRole(roleAfterTest)
 }
@@ -466,36 +892,105 @@ func (e *testEnv) getFinishActions() []action {
 	return finishAction
 }
 
-func (e *testEnv) executeSteps(ctx context.Context, t *testing.T, steps []types.Step) context.Context {
+// executeSteps runs the steps in order and returns the resulting context. It returns false
+// when a step panicked, in which case the remaining steps are not run.
+func (e *testEnv) executeSteps(ctx context.Context, t *testing.T, featName string, steps []types.Step) (context.Context, bool) {
 	t.Helper()
 	if e.cfg.DryRunMode() {
-		return ctx
+		return ctx, true
 	}
 	for _, setup := range steps {
-		ctx = setup.Func()(ctx, t, e.cfg)
+		var ok bool
+		if ctx, ok = e.executeStep(ctx, t, featName, setup); !ok {
+			return ctx, false
+		}
 	}
-	return ctx
+	return ctx, true
 }
 
-func (e *testEnv) execFeature(ctx context.Context, t *testing.T, featName string, f types.Feature) context.Context {
+// executeStep runs a single step. Unless graceful teardown is disabled, a panic in the step
+// is recovered and reported as a test failure so that the teardown steps of the feature and
+// the AfterEachFeature actions still run.
+func (e *testEnv) executeStep(ctx context.Context, t *testing.T, featName string, step types.Step) (newCtx context.Context, ok bool) {
 	t.Helper()
+	if !e.cfg.DisableGracefulTeardown() {
+		defer func() {
+			if rErr := recover(); rErr != nil {
+				t.Errorf("Recovered from panic in step %q: %v, stack: %s", step.Name(), rErr, string(debug.Stack()))
+				newCtx, ok = ctx, false
+			}
+		}()
+	}
+	stepName := step.Name()
+	if stepName == "" {
+		stepName = path.Base(t.Name())
+	}
+	defer e.recordStepTiming(t, featName, stepName, step.Level(), time.Now())
+	logger := features.NewLogger(t, e.cfg.StepLogFormat(), featName, stepName)
+	newCtx = step.Func()(features.WithLogger(ctx, logger), t, featureConfig(ctx, e.cfg))
+	if newCtx != nil {
+		// the logger writes through t, it must not outlive the step
+		newCtx = features.WithLogger(newCtx, nil)
+	}
+	return newCtx, true
+}
+
+// execFeature runs the steps of the feature in a subtest and reports whether it passed and whether it
+// skipped itself.
+func (e *testEnv) execFeature(ctx context.Context, t *testing.T, featName string, f types.Feature) (context.Context, bool, bool) {
+	t.Helper()
+	if !e.cfg.DryRunMode() {
+		defer e.recordFeatureTiming(featName, time.Now())
+	}
 	// feature-level subtest
-	t.Run(featName, func(newT *testing.T) {
+	var skipped bool
+	passed := t.Run(featName, func(newT *testing.T) {
 		newT.Helper()
+		// a step calling t.Skip() ends the subtest, the deferred functions still run
+		defer func() { skipped = newT.Skipped() }()
 
 		if fDescription, ok := f.(types.DescribableFeature); ok && fDescription.Description() != "" {
 			t.Logf("Processing Feature: %s", fDescription.Description())
 		}
 
+		// the API calls sent by the steps with their context are reported, and checked against the budget
+		recorder := apicalls.NewRecorder()
+		ctx = apicalls.WithRecorder(ctx, recorder)
+		defer e.checkAPICalls(newT, f.Name(), recorder)
+
+		// the objects created with a resources.Tracker are deleted once the feature is done
+		registry := resources.NewCleanupRegistry()
+		ctx = resources.WithCleanupRegistry(ctx, registry)
+		// the registry is emptied once the feature is done, it must not outlive the feature
+		defer func() { ctx = resources.WithCleanupRegistry(ctx, nil) }()
+		defer e.cleanupTrackedObjects(ctx, newT, featName, registry)
+
+		// setup and assessment steps share the feature timeout and teardown steps have their
+		// own, steps are expected to honour the cancellation of their context to be interrupted
+		parentCtx := ctx
+		featureCtx, cancel := withStepTimeout(ctx, e.cfg.FeatureTimeout())
+		defer cancel()
+		stepCtx := featureCtx
+		if e.cfg.FeatureTimeout() > 0 || e.cfg.TeardownTimeout() > 0 {
+			// keep the values stored by the steps but not their deadline, even when a step calls t.FailNow()
+			defer func() { ctx = withValuesFrom(parentCtx, stepCtx) }()
+		} else {
+			defer func() { ctx = stepCtx }()
+		}
+
 		// setups run at feature-level
 		setups := features.GetStepsByLevel(f.Steps(), types.LevelSetup)
-		ctx = e.executeSteps(ctx, newT, setups)
+		stepCtx, setupOK := e.executeSteps(stepCtx, newT, featName, setups)
 
 		// assessments run as feature/assessment sub level
 		assessments := features.GetStepsByLevel(f.Steps(), types.LevelAssess)
 
-		failed := false
+		// a panicking setup fails the feature, its assessments are skipped but teardowns still run
+		failed := !setupOK
 		for i, assess := range assessments {
+			if failed || deadlineExceeded(featureCtx) {
+				break
+			}
 			assessName := assess.Name()
 			if dAssess, ok := assess.(types.DescribableStep); ok && dAssess.Description() != "" {
 				t.Logf("Processing Assessment: %s", dAssess.Description())
@@ -512,12 +1007,24 @@ func (e *testEnv) execFeature(ctx context.Context, t *testing.T, featName string
 				if skipped {
 					internalT.Skip(message)
 				}
+				if skipped, message := requireMarkedStepProcessing(stepCtx, featureConfig(stepCtx, e.cfg), assess, assessName); skipped {
+					internalT.Skip(message)
+				}
 				// Set shouldFailNow to true before actually running the assessment, because if the assessment
 				// calls t.FailNow(), the function will be abruptly stopped in the middle of `e.executeSteps()`.
 				shouldFailNow = true
-				ctx = e.executeSteps(ctx, internalT, []types.Step{assess})
+				if afterActions := e.getAfterAssessmentActions(); len(afterActions) > 0 {
+					// the afterEachAssessment actions run even when the assessment calls t.FailNow()
+					defer func() {
+						stepCtx = e.processAssessmentActions(stepCtx, internalT, featName, assessName, afterActions)
+					}()
+				}
+				stepCtx = e.processAssessmentActions(stepCtx, internalT, featName, assessName, e.getBeforeAssessmentActions())
+				var assessOK bool
+				stepCtx, assessOK = e.executeSteps(stepCtx, internalT, featName, []types.Step{assess})
 				// If we reach this point, it means the assessment did not call t.FailNow().
-				shouldFailNow = false
+				// A recovered panic is handled like a t.FailNow() invocation.
+				shouldFailNow = !assessOK
 			})
 			// Check if the Test assessment under question performed either 2 things:
 			// - a t.FailNow() invocation
@@ -530,6 +1037,15 @@ func (e *testEnv) execFeature(ctx context.Context, t *testing.T, featName string
 			}
 		}
 
+		switch {
+		case timeoutExpired(featureCtx, parentCtx, e.cfg.FeatureTimeout()):
+			newT.Errorf("feature %s exceeded its timeout of %s", featName, e.cfg.FeatureTimeout())
+			failed = true
+		case deadlineExceeded(featureCtx):
+			newT.Errorf("feature %s was interrupted: the deadline of the test suite expired", featName)
+			failed = true
+		}
+
 		// Let us fail the test fast and not run the teardown in case if the framework specific fail-fast mode is
 		// invoked to make sure we leave the traces of the failed test behind to enable better debugging for the
 		// test developers
@@ -537,12 +1053,40 @@ func (e *testEnv) execFeature(ctx context.Context, t *testing.T, featName string
 			newT.FailNow()
 		}
 
-		// teardowns run at feature-level
+		// teardowns run at feature-level, with the values of the previous steps but
+		// under their own timeout
+		teardownCtx := stepCtx
+		if e.cfg.FeatureTimeout() > 0 || e.cfg.TeardownTimeout() > 0 {
+			var cancelTeardown context.CancelFunc
+			teardownCtx, cancelTeardown = withStepTimeout(withValuesFrom(parentCtx, stepCtx), e.cfg.TeardownTimeout())
+			defer cancelTeardown()
+		}
 		teardowns := features.GetStepsByLevel(f.Steps(), types.LevelTeardown)
-		ctx = e.executeSteps(ctx, newT, teardowns)
+		stepCtx, _ = e.executeSteps(teardownCtx, newT, featName, teardowns)
+		switch {
+		case timeoutExpired(teardownCtx, parentCtx, e.cfg.TeardownTimeout()):
+			newT.Errorf("teardown of feature %s exceeded its timeout of %s", featName, e.cfg.TeardownTimeout())
+		case deadlineExceeded(teardownCtx):
+			newT.Errorf("teardown of feature %s was interrupted: the deadline of the test suite expired", featName)
+		}
 	})
 
-	return ctx
+	return ctx, passed, skipped
+}
+
+// cleanupTrackedObjects deletes the objects created with a resources.Tracker during the feature, even when
+// a step called t.FailNow(). Like the teardown steps, the cleanup is skipped when a feature fails in
+// fail-fast mode to leave its traces behind.
+func (e *testEnv) cleanupTrackedObjects(ctx context.Context, t *testing.T, featName string, registry *resources.CleanupRegistry) {
+	t.Helper()
+	if registry.Len() == 0 || (e.cfg.FailFast() && t.Failed()) {
+		return
+	}
+	ctx, cancel := withStepTimeout(context.WithoutCancel(ctx), e.cfg.TeardownTimeout())
+	defer cancel()
+	if err := registry.Cleanup(ctx); err != nil {
+		t.Errorf("cleanup of the objects created by feature %s failed: %s", featName, err)
+	}
 }
 
 // requireFeatureProcessing is a wrapper around the requireProcessing function to process the feature level validation
@@ -563,6 +1107,20 @@ func (e *testEnv) requireAssessmentProcessing(a types.Step, assessmentIndex int)
 	return e.requireProcessing("assessment", assessmentName, requiredRegexp, skipRegexp, nil)
 }
 
+// requireMarkedStepProcessing checks whether the step was marked as skipped or as an expected
+// failure when the feature was built
+func requireMarkedStepProcessing(ctx context.Context, cfg *envconf.Config, step types.Step, stepName string) (skip bool, message string) {
+	if s, ok := step.(types.ExpectedFailureStep); ok && s.ExpectedFailure() != "" {
+		return true, fmt.Sprintf(`XFAIL assessment "%s": %s`, stepName, s.ExpectedFailure())
+	}
+	if s, ok := step.(types.SkippableStep); ok {
+		if skip, reason := s.ShouldSkip(ctx, cfg); skip {
+			return true, fmt.Sprintf(`Skipping assessment "%s": %s`, stepName, reason)
+		}
+	}
+	return false, ""
+}
+
 // requireProcessing is a utility function that can be used to make a decision on if a specific Test assessment or feature needs to be
 // processed or not.
 // testName argument indicate the Feature Name or test Name that can be mapped against the skip or include regex flags
@@ -604,6 +1162,16 @@ func (e *testEnv) requireProcessing(kind, testName string, requiredRegexp, skipR
 			return skip, message
 		}
 
+		if selector := e.cfg.LabelSelector(); selector != "" {
+			matched, err := labels.MatchesSelector(selector)
+			if err != nil {
+				return true, fmt.Sprintf(`Skipping feature "%s": %s`, testName, err)
+			}
+			if !matched {
+				return true, fmt.Sprintf(`Skipping feature "%s": labels %v do not match selector "%s"`, testName, labels, selector)
+			}
+		}
+
 		// skip running a feature if labels matches with --skip-labels
 		for key, vals := range e.cfg.SkipLabels() {
 			for _, v := range vals {
@@ -622,13 +1190,15 @@ func (e *testEnv) requireProcessing(kind, testName string, requiredRegexp, skipR
 // copy to avoid mutation when we just want an informational copy.
 func (e *testEnv) deepCopyConfig() *envconf.Config {
 	// Basic copy which takes care of all the basic types (str, bool...)
-	configCopy := *e.cfg
+	configCopy := e.cfg.Clone()
 
 	// Manually setting fields that are struct types
 	if client := e.cfg.GetClient(); client != nil {
 		// Need to recreate the underlying client because client.Resource is not thread safe
 		// Panic on error because this should never happen since the client was built once already
-		clientCopy, err := klient.New(client.RESTConfig())
+		restConfig := rest.CopyConfig(client.RESTConfig())
+		apicalls.Instrument(restConfig)
+		clientCopy, err := klient.New(restConfig)
 		if err != nil {
 			panic(err)
 		}
@@ -662,7 +1232,7 @@ func (e *testEnv) deepCopyConfig() *envconf.Config {
 		skipLabels[k] = copyVals
 	}
 	configCopy.WithSkipLabels(e.cfg.SkipLabels())
-	return &configCopy
+	return configCopy
 }
 
 // deepCopyFeature just copies the values from the Feature to create a deep
@@ -678,5 +1248,18 @@ func deepCopyFeature(f types.Feature) types.Feature {
 	for _, step := range f.Steps() {
 		fcopy = fcopy.WithStep(step.Name(), step.Level(), nil)
 	}
+	minVersion, maxVersion := featureKubeVersionRange(f)
+	fcopy = fcopy.WithOrder(featureOrder(f)).DependsOn(featureDependencies(f)...).RequiresFixture(featureFixtures(f)...).
+		WithMinKubeVersion(minVersion).WithMaxKubeVersion(maxVersion)
+	if df, ok := f.(types.DescribableFeature); ok {
+		fcopy = fcopy.WithDescription(df.Description())
+	}
+	if mf, ok := f.(types.MetadataFeature); ok {
+		metadata := mf.Metadata()
+		fcopy = fcopy.WithID(metadata.ID).WithOwner(metadata.Owners...)
+		for _, link := range metadata.Links {
+			fcopy = fcopy.WithLink(link)
+		}
+	}
 	return fcopy.Feature()
 }
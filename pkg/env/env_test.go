@@ -18,10 +18,18 @@ package env
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
 	"sigs.k8s.io/e2e-framework/pkg/types"
 
 	"sigs.k8s.io/e2e-framework/pkg/envconf"
@@ -746,6 +754,357 @@ func TestTestEnv_TestInParallel(t *testing.T) {
 	}
 }
 
+func TestTestEnv_FeatureConfig(t *testing.T) {
+	env := NewParallel()
+	var mu sync.Mutex
+	namespaces := make(map[string][]string)
+	record := func(feature, namespace string) {
+		mu.Lock()
+		defer mu.Unlock()
+		namespaces[feature] = append(namespaces[feature], namespace)
+	}
+
+	env.BeforeEachFeature(func(ctx context.Context, cfg *envconf.Config, _ *testing.T, feature types.Feature) (context.Context, error) {
+		return envconf.WithFeatureConfig(ctx, cfg.Clone().WithNamespace("ns-"+feature.Name())), nil
+	})
+	env.AfterEachFeature(func(ctx context.Context, cfg *envconf.Config, _ *testing.T, feature types.Feature) (context.Context, error) {
+		record(feature.Name(), cfg.Namespace())
+		return ctx, nil
+	})
+
+	assess := func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		record(t.Name()[strings.Index(t.Name(), "/")+1:strings.LastIndex(t.Name(), "/")], cfg.Namespace())
+		return ctx
+	}
+	f1 := features.New("f1").Assess("namespace", assess).Feature()
+	f2 := features.New("f2").Assess("namespace", assess).Feature()
+	_ = env.TestInParallel(t, f1, f2)
+
+	for _, feature := range []string{"f1", "f2"} {
+		if got := namespaces[feature]; len(got) != 2 || got[0] != "ns-"+feature || got[1] != "ns-"+feature {
+			t.Errorf("expected the steps and after actions of %s to use namespace ns-%s, got %v", feature, feature, got)
+		}
+	}
+	if ns := env.EnvConf().Namespace(); ns != "" {
+		t.Errorf("expected the config of the environment not to be modified, got namespace %q", ns)
+	}
+}
+
+func TestTestEnv_TestInParallelWithMaxParallel(t *testing.T) {
+	env := NewWithConfig(envconf.New().WithParallelTestEnabled().WithMaxParallelTests(2))
+	var running, maxRunning atomic.Int32
+
+	var testFeatures []types.Feature
+	for i := 0; i < 5; i++ {
+		f := features.New(fmt.Sprintf("test-max-parallel-feature%d", i)).
+			Assess("track concurrency", func(ctx context.Context, t *testing.T, config *envconf.Config) context.Context {
+				current := running.Add(1)
+				for {
+					observed := maxRunning.Load()
+					if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+				time.Sleep(100 * time.Millisecond)
+				running.Add(-1)
+				return ctx
+			})
+		testFeatures = append(testFeatures, f.Feature())
+	}
+
+	_ = env.TestInParallel(t, testFeatures...)
+	if maxRunning.Load() > 2 {
+		t.Fatalf("expected at most 2 features to run concurrently, got %d", maxRunning.Load())
+	}
+}
+
+func TestTestEnv_OrderFeatures(t *testing.T) {
+	newFeatures := func() []types.Feature {
+		return []types.Feature{
+			features.New("a").Feature(),
+			features.New("b").WithPriority(10).Feature(),
+			features.New("c").WithOrder(5).Feature(),
+			features.New("d").WithPriority(10).Feature(),
+			features.New("e").Feature(),
+		}
+	}
+	newEnv := func(cfg *envconf.Config) *testEnv {
+		return &testEnv{ctx: context.Background(), cfg: cfg}
+	}
+	names := func(fs []types.Feature) string {
+		var out []string
+		for _, f := range fs {
+			out = append(out, f.Name())
+		}
+		return strings.Join(out, ",")
+	}
+
+	unordered := newEnv(envconf.New())
+	if got := names(unordered.orderFeatures(newFeatures())); got != "a,b,c,d,e" {
+		t.Errorf("expected features to keep their order, got %s", got)
+	}
+
+	sorted := newEnv(envconf.New().WithSortedFeatures())
+	if got := names(sorted.orderFeatures(newFeatures())); got != "b,d,a,e,c" {
+		t.Errorf("unexpected sorted order %s", got)
+	}
+
+	first := names(newEnv(envconf.New().WithShuffledFeatures(42)).orderFeatures(newFeatures()))
+	second := names(newEnv(envconf.New().WithShuffledFeatures(42)).orderFeatures(newFeatures()))
+	if first != second {
+		t.Errorf("expected the same seed to produce the same order, got %s and %s", first, second)
+	}
+
+	shuffledAndSorted := names(newEnv(envconf.New().WithSortedFeatures().WithShuffledFeatures(42)).orderFeatures(newFeatures()))
+	if !strings.HasSuffix(shuffledAndSorted, ",c") || !(strings.HasPrefix(shuffledAndSorted, "b,d,") || strings.HasPrefix(shuffledAndSorted, "d,b,")) {
+		t.Errorf("expected shuffle to only apply within the same order, got %s", shuffledAndSorted)
+	}
+}
+
+type labelShardStrategy struct{}
+
+func (labelShardStrategy) Contains(name string, labels types.Labels, index, total int) bool {
+	return labels.Contains("shard", fmt.Sprintf("%d", index))
+}
+
+func TestTestEnv_ShardFeatures(t *testing.T) {
+	testFeatures := []types.Feature{
+		features.New("a").WithLabel("shard", "0").Feature(),
+		features.New("b").WithLabel("shard", "1").Feature(),
+		features.New("c").WithLabel("shard", "1").Feature(),
+	}
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New().WithShard(1, 2).WithShardStrategy(labelShardStrategy{})}
+	sharded := env.shardFeatures(testFeatures)
+	if len(sharded) != 2 || sharded[0].Name() != "b" || sharded[1].Name() != "c" {
+		t.Errorf("unexpected features for shard 1: %v", sharded)
+	}
+
+	unsharded := &testEnv{ctx: context.Background(), cfg: envconf.New()}
+	if len(unsharded.shardFeatures(testFeatures)) != len(testFeatures) {
+		t.Error("expected all features to run when sharding is disabled")
+	}
+}
+
+func TestTestEnv_FeatureTimeout(t *testing.T) {
+	var deadlineSeen bool
+	f := features.New("timeout").
+		Setup(func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+			return context.WithValue(ctx, &ctxTestKeyString{}, "setup")
+		}).
+		Assess("deadline", func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+			_, deadlineSeen = ctx.Deadline()
+			return ctx
+		}).
+		Teardown(func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+			if ctx.Value(&ctxTestKeyString{}) != "setup" {
+				t.Error("expected teardown to see the values stored by the setup")
+			}
+			return ctx
+		}).Feature()
+
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New().WithFeatureTimeout(time.Minute)}
+	ctx, passed, _ := env.execFeature(context.Background(), t, f.Name(), f)
+	if !passed {
+		t.Fatal("expected feature to pass")
+	}
+	if !deadlineSeen {
+		t.Error("expected assessment context to carry the feature deadline")
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected the feature timeout not to leak into the returned context")
+	}
+	if ctx.Value(&ctxTestKeyString{}) != "setup" {
+		t.Error("expected values stored by the feature to be kept")
+	}
+}
+
+func TestTestEnv_ExecuteStepsWithoutGracefulTeardown(t *testing.T) {
+	steps := features.New("panic").Assess("boom", func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+		panic("boom")
+	}).Feature().Steps()
+
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New().WithDisableGracefulTeardown()}
+	defer func() {
+		if rErr := recover(); rErr != "boom" {
+			t.Errorf("expected step panic to be propagated, got %v", rErr)
+		}
+	}()
+	env.executeSteps(context.Background(), t, "panic", steps)
+	t.Error("expected executeSteps to panic")
+}
+
+func TestTestEnv_StepLogger(t *testing.T) {
+	var loggers []*features.Logger
+	record := func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+		loggers = append(loggers, features.LoggerFrom(ctx))
+		features.LoggerFrom(ctx).Info("running step")
+		return ctx
+	}
+	f := features.New("logged").WithSetup("prepare", record).Assess("check", record).Feature()
+
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New().WithStepLogFormat(features.LogFormatJSON), results: &resultCollector{}, plan: &planCollector{}}
+	ctx := env.Test(t, f)
+	if len(loggers) != 2 || loggers[0] == loggers[1] {
+		t.Fatalf("expected a logger per step, got %v", loggers)
+	}
+	if features.LoggerFrom(ctx) == loggers[1] {
+		t.Error("expected the step logger not to outlive the step")
+	}
+}
+
+func TestTestEnv_CleanupRegistry(t *testing.T) {
+	var registry *resources.CleanupRegistry
+	f := features.New("tracked").Assess("check", func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+		registry = resources.CleanupRegistryFromContext(ctx)
+		return ctx
+	}).Feature()
+
+	var createErr error
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New(), results: &resultCollector{}, plan: &planCollector{}}
+	env.AfterEachFeature(func(ctx context.Context, _ *envconf.Config, _ *testing.T, _ types.Feature) (context.Context, error) {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "leaked", Namespace: "default"}}
+		createErr = new(resources.Resources).Tracker().Create(ctx, cm)
+		return ctx, nil
+	})
+	ctx := env.Test(t, f)
+	if registry == nil {
+		t.Fatal("expected the steps of the feature to have a cleanup registry")
+	}
+	if createErr == nil {
+		t.Error("expected the tracker to fail in AfterEachFeature as the registry of the feature was already cleaned up")
+	}
+	if resources.CleanupRegistryFromContext(ctx) != nil {
+		t.Error("expected the cleanup registry not to outlive the feature")
+	}
+}
+
+func TestTestEnv_FeatureMetadataInHooks(t *testing.T) {
+	f := features.New("traced").
+		WithDescription("checks the traced workload").
+		WithID("TC-42").
+		WithOwner("sig-testing", "team-a").
+		WithLink("https://example.com/spec").
+		Assess("run", func(ctx context.Context, _ *testing.T, _ *envconf.Config) context.Context { return ctx }).
+		Feature()
+
+	var description string
+	var metadata types.FeatureMetadata
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New(), results: &resultCollector{}, plan: &planCollector{}}
+	env.BeforeEachFeature(func(ctx context.Context, _ *envconf.Config, _ *testing.T, feature types.Feature) (context.Context, error) {
+		if df, ok := feature.(types.DescribableFeature); ok {
+			description = df.Description()
+		}
+		if mf, ok := feature.(types.MetadataFeature); ok {
+			metadata = mf.Metadata()
+		}
+		return ctx, nil
+	})
+	_ = env.Test(t, f)
+
+	if description != "checks the traced workload" {
+		t.Errorf("expected the hook to see the description of the feature, got %q", description)
+	}
+	want := types.FeatureMetadata{ID: "TC-42", Owners: []string{"sig-testing", "team-a"}, Links: []string{"https://example.com/spec"}}
+	if metadata.ID != want.ID || strings.Join(metadata.Owners, ",") != strings.Join(want.Owners, ",") || strings.Join(metadata.Links, ",") != strings.Join(want.Links, ",") {
+		t.Errorf("expected the hook to see the metadata %+v, got %+v", want, metadata)
+	}
+}
+
+func TestTestEnv_AssessmentActions(t *testing.T) {
+	var calls []string
+	record := func(call string) AssessmentFunc {
+		return func(ctx context.Context, _ *envconf.Config, _ *testing.T, feature, assessment string) (context.Context, error) {
+			calls = append(calls, fmt.Sprintf("%s %s/%s", call, feature, assessment))
+			return ctx, nil
+		}
+	}
+	step := func(name string) types.StepFunc {
+		return func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+			calls = append(calls, name)
+			return ctx
+		}
+	}
+	f := features.New("hooked").
+		Setup(step("setup")).
+		Assess("first", step("first")).
+		Assess("second", step("second")).
+		Teardown(step("teardown")).
+		Feature()
+
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New(), results: &resultCollector{}, plan: &planCollector{}}
+	env.BeforeEachAssessment(record("before"))
+	env.AfterEachAssessment(record("after"))
+	_ = env.Test(t, f)
+
+	expected := []string{
+		"setup",
+		"before hooked/first", "first", "after hooked/first",
+		"before hooked/second", "second", "after hooked/second",
+		"teardown",
+	}
+	if strings.Join(calls, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+}
+
+func TestTestEnv_HookErrorPolicy(t *testing.T) {
+	failing := func(ctx context.Context, _ *envconf.Config, _ *testing.T, _ types.Feature) (context.Context, error) {
+		return ctx, fmt.Errorf("prerequisite missing")
+	}
+	var ran bool
+	f := features.New("guarded").Assess("run", func(ctx context.Context, _ *testing.T, _ *envconf.Config) context.Context {
+		ran = true
+		return ctx
+	}).Feature()
+
+	t.Run("fail-feature", func(t *testing.T) {
+		env := &testEnv{ctx: context.Background(), cfg: envconf.New().WithHookErrorPolicy(envconf.HookErrorFailFeature)}
+		env.BeforeEachFeature(failing, failing)
+		_, err := env.processFeatureActions(context.Background(), t, f, env.getBeforeFeatureActions())
+		var actionErr *ActionError
+		if !errors.As(err, &actionErr) || actionErr.Role != "BeforeEachFeature" || actionErr.Err.Error() != "prerequisite missing" {
+			t.Errorf("expected the failure of the feature to be returned, got %v", err)
+		}
+	})
+
+	t.Run("continue", func(t *testing.T) {
+		env := &testEnv{ctx: context.Background(), results: &resultCollector{}, plan: &planCollector{}}
+		env.cfg = envconf.New().WithHookErrorPolicy(envconf.HookErrorContinue)
+		env.BeforeEachTest(func(ctx context.Context, _ *envconf.Config, _ *testing.T) (context.Context, error) {
+			return ctx, fmt.Errorf("flaky setup")
+		})
+		env.BeforeEachFeature(failing)
+		_ = env.Test(t, f)
+		if !ran {
+			t.Error("expected the feature to run when hook errors are ignored")
+		}
+	})
+}
+
+func TestTestEnv_SkippedAssessments(t *testing.T) {
+	var executed []string
+	record := func(name string) features.Func {
+		return func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+			executed = append(executed, name)
+			return ctx
+		}
+	}
+	f := features.New("marked").
+		Assess("skipped", record("skipped")).Skip("broken").
+		Assess("xfail", record("xfail")).ExpectFail("known bug").
+		Assess("run", record("run")).
+		Teardown(record("teardown")).
+		Feature()
+
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New()}
+	if _, passed, _ := env.execFeature(context.Background(), t, f.Name(), f); !passed {
+		t.Fatal("expected feature to pass")
+	}
+	if strings.Join(executed, ",") != "run,teardown" {
+		t.Errorf("unexpected executed steps: %v", executed)
+	}
+}
+
 // Create a dedicated env that can be used to test the parallel execution of tests and features to make sure
 // they don't share the same config object but they inherit the one from the parent env.
 // Meaning that each test inherit the global testEnv and each feature inherit the testEnv of the test.
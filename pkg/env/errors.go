@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"path"
+)
+
+// ActionError is the error of an environment function registered with Setup, Finish or one of the
+// hooks, such as BeforeEachFeature. It wraps the error returned by the function, so errors.Is and
+// errors.As match the original error.
+type ActionError struct {
+	// Role is the kind of environment function that failed, such as Setup or BeforeEachFeature
+	Role string
+	// Func is the name of the function that failed
+	Func string
+	// Err is the error returned by the function
+	Err error
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("%s failure in %s: %v", e.Role, e.Func, e.Err)
+}
+
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
+
+// IsSetupError checks if err comes from a function registered with Setup
+func IsSetupError(err error) bool {
+	var actionErr *ActionError
+	return errors.As(err, &actionErr) && actionErr.Role == roleSetup.String()
+}
+
+// newActionError wraps the error of the function f of an action of the given role
+func newActionError(role actionRole, f any, err error) error {
+	return &ActionError{Role: role.String(), Func: path.Base(funcName(f)), Err: err}
+}
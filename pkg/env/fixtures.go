@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	klog "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+// fixture is an expensive dependency of the features, such as an operator, registered with Fixture
+type fixture struct {
+	name     string
+	setup    types.EnvFunc
+	teardown types.EnvFunc
+
+	once sync.Once
+	// base is the context the setup function ran with
+	base context.Context
+	// ctx is the context returned by the setup function, passed to the teardown function
+	ctx context.Context
+	err error
+	// refs is the number of features running with the fixture
+	refs int
+}
+
+// fixtureRegistry holds the fixtures of an environment, shared with its child environments
+type fixtureRegistry struct {
+	mu       sync.Mutex
+	fixtures map[string]*fixture
+	// ready lists the fixtures set up successfully, in the order they were set up
+	ready []*fixture
+}
+
+func newFixtureRegistry() *fixtureRegistry {
+	return &fixtureRegistry{fixtures: map[string]*fixture{}}
+}
+
+// Fixture registers a fixture named name, such as an operator or a dataset shared by several features.
+// The setup function runs the first time a feature declaring the fixture with RequiresFixture runs,
+// before its BeforeEachFeature functions, and the features running at the same time wait for it. A
+// failing setup fails the features requiring the fixture without being retried. The fixtures that
+// were set up are torn down in reverse order at the end of the test suite, before the Finish
+// functions, and the teardown function gets the context returned by the setup function. The values
+// stored in that context by the setup function are visible to the features requiring the fixture.
+func (e *testEnv) Fixture(name string, setup, teardown Func) types.Environment {
+	e.fixtures.mu.Lock()
+	defer e.fixtures.mu.Unlock()
+	if _, ok := e.fixtures.fixtures[name]; ok {
+		panic(fmt.Sprintf("fixture %q is already registered", name))
+	}
+	e.fixtures.fixtures[name] = &fixture{name: name, setup: setup, teardown: teardown}
+	return e
+}
+
+// featureFixtures returns the names of the fixtures required by the feature, if any
+func featureFixtures(f types.Feature) []string {
+	if ff, ok := f.(types.FixtureFeature); ok {
+		return ff.Fixtures()
+	}
+	return nil
+}
+
+// acquire sets up the fixtures identified by names unless they already are, and counts a reference to
+// each of them until release is called with the returned fixtures. The fixtures acquired before a
+// failure are released.
+func (r *fixtureRegistry) acquire(ctx context.Context, cfg *envconf.Config, results *resultCollector, names []string) ([]*fixture, error) {
+	var acquired []*fixture
+	for _, name := range names {
+		f, err := r.setUp(ctx, cfg, results, name)
+		if err != nil {
+			r.release(acquired)
+			return nil, err
+		}
+		acquired = append(acquired, f)
+	}
+	return acquired, nil
+}
+
+// setUp runs the setup function of the fixture the first time it is required and references it
+func (r *fixtureRegistry) setUp(ctx context.Context, cfg *envconf.Config, results *resultCollector, name string) (*fixture, error) {
+	var f *fixture
+	if r != nil {
+		r.mu.Lock()
+		f = r.fixtures[name]
+		r.mu.Unlock()
+	}
+	if f == nil {
+		return nil, fmt.Errorf("unknown fixture %q", name)
+	}
+	f.once.Do(func() {
+		klog.V(2).InfoS("Setting up fixture", "fixture", name)
+		setup := action{role: roleFixtureSetup, funcs: []types.EnvFunc{f.setup}}
+		f.base = ctx
+		f.ctx, f.err = setup.run(ctx, cfg, results)
+		if f.err != nil {
+			return
+		}
+		r.mu.Lock()
+		r.ready = append(r.ready, f)
+		r.mu.Unlock()
+	})
+	if f.err != nil {
+		return nil, fmt.Errorf("fixture %s: %w", name, f.err)
+	}
+	r.mu.Lock()
+	f.refs++
+	r.mu.Unlock()
+	return f, nil
+}
+
+// value returns the value stored for key by the setup function of the fixture, if any
+func (f *fixture) value(key any) (value any, ok bool) {
+	value = f.ctx.Value(key)
+	// values of uncomparable types are taken from the fixture
+	defer func() {
+		if recover() != nil {
+			ok = true
+		}
+	}()
+	return value, value != f.base.Value(key)
+}
+
+// fixtureContext is a context.Context that resolves the values stored by the setup functions of the
+// fixtures required by a feature before the values of the feature context, until the feature is done.
+type fixtureContext struct {
+	context.Context
+	fixtures []*fixture
+	done     *atomic.Bool
+}
+
+func (c fixtureContext) Value(key any) any {
+	if !c.done.Load() {
+		for _, f := range c.fixtures {
+			if v, ok := f.value(key); ok {
+				return v
+			}
+		}
+	}
+	return c.Context.Value(key)
+}
+
+// withFixtureValues returns a context carrying the values stored by the setup functions of the
+// fixtures, and a function to call once the feature is done so that the context passed on to the
+// next features no longer carries them.
+func withFixtureValues(ctx context.Context, fixtures []*fixture) (context.Context, func()) {
+	if len(fixtures) == 0 {
+		return ctx, func() {}
+	}
+	done := &atomic.Bool{}
+	return fixtureContext{Context: ctx, fixtures: fixtures, done: done}, func() { done.Store(true) }
+}
+
+// release drops the references of a feature to the fixtures
+func (r *fixtureRegistry) release(fixtures []*fixture) {
+	if len(fixtures) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, f := range fixtures {
+		f.refs--
+	}
+}
+
+// tearDown runs the teardown functions of the fixtures that were set up, in reverse order. Errors are
+// logged, like the errors of the Finish functions.
+func (r *fixtureRegistry) tearDown(cfg *envconf.Config, results *resultCollector) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	ready := r.ready
+	r.ready = nil
+	r.mu.Unlock()
+	for i := len(ready) - 1; i >= 0; i-- {
+		f := ready[i]
+		r.mu.Lock()
+		refs := f.refs
+		r.mu.Unlock()
+		if refs > 0 {
+			klog.InfoS("Tearing down fixture still in use", "fixture", f.name, "features", refs)
+		}
+		klog.V(2).InfoS("Tearing down fixture", "fixture", f.name)
+		// the setup context may derive from the context of a test, which is done by now
+		teardown := action{role: roleFixtureTeardown, funcs: []types.EnvFunc{f.teardown}}
+		if _, err := teardown.run(context.WithoutCancel(f.ctx), cfg, results); err != nil {
+			klog.V(2).ErrorS(err, "Cleanup failed", "fixture", f.name)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+)
+
+type fixtureCtxKey struct{}
+
+func TestTestEnv_Fixtures(t *testing.T) {
+	tests := []struct {
+		name string
+		env  *testEnv
+		run  func(env *testEnv, t *testing.T, feats ...features.Feature)
+	}{
+		{
+			name: "sequential",
+			env:  newTestEnv(),
+			run:  func(env *testEnv, t *testing.T, feats ...features.Feature) { env.Test(t, feats...) },
+		},
+		{
+			name: "parallel",
+			env:  newTestEnvWithParallel(),
+			run:  func(env *testEnv, t *testing.T, feats ...features.Feature) { env.TestInParallel(t, feats...) },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var events []string
+			record := func(event string) {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, event)
+			}
+			env := test.env
+			env.Fixture("operator", func(ctx context.Context, _ *envconf.Config) (context.Context, error) {
+				record("setup operator")
+				return context.WithValue(ctx, fixtureCtxKey{}, "installed"), nil
+			}, func(ctx context.Context, _ *envconf.Config) (context.Context, error) {
+				record("teardown operator " + ctx.Value(fixtureCtxKey{}).(string))
+				return ctx, nil
+			})
+			env.Fixture("unused", func(ctx context.Context, _ *envconf.Config) (context.Context, error) {
+				record("setup unused")
+				return ctx, nil
+			}, nil)
+			env.BeforeEachFeature(func(ctx context.Context, _ *envconf.Config, _ *testing.T, f features.Feature) (context.Context, error) {
+				record("before " + f.Name())
+				return ctx, nil
+			})
+			assess := func(ctx context.Context, _ *testing.T, _ *envconf.Config) context.Context { return ctx }
+
+			test.run(env, t,
+				features.New("a").RequiresFixture("operator").Assess("assess", assess).Feature(),
+				features.New("b").RequiresFixture("operator").Assess("assess", assess).Feature(),
+				features.New("c").Assess("assess", assess).Feature(),
+			)
+			env.fixtures.tearDown(env.cfg, env.results)
+
+			if n := strings.Count(strings.Join(events, ","), "setup operator"); n != 1 {
+				t.Errorf("expected the fixture to be set up once, got %d times: %v", n, events)
+			}
+			setup := slices.Index(events, "setup operator")
+			if setup < 0 || setup > slices.Index(events, "before a") || setup > slices.Index(events, "before b") {
+				t.Errorf("expected the fixture to be set up before the features requiring it, got %v", events)
+			}
+			if last := events[len(events)-1]; last != "teardown operator installed" {
+				t.Errorf("expected the fixture to be torn down with its context, got %v", events)
+			}
+			if strings.Contains(strings.Join(events, ","), "unused") {
+				t.Errorf("expected fixtures no feature requires not to be set up, got %v", events)
+			}
+		})
+	}
+}
+
+func TestTestEnv_FixtureValues(t *testing.T) {
+	type featureCtxKey struct{}
+	env := newTestEnv()
+	env.ctx = context.WithValue(env.ctx, featureCtxKey{}, "env")
+	env.Fixture("operator", func(ctx context.Context, _ *envconf.Config) (context.Context, error) {
+		return context.WithValue(ctx, fixtureCtxKey{}, "installed"), nil
+	}, nil)
+
+	got := map[string][2]any{}
+	assess := func(name string) features.Func {
+		return func(ctx context.Context, _ *testing.T, _ *envconf.Config) context.Context {
+			got[name] = [2]any{ctx.Value(fixtureCtxKey{}), ctx.Value(featureCtxKey{})}
+			return ctx
+		}
+	}
+	env.Test(t,
+		features.New("a").RequiresFixture("operator").Assess("assess", assess("a")).Feature(),
+		features.New("b").Assess("assess", assess("b")).Feature(),
+	)
+	env.fixtures.tearDown(env.cfg, env.results)
+
+	want := map[string][2]any{
+		"a": {"installed", "env"},
+		"b": {nil, "env"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the fixture values only in the features requiring it, got %v, want %v", got, want)
+	}
+}
+
+func TestFixtureRegistry_Acquire(t *testing.T) {
+	setups := 0
+	env := newTestEnv()
+	env.Fixture("broken", func(ctx context.Context, _ *envconf.Config) (context.Context, error) {
+		setups++
+		return ctx, errors.New("install failed")
+	}, nil)
+	env.Fixture("ok", func(ctx context.Context, _ *envconf.Config) (context.Context, error) {
+		return ctx, nil
+	}, nil)
+
+	tests := []struct {
+		name  string
+		names []string
+		err   string
+	}{
+		{name: "failed setup", names: []string{"ok", "broken"}, err: "fixture broken: Fixture setup failure in"},
+		{name: "failed setup is not retried", names: []string{"broken"}, err: "install failed"},
+		{name: "unknown fixture", names: []string{"missing"}, err: `unknown fixture "missing"`},
+		{name: "fixtures set up", names: []string{"ok"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			acquired, err := env.fixtures.acquire(context.Background(), env.cfg, env.results, test.names)
+			if test.err == "" {
+				if err != nil || len(acquired) != len(test.names) {
+					t.Fatalf("expected the fixtures to be acquired, got %v", err)
+				}
+				env.fixtures.release(acquired)
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("expected error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+	if setups != 1 {
+		t.Errorf("expected the failing setup to run once, got %d", setups)
+	}
+	if refs := env.fixtures.fixtures["ok"].refs; refs != 0 {
+		t.Errorf("expected the references to be released, got %d", refs)
+	}
+}
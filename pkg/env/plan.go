@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sync"
+
+	klog "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/e2e-framework/pkg/features"
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+// testPlan is the machine readable plan of a dry-run
+type testPlan struct {
+	Setup  []string      `json:"setup,omitempty"`
+	Finish []string      `json:"finish,omitempty"`
+	Tests  []plannedTest `json:"tests"`
+}
+
+// plannedTest lists the features a Test or TestInParallel call would run
+type plannedTest struct {
+	Name     string           `json:"name"`
+	Features []plannedFeature `json:"features"`
+}
+
+// plannedFeature describes a feature that would run and its steps
+type plannedFeature struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Labels      map[string][]string `json:"labels,omitempty"`
+	Setups      []string            `json:"setups,omitempty"`
+	Assessments []plannedStep       `json:"assessments,omitempty"`
+	Teardowns   []string            `json:"teardowns,omitempty"`
+}
+
+// plannedStep describes an assessment that would run
+type plannedStep struct {
+	Name            string `json:"name"`
+	Description     string `json:"description,omitempty"`
+	ExpectedFailure string `json:"expectedFailure,omitempty"`
+}
+
+// planCollector records the features selected during a dry-run by an environment and
+// all the child environments created for each Test and TestInParallel call
+type planCollector struct {
+	mu      sync.Mutex
+	tests   []plannedTest
+	catalog []catalogFeature
+}
+
+func (p *planCollector) add(testName string, feature plannedFeature) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.tests {
+		if p.tests[i].Name == testName {
+			p.tests[i].Features = append(p.tests[i].Features, feature)
+			return
+		}
+	}
+	p.tests = append(p.tests, plannedTest{Name: testName, Features: []plannedFeature{feature}})
+}
+
+// planFeature describes the feature and the assessments selected by the assessment filters
+func (e *testEnv) planFeature(featureName string, f types.Feature) plannedFeature {
+	planned := plannedFeature{Name: featureName, Labels: f.Labels()}
+	if df, ok := f.(types.DescribableFeature); ok {
+		planned.Description = df.Description()
+	}
+	for _, step := range features.GetStepsByLevel(f.Steps(), types.LevelSetup) {
+		planned.Setups = append(planned.Setups, step.Name())
+	}
+	for i, assess := range features.GetStepsByLevel(f.Steps(), types.LevelAssess) {
+		if skipped, _ := e.requireAssessmentProcessing(assess, i+1); skipped {
+			continue
+		}
+		planned.Assessments = append(planned.Assessments, planStep(assess, i))
+	}
+	for _, step := range features.GetStepsByLevel(f.Steps(), types.LevelTeardown) {
+		planned.Teardowns = append(planned.Teardowns, step.Name())
+	}
+	return planned
+}
+
+// planStep describes the i-th assessment of a feature
+func planStep(assess types.Step, i int) plannedStep {
+	step := plannedStep{Name: assess.Name()}
+	if step.Name == "" {
+		step.Name = fmt.Sprintf("Assessment-%d", i+1)
+	}
+	if ds, ok := assess.(types.DescribableStep); ok {
+		step.Description = ds.Description()
+	}
+	if xs, ok := assess.(types.ExpectedFailureStep); ok {
+		step.ExpectedFailure = xs.ExpectedFailure()
+	}
+	return step
+}
+
+// funcName returns the name of the function f, as reported by the runtime
+func funcName(f any) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(f).Pointer())
+	if fn == nil {
+		return "unknown"
+	}
+	return fn.Name()
+}
+
+// plannedFuncs returns the names of the env functions registered for the role
+func (e *testEnv) plannedFuncs(role actionRole) []string {
+	var names []string
+	for _, a := range e.getActionsByRole(role) {
+		for _, f := range a.funcs {
+			if f != nil {
+				names = append(names, funcName(f))
+			}
+		}
+	}
+	return names
+}
+
+// writeDryRunPlan writes the plan of the dry-run to the configured destination
+func (e *testEnv) writeDryRunPlan() error {
+	path := e.cfg.DryRunPlan()
+	if !e.cfg.DryRunMode() || path == "" || e.plan == nil {
+		return nil
+	}
+	e.plan.mu.Lock()
+	plan := testPlan{
+		Setup:  e.plannedFuncs(roleSetup),
+		Finish: e.plannedFuncs(roleFinish),
+		Tests:  append([]plannedTest{}, e.plan.tests...),
+	}
+	e.plan.mu.Unlock()
+
+	var data []byte
+	var err error
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(plan)
+	default:
+		data, err = json.MarshalIndent(plan, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return fmt.Errorf("encoding dry-run plan: %w", err)
+	}
+
+	if path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	klog.V(2).InfoS("Writing dry-run plan", "path", path)
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+)
+
+func planSetupFunc(ctx context.Context, _ *envconf.Config) (context.Context, error) {
+	return ctx, nil
+}
+
+func TestTestEnv_WriteDryRunPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	env := &testEnv{
+		ctx:     context.Background(),
+		cfg:     envconf.New().WithDryRunMode().WithDryRunPlan(path),
+		results: &resultCollector{},
+		plan:    &planCollector{},
+	}
+	env.Setup(planSetupFunc)
+
+	var executed bool
+	f := features.New("planned").WithLabel("type", "plan").
+		Assess("check", func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+			executed = true
+			return ctx
+		}).
+		Assess("broken", func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+			return ctx
+		}).ExpectFail("known bug").
+		Feature()
+	env.Test(t, f)
+	if executed {
+		t.Error("expected assessment not to be executed in dry-run mode")
+	}
+
+	if err := env.writeDryRunPlan(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var plan testPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plan.Setup) != 1 || !strings.HasSuffix(plan.Setup[0], "planSetupFunc") {
+		t.Errorf("unexpected setup functions: %v", plan.Setup)
+	}
+	if len(plan.Tests) != 1 || plan.Tests[0].Name != t.Name() || len(plan.Tests[0].Features) != 1 {
+		t.Fatalf("unexpected tests: %+v", plan.Tests)
+	}
+	feature := plan.Tests[0].Features[0]
+	if feature.Name != "planned" || feature.Labels["type"][0] != "plan" {
+		t.Errorf("unexpected feature: %+v", feature)
+	}
+	if len(feature.Assessments) != 2 || feature.Assessments[1].ExpectedFailure != "known bug" {
+		t.Errorf("unexpected assessments: %+v", feature.Assessments)
+	}
+}
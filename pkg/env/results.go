@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	klog "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/apicalls"
+)
+
+// FailedFeaturesFile is the name of the file, stored in the artifacts directory, listing the
+// features that failed during the last run. It can be passed to the --rerun-failed flag.
+const FailedFeaturesFile = "failed-features.txt"
+
+// APICallsFile is the name of the file, stored in the artifacts directory, reporting the API calls
+// sent by each feature
+const APICallsFile = "api-calls.json"
+
+// featureResult is the outcome of a feature executed by the environment
+type featureResult struct {
+	name   string
+	passed bool
+}
+
+// resultCollector records the outcome of the features executed by an environment and
+// all the child environments created for each Test and TestInParallel call
+type resultCollector struct {
+	mu       sync.Mutex
+	results  []featureResult
+	apiCalls []featureAPICalls
+	timings  []timing
+}
+
+// featureAPICalls is the summary of the API calls sent by a run of a feature
+type featureAPICalls struct {
+	Feature string `json:"feature"`
+	apicalls.Summary
+}
+
+func (r *resultCollector) record(name string, passed bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, featureResult{name: name, passed: passed})
+}
+
+func (r *resultCollector) recordAPICalls(name string, summary apicalls.Summary) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.apiCalls = append(r.apiCalls, featureAPICalls{Feature: name, Summary: summary})
+}
+
+// failed returns the unique names of the features that failed, in execution order
+func (r *resultCollector) failed() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var names []string
+	for _, result := range r.results {
+		if !result.passed && result.name != "" && !slices.Contains(names, result.name) {
+			names = append(names, result.name)
+		}
+	}
+	return names
+}
+
+// passRate is the number of successful runs of a feature out of its total number of runs
+type passRate struct {
+	name   string
+	passed int
+	runs   int
+}
+
+// passRates returns the pass rate of each feature, in execution order
+func (r *resultCollector) passRates() []passRate {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var rates []passRate
+	index := make(map[string]int)
+	for _, result := range r.results {
+		i, ok := index[result.name]
+		if !ok {
+			i = len(rates)
+			index[result.name] = i
+			rates = append(rates, passRate{name: result.name})
+		}
+		rates[i].runs++
+		if result.passed {
+			rates[i].passed++
+		}
+	}
+	return rates
+}
+
+// logPassRates logs the pass rate of each feature, which is used to report flaky features
+func (r *resultCollector) logPassRates() {
+	for _, rate := range r.passRates() {
+		klog.InfoS("Feature pass rate", "feature", rate.name, "passed", rate.passed, "runs", rate.runs, "rate", fmt.Sprintf("%.1f%%", float64(rate.passed)*100/float64(rate.runs)))
+	}
+}
+
+// empty returns true if no feature outcome was recorded
+func (r *resultCollector) empty() bool {
+	if r == nil {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.results) == 0
+}
+
+// writeFailedFeatures persists the names of the failed features in the artifacts directory so that
+// they can be rerun using the --rerun-failed flag. Nothing is written if no artifacts directory is
+// configured or no feature was executed.
+func (e *testEnv) writeFailedFeatures() error {
+	if e.cfg.ArtifactsDir() == "" || e.results.empty() {
+		return nil
+	}
+	path, err := e.cfg.ArtifactPath("", FailedFeaturesFile)
+	if err != nil {
+		return err
+	}
+	failed := e.results.failed()
+	klog.V(2).InfoS("Persisting failed features", "count", len(failed), "path", path)
+	content := strings.Join(failed, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// checkAPICalls records the API calls sent by a feature and fails it if they exceed the API call budget
+func (e *testEnv) checkAPICalls(t *testing.T, name string, recorder *apicalls.Recorder) {
+	t.Helper()
+	summary := recorder.Summary()
+	e.results.recordAPICalls(name, summary)
+	klog.V(2).InfoS("Feature API calls", "feature", name, "calls", summary.Total, "throttled", summary.Throttled)
+	if budget := e.cfg.APICallBudget(); budget > 0 && summary.Total > budget {
+		t.Errorf("feature %s exceeded its API call budget of %d: %s", name, budget, summary)
+	}
+}
+
+// writeAPICalls writes the API calls sent by each feature in the artifacts directory. Nothing is
+// written if no artifacts directory is configured or no feature sent API calls.
+func (e *testEnv) writeAPICalls() error {
+	if e.cfg.ArtifactsDir() == "" || e.results == nil {
+		return nil
+	}
+	e.results.mu.Lock()
+	var reports []featureAPICalls
+	for _, calls := range e.results.apiCalls {
+		if calls.Total > 0 {
+			reports = append(reports, calls)
+		}
+	}
+	e.results.mu.Unlock()
+	if len(reports) == 0 {
+		return nil
+	}
+	path, err := e.cfg.ArtifactPath("", APICallsFile)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
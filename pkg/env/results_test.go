@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/klient/apicalls"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+func TestTestEnv_WriteFailedFeatures(t *testing.T) {
+	dir := t.TempDir()
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New().WithArtifactsDir(dir), results: &resultCollector{}}
+
+	if err := env.writeFailedFeatures(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, FailedFeaturesFile)); !os.IsNotExist(err) {
+		t.Fatal("expected no file to be written when no feature was executed")
+	}
+
+	env.results.record("a", true)
+	env.results.record("b", false)
+	env.results.record("c", false)
+	env.results.record("b", false)
+	if err := env.writeFailedFeatures(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, FailedFeaturesFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "b\nc\n" {
+		t.Errorf("unexpected failed features file content %q", string(data))
+	}
+}
+
+func TestTestEnv_RerunFeatures(t *testing.T) {
+	testFeatures := []types.Feature{features.New("a").Feature(), features.New("b").Feature()}
+
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New().WithRerunFeatures("b")}
+	rerun := env.rerunFeatures(testFeatures)
+	if len(rerun) != 1 || rerun[0].Name() != "b" {
+		t.Errorf("unexpected features to rerun: %v", rerun)
+	}
+
+	env = &testEnv{ctx: context.Background(), cfg: envconf.New()}
+	if len(env.rerunFeatures(testFeatures)) != 2 {
+		t.Error("expected all the features to run when rerun is not configured")
+	}
+}
+
+func TestResultCollector_PassRates(t *testing.T) {
+	r := &resultCollector{}
+	r.record("a", true)
+	r.record("b", false)
+	r.record("a", false)
+	r.record("a", true)
+
+	rates := r.passRates()
+	if len(rates) != 2 {
+		t.Fatalf("expected 2 pass rates, got %d", len(rates))
+	}
+	if rates[0] != (passRate{name: "a", passed: 2, runs: 3}) || rates[1] != (passRate{name: "b", passed: 0, runs: 1}) {
+		t.Errorf("unexpected pass rates %v", rates)
+	}
+}
+
+func TestTestEnv_TestWithRepeat(t *testing.T) {
+	env := NewWithConfig(envconf.New().WithRepeat(3))
+	var runs int
+	f := features.New("repeated").Assess("count", func(ctx context.Context, t *testing.T, config *envconf.Config) context.Context {
+		runs++
+		return ctx
+	})
+	_ = env.Test(t, f.Feature())
+	if runs != 3 {
+		t.Errorf("expected the feature to run 3 times, got %d", runs)
+	}
+}
+
+func TestTestEnv_APICalls(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	restConfig := &rest.Config{Host: srv.URL}
+	apicalls.Instrument(restConfig)
+	client, err := klient.New(restConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	env := NewWithConfig(envconf.New().WithClient(client).WithArtifactsDir(dir).WithAPICallBudget(5))
+	f := features.New("proxied").
+		Setup(func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+			if _, err := cfg.Client().Resources().ProxyGet(ctx, "http", "default", "web", "80", "/"); err != nil {
+				t.Fatal(err)
+			}
+			return ctx
+		}).
+		Assess("proxy twice", func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+			for i := 0; i < 2; i++ {
+				if _, err := cfg.Client().Resources().ProxyGet(ctx, "http", "default", "pod/web-0", "80", "/"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return ctx
+		})
+	_ = env.Test(t, f.Feature())
+
+	if err := env.(*testEnv).writeAPICalls(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, APICallsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reports []featureAPICalls
+	if err := json.Unmarshal(data, &reports); err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || reports[0].Feature != "proxied" || reports[0].Total != 3 {
+		t.Errorf("unexpected API calls report %s", string(data))
+	}
+}
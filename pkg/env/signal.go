@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// interruptSignals are the signals that abort a test run. They cancel the context of
+// the run and trigger the Finish actions so that clusters and other resources created
+// by the Setup actions are not leaked.
+var interruptSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// notifyInterrupt calls onSignal from a new goroutine when the process receives one
+// of the interruptSignals. The returned function stops listening for the signals.
+func notifyInterrupt(onSignal func(os.Signal)) (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, interruptSignals...)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-sigCh:
+			onSignal(sig)
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
+
+// signalExitCode returns the conventional exit code of a process terminated by sig.
+func signalExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
+
+// runContext holds the context of a test run, updated as the Setup actions complete,
+// so that it can be read from the goroutine handling interrupt signals.
+type runContext struct {
+	mu  sync.Mutex
+	ctx context.Context
+}
+
+func (r *runContext) get() context.Context {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ctx
+}
+
+func (r *runContext) set(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ctx = ctx
+}
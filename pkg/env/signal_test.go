@@ -0,0 +1,38 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestSignalExitCode(t *testing.T) {
+	tests := []struct {
+		sig  os.Signal
+		want int
+	}{
+		{sig: syscall.SIGINT, want: 130},
+		{sig: syscall.SIGTERM, want: 143},
+	}
+	for _, test := range tests {
+		if got := signalExitCode(test.sig); got != test.want {
+			t.Errorf("signalExitCode(%v) = %d, want %d", test.sig, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	klog "k8s.io/klog/v2"
+)
+
+// soakIteration is the outcome of an iteration of a soak run
+type soakIteration struct {
+	index    int
+	passed   bool
+	duration time.Duration
+}
+
+// soakFeatures runs the features with run in a loop, each iteration as a subtest of t, until the
+// duration elapses, the run is cancelled or more iterations failed than tolerated. The context
+// returned by an iteration is passed to the next one.
+func (e *testEnv) soakFeatures(ctx context.Context, t *testing.T, duration time.Duration, run func(context.Context, *testing.T) context.Context) context.Context {
+	t.Helper()
+	maxFailures := e.cfg.SoakMaxFailures()
+	deadline := time.Now().Add(duration)
+	klog.V(2).InfoS("Starting soak run", "test", t.Name(), "duration", duration, "maxFailures", maxFailures)
+
+	var iterations []soakIteration
+	failures := 0
+	for index := 1; time.Now().Before(deadline); index++ {
+		if ctx.Err() != nil {
+			t.Logf("Soak run stopped after %d iterations: %v", len(iterations), ctx.Err())
+			break
+		}
+		start := time.Now()
+		passed := t.Run(fmt.Sprintf("iteration-%d", index), func(iterT *testing.T) {
+			iterT.Helper()
+			ctx = run(ctx, iterT)
+		})
+		iteration := soakIteration{index: index, passed: passed, duration: time.Since(start)}
+		iterations = append(iterations, iteration)
+		klog.V(2).InfoS("Soak iteration done", "test", t.Name(), "iteration", index, "passed", passed, "duration", iteration.duration)
+		if passed {
+			continue
+		}
+		failures++
+		if failures > maxFailures || e.cfg.FailFast() {
+			t.Logf("Soak run stopped after %d failed iterations out of %d", failures, len(iterations))
+			break
+		}
+	}
+	t.Log(soakSummary(iterations, time.Until(deadline) <= 0))
+	return ctx
+}
+
+// soakSummary describes the iterations of a soak run
+func soakSummary(iterations []soakIteration, completed bool) string {
+	failed := 0
+	var longest time.Duration
+	for _, iteration := range iterations {
+		if !iteration.passed {
+			failed++
+		}
+		if iteration.duration > longest {
+			longest = iteration.duration
+		}
+	}
+	status := "completed"
+	if !completed {
+		status = "stopped"
+	}
+	return fmt.Sprintf("Soak run %s: %d iterations, %d passed, %d failed, longest iteration %s",
+		status, len(iterations), len(iterations)-failed, failed, longest.Round(time.Millisecond))
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+)
+
+func TestTestEnv_TestWithSoakDuration(t *testing.T) {
+	cfg := envconf.New()
+	if _, err := RunForDuration(50*time.Millisecond)(context.Background(), cfg); err != nil {
+		t.Fatal(err)
+	}
+	env := NewWithConfig(cfg)
+	type runsKey struct{}
+	var runs int
+	f := features.New("soaked").Assess("count", func(ctx context.Context, t *testing.T, config *envconf.Config) context.Context {
+		runs++
+		time.Sleep(10 * time.Millisecond)
+		return context.WithValue(ctx, runsKey{}, runs)
+	})
+	ctx := env.Test(t, f.Feature())
+	if runs < 2 {
+		t.Errorf("expected the feature to run several times, got %d", runs)
+	}
+	if got, _ := ctx.Value(runsKey{}).(int); got != runs {
+		t.Errorf("expected the context of the last iteration, got %d runs instead of %d", got, runs)
+	}
+}
+
+func TestSoakSummary(t *testing.T) {
+	tests := []struct {
+		name       string
+		iterations []soakIteration
+		completed  bool
+		want       string
+	}{
+		{
+			name:      "no iterations",
+			completed: true,
+			want:      "Soak run completed: 0 iterations, 0 passed, 0 failed, longest iteration 0s",
+		},
+		{
+			name: "stopped on failure",
+			iterations: []soakIteration{
+				{index: 1, passed: true, duration: time.Second},
+				{index: 2, passed: false, duration: 3 * time.Second},
+			},
+			want: "Soak run stopped: 2 iterations, 1 passed, 1 failed, longest iteration 3s",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := soakSummary(test.iterations, test.completed); got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
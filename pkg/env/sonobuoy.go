@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	klog "k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// SonobuoyResultsFile is the name of the file, in the results tarball, reporting the results of the
+	// features in the manual results format of Sonobuoy
+	SonobuoyResultsFile = "sonobuoy_results.yaml"
+	// SonobuoyResultsTarball is the name of the tarball written in the Sonobuoy results directory
+	SonobuoyResultsTarball = "results.tar.gz"
+	// SonobuoyDoneFile is the name of the file, written in the Sonobuoy results directory, that signals
+	// Sonobuoy that the plugin is done by holding the path of the results tarball
+	SonobuoyDoneFile = "done"
+
+	// sonobuoyArtifactsDir is the directory of the results tarball holding the artifacts of the suite
+	sonobuoyArtifactsDir = "artifacts"
+)
+
+// Statuses of the items of the Sonobuoy results
+const (
+	sonobuoyPassed = "passed"
+	sonobuoyFailed = "failed"
+)
+
+// sonobuoyItem is an item of the manual results format of Sonobuoy, which is a tree of items each
+// reporting a status
+type sonobuoyItem struct {
+	Name    string                 `json:"name"`
+	Status  string                 `json:"status"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	Items   []sonobuoyItem         `json:"items,omitempty"`
+}
+
+// sonobuoyResults returns the results of the suite in the manual results format of Sonobuoy, with
+// an item per feature. The suite fails when a feature failed or the exit code is not 0.
+func (e *testEnv) sonobuoyResults(exitCode int) sonobuoyItem {
+	results := sonobuoyItem{Name: "e2e-framework", Status: sonobuoyPassed}
+	for _, rate := range e.results.passRates() {
+		item := sonobuoyItem{Name: rate.name, Status: sonobuoyPassed}
+		if rate.passed < rate.runs {
+			item.Status = sonobuoyFailed
+			results.Status = sonobuoyFailed
+		}
+		if rate.runs > 1 {
+			item.Details = map[string]interface{}{"runs": rate.runs, "passed": rate.passed}
+		}
+		results.Items = append(results.Items, item)
+	}
+	if exitCode != 0 {
+		results.Status = sonobuoyFailed
+		results.Details = map[string]interface{}{"exitCode": exitCode}
+	}
+	return results
+}
+
+// writeSonobuoyResults writes the results of the suite as a Sonobuoy plugin: a tarball holding the
+// results in the manual format and the artifacts of the suite, followed by the done file pointing
+// Sonobuoy to the tarball. Nothing is written if no Sonobuoy results directory is configured.
+func (e *testEnv) writeSonobuoyResults(exitCode int) error {
+	dir := e.cfg.SonobuoyResultsDir()
+	if dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("sonobuoy results directory %s: %w", dir, err)
+	}
+	results, err := yaml.Marshal(e.sonobuoyResults(exitCode))
+	if err != nil {
+		return fmt.Errorf("encoding sonobuoy results: %w", err)
+	}
+
+	tarball, err := filepath.Abs(filepath.Join(dir, SonobuoyResultsTarball))
+	if err != nil {
+		return err
+	}
+	klog.V(2).InfoS("Writing Sonobuoy results", "path", tarball)
+	if err := writeResultsTarball(tarball, results, e.cfg.ArtifactsDir()); err != nil {
+		return fmt.Errorf("writing sonobuoy results tarball: %w", err)
+	}
+	// the done file is written last, Sonobuoy collects the results as soon as it exists
+	return os.WriteFile(filepath.Join(dir, SonobuoyDoneFile), []byte(tarball), 0o644)
+}
+
+// writeResultsTarball writes the gzipped tarball at path holding the results file and the content of
+// the artifacts directory, when it is set
+func writeResultsTarball(path string, results []byte, artifactsDir string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	if err := tw.WriteHeader(&tar.Header{Name: SonobuoyResultsFile, Mode: 0o644, Size: int64(len(results))}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(results); err != nil {
+		return err
+	}
+	if artifactsDir != "" {
+		if err := addArtifacts(tw, artifactsDir, path); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// addArtifacts adds the files of the artifacts directory to the tarball, except the tarball itself when
+// it is written in the artifacts directory. A missing artifacts directory is ignored.
+func addArtifacts(tw *tar.Writer, artifactsDir, tarball string) error {
+	if _, err := os.Stat(artifactsDir); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.WalkDir(artifactsDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if abs, err := filepath.Abs(p); err == nil && (abs == tarball || abs == filepath.Join(filepath.Dir(tarball), SonobuoyDoneFile)) {
+			return nil
+		}
+		rel, err := filepath.Rel(artifactsDir, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(sonobuoyArtifactsDir, rel))
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		in, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		_, err = io.Copy(tw, in)
+		return err
+	})
+}
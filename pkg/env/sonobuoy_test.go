@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+func TestTestEnv_SonobuoyResults(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   func(r *resultCollector)
+		exitCode int
+		want     sonobuoyItem
+	}{
+		{
+			name:   "passed",
+			record: func(r *resultCollector) { r.record("a", true) },
+			want:   sonobuoyItem{Name: "e2e-framework", Status: "passed", Items: []sonobuoyItem{{Name: "a", Status: "passed"}}},
+		},
+		{
+			name: "failed feature",
+			record: func(r *resultCollector) {
+				r.record("a", true)
+				r.record("b", false)
+				r.record("b", true)
+			},
+			exitCode: 1,
+			want: sonobuoyItem{
+				Name:    "e2e-framework",
+				Status:  "failed",
+				Details: map[string]interface{}{"exitCode": 1},
+				Items: []sonobuoyItem{
+					{Name: "a", Status: "passed"},
+					{Name: "b", Status: "failed", Details: map[string]interface{}{"runs": 2, "passed": 1}},
+				},
+			},
+		},
+		{
+			name:     "failed setup",
+			record:   func(*resultCollector) {},
+			exitCode: 1,
+			want:     sonobuoyItem{Name: "e2e-framework", Status: "failed", Details: map[string]interface{}{"exitCode": 1}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env := &testEnv{ctx: context.Background(), cfg: envconf.New(), results: &resultCollector{}}
+			test.record(env.results)
+			got, err := yaml.Marshal(env.sonobuoyResults(test.exitCode))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := yaml.Marshal(test.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("unexpected results:\n%s\nexpected:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestTestEnv_WriteSonobuoyResults(t *testing.T) {
+	env := &testEnv{ctx: context.Background(), cfg: envconf.New(), results: &resultCollector{}}
+	t.Setenv("SONOBUOY_RESULTS_DIR", "")
+	if err := env.writeSonobuoyResults(0); err != nil {
+		t.Fatal(err)
+	}
+
+	resultsDir := t.TempDir()
+	artifactsDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(artifactsDir, "feature"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(artifactsDir, "feature", "logs.txt"), []byte("logs"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SONOBUOY_RESULTS_DIR", resultsDir)
+	env.cfg.WithArtifactsDir(artifactsDir)
+	env.results.record("a", false)
+	if err := env.writeSonobuoyResults(1); err != nil {
+		t.Fatal(err)
+	}
+
+	done, err := os.ReadFile(filepath.Join(resultsDir, SonobuoyDoneFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(resultsDir, SonobuoyResultsTarball); string(done) != want {
+		t.Errorf("expected the done file to hold %s, got %s", want, done)
+	}
+
+	files := readTarball(t, string(done))
+	if files["artifacts/feature/logs.txt"] != "logs" {
+		t.Errorf("expected the artifacts in the tarball, got %v", files)
+	}
+	var results sonobuoyItem
+	if err := yaml.Unmarshal([]byte(files[SonobuoyResultsFile]), &results); err != nil {
+		t.Fatal(err)
+	}
+	if results.Status != "failed" || len(results.Items) != 1 || results.Items[0].Name != "a" {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+// readTarball returns the content of the files of the gzipped tarball at path
+func readTarball(t *testing.T, path string) map[string]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(data)
+	}
+}
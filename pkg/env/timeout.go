@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+// valuesContext is a context.Context that takes its deadline and cancellation from
+// the embedded context but resolves values from another one. It is used to carry the
+// values stored by a function that ran under a timeout without carrying its deadline.
+type valuesContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c valuesContext) Value(key any) any {
+	return c.values.Value(key)
+}
+
+// withValuesFrom returns a context with the deadline and cancellation of parent and
+// the values of values.
+func withValuesFrom(parent, values context.Context) context.Context {
+	if parent == values {
+		return parent
+	}
+	return valuesContext{Context: parent, values: values}
+}
+
+type funcResult struct {
+	ctx context.Context
+	err error
+}
+
+// runFuncWithTimeout runs the env function f and stops waiting for it once the timeout
+// expires. The context passed to f is cancelled at that point, so functions that honour
+// it return early; those that do not are abandoned and keep running in the background.
+func runFuncWithTimeout(ctx context.Context, cfg *envconf.Config, f types.EnvFunc, role actionRole, timeout time.Duration) (context.Context, error) {
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan funcResult, 1)
+	go func() {
+		newCtx, err := f(tctx, cfg)
+		done <- funcResult{ctx: newCtx, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.ctx == nil {
+			return ctx, res.err
+		}
+		return withValuesFrom(ctx, res.ctx), res.err
+	case <-tctx.Done():
+		if errors.Is(tctx.Err(), context.DeadlineExceeded) {
+			return ctx, fmt.Errorf("%s action timed out after %s: %w", role, timeout, context.DeadlineExceeded)
+		}
+		return ctx, tctx.Err()
+	}
+}
+
+// timeout returns the configured maximum duration of each function of the action,
+// or 0 when the action runs without a timeout.
+func (a *action) timeout(cfg *envconf.Config) time.Duration {
+	switch a.role {
+	case roleSetup, roleFixtureSetup:
+		return cfg.SetupTimeout()
+	case roleFinish, roleFixtureTeardown:
+		return cfg.TeardownTimeout()
+	default:
+		return 0
+	}
+}
+
+// withStepTimeout returns a context for feature steps that is cancelled once the
+// timeout expires. A timeout of 0 returns ctx as is.
+func withStepTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// deadlineExceeded reports whether ctx was cancelled because its deadline passed.
+func deadlineExceeded(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// timeoutExpired reports whether ctx, created by withStepTimeout from parent, was cancelled because
+// its own timeout expired, rather than the deadline it inherits from parent, such as the one of the
+// test suite.
+func timeoutExpired(ctx, parent context.Context, timeout time.Duration) bool {
+	if timeout <= 0 || !deadlineExceeded(ctx) {
+		return false
+	}
+	parentDeadline, ok := parent.Deadline()
+	if !ok {
+		return true
+	}
+	deadline, _ := ctx.Deadline()
+	return deadline.Before(parentDeadline)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	klog "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+// TimingsFile is the name of the file, stored in the artifacts directory, reporting the duration of
+// the features, steps and environment functions of the run
+const TimingsFile = "timings.json"
+
+// slowestCount is the number of entries of each section of the summary of the slowest features,
+// steps and environment functions
+const slowestCount = 10
+
+// Kinds of the timings recorded during a run
+const (
+	timingFeature    = "feature"
+	timingSetup      = "setup"
+	timingAssessment = "assessment"
+	timingTeardown   = "teardown"
+	timingEnvFunc    = "env"
+)
+
+// timing is the duration of a feature, a step or an environment function
+type timing struct {
+	Kind    string  `json:"kind"`
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+	Slow    bool    `json:"slow,omitempty"`
+
+	duration time.Duration
+}
+
+func (r *resultCollector) recordTiming(kind, name string, duration time.Duration, slow bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timings = append(r.timings, timing{Kind: kind, Name: name, Seconds: duration.Seconds(), Slow: slow, duration: duration})
+}
+
+// slowest returns the slowest timings of the given kinds, slowest first
+func (r *resultCollector) slowest(n int, kinds ...string) []timing {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	var result []timing
+	for _, t := range r.timings {
+		for _, kind := range kinds {
+			if t.Kind == kind {
+				result = append(result, t)
+				break
+			}
+		}
+	}
+	r.mu.Unlock()
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].duration > result[j].duration
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// printTimings prints the summary of the slowest features, steps and environment functions.
+// Nothing is printed if no timing was recorded.
+func (r *resultCollector) printTimings(w io.Writer) error {
+	sections := []struct {
+		title   string
+		timings []timing
+	}{
+		{title: "Slowest features", timings: r.slowest(slowestCount, timingFeature)},
+		{title: "Slowest steps", timings: r.slowest(slowestCount, timingSetup, timingAssessment, timingTeardown)},
+		{title: "Slowest environment functions", timings: r.slowest(slowestCount, timingEnvFunc)},
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	printed := false
+	for _, section := range sections {
+		if len(section.timings) == 0 {
+			continue
+		}
+		if printed {
+			fmt.Fprintln(tw)
+		}
+		printed = true
+		fmt.Fprintf(tw, "%s:\n", section.title)
+		for _, t := range section.timings {
+			fmt.Fprintf(tw, "  %s\t%s\t%s", t.duration.Round(time.Millisecond), t.Kind, t.Name)
+			if t.Slow {
+				fmt.Fprint(tw, "\tSLOW")
+			}
+			fmt.Fprintln(tw)
+		}
+	}
+	return tw.Flush()
+}
+
+// writeTimings writes the duration of the features, steps and environment functions in the artifacts
+// directory. Nothing is written if no artifacts directory is configured or no timing was recorded.
+func (e *testEnv) writeTimings() error {
+	if e.cfg.ArtifactsDir() == "" || e.results == nil {
+		return nil
+	}
+	e.results.mu.Lock()
+	timings := append([]timing{}, e.results.timings...)
+	e.results.mu.Unlock()
+	if len(timings) == 0 {
+		return nil
+	}
+	path, err := e.cfg.ArtifactPath("", TimingsFile)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(timings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// recordFeatureTiming records the duration of the feature started at start
+func (e *testEnv) recordFeatureTiming(featName string, start time.Time) {
+	e.results.recordTiming(timingFeature, featName, time.Since(start), false)
+}
+
+// recordStepTiming records the duration of the step of a feature started at start and reports it in
+// the test output when it exceeds the slow threshold
+func (e *testEnv) recordStepTiming(t *testing.T, featName, stepName string, level types.Level, start time.Time) {
+	t.Helper()
+	duration := time.Since(start)
+	slow := isSlow(e.cfg.SlowThreshold(), duration)
+	if slow {
+		t.Logf("Step %q of feature %q took %s, exceeding the slow threshold of %s", stepName, featName, duration.Round(time.Millisecond), e.cfg.SlowThreshold())
+	}
+	e.results.recordTiming(stepTimingKind(level), featName+"/"+stepName, duration, slow)
+}
+
+// recordEnvFuncTiming records the duration of an environment function run for the role and logs it
+// when it exceeds the slow threshold
+func recordEnvFuncTiming(results *resultCollector, threshold time.Duration, role actionRole, f types.EnvFunc, start time.Time) {
+	duration := time.Since(start)
+	name := fmt.Sprintf("%s %s", role, path.Base(funcName(f)))
+	slow := isSlow(threshold, duration)
+	if slow {
+		klog.InfoS("Slow environment function", "function", name, "duration", duration.Round(time.Millisecond), "threshold", threshold)
+	}
+	results.recordTiming(timingEnvFunc, name, duration, slow)
+}
+
+func isSlow(threshold, duration time.Duration) bool {
+	return threshold > 0 && duration > threshold
+}
+
+func stepTimingKind(level types.Level) string {
+	switch level {
+	case types.LevelSetup:
+		return timingSetup
+	case types.LevelTeardown:
+		return timingTeardown
+	default:
+		return timingAssessment
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/features"
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+func TestResultCollector_Slowest(t *testing.T) {
+	r := &resultCollector{}
+	r.recordTiming(timingFeature, "fast", time.Second, false)
+	r.recordTiming(timingAssessment, "feature/check", 3*time.Second, true)
+	r.recordTiming(timingFeature, "slow", 5*time.Second, false)
+	r.recordTiming(timingFeature, "medium", 2*time.Second, false)
+
+	slowest := r.slowest(2, timingFeature)
+	if len(slowest) != 2 || slowest[0].Name != "slow" || slowest[1].Name != "medium" {
+		t.Errorf("unexpected slowest features: %+v", slowest)
+	}
+
+	var out bytes.Buffer
+	if err := r.printTimings(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Slowest features:", "Slowest steps:", "5s", "feature/check", "SLOW"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in the summary:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Slowest environment functions") {
+		t.Errorf("expected no environment functions section without timings:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := (&resultCollector{}).printTimings(&out); err != nil || out.Len() != 0 {
+		t.Errorf("expected nothing to be printed without timings, got %q, %v", out.String(), err)
+	}
+}
+
+func TestTestEnv_Timings(t *testing.T) {
+	dir := t.TempDir()
+	env := &testEnv{
+		ctx:     context.Background(),
+		cfg:     envconf.New().WithArtifactsDir(dir).WithSlowThreshold(10 * time.Millisecond),
+		results: &resultCollector{},
+		plan:    &planCollector{},
+	}
+	sleep := func(d time.Duration) features.Func {
+		return func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context {
+			time.Sleep(d)
+			return ctx
+		}
+	}
+	f := features.New("timed").
+		WithSetup("prepare", sleep(0)).
+		Assess("slow check", sleep(20*time.Millisecond)).
+		WithTeardown("cleanup", sleep(0)).
+		Feature()
+	env.Test(t, f)
+
+	if _, err := (&action{role: roleSetup, funcs: []types.EnvFunc{planSetupFunc}}).run(context.Background(), env.cfg, env.results); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := env.writeTimings(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, TimingsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var timings []timing
+	if err := json.Unmarshal(data, &timings); err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]timing{}
+	for _, timing := range timings {
+		byName[timing.Name] = timing
+	}
+	if len(timings) != 5 {
+		t.Errorf("unexpected timings: %+v", timings)
+	}
+	if timing := byName["timed/slow check"]; timing.Kind != timingAssessment || !timing.Slow || timing.Seconds < 0.02 {
+		t.Errorf("expected the assessment to be reported as slow: %+v", timing)
+	}
+	if timing := byName["timed/prepare"]; timing.Kind != timingSetup || timing.Slow {
+		t.Errorf("unexpected setup timing: %+v", timing)
+	}
+	if timing := byName["timed"]; timing.Kind != timingFeature || timing.Seconds < 0.02 {
+		t.Errorf("unexpected feature timing: %+v", timing)
+	}
+	if timing := byName["Setup env.planSetupFunc"]; timing.Kind != timingEnvFunc {
+		t.Errorf("unexpected environment function timing: %+v", timings)
+	}
+}
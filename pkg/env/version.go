@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+// featureKubeVersionRange returns the Kubernetes versions supported by the feature, if any
+func featureKubeVersionRange(f types.Feature) (minVersion, maxVersion string) {
+	if vf, ok := f.(types.VersionedFeature); ok {
+		return vf.KubeVersionRange()
+	}
+	return "", ""
+}
+
+// unsupportedKubeVersion returns a message explaining why serverVersion is outside of the range
+// of supported versions, or an empty string when it is supported. A maximum version with only
+// major and minor components includes all the patch releases of that minor version.
+func unsupportedKubeVersion(serverVersion *version.Version, minVersion, maxVersion string) (string, error) {
+	if minVersion != "" {
+		minV, err := version.ParseGeneric(minVersion)
+		if err != nil {
+			return "", fmt.Errorf("invalid minimum Kubernetes version: %w", err)
+		}
+		if serverVersion.LessThan(minV) {
+			return fmt.Sprintf("server version %s is older than the minimum supported version %s", serverVersion, minVersion), nil
+		}
+	}
+	if maxVersion != "" {
+		maxV, err := version.ParseGeneric(maxVersion)
+		if err != nil {
+			return "", fmt.Errorf("invalid maximum Kubernetes version: %w", err)
+		}
+		newer := serverVersion.GreaterThan(maxV)
+		if len(maxV.Components()) == 2 {
+			newer = serverVersion.Major() > maxV.Major() ||
+				(serverVersion.Major() == maxV.Major() && serverVersion.Minor() > maxV.Minor())
+		}
+		if newer {
+			return fmt.Sprintf("server version %s is newer than the maximum supported version %s", serverVersion, maxVersion), nil
+		}
+	}
+	return "", nil
+}
+
+// requireKubeVersion checks the Kubernetes versions supported by the feature against the version
+// of the API server of the cluster. It returns a skip message when the version is not supported.
+func (e *testEnv) requireKubeVersion(f types.Feature) (skip bool, message string, err error) {
+	minVersion, maxVersion := featureKubeVersionRange(f)
+	if (minVersion == "" && maxVersion == "") || e.cfg.DryRunMode() {
+		return false, "", nil
+	}
+	serverVersion, err := e.cfg.ServerVersion()
+	if err != nil {
+		return false, "", fmt.Errorf("checking the Kubernetes version of feature %s: %w", f.Name(), err)
+	}
+	reason, err := unsupportedKubeVersion(serverVersion, minVersion, maxVersion)
+	if err != nil {
+		return false, "", fmt.Errorf("feature %s: %w", f.Name(), err)
+	}
+	if reason != "" {
+		return true, fmt.Sprintf("Skipping feature %q: %s", f.Name(), reason), nil
+	}
+	return false, "", nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+func TestUnsupportedKubeVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		server      string
+		min         string
+		max         string
+		unsupported bool
+		wantErr     bool
+	}{
+		{name: "no range", server: "v1.28.3"},
+		{name: "at minimum", server: "v1.29.0", min: "1.29"},
+		{name: "below minimum", server: "v1.28.9", min: "1.29", unsupported: true},
+		{name: "provider suffix", server: "v1.30.2-gke.1000", min: "1.29", max: "1.30"},
+		{name: "patch of maximum minor", server: "v1.31.4", max: "1.31"},
+		{name: "above maximum minor", server: "v1.32.0", max: "1.31", unsupported: true},
+		{name: "above maximum patch", server: "v1.31.4", max: "1.31.2", unsupported: true},
+		{name: "invalid minimum", server: "v1.31.4", min: "latest", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reason, err := unsupportedKubeVersion(version.MustParseGeneric(test.server), test.min, test.max)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (reason != "") != test.unsupported {
+				t.Errorf("expected unsupported %v, got reason %q", test.unsupported, reason)
+			}
+		})
+	}
+}
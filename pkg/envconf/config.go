@@ -17,44 +17,99 @@ limitations under the License.
 package envconf
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
+	"sync"
+	"time"
 
+	"k8s.io/client-go/rest"
 	log "k8s.io/klog/v2"
 
 	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/klient/apicalls"
+	"sigs.k8s.io/e2e-framework/klient/conf"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
 	"sigs.k8s.io/e2e-framework/pkg/flags"
 )
 
+// artifactNameRegex matches the characters that are replaced when a feature name is used as a directory name
+var artifactNameRegex = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// Default rate limits of the clients created by Config, higher than the client-go defaults to
+// avoid throttling suites running features in parallel
+const (
+	DefaultClientQPS   float32 = 50
+	DefaultClientBurst         = 100
+)
+
 // Config represents and environment configuration
+//
+// The client, namespace and kubeconfig of a Config may be read and replaced concurrently, for instance
+// by features running in parallel. The other settings are expected to be set before the tests run and
+// to be only read afterwards. Rather than modifying the Config of the environment, a feature needing a
+// different namespace or client works on a Clone, see WithFeatureConfig.
 type Config struct {
+	// mu guards client, namespace and kubeconfig
+	mu                      *sync.RWMutex
 	client                  klient.Client
 	kubeconfig              string
 	namespace               string
 	assessmentRegex         *regexp.Regexp
 	featureRegex            *regexp.Regexp
 	labels                  flags.LabelsMap
+	labelSelector           string
 	skipFeatureRegex        *regexp.Regexp
 	skipLabels              flags.LabelsMap
 	skipAssessmentRegex     *regexp.Regexp
 	parallelTests           bool
+	maxParallelTests        int
+	sortFeatures            bool
+	shuffleFeatures         bool
+	shuffleSeed             int64
 	dryRun                  bool
+	dryRunPlan              string
+	listFeatures            string
 	failFast                bool
 	disableGracefulTeardown bool
 	kubeContext             string
+	inCluster               bool
+	clientQPS               float32
+	clientBurst             int
+	apiCallBudget           int
+	artifactsDir            string
+	sonobuoyResultsDir      string
+	redactor                *k8s.Redactor
+	shards                  int
+	shardIndex              int
+	shardStrategy           ShardStrategy
+	rerunFeatures           []string
+	rerun                   bool
+	repeat                  int
+	soakDuration            time.Duration
+	soakMaxFailures         int
+	setupTimeout            time.Duration
+	featureTimeout          time.Duration
+	teardownTimeout         time.Duration
+	slowThreshold           time.Duration
+	containerEngine         string
+	stepLogFormat           string
+	hookErrorPolicy         HookErrorPolicy
+	informers               *sharedInformers
 }
 
 // New creates and initializes an empty environment configuration
 func New() *Config {
-	return &Config{}
+	return &Config{mu: &sync.RWMutex{}, informers: &sharedInformers{}}
 }
 
 // NewWithKubeConfig creates and initializes an empty environment configuration
 func NewWithKubeConfig(kubeconfig string) *Config {
-	c := &Config{}
-	return c.WithKubeconfigFile(kubeconfig)
+	return New().WithKubeconfigFile(kubeconfig)
 }
 
 // NewFromFlags initializes an environment config using flag values
@@ -72,6 +127,7 @@ func NewFromFlags() (*Config, error) {
 		e.featureRegex = regexp.MustCompile(envFlags.Feature())
 	}
 	e.labels = envFlags.Labels()
+	e.labelSelector = envFlags.LabelSelector()
 	e.namespace = envFlags.Namespace()
 	e.kubeconfig = envFlags.Kubeconfig()
 	if envFlags.SkipFeatures() != "" {
@@ -82,32 +138,71 @@ func NewFromFlags() (*Config, error) {
 	}
 	e.skipLabels = envFlags.SkipLabels()
 	e.parallelTests = envFlags.Parallel()
+	e.maxParallelTests = envFlags.ParallelMax()
 	e.dryRun = envFlags.DryRun()
+	e.dryRunPlan = envFlags.DryRunPlan()
+	e.listFeatures = envFlags.ListFeatures()
 	e.failFast = envFlags.FailFast()
 	e.disableGracefulTeardown = envFlags.DisableGracefulTeardown()
 	e.kubeContext = envFlags.KubeContext()
+	e.inCluster = envFlags.InCluster() || (e.kubeconfig == "" && conf.ResolveKubeConfigFile() == "" && conf.IsInCluster())
+	e.clientQPS = envFlags.ClientQPS()
+	e.clientBurst = envFlags.ClientBurst()
+	e.artifactsDir = envFlags.Artifacts()
+	e.sonobuoyResultsDir = envFlags.SonobuoyResults()
+	e.shards = envFlags.Shards()
+	e.shardIndex = envFlags.ShardIndex()
+	e.repeat = envFlags.Repeat()
+	e.soakDuration = envFlags.SoakDuration()
+	e.soakMaxFailures = envFlags.SoakMaxFailures()
+	e.setupTimeout = envFlags.SetupTimeout()
+	e.featureTimeout = envFlags.FeatureTimeout()
+	e.teardownTimeout = envFlags.TeardownTimeout()
+	e.slowThreshold = envFlags.SlowThreshold()
+	e.containerEngine = envFlags.ContainerEngine()
+	e.stepLogFormat = envFlags.StepLogFormat()
+	e.hookErrorPolicy = HookErrorPolicy(envFlags.HookErrorPolicy())
+	if envFlags.RerunFailed() != "" {
+		names, err := readFeatureNames(envFlags.RerunFailed())
+		if err != nil {
+			return nil, err
+		}
+		e.WithRerunFeatures(names...)
+	}
 
 	return e, nil
 }
 
 // WithKubeconfigFile creates a new klient.Client and injects it in the cfg
 func (c *Config) WithKubeconfigFile(kubecfg string) *Config {
+	mu := c.lock()
+	mu.Lock()
+	defer mu.Unlock()
 	c.kubeconfig = kubecfg
 	return c
 }
 
 func (c *Config) KubeconfigFile() string {
+	mu := c.lock()
+	mu.RLock()
+	defer mu.RUnlock()
 	return c.kubeconfig
 }
 
 // WithClient used to update the environment klient.Client
 func (c *Config) WithClient(client klient.Client) *Config {
+	mu := c.lock()
+	mu.Lock()
+	defer mu.Unlock()
 	c.client = client
 	return c
 }
 
 // GetClient returns the client for the environment
 func (c *Config) GetClient() klient.Client {
+	mu := c.lock()
+	mu.RLock()
+	defer mu.RUnlock()
 	return c.client
 }
 
@@ -115,11 +210,11 @@ func (c *Config) GetClient() klient.Client {
 // created klient.Client or create a new one based on configuration
 // previously set. Will return an error if unable to do so.
 func (c *Config) NewClient() (klient.Client, error) {
-	if c.client != nil {
-		return c.client, nil
+	if client := c.GetClient(); client != nil {
+		return client, nil
 	}
 
-	client, err := klient.NewWithKubeConfigFile(c.kubeconfig)
+	client, err := c.newClient()
 	if err != nil {
 		return nil, fmt.Errorf("client failed: %w", err)
 	}
@@ -133,19 +228,61 @@ func (c *Config) NewClient() (klient.Client, error) {
 // are confident in the configuration or call NewClient() to ensure its
 // safe creation.
 func (c *Config) Client() klient.Client {
-	if c.client != nil {
-		return c.client
+	if client := c.GetClient(); client != nil {
+		return client
 	}
 
-	client, err := klient.NewWithKubeConfigFile(c.kubeconfig)
+	client, err := c.newClient()
 	if err != nil {
 		panic(fmt.Errorf("client failed: %w", err).Error())
 	}
 	return client
 }
 
+// newClient creates a client for the service account of the pod in in-cluster mode, for
+// the kubeconfig context set with WithKubeContext, or for the context resolved by the
+// klient/conf package when none is set. The client is rate limited as set with WithClientQPS
+// and instrumented to report the API calls of each feature.
+func (c *Config) newClient() (klient.Client, error) {
+	var (
+		cfg *rest.Config
+		err error
+	)
+	switch {
+	case c.inCluster:
+		cfg, err = conf.NewInCluster()
+	case c.kubeContext != "":
+		cfg, err = conf.NewFromContext(c.KubeconfigFile(), c.kubeContext)
+	default:
+		cfg, err = conf.New(c.KubeconfigFile())
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg.QPS, cfg.Burst = c.ClientQPS()
+	apicalls.Instrument(cfg)
+	return klient.New(cfg)
+}
+
+// Resources returns the resources.Resources of the client of the config, scoped to the namespace if one is
+// given. The returned value is a copy that can be scoped further with WithNamespace without affecting the
+// other users of the client.
+func (c *Config) Resources(namespace ...string) *resources.Resources {
+	return c.Client().Resources(namespace...)
+}
+
+// Tracker returns a resources.Tracker creating objects with the client of the config. The objects it
+// creates in the steps of a feature are deleted automatically once the feature is done, in the reverse
+// order of their creation.
+func (c *Config) Tracker() *resources.Tracker {
+	return c.Client().Resources().Tracker()
+}
+
 // WithNamespace updates the environment namespace value
 func (c *Config) WithNamespace(ns string) *Config {
+	mu := c.lock()
+	mu.Lock()
+	defer mu.Unlock()
 	c.namespace = ns
 	return c
 }
@@ -153,12 +290,14 @@ func (c *Config) WithNamespace(ns string) *Config {
 // WithRandomNamespace sets the environment's namespace
 // to a random value
 func (c *Config) WithRandomNamespace() *Config {
-	c.namespace = randNS()
-	return c
+	return c.WithNamespace(randNS())
 }
 
 // Namespace returns the namespace for the environment
 func (c *Config) Namespace() string {
+	mu := c.lock()
+	mu.RLock()
+	defer mu.RUnlock()
 	return c.namespace
 }
 
@@ -217,6 +356,19 @@ func (c *Config) Labels() map[string][]string {
 	return c.labels
 }
 
+// WithLabelSelector sets a label selector expression, such as "env in (dev,staging),tier!=slow",
+// that features must match to be executed. It uses the Kubernetes label selector grammar and
+// applies in addition to the label filters set with WithLabels.
+func (c *Config) WithLabelSelector(selector string) *Config {
+	c.labelSelector = selector
+	return c
+}
+
+// LabelSelector returns the environment's label selector expression
+func (c *Config) LabelSelector() string {
+	return c.labelSelector
+}
+
 // WithSkipLabels sets the environment label filters
 func (c *Config) WithSkipLabels(lbls map[string][]string) *Config {
 	c.skipLabels = lbls
@@ -241,6 +393,222 @@ func (c *Config) ParallelTestEnabled() bool {
 	return c.parallelTests
 }
 
+// WithMaxParallelTests can be used to limit the number of test features that are run
+// concurrently when the parallel run of the test features is enabled. A value of 0 or
+// less runs all the features at once.
+func (c *Config) WithMaxParallelTests(n int) *Config {
+	c.maxParallelTests = n
+	return c
+}
+
+// MaxParallelTests returns the maximum number of test features run concurrently. A value
+// of 0 or less means that the number of concurrent features is unbounded.
+func (c *Config) MaxParallelTests() int {
+	return c.maxParallelTests
+}
+
+// WithSortedFeatures can be used to execute the features passed to Test and TestInParallel
+// in the order declared using the features.WithOrder and features.WithPriority builder
+// options instead of the order in which they are passed. Features with the same order keep
+// their relative position.
+func (c *Config) WithSortedFeatures() *Config {
+	c.sortFeatures = true
+	return c
+}
+
+// SortedFeatures indicates if the features are sorted by their order before execution
+func (c *Config) SortedFeatures() bool {
+	return c.sortFeatures
+}
+
+// WithShuffledFeatures can be used to randomize the order in which the features passed to
+// Test and TestInParallel are executed. The shuffle is reproducible for a given seed which
+// makes it possible to detect and replay failures caused by coupling between features. When
+// combined with WithSortedFeatures, only the features with the same order are shuffled.
+func (c *Config) WithShuffledFeatures(seed int64) *Config {
+	c.shuffleFeatures = true
+	c.shuffleSeed = seed
+	return c
+}
+
+// ShuffledFeatures returns the seed used to shuffle the features and whether the features
+// are shuffled at all
+func (c *Config) ShuffledFeatures() (int64, bool) {
+	return c.shuffleSeed, c.shuffleFeatures
+}
+
+// WithShard can be used to only run the subset of the test features that belongs to the shard
+// index out of total shards. This allows the same test binary to be distributed across several
+// CI workers. A total of 1 or less disables sharding.
+func (c *Config) WithShard(index, total int) *Config {
+	c.shardIndex = index
+	c.shards = total
+	return c
+}
+
+// Shard returns the index of the shard of test features to run and the total number of shards
+func (c *Config) Shard() (index, total int) {
+	return c.shardIndex, c.shards
+}
+
+// WithShardStrategy can be used to customize how the test features are partitioned into shards.
+// When not configured, HashShardStrategy is used.
+func (c *Config) WithShardStrategy(strategy ShardStrategy) *Config {
+	c.shardStrategy = strategy
+	return c
+}
+
+// ShardStrategy returns the strategy used to partition the test features into shards
+func (c *Config) ShardStrategy() ShardStrategy {
+	if c.shardStrategy == nil {
+		return HashShardStrategy{}
+	}
+	return c.shardStrategy
+}
+
+// WithRerunFeatures can be used to restrict the run to the features identified by names, such as
+// the features that failed during a previous run. Calling it without any name runs no feature.
+func (c *Config) WithRerunFeatures(names ...string) *Config {
+	c.rerun = true
+	c.rerunFeatures = names
+	return c
+}
+
+// RerunFeatures returns the names of the features the run is restricted to and whether the
+// run is restricted at all
+func (c *Config) RerunFeatures() ([]string, bool) {
+	return c.rerunFeatures, c.rerun
+}
+
+// WithRepeat can be used to run each selected feature n times, which helps detecting flaky
+// features. The pass rate of each feature is reported at the end of the run.
+func (c *Config) WithRepeat(n int) *Config {
+	c.repeat = n
+	return c
+}
+
+// Repeat returns the number of times each selected feature is run. A value of 1 or less
+// runs each feature once.
+func (c *Config) Repeat() int {
+	return c.repeat
+}
+
+// WithSoakDuration makes each Test and TestInParallel call run its selected features in a loop
+// during the given wall-clock duration, which is used for stability testing. A value of 0
+// disables the soak mode.
+func (c *Config) WithSoakDuration(d time.Duration) *Config {
+	c.soakDuration = d
+	return c
+}
+
+// SoakDuration returns the duration during which the features are run in a loop, 0 when the
+// soak mode is disabled
+func (c *Config) SoakDuration() time.Duration {
+	return c.soakDuration
+}
+
+// WithSoakMaxFailures sets the number of failed iterations tolerated before a soak run stops.
+// The default of 0 stops the run on the first failed iteration.
+func (c *Config) WithSoakMaxFailures(n int) *Config {
+	c.soakMaxFailures = n
+	return c
+}
+
+// SoakMaxFailures returns the number of failed iterations tolerated before a soak run stops
+func (c *Config) SoakMaxFailures() int {
+	return c.soakMaxFailures
+}
+
+// WithSetupTimeout sets the maximum duration of each environment Setup function. A function
+// exceeding it fails the setup of the environment. A value of 0 disables the timeout.
+func (c *Config) WithSetupTimeout(timeout time.Duration) *Config {
+	c.setupTimeout = timeout
+	return c
+}
+
+// SetupTimeout returns the maximum duration of each environment Setup function
+func (c *Config) SetupTimeout() time.Duration {
+	return c.setupTimeout
+}
+
+// WithFeatureTimeout sets the maximum duration of the setup and assessment steps of each
+// feature. The steps are passed a context that is cancelled once the timeout expires and the
+// feature is failed. A value of 0 disables the timeout.
+func (c *Config) WithFeatureTimeout(timeout time.Duration) *Config {
+	c.featureTimeout = timeout
+	return c
+}
+
+// FeatureTimeout returns the maximum duration of the setup and assessment steps of each feature
+func (c *Config) FeatureTimeout() time.Duration {
+	return c.featureTimeout
+}
+
+// WithTeardownTimeout sets the maximum duration of the teardown steps of each feature and
+// of each environment Finish function. A value of 0 disables the timeout.
+func (c *Config) WithTeardownTimeout(timeout time.Duration) *Config {
+	c.teardownTimeout = timeout
+	return c
+}
+
+// TeardownTimeout returns the maximum duration of each feature teardown and Finish function
+func (c *Config) TeardownTimeout() time.Duration {
+	return c.teardownTimeout
+}
+
+// WithSlowThreshold sets the duration above which the steps and the environment functions are
+// reported as slow. A value of 0 disables the reporting.
+func (c *Config) WithSlowThreshold(threshold time.Duration) *Config {
+	c.slowThreshold = threshold
+	return c
+}
+
+// SlowThreshold returns the duration above which steps and environment functions are reported as slow
+func (c *Config) SlowThreshold() time.Duration {
+	return c.slowThreshold
+}
+
+// WithContainerEngine sets the name of the container engine used to build and pull images,
+// one of docker, buildkit, podman or nerdctl
+func (c *Config) WithContainerEngine(name string) *Config {
+	c.containerEngine = name
+	return c
+}
+
+// ContainerEngine returns the name of the container engine used to build and pull images.
+// An empty name means the engine is detected from the PATH.
+func (c *Config) ContainerEngine() string {
+	return c.containerEngine
+}
+
+// WithStepLogFormat sets the format of the messages written with the loggers of the steps,
+// "text" or "json"
+func (c *Config) WithStepLogFormat(format string) *Config {
+	c.stepLogFormat = format
+	return c
+}
+
+// StepLogFormat returns the format of the messages written with the loggers of the steps. An
+// empty format means text.
+func (c *Config) StepLogFormat() string {
+	return c.stepLogFormat
+}
+
+// WithHookErrorPolicy sets what a failing BeforeEachTest, BeforeEachFeature, BeforeEachAssessment
+// or after hook function aborts
+func (c *Config) WithHookErrorPolicy(policy HookErrorPolicy) *Config {
+	c.hookErrorPolicy = policy
+	return c
+}
+
+// HookErrorPolicy returns what a failing hook function aborts, HookErrorFailTest when not set
+func (c *Config) HookErrorPolicy() HookErrorPolicy {
+	if c.hookErrorPolicy == "" {
+		return HookErrorFailTest
+	}
+	return c.hookErrorPolicy
+}
+
 func (c *Config) WithDryRunMode() *Config {
 	c.dryRun = true
 	return c
@@ -250,6 +618,34 @@ func (c *Config) DryRunMode() bool {
 	return c.dryRun
 }
 
+// WithDryRunPlan sets the path of the file where the plan of a dry-run is written. The plan
+// lists the Setup and Finish functions, and the features and assessments each test would
+// run. It is written as YAML for a .yaml or .yml extension, as JSON otherwise, and to stdout
+// for "-".
+func (c *Config) WithDryRunPlan(path string) *Config {
+	c.dryRunPlan = path
+	return c
+}
+
+// DryRunPlan returns the path of the file where the plan of a dry-run is written
+func (c *Config) DryRunPlan() string {
+	return c.dryRunPlan
+}
+
+// WithListFeatures sets the path of the file where the catalog of the features passed to Test and
+// TestInParallel, with their metadata and assessments, is written as JSON, or stdout for "-". It
+// enables the dry-run mode, as the features are listed without being run.
+func (c *Config) WithListFeatures(path string) *Config {
+	c.listFeatures = path
+	c.dryRun = path != "" || c.dryRun
+	return c
+}
+
+// ListFeatures returns the path of the file where the catalog of the features is written
+func (c *Config) ListFeatures() string {
+	return c.listFeatures
+}
+
 // WithFailFast can be used to enable framework specific fail fast mode
 // that controls the test execution of the features and assessments under
 // test
@@ -267,7 +663,7 @@ func (c *Config) FailFast() bool {
 
 // WithDisableGracefulTeardown can be used to programmatically disabled the panic
 // recovery enablement on test startup. This will prevent test Finish steps
-// from being executed on panic
+// and feature Teardown steps from being executed on panic
 func (c *Config) WithDisableGracefulTeardown() *Config {
 	c.disableGracefulTeardown = true
 	return c
@@ -284,33 +680,192 @@ func (c *Config) WithKubeContext(kubeContext string) *Config {
 	return c
 }
 
+// WithInClusterMode makes the tests run against the cluster they are deployed in, for instance
+// as a Job, with the credentials of the service account of their pod. The kubeconfig and its
+// contexts are ignored, and the env funcs of the envfuncs package that provision clusters with
+// a provider, such as CreateCluster and DestroyCluster, are skipped. The mode is enabled by the
+// --in-cluster flag, or detected by NewFromFlags when no kubeconfig is available in a pod.
+func (c *Config) WithInClusterMode() *Config {
+	c.inCluster = true
+	return c
+}
+
+// InClusterMode returns true if the tests run against the cluster they are deployed in
+func (c *Config) InClusterMode() bool {
+	return c.inCluster
+}
+
+// WithClientQPS sets the maximum number of queries per second, and the maximum burst of queries above
+// it, of the client created by the config. The client-go defaults of 5 QPS and a burst of 10 throttle
+// suites running many features in parallel, so DefaultClientQPS and DefaultClientBurst are used when
+// they are not set. The values are also set by the --client-qps and --client-burst flags.
+func (c *Config) WithClientQPS(qps float32, burst int) *Config {
+	c.clientQPS = qps
+	c.clientBurst = burst
+	return c
+}
+
+// ClientQPS returns the maximum number of queries per second and the maximum burst of the client
+// created by the config
+func (c *Config) ClientQPS() (float32, int) {
+	qps, burst := c.clientQPS, c.clientBurst
+	if qps <= 0 {
+		qps = DefaultClientQPS
+	}
+	if burst <= 0 {
+		burst = DefaultClientBurst
+	}
+	return qps, burst
+}
+
+// WithAPICallBudget sets the maximum number of API calls a feature is allowed to send with the clients
+// created by the config, including the calls of its setup and teardown steps. A feature exceeding the
+// budget fails, which catches tests and controllers hammering the API server. Zero, the default, means
+// that the calls are reported but not limited.
+func (c *Config) WithAPICallBudget(maxCalls int) *Config {
+	c.apiCallBudget = maxCalls
+	return c
+}
+
+// APICallBudget returns the maximum number of API calls of a feature, 0 if not limited
+func (c *Config) APICallBudget() int {
+	return c.apiCallBudget
+}
+
 // KubeContext is used to get the kubeconfig context
 func (c *Config) KubeContext() string {
 	return c.kubeContext
 }
 
-func randNS() string {
-	return RandomName("testns-", 32)
+// WithArtifactsDir is used to set the directory where the test artifacts, such as logs,
+// diagnostics and reports, are written
+func (c *Config) WithArtifactsDir(dir string) *Config {
+	c.artifactsDir = dir
+	return c
 }
 
-// RandomName generates a random name of n length with the provided
-// prefix. If prefix is omitted, the then entire name is random char.
-func RandomName(prefix string, n int) string {
-	if n == 0 {
-		n = 32
+// ArtifactsDir returns the directory where the test artifacts are written. When not set using
+// the --artifacts flag or WithArtifactsDir, the ARTIFACTS environment variable is used following
+// the Prow convention. An empty value means that no artifacts directory is configured.
+func (c *Config) ArtifactsDir() string {
+	if c.artifactsDir != "" {
+		return c.artifactsDir
 	}
-	if len(prefix) >= n {
-		return prefix
+	return os.Getenv("ARTIFACTS")
+}
+
+// WithRedactor sets the redactor applied to the objects printed in failure messages and to the
+// cluster logs exported as artifacts, so that credentials do not end up in CI artifacts
+//
+//	cfg.WithRedactor(k8s.NewRedactor(
+//		k8s.RedactKinds("Secret"),
+//		k8s.RedactFields("ConfigMap", "data.password"),
+//		k8s.RedactPatterns(regexp.MustCompile(`Bearer [A-Za-z0-9._-]+`)),
+//	))
+func (c *Config) WithRedactor(r *k8s.Redactor) *Config {
+	c.redactor = r
+	return c
+}
+
+// Redactor returns the redactor set with WithRedactor, or k8s.DefaultRedactor which redacts the
+// data of secrets
+func (c *Config) Redactor() *k8s.Redactor {
+	if c.redactor == nil {
+		return k8s.DefaultRedactor
+	}
+	return c.redactor
+}
+
+// WithSonobuoyResultsDir sets the directory where the results of the suite are written in the
+// Sonobuoy plugin format, which is used when the suite runs as a Sonobuoy plugin
+func (c *Config) WithSonobuoyResultsDir(dir string) *Config {
+	c.sonobuoyResultsDir = dir
+	return c
+}
+
+// SonobuoyResultsDir returns the directory where the results are written in the Sonobuoy plugin
+// format. When not set using the --sonobuoy-results-dir flag or WithSonobuoyResultsDir, the
+// SONOBUOY_RESULTS_DIR environment variable set by Sonobuoy for its plugins is used. An empty
+// value means that the suite does not run as a Sonobuoy plugin.
+func (c *Config) SonobuoyResultsDir() string {
+	if c.sonobuoyResultsDir != "" {
+		return c.sonobuoyResultsDir
+	}
+	return os.Getenv("SONOBUOY_RESULTS_DIR")
+}
+
+// ArtifactPath returns the path of the artifact name stored under a sub-directory of the artifacts
+// directory dedicated to feature. The sub-directory is created if needed. An empty feature stores
+// the artifact at the root of the artifacts directory.
+func (c *Config) ArtifactPath(feature, name string) (string, error) {
+	root := c.ArtifactsDir()
+	if root == "" {
+		return "", fmt.Errorf("artifacts directory is not configured")
+	}
+	dir := root
+	if feature != "" {
+		dir = filepath.Join(root, artifactNameRegex.ReplaceAllString(feature, "-"))
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("artifacts directory %s: %w", dir, err)
 	}
+	return filepath.Join(dir, name), nil
+}
 
-	p := make([]byte, n)
-	_, err := rand.Read(p)
+// readFeatureNames reads the feature names listed in file, one per line
+func readFeatureNames(file string) ([]string, error) {
+	data, err := os.ReadFile(file)
 	if err != nil {
-		log.ErrorS(err, "failed to generate random name. falling back to prefix directly")
-		return prefix
+		return nil, fmt.Errorf("rerun failed features: %w", err)
+	}
+	var names []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			names = append(names, name)
+		}
 	}
-	if prefix == "" {
-		return hex.EncodeToString(p)[:n]
+	return names, nil
+}
+
+type featureConfigKey struct{}
+
+// zeroConfigMu guards the configs that were not created with New
+var zeroConfigMu sync.RWMutex
+
+// lock returns the mutex guarding the fields of c that may be accessed concurrently
+func (c *Config) lock() *sync.RWMutex {
+	if c.mu == nil {
+		return &zeroConfigMu
 	}
-	return fmt.Sprintf("%s-%s", prefix, hex.EncodeToString(p))[:n]
+	return c.mu
+}
+
+// Clone returns a copy of the config that can be modified, for instance with WithNamespace or
+// WithClient, without affecting c. The copy shares the client and the shared informers of c until
+// they are replaced.
+func (c *Config) Clone() *Config {
+	mu := c.lock()
+	mu.RLock()
+	clone := *c
+	mu.RUnlock()
+	clone.mu = &sync.RWMutex{}
+	return &clone
+}
+
+// WithFeatureConfig returns a copy of ctx carrying cfg. When a BeforeEachFeature func returns such a
+// context, cfg is passed to the steps of the feature and to the AfterEachFeature funcs instead of the
+// config of the environment, which lets features running in parallel use their own namespace or client
+// rather than racing to mutate the shared config. cfg is usually created with Clone.
+//
+//	testEnv.BeforeEachFeature(func(ctx context.Context, cfg *envconf.Config, t *testing.T, f features.Feature) (context.Context, error) {
+//		return envconf.WithFeatureConfig(ctx, cfg.Clone().WithNamespace(envconf.RandomName("feature", 16))), nil
+//	})
+func WithFeatureConfig(ctx context.Context, cfg *Config) context.Context {
+	return context.WithValue(ctx, featureConfigKey{}, cfg)
+}
+
+// FeatureConfigFromContext returns the config carried by ctx, or nil
+func FeatureConfigFromContext(ctx context.Context) *Config {
+	cfg, _ := ctx.Value(featureConfigKey{}).(*Config)
+	return cfg
 }
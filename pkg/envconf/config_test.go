@@ -19,7 +19,9 @@ package envconf
 import (
 	"flag"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -87,6 +89,81 @@ func TestConfig_New_WithIgnorePanicRecovery(t *testing.T) {
 	}
 }
 
+func TestConfig_New_WithArtifacts(t *testing.T) {
+	flag.CommandLine = &flag.FlagSet{}
+	os.Args = []string{"test-binary", "--artifacts", "/tmp/artifacts"}
+	cfg, err := NewFromFlags()
+	if err != nil {
+		t.Error("failed to parse args", err)
+	}
+	if cfg.ArtifactsDir() != "/tmp/artifacts" {
+		t.Errorf("expected artifacts directory to be /tmp/artifacts when --artifacts argument is passed, got %q", cfg.ArtifactsDir())
+	}
+}
+
+func TestConfig_New_WithSonobuoyResults(t *testing.T) {
+	t.Setenv("SONOBUOY_RESULTS_DIR", "/tmp/sonobuoy/results")
+	if dir := New().SonobuoyResultsDir(); dir != "/tmp/sonobuoy/results" {
+		t.Errorf("expected the Sonobuoy results directory to default to SONOBUOY_RESULTS_DIR, got %q", dir)
+	}
+
+	flag.CommandLine = &flag.FlagSet{}
+	os.Args = []string{"test-binary", "--sonobuoy-results-dir", "/tmp/results"}
+	cfg, err := NewFromFlags()
+	if err != nil {
+		t.Fatal("failed to parse args", err)
+	}
+	if cfg.SonobuoyResultsDir() != "/tmp/results" {
+		t.Errorf("expected Sonobuoy results directory to be /tmp/results when --sonobuoy-results-dir argument is passed, got %q", cfg.SonobuoyResultsDir())
+	}
+}
+
+func TestConfig_New_WithRerunFailed(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "failed-features.txt")
+	if err := os.WriteFile(file, []byte("feature a\n\nfeature b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	flag.CommandLine = &flag.FlagSet{}
+	os.Args = []string{"test-binary", "--rerun-failed", file}
+	cfg, err := NewFromFlags()
+	if err != nil {
+		t.Fatal("failed to parse args", err)
+	}
+	names, ok := cfg.RerunFeatures()
+	if !ok || strings.Join(names, ",") != "feature a,feature b" {
+		t.Errorf("unexpected features to rerun: %v, %v", names, ok)
+	}
+}
+
+func TestConfig_ArtifactPath(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("ARTIFACTS", root)
+
+	cfg := New()
+	if cfg.ArtifactsDir() != root {
+		t.Fatalf("expected artifacts directory to default to the ARTIFACTS environment variable, got %q", cfg.ArtifactsDir())
+	}
+
+	path, err := cfg.ArtifactPath("pod / exec feature", "logs.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, "pod-exec-feature", "logs.txt"); path != want {
+		t.Errorf("unexpected artifact path %q, want %q", path, want)
+	}
+	if _, err := os.Stat(filepath.Dir(path)); err != nil {
+		t.Errorf("expected feature artifacts directory to be created: %v", err)
+	}
+
+	if _, err := New().WithArtifactsDir("").ArtifactPath("feature", "name"); err != nil {
+		t.Errorf("expected ARTIFACTS environment variable to be used: %v", err)
+	}
+	t.Setenv("ARTIFACTS", "")
+	if _, err := New().ArtifactPath("feature", "name"); err == nil {
+		t.Error("expected an error when no artifacts directory is configured")
+	}
+}
+
 func TestRandomName(t *testing.T) {
 	t.Run("no prefix yields random name without dash", func(t *testing.T) {
 		out := RandomName("", 16)
@@ -114,3 +191,112 @@ func TestRandomGeneratorIsIndeedGeneratingRandom(t *testing.T) {
 		}
 	}
 }
+
+func TestConfig_NewClient_WithKubeContext(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	data := `apiVersion: v1
+kind: Config
+clusters:
+- {name: a, cluster: {server: "https://a.example.com"}}
+- {name: b, cluster: {server: "https://b.example.com"}}
+contexts:
+- {name: a, context: {cluster: a, user: admin}}
+- {name: b, context: {cluster: b, user: admin}}
+users:
+- {name: admin, user: {token: secret}}
+current-context: a
+`
+	if err := os.WriteFile(kubeconfig, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		context  string
+		wantHost string
+	}{
+		{name: "current context", wantHost: "https://a.example.com"},
+		{name: "named context", context: "b", wantHost: "https://b.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewWithKubeConfig(kubeconfig).WithKubeContext(tt.context).NewClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if host := client.RESTConfig().Host; host != tt.wantHost {
+				t.Errorf("expected host %q, got %q", tt.wantHost, host)
+			}
+		})
+	}
+}
+
+func TestConfig_NewClient_WithClientQPS(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	data := `apiVersion: v1
+kind: Config
+clusters:
+- {name: a, cluster: {server: "https://a.example.com"}}
+contexts:
+- {name: a, context: {cluster: a, user: admin}}
+users:
+- {name: admin, user: {token: secret}}
+current-context: a
+`
+	if err := os.WriteFile(kubeconfig, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		qps       float32
+		burst     int
+		wantQPS   float32
+		wantBurst int
+	}{
+		{name: "defaults", wantQPS: DefaultClientQPS, wantBurst: DefaultClientBurst},
+		{name: "custom", qps: 200, burst: 400, wantQPS: 200, wantBurst: 400},
+		{name: "default burst", qps: 20, wantQPS: 20, wantBurst: DefaultClientBurst},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewWithKubeConfig(kubeconfig).WithClientQPS(tt.qps, tt.burst).NewClient()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg := client.RESTConfig(); cfg.QPS != tt.wantQPS || cfg.Burst != tt.wantBurst {
+				t.Errorf("expected QPS %v and burst %d, got %v and %d", tt.wantQPS, tt.wantBurst, cfg.QPS, cfg.Burst)
+			}
+		})
+	}
+}
+
+func TestConfig_Clone(t *testing.T) {
+	cfg := New().WithNamespace("shared").WithParallelTestEnabled()
+	clone := cfg.Clone().WithNamespace("feature")
+	if cfg.Namespace() != "shared" || clone.Namespace() != "feature" {
+		t.Errorf("expected namespaces shared and feature, got %q and %q", cfg.Namespace(), clone.Namespace())
+	}
+	if !clone.ParallelTestEnabled() {
+		t.Error("expected the clone to keep the settings of the config")
+	}
+
+	// run with -race to check that the guarded fields can be used concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cfg.WithNamespace(RandomName("ns", 16))
+			_ = cfg.Namespace()
+			_ = cfg.Clone().WithKubeconfigFile("kubeconfig").KubeconfigFile()
+			_ = cfg.GetClient()
+		}()
+	}
+	wg.Wait()
+
+	var zero Config
+	if zero.WithNamespace("zero").Clone().Namespace() != "zero" {
+		t.Error("expected a zero config to be usable")
+	}
+}
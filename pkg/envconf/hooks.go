@@ -0,0 +1,36 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envconf
+
+// HookErrorPolicy decides what a failing hook function, registered with BeforeEachTest,
+// BeforeEachFeature, BeforeEachAssessment or their after counterparts, aborts
+type HookErrorPolicy string
+
+const (
+	// HookErrorFailTest fails the test and stops it, the remaining features of the test
+	// function are not run. This is the default policy.
+	HookErrorFailTest HookErrorPolicy = "fail-test"
+	// HookErrorFailFeature fails the feature whose BeforeEachFeature or AfterEachFeature
+	// function failed, the other features of the test still run. The steps of a feature
+	// whose BeforeEachFeature function failed are not run, its AfterEachFeature functions
+	// still run to clean up. A failing BeforeEachTest function still fails the whole test,
+	// as all its features depend on it.
+	HookErrorFailFeature HookErrorPolicy = "fail-feature"
+	// HookErrorContinue logs the errors of the hook functions and carries on as if they
+	// succeeded
+	HookErrorContinue HookErrorPolicy = "continue"
+)
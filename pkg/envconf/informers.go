@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envconf
+
+import (
+	"sync"
+
+	"k8s.io/client-go/informers"
+	log "k8s.io/klog/v2"
+)
+
+// sharedInformers holds the informer factory shared by all the copies of a Config
+type sharedInformers struct {
+	mu      sync.Mutex
+	factory informers.SharedInformerFactory
+	stop    chan struct{}
+}
+
+// informersInit guards the lazy creation of the sharedInformers of a Config
+var informersInit sync.Mutex
+
+func (c *Config) sharedInformers() *sharedInformers {
+	informersInit.Lock()
+	defer informersInit.Unlock()
+	if c.informers == nil {
+		c.informers = &sharedInformers{}
+	}
+	return c.informers
+}
+
+// Informers returns a shared informer factory for the cluster of the environment. The factory is
+// created on first use and shared by the features of the environment, so that features watching the
+// same kinds share a single watch connection. Informers requested from the factory only receive
+// events once StartInformers is called, and they are stopped when the environment finishes.
+//
+//	factory, err := cfg.Informers()
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	pods := factory.Core().V1().Pods().Lister()
+//	cfg.StartInformers()
+func (c *Config) Informers() (informers.SharedInformerFactory, error) {
+	s := c.sharedInformers()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.factory != nil {
+		return s.factory, nil
+	}
+
+	client, err := c.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	s.factory = informers.NewSharedInformerFactory(client.Clientset(), 0)
+	s.stop = make(chan struct{})
+	return s.factory, nil
+}
+
+// StartInformers starts the informers requested from the factory returned by Informers that are not
+// running yet and waits for their caches to be synced. It is safe to call it several times, for instance
+// after each feature requested new informers.
+func (c *Config) StartInformers() {
+	s := c.sharedInformers()
+	s.mu.Lock()
+	factory, stop := s.factory, s.stop
+	s.mu.Unlock()
+	if factory == nil {
+		return
+	}
+
+	factory.Start(stop)
+	for informerType, synced := range factory.WaitForCacheSync(stop) {
+		if !synced {
+			log.V(4).InfoS("Informer cache failed to sync", "type", informerType)
+		}
+	}
+}
+
+// StopInformers stops the informers of the shared factory and waits for them to terminate. A later
+// call to Informers creates a new factory. The test environment calls it once the tests complete.
+func (c *Config) StopInformers() {
+	s := c.sharedInformers()
+	s.mu.Lock()
+	factory, stop := s.factory, s.stop
+	s.factory, s.stop = nil, nil
+	s.mu.Unlock()
+	if factory == nil {
+		return
+	}
+
+	close(stop)
+	factory.Shutdown()
+}
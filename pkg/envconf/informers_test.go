@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envconf
+
+import (
+	"testing"
+
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/e2e-framework/klient"
+)
+
+func TestConfig_Informers(t *testing.T) {
+	client, err := klient.New(&rest.Config{Host: "https://127.0.0.1:1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := New().WithClient(client)
+
+	factory, err := cfg.Informers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	copied := *cfg
+	shared, err := copied.Informers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if factory != shared {
+		t.Error("expected copies of the config to share the informer factory")
+	}
+
+	cfg.StopInformers()
+	renewed, err := cfg.Informers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renewed == factory {
+		t.Error("expected a new informer factory after the informers were stopped")
+	}
+	cfg.StopInformers()
+	cfg.StopInformers()
+}
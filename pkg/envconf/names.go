@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envconf
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	log "k8s.io/klog/v2"
+)
+
+// maxNameAttempts bounds the number of names generated to find one not returned before
+const maxNameAttempts = 10
+
+// invalidNameChars matches the characters that are not allowed in a DNS-1123 label
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]`)
+
+// generatedNames holds the names returned by RandomNameWithSuffix, which never returns the same name twice
+var generatedNames sync.Map
+
+func randNS() string {
+	return RandomName("testns-", 32)
+}
+
+// RandomName generates a random name of n length with the provided
+// prefix. If prefix is omitted, the then entire name is random char.
+// See RandomNameWithSuffix.
+func RandomName(prefix string, n int) string {
+	return RandomNameWithSuffix(prefix, "", n)
+}
+
+// RandomNameWithSuffix generates a name of n length made of the prefix, random characters from a
+// cryptographic source and the suffix, joined with dashes. n defaults to 32 when 0. The prefix and
+// suffix are lower-cased and their characters not allowed in a DNS-1123 label are replaced by dashes.
+// The name is never one returned before by the process, so that features running in parallel do not
+// collide, unless there is no room for enough random characters, in which case the prefix and suffix
+// may be returned as is.
+func RandomNameWithSuffix(prefix, suffix string, n int) string {
+	if n == 0 {
+		n = 32
+	}
+	prefix, suffix = sanitizeName(prefix), sanitizeName(suffix)
+	if prefix != "" && !strings.HasSuffix(prefix, "-") {
+		prefix += "-"
+	}
+	if suffix != "" && !strings.HasPrefix(suffix, "-") {
+		suffix = "-" + suffix
+	}
+	size := n - len(prefix) - len(suffix)
+	if size <= 0 {
+		return strings.TrimSuffix(prefix, "-") + suffix
+	}
+
+	var name string
+	for attempt := 0; attempt < maxNameAttempts; attempt++ {
+		p := make([]byte, (size+1)/2)
+		if _, err := rand.Read(p); err != nil {
+			log.ErrorS(err, "failed to generate random name. falling back to prefix directly")
+			return strings.TrimSuffix(prefix, "-") + suffix
+		}
+		name = prefix + hex.EncodeToString(p)[:size] + suffix
+		if _, generated := generatedNames.LoadOrStore(name, struct{}{}); !generated {
+			return name
+		}
+	}
+	log.V(2).InfoS("Failed to generate a name not returned before", "name", name)
+	return name
+}
+
+// sanitizeName lower-cases s and replaces the characters not allowed in a DNS-1123 label by dashes
+func sanitizeName(s string) string {
+	return invalidNameChars.ReplaceAllString(strings.ToLower(s), "-")
+}
+
+// ValidateName returns an error if name is not a valid DNS-1123 label, as required for the names of
+// namespaces and of most objects
+func ValidateName(name string) error {
+	msgs := validation.IsDNS1123Label(name)
+	if len(msgs) == 0 {
+		return nil
+	}
+	errs := make([]error, 0, len(msgs))
+	for _, msg := range msgs {
+		errs = append(errs, errors.New(msg))
+	}
+	return errors.Join(errs...)
+}
+
+// RandomNamespaceFor creates a namespace with a random name derived from the name of the test and
+// returns its name. The namespace is deleted, without waiting for its removal, when the test and its
+// subtests complete. The test fails immediately when the namespace cannot be created.
+func (c *Config) RandomNamespaceFor(t testing.TB) string {
+	t.Helper()
+	prefix := strings.Trim(sanitizeName(t.Name()), "-")
+	if len(prefix) > 50 {
+		prefix = strings.TrimRight(prefix[:50], "-")
+	}
+	name := RandomName(prefix, validation.DNS1123LabelMaxLength)
+
+	client, err := c.NewClient()
+	if err != nil {
+		t.Fatalf("create namespace %s: %s", name, err)
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := client.Resources().Create(context.TODO(), namespace); err != nil {
+		t.Fatalf("create namespace %s: %s", name, err)
+	}
+	t.Cleanup(func() {
+		if err := client.Resources().Delete(context.TODO(), namespace); err != nil && !apierrors.IsNotFound(err) {
+			t.Errorf("delete namespace %s: %s", name, err)
+		}
+	})
+	return name
+}
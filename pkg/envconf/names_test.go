@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envconf
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRandomNameWithSuffix(t *testing.T) {
+	tests := []struct {
+		name       string
+		prefix     string
+		suffix     string
+		n          int
+		wantPrefix string
+		wantSuffix string
+		wantLen    int
+	}{
+		{name: "prefix and suffix", prefix: "web", suffix: "svc", n: 20, wantPrefix: "web-", wantSuffix: "-svc", wantLen: 20},
+		{name: "prefix with dash", prefix: "testns-", n: 32, wantPrefix: "testns-", wantLen: 32},
+		{name: "sanitized", prefix: "TestFeature/Sub_Test", suffix: "X.Y", n: 40, wantPrefix: "testfeature-sub-test-", wantSuffix: "-x-y", wantLen: 40},
+		{name: "default length", suffix: "db", wantSuffix: "-db", wantLen: 32},
+		{name: "no room", prefix: "long-prefix", suffix: "suffix", n: 10, wantPrefix: "long-prefix-suffix", wantLen: 18},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := RandomNameWithSuffix(tc.prefix, tc.suffix, tc.n)
+			if len(out) != tc.wantLen || !strings.HasPrefix(out, tc.wantPrefix) || !strings.HasSuffix(out, tc.wantSuffix) {
+				t.Errorf("unexpected name %q", out)
+			}
+			if strings.Contains(out, "--") {
+				t.Errorf("name %q should not contain consecutive dashes", out)
+			}
+			if tc.wantLen <= 63 {
+				if err := ValidateName(out); err != nil {
+					t.Errorf("name %q is invalid: %s", out, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRandomName_NoCollision(t *testing.T) {
+	// two random characters only allow 256 names
+	names := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		name := RandomName("collision", 12)
+		if names[name] {
+			t.Fatalf("name %q was generated twice", name)
+		}
+		names[name] = true
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "testns-0a1b"},
+		{name: "Upper", wantErr: true},
+		{name: "-leading", wantErr: true},
+		{name: "dot.ted", wantErr: true},
+		{name: strings.Repeat("a", 64), wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateName(tc.name); (err != nil) != tc.wantErr {
+				t.Errorf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
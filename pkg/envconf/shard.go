@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envconf
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"sigs.k8s.io/e2e-framework/pkg/flags"
+)
+
+// ShardStrategy decides which shard of a test suite a feature belongs to when the features are
+// partitioned across several test processes using the --shards and --shard-index flags.
+// Implementations must be deterministic so that every feature is run by exactly one shard.
+type ShardStrategy interface {
+	// Contains returns true if the feature identified by name and labels belongs to the shard
+	// index out of total shards
+	Contains(name string, labels flags.LabelsMap, index, total int) bool
+}
+
+// HashShardStrategy is the default ShardStrategy. It assigns a feature to a shard using a hash of
+// its name and labels, which keeps the partitioning stable when features are added or reordered.
+type HashShardStrategy struct{}
+
+func (HashShardStrategy) Contains(name string, labels flags.LabelsMap, index, total int) bool {
+	if total <= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		values := append([]string{}, labels[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			_, _ = h.Write([]byte("," + k + "=" + v))
+		}
+	}
+	return int(h.Sum32()%uint32(total)) == index
+}
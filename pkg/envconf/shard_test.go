@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envconf
+
+import (
+	"fmt"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/pkg/flags"
+)
+
+func TestHashShardStrategy(t *testing.T) {
+	strategy := HashShardStrategy{}
+	const total = 4
+	counts := make([]int, total)
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("feature-%d", i)
+		labels := flags.LabelsMap{"type": {"smoke", "network"}}
+		shards := 0
+		for index := 0; index < total; index++ {
+			if strategy.Contains(name, labels, index, total) {
+				shards++
+				counts[index]++
+			}
+		}
+		if shards != 1 {
+			t.Fatalf("expected feature %s to belong to exactly one shard, got %d", name, shards)
+		}
+		reordered := flags.LabelsMap{"type": {"network", "smoke"}}
+		for index := 0; index < total; index++ {
+			if strategy.Contains(name, labels, index, total) != strategy.Contains(name, reordered, index, total) {
+				t.Fatalf("expected the shard of feature %s not to depend on the order of its labels", name)
+			}
+		}
+	}
+	for index, count := range counts {
+		if count == 0 {
+			t.Errorf("expected shard %d to contain features", index)
+		}
+	}
+	if !strategy.Contains("feature", nil, 0, 1) {
+		t.Error("expected every feature to belong to the only shard")
+	}
+}
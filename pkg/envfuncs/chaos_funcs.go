@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+// drainTimeout bounds how long DrainNode retries evictions blocked by a PodDisruptionBudget and waits for
+// the evicted pods to be gone
+const drainTimeout = 5 * time.Minute
+
+// mirrorPodAnnotation is set by the kubelet on the API objects of its static pods
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// KillPodsMatching returns an env.Func that deletes, without grace period, the pods of namespace matching the
+// label selector, which simulates workloads crashing. An empty namespace matches the pods of all namespaces.
+// An error is returned when no pod matches, as the disruption would silently not happen.
+func KillPodsMatching(namespace, selector string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		client, err := cfg.NewClient()
+		if err != nil {
+			return ctx, fmt.Errorf("kill pods: %w", err)
+		}
+		var pods corev1.PodList
+		if err := client.Resources(namespace).List(ctx, &pods, resources.WithLabelSelector(selector)); err != nil {
+			return ctx, fmt.Errorf("kill pods: %w", err)
+		}
+		if len(pods.Items) == 0 {
+			return ctx, fmt.Errorf("kill pods: no pod matches %q in namespace %q", selector, namespace)
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			log.V(4).InfoS("Killing pod", "namespace", pod.Namespace, "name", pod.Name)
+			if err := client.Resources().Delete(ctx, pod, resources.WithGracePeriod(0)); err != nil && !apierrors.IsNotFound(err) {
+				return ctx, fmt.Errorf("kill pod %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// CordonNode returns an env.Func that marks the node as unschedulable, as kubectl cordon does
+func CordonNode(name string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		client, err := cfg.NewClient()
+		if err != nil {
+			return ctx, fmt.Errorf("cordon node: %w", err)
+		}
+		if err := setUnschedulable(ctx, client.Resources(), name, true); err != nil {
+			return ctx, fmt.Errorf("cordon node %s: %w", name, err)
+		}
+		return ctx, nil
+	}
+}
+
+// UncordonNode returns an env.Func that marks the node as schedulable again, as kubectl uncordon does
+func UncordonNode(name string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		client, err := cfg.NewClient()
+		if err != nil {
+			return ctx, fmt.Errorf("uncordon node: %w", err)
+		}
+		if err := setUnschedulable(ctx, client.Resources(), name, false); err != nil {
+			return ctx, fmt.Errorf("uncordon node %s: %w", name, err)
+		}
+		return ctx, nil
+	}
+}
+
+func setUnschedulable(ctx context.Context, r *resources.Resources, name string, unschedulable bool) error {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	patch := k8s.Patch{
+		PatchType: types.MergePatchType,
+		Data:      []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable)),
+	}
+	return r.Patch(ctx, node, patch)
+}
+
+// DrainNode returns an env.Func that cordons the node and evicts its pods, as kubectl drain does. DaemonSet and
+// static pods are left running. Evictions denied by a PodDisruptionBudget are retried, and the env.Func returns
+// once the evicted pods are gone, or fails after 5 minutes.
+func DrainNode(name string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		client, err := cfg.NewClient()
+		if err != nil {
+			return ctx, fmt.Errorf("drain node: %w", err)
+		}
+		r := client.Resources()
+		if err := setUnschedulable(ctx, r, name, true); err != nil {
+			return ctx, fmt.Errorf("drain node %s: %w", name, err)
+		}
+		var pods corev1.PodList
+		if err := r.List(ctx, &pods, resources.WithFieldSelector("spec.nodeName="+name)); err != nil {
+			return ctx, fmt.Errorf("drain node %s: %w", name, err)
+		}
+		evicted := podsToEvict(pods.Items)
+		for i := range evicted {
+			if err := evictPod(ctx, r, &evicted[i]); err != nil {
+				return ctx, fmt.Errorf("drain node %s: %w", name, err)
+			}
+		}
+		for i := range evicted {
+			if err := waitForPodGone(ctx, r, &evicted[i]); err != nil {
+				return ctx, fmt.Errorf("drain node %s: %w", name, err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// podsToEvict filters out the pods that a drain leaves behind: DaemonSet pods, which would be recreated on the
+// node, static pods, which are managed by the kubelet, and pods that already terminated
+func podsToEvict(pods []corev1.Pod) []corev1.Pod {
+	var evict []corev1.Pod
+	for _, pod := range pods {
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if owner := metav1.GetControllerOf(&pod); owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+		evict = append(evict, pod)
+	}
+	return evict
+}
+
+// evictPod evicts the pod through the eviction API, retrying while a PodDisruptionBudget denies the eviction
+func evictPod(ctx context.Context, r *resources.Resources, pod *corev1.Pod) error {
+	eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+	err := wait.For(func(ctx context.Context) (bool, error) {
+		log.V(4).InfoS("Evicting pod", "namespace", pod.Namespace, "name", pod.Name)
+		err := r.GetControllerRuntimeClient().SubResource("eviction").Create(ctx, pod, eviction)
+		switch {
+		case err == nil, apierrors.IsNotFound(err):
+			return true, nil
+		case apierrors.IsTooManyRequests(err):
+			log.V(4).InfoS("Eviction denied by disruption budget, retrying", "namespace", pod.Namespace, "name", pod.Name)
+			return false, nil
+		default:
+			return false, err
+		}
+	}, wait.WithContext(ctx), wait.WithTimeout(drainTimeout), wait.WithInterval(5*time.Second), wait.WithImmediate())
+	if err != nil {
+		return fmt.Errorf("evict pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// waitForPodGone waits until the pod is deleted, or replaced by a new pod with the same name
+func waitForPodGone(ctx context.Context, r *resources.Resources, pod *corev1.Pod) error {
+	err := wait.For(func(ctx context.Context) (bool, error) {
+		var current corev1.Pod
+		if err := r.Get(ctx, pod.Name, pod.Namespace, &current); err != nil {
+			return apierrors.IsNotFound(err), nil
+		}
+		return current.UID != pod.UID, nil
+	}, wait.WithContext(ctx), wait.WithTimeout(drainTimeout), wait.WithInterval(2*time.Second), wait.WithImmediate())
+	if err != nil {
+		return fmt.Errorf("pod %s/%s was not deleted: %w", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// NetworkPartitionNode returns an env.Func that retrieves the cluster stored in the context under clusterName and
+// cuts the node off the network of the cluster. The node and its workloads keep running but become unreachable,
+// and the node eventually turns NotReady. The provider must implement support.E2EClusterProviderWithNetworkPartition.
+func NetworkPartitionNode(clusterName, nodeName string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		cluster, err := clusterFromContextAs[support.E2EClusterProviderWithNetworkPartition](ctx, clusterName, "network partitions")
+		if err != nil {
+			return ctx, err
+		}
+		if err := cluster.PartitionNode(ctx, &support.Node{Name: nodeName, Cluster: clusterName}); err != nil {
+			return ctx, fmt.Errorf("network partition node %s: %w", nodeName, err)
+		}
+		return ctx, nil
+	}
+}
+
+// HealNetworkPartition returns an env.Func that reconnects a node partitioned with NetworkPartitionNode to the
+// network of the cluster
+func HealNetworkPartition(clusterName, nodeName string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		cluster, err := clusterFromContextAs[support.E2EClusterProviderWithNetworkPartition](ctx, clusterName, "network partitions")
+		if err != nil {
+			return ctx, err
+		}
+		if err := cluster.HealNode(ctx, &support.Node{Name: nodeName, Cluster: clusterName}); err != nil {
+			return ctx, fmt.Errorf("heal network partition of node %s: %w", nodeName, err)
+		}
+		return ctx, nil
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodsToEvict(t *testing.T) {
+	controller := true
+	pod := func(name string, mutate func(*corev1.Pod)) corev1.Pod {
+		p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+		if mutate != nil {
+			mutate(&p)
+		}
+		return p
+	}
+	pods := []corev1.Pod{
+		pod("replicaset", func(p *corev1.Pod) {
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web", Controller: &controller}}
+		}),
+		pod("daemonset", func(p *corev1.Pod) {
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", Controller: &controller}}
+		}),
+		pod("static", func(p *corev1.Pod) {
+			p.Annotations = map[string]string{mirrorPodAnnotation: "hash"}
+		}),
+		pod("completed", func(p *corev1.Pod) {
+			p.Status.Phase = corev1.PodSucceeded
+		}),
+		pod("bare", nil),
+	}
+
+	evict := podsToEvict(pods)
+	var names []string
+	for _, p := range evict {
+		names = append(names, p.Name)
+	}
+	if len(names) != 2 || names[0] != "replicaset" || names[1] != "bare" {
+		t.Errorf("expected pods replicaset and bare to be evicted, got %v", names)
+	}
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/utils"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+// ContainerEngine builds container images on the host so that they can be loaded into
+// the clusters of the providers implementing support.E2EClusterProviderWithImageLoader
+type ContainerEngine interface {
+	// BuildImage builds the image tagged with tag from the build context at contextPath. An
+	// empty dockerfile uses the default Dockerfile of the build context.
+	BuildImage(ctx context.Context, contextPath, dockerfile, tag string, args ...string) error
+}
+
+// ImagePuller is implemented by the container engines that can pull images from a registry
+type ImagePuller interface {
+	// PullImage pulls the image from its registry into the local image store of the engine
+	PullImage(ctx context.Context, image string, args ...string) error
+}
+
+// ImageDistributor is implemented by the container engines that can pull, tag, push and save
+// images, which is required to preload images with PreloadImages
+type ImageDistributor interface {
+	ImagePuller
+
+	// TagImage adds the target tag to the source image
+	TagImage(ctx context.Context, source, target string) error
+	// PushImage pushes the image to its registry
+	PushImage(ctx context.Context, image string, args ...string) error
+	// SaveImage saves the image to a TAR archive at archivePath
+	SaveImage(ctx context.Context, image, archivePath string) error
+	// LoadImageFromArchive loads the images of the TAR archive at archivePath into the local
+	// image store of the engine
+	LoadImageFromArchive(ctx context.Context, archivePath string) error
+}
+
+// cliContainerEngine builds and distributes images by running the commands of a container engine CLI
+type cliContainerEngine struct {
+	command string
+	// cli is the container engine binary used to pull, tag, push and save images, those
+	// operations are not supported when it is empty
+	cli string
+}
+
+// NewCLIContainerEngine returns a ContainerEngine that builds images by running command, such
+// as "nerdctl build", with the -t and -f flags followed by any extra arguments and the build context.
+func NewCLIContainerEngine(command string) ContainerEngine {
+	return &cliContainerEngine{command: command}
+}
+
+// DockerEngine returns a ContainerEngine building images with `docker build` and distributing them with
+// the docker CLI
+func DockerEngine() ContainerEngine {
+	return &cliContainerEngine{command: "docker build", cli: "docker"}
+}
+
+// BuildKitEngine returns a ContainerEngine building images with `docker buildx build --load` and
+// distributing them with the docker CLI
+func BuildKitEngine() ContainerEngine {
+	return &cliContainerEngine{command: "docker buildx build --load", cli: "docker"}
+}
+
+// PodmanEngine returns a ContainerEngine building and distributing images with the podman CLI
+func PodmanEngine() ContainerEngine {
+	return &cliContainerEngine{command: "podman build", cli: "podman"}
+}
+
+// NerdctlEngine returns a ContainerEngine building and distributing images with the nerdctl CLI
+func NerdctlEngine() ContainerEngine {
+	return &cliContainerEngine{command: "nerdctl build", cli: "nerdctl"}
+}
+
+// containerEngines are the supported container engines, by name
+var containerEngines = map[string]func() ContainerEngine{
+	"docker":   DockerEngine,
+	"buildkit": BuildKitEngine,
+	"podman":   PodmanEngine,
+	"nerdctl":  NerdctlEngine,
+}
+
+// ContainerEngineByName returns the container engine identified by name, one of docker,
+// buildkit, podman or nerdctl.
+func ContainerEngineByName(name string) (ContainerEngine, error) {
+	newEngine, ok := containerEngines[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown container engine %q", name)
+	}
+	return newEngine(), nil
+}
+
+// DetectContainerEngine returns the first container engine whose CLI is found in the PATH,
+// looking for docker, podman and nerdctl in that order.
+func DetectContainerEngine() (ContainerEngine, error) {
+	for _, name := range []string{"docker", "podman", "nerdctl"} {
+		if _, err := exec.LookPath(name); err == nil {
+			log.V(4).InfoS("Detected container engine", "engine", name)
+			return ContainerEngineByName(name)
+		}
+	}
+	return nil, fmt.Errorf("no container engine found in PATH, install docker, podman or nerdctl")
+}
+
+// containerEngine returns the engine set with WithContainerEngine, the one selected with the
+// --container-engine flag or the one detected on the host, in that order
+func containerEngine(cfg *envconf.Config, options *buildImageOptions) (ContainerEngine, error) {
+	if options.engine != nil {
+		return options.engine, nil
+	}
+	if name := cfg.ContainerEngine(); name != "" {
+		return ContainerEngineByName(name)
+	}
+	return DetectContainerEngine()
+}
+
+func (e *cliContainerEngine) BuildImage(ctx context.Context, contextPath, dockerfile, tag string, args ...string) error {
+	p := utils.RunCommand(e.buildCommand(contextPath, dockerfile, tag, args...))
+	if p.Err() != nil {
+		return fmt.Errorf("%s %s failed: %s: %s", e.command, tag, p.Err(), p.Result())
+	}
+	return nil
+}
+
+func (e *cliContainerEngine) PullImage(ctx context.Context, image string, args ...string) error {
+	return e.run("pull", append(append([]string{}, args...), image)...)
+}
+
+func (e *cliContainerEngine) TagImage(ctx context.Context, source, target string) error {
+	return e.run("tag", source, target)
+}
+
+func (e *cliContainerEngine) PushImage(ctx context.Context, image string, args ...string) error {
+	return e.run("push", append(append([]string{}, args...), image)...)
+}
+
+func (e *cliContainerEngine) SaveImage(ctx context.Context, image, archivePath string) error {
+	return e.run("save", "-o", archivePath, image)
+}
+
+func (e *cliContainerEngine) LoadImageFromArchive(ctx context.Context, archivePath string) error {
+	return e.run("load", "-i", archivePath)
+}
+
+// run runs the subcommand of the container engine CLI with the arguments
+func (e *cliContainerEngine) run(subcommand string, args ...string) error {
+	if e.cli == "" {
+		return fmt.Errorf("%s: %s is not supported", e.command, subcommand)
+	}
+	command := append([]string{e.cli, subcommand}, args...)
+	p := utils.RunCommand(strings.Join(command, " "))
+	if p.Err() != nil {
+		return fmt.Errorf("%s %s failed: %s: %s", e.cli, subcommand, p.Err(), p.Result())
+	}
+	return nil
+}
+
+func (e *cliContainerEngine) buildCommand(contextPath, dockerfile, tag string, args ...string) string {
+	command := []string{e.command, "-t", tag}
+	if dockerfile != "" {
+		command = append(command, "-f", dockerfile)
+	}
+	command = append(command, args...)
+	command = append(command, contextPath)
+	return strings.Join(command, " ")
+}
+
+type buildImageOptions struct {
+	engine      ContainerEngine
+	buildArgs   []string
+	loadArgs    []string
+	loadTimeout time.Duration
+}
+
+// BuildImageOpts configures how BuildAndLoadImage and PullAndLoadImage build, pull and load an image
+type BuildImageOpts func(*buildImageOptions)
+
+// WithContainerEngine sets the container engine used to build or pull the image. By default, the
+// engine selected with the --container-engine flag is used, or the first one found in the PATH.
+func WithContainerEngine(engine ContainerEngine) BuildImageOpts {
+	return func(o *buildImageOptions) {
+		o.engine = engine
+	}
+}
+
+// WithBuildArgs passes additional arguments to the build command of the container engine
+func WithBuildArgs(args ...string) BuildImageOpts {
+	return func(o *buildImageOptions) {
+		o.buildArgs = append(o.buildArgs, args...)
+	}
+}
+
+// WithLoadArgs passes additional arguments to the image loading command of the cluster provider
+func WithLoadArgs(args ...string) BuildImageOpts {
+	return func(o *buildImageOptions) {
+		o.loadArgs = append(o.loadArgs, args...)
+	}
+}
+
+// WithLoadTimeout sets the maximum duration of loading the image into the cluster
+func WithLoadTimeout(timeout time.Duration) BuildImageOpts {
+	return func(o *buildImageOptions) {
+		o.loadTimeout = timeout
+	}
+}
+
+// BuildAndLoadImage returns an EnvFunc that builds the image tagged with tag from the build context at
+// buildCtxPath, using the given Dockerfile or the default one of the build context when empty, and then
+// loads it into the cluster previously created with CreateCluster under clusterName.
+func BuildAndLoadImage(buildCtxPath, dockerfile, tag, clusterName string, opts ...BuildImageOpts) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		options := &buildImageOptions{}
+		for _, opt := range opts {
+			opt(options)
+		}
+
+		cluster, err := clusterFromContextAs[support.E2EClusterProviderWithImageLoader](ctx, clusterName, "loading images")
+		if err != nil {
+			return ctx, fmt.Errorf("build and load image func: %w", err)
+		}
+		engine, err := containerEngine(cfg, options)
+		if err != nil {
+			return ctx, fmt.Errorf("build and load image func: %w", err)
+		}
+
+		log.V(4).InfoS("Building image", "image", tag, "context", buildCtxPath, "dockerfile", dockerfile)
+		if err := engine.BuildImage(ctx, buildCtxPath, dockerfile, tag, options.buildArgs...); err != nil {
+			return ctx, fmt.Errorf("build image: %w", err)
+		}
+
+		log.V(4).InfoS("Loading image", "image", tag, "cluster", clusterName)
+		if err := loadImageWithTimeout(ctx, cluster, tag, options.loadTimeout, options.loadArgs...); err != nil {
+			return ctx, fmt.Errorf("load image: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// PullAndLoadImage returns an EnvFunc that pulls the image from its registry with the container engine
+// and then loads it into the cluster previously created with CreateCluster under clusterName. This
+// avoids pulling the image from each node of the cluster. Extra pull arguments can be passed with
+// WithBuildArgs.
+func PullAndLoadImage(image, clusterName string, opts ...BuildImageOpts) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		options := &buildImageOptions{}
+		for _, opt := range opts {
+			opt(options)
+		}
+
+		cluster, err := clusterFromContextAs[support.E2EClusterProviderWithImageLoader](ctx, clusterName, "loading images")
+		if err != nil {
+			return ctx, fmt.Errorf("pull and load image func: %w", err)
+		}
+		engine, err := containerEngine(cfg, options)
+		if err != nil {
+			return ctx, fmt.Errorf("pull and load image func: %w", err)
+		}
+		puller, ok := engine.(ImagePuller)
+		if !ok {
+			return ctx, fmt.Errorf("pull and load image func: container engine %T does not support pulling images", engine)
+		}
+
+		log.V(4).InfoS("Pulling image", "image", image)
+		if err := puller.PullImage(ctx, image, options.buildArgs...); err != nil {
+			return ctx, fmt.Errorf("pull image: %w", err)
+		}
+
+		log.V(4).InfoS("Loading image", "image", image, "cluster", clusterName)
+		if err := loadImageWithTimeout(ctx, cluster, image, options.loadTimeout, options.loadArgs...); err != nil {
+			return ctx, fmt.Errorf("load image: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// loadImageWithTimeout loads the image into the cluster and stops waiting for it once the
+// timeout expires, when it is set
+func loadImageWithTimeout(ctx context.Context, cluster support.E2EClusterProviderWithImageLoader, image string, timeout time.Duration, args ...string) error {
+	if timeout <= 0 {
+		return cluster.LoadImage(ctx, image, args...)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cluster.LoadImage(ctx, image, args...)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("image %s not loaded after %s: %w", image, timeout, ctx.Err())
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/third_party/kubectl"
+)
+
+// Kubectl returns an env.Func that runs kubectl with the given args against the cluster
+// configured in the envconf.Config kubeconfig file. Each argument is passed to kubectl
+// as is, e.g. envfuncs.Kubectl("wait", "--for=condition=Available", "deployment/app").
+//
+// NOTE: For more control over the kubectl invocation, use the kubectl.Manager directly
+func Kubectl(args ...string) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if _, err := kubectl.New(c.KubeconfigFile()).Run(ctx, kubectl.WithArgs(args...)); err != nil {
+			return ctx, fmt.Errorf("kubectl %v failed: %w", args, err)
+		}
+		return ctx, nil
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// defaultObjects are the objects created by Kubernetes in every namespace, keyed by kind
+var defaultObjects = map[string]string{
+	"ServiceAccount": "default",
+	"ConfigMap":      "kube-root-ca.crt",
+}
+
+// FailOnLeftoverResources returns an env.Func, to be used with Finish, that fails the test suite when objects of
+// the given kinds created by the tests remain in the namespaces, which catches the features missing teardown steps.
+// The objects of cluster scoped kinds are looked up once regardless of namespaces, and an empty list of namespaces
+// looks up all the namespaces.
+//
+// Objects created before FailOnLeftoverResources is called, which is usually when the Finish funcs are registered
+// in TestMain, are not reported. Neither are the objects with an owner, which are deleted along with it, the
+// objects being deleted, and the objects Kubernetes creates in every namespace such as the default service
+// account. The Finish funcs are run in order, so it is registered after the funcs cleaning up the cluster.
+//
+//	testEnv.Finish(
+//		envfuncs.DeleteNamespace(namespace),
+//		envfuncs.FailOnLeftoverResources([]string{"default"},
+//			schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+//			schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+//		),
+//	)
+func FailOnLeftoverResources(namespaces []string, gvks ...schema.GroupVersionKind) env.Func {
+	since := time.Now()
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		leftovers, err := findLeftoverResources(ctx, cfg, since, namespaces, gvks)
+		if err != nil {
+			return ctx, fmt.Errorf("look up leftover resources: %w", err)
+		}
+		if len(leftovers) > 0 {
+			return ctx, fmt.Errorf("%w: %d leftover resources: %s", env.ErrSuiteFailed, len(leftovers), strings.Join(leftovers, ", "))
+		}
+		return ctx, nil
+	}
+}
+
+// WarnOnLeftoverResources returns an env.Func that logs the objects of the given kinds created by the tests
+// that remain in the namespaces, without failing the test suite. See FailOnLeftoverResources.
+func WarnOnLeftoverResources(namespaces []string, gvks ...schema.GroupVersionKind) env.Func {
+	since := time.Now()
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		leftovers, err := findLeftoverResources(ctx, cfg, since, namespaces, gvks)
+		if err != nil {
+			return ctx, fmt.Errorf("look up leftover resources: %w", err)
+		}
+		for _, leftover := range leftovers {
+			log.InfoS("Warning: leftover resource", "resource", leftover)
+		}
+		return ctx, nil
+	}
+}
+
+// findLeftoverResources lists the objects of the kinds in the namespaces and describes those left over by the tests
+func findLeftoverResources(ctx context.Context, cfg *envconf.Config, since time.Time, namespaces []string, gvks []schema.GroupVersionKind) ([]string, error) {
+	client, err := cfg.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	var leftovers []string
+	for _, gvk := range gvks {
+		seen := make(map[string]bool)
+		for _, namespace := range namespaces {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			if err := client.Resources(namespace).List(ctx, list); err != nil {
+				return nil, fmt.Errorf("list %s in namespace %q: %w", gvk.Kind, namespace, err)
+			}
+			for i := range list.Items {
+				obj := &list.Items[i]
+				desc := fmt.Sprintf("%s %s", gvk.Kind, obj.GetName())
+				if obj.GetNamespace() != "" {
+					desc = fmt.Sprintf("%s %s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
+				}
+				// cluster scoped objects are listed once per namespace
+				if seen[desc] || !isLeftover(obj, gvk.Kind, since) {
+					continue
+				}
+				seen[desc] = true
+				leftovers = append(leftovers, desc)
+			}
+		}
+	}
+	return leftovers, nil
+}
+
+// isLeftover returns true if the object was created by the tests and is not expected to be deleted along
+// with another object
+func isLeftover(obj *unstructured.Unstructured, kind string, since time.Time) bool {
+	// the creation timestamp has a precision of a second
+	if obj.GetCreationTimestamp().Time.Before(since.Truncate(time.Second)) {
+		return false
+	}
+	if obj.GetDeletionTimestamp() != nil || len(obj.GetOwnerReferences()) > 0 {
+		return false
+	}
+	return defaultObjects[kind] != obj.GetName()
+}
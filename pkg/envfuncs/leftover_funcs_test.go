@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestIsLeftover(t *testing.T) {
+	since := time.Date(2025, 1, 1, 12, 0, 0, 500, time.UTC)
+	newObject := func(kind, name string, created time.Time, mutate ...func(*unstructured.Unstructured)) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetKind(kind)
+		obj.SetName(name)
+		obj.SetCreationTimestamp(metav1.NewTime(created))
+		for _, fn := range mutate {
+			fn(obj)
+		}
+		return obj
+	}
+	deleting := func(obj *unstructured.Unstructured) {
+		now := metav1.NewTime(since)
+		obj.SetDeletionTimestamp(&now)
+	}
+	owned := func(obj *unstructured.Unstructured) {
+		obj.SetOwnerReferences([]metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-abc"}})
+	}
+
+	tests := []struct {
+		name string
+		obj  *unstructured.Unstructured
+		want bool
+	}{
+		{name: "created by the tests", obj: newObject("Deployment", "web", since.Add(time.Minute)), want: true},
+		{name: "created in the same second", obj: newObject("ConfigMap", "config", since.Truncate(time.Second)), want: true},
+		{name: "pre-existing", obj: newObject("Deployment", "coredns", since.Add(-time.Hour))},
+		{name: "being deleted", obj: newObject("Deployment", "web", since.Add(time.Minute), deleting)},
+		{name: "owned", obj: newObject("Pod", "web-abc-123", since.Add(time.Minute), owned)},
+		{name: "default service account", obj: newObject("ServiceAccount", "default", since.Add(time.Minute))},
+		{name: "root CA config map", obj: newObject("ConfigMap", "kube-root-ca.crt", since.Add(time.Minute))},
+		{name: "other service account", obj: newObject("ServiceAccount", "runner", since.Add(time.Minute)), want: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isLeftover(tc.obj, tc.obj.GetKind(), since); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/e2e-framework/pkg/ctxutil"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+// ClusterNodesContextKey is the context key under which AddClusterNode records the nodes it added to a cluster
+type ClusterNodesContextKey string
+
+// AddedNodesFromContext returns the nodes added with AddClusterNode to the cluster stored in the context under
+// clusterName, in the order they were added
+func AddedNodesFromContext(ctx context.Context, clusterName string) []support.Node {
+	nodes, _ := ctxutil.Load[[]support.Node](ctx, ClusterNodesContextKey(clusterName))
+	return nodes
+}
+
+// AddClusterNode returns an env.Func that retrieves the cluster stored in the context under clusterName and adds
+// a node with the given role to it. The node gets a random name prefixed with the cluster name and the role, and
+// it is recorded in the context so that it can be retrieved with AddedNodesFromContext. args are passed to the
+// provider. The provider must implement support.E2EClusterProviderWithLifeCycle.
+func AddClusterNode(clusterName, role string, args ...string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		cluster, err := clusterFromContextAs[support.E2EClusterProviderWithLifeCycle](ctx, clusterName, "node lifecycle operations")
+		if err != nil {
+			return ctx, err
+		}
+		prefix := fmt.Sprintf("%s-%s", clusterName, role)
+		node := support.Node{Name: envconf.RandomName(prefix, len(prefix)+6), Role: role, Cluster: clusterName}
+		if err := cluster.AddNode(ctx, &node, args...); err != nil {
+			return ctx, fmt.Errorf("add node to cluster %s: %w", clusterName, err)
+		}
+		nodes := append(AddedNodesFromContext(ctx, clusterName), node)
+		return ctxutil.Store(ctx, ClusterNodesContextKey(clusterName), nodes), nil
+	}
+}
+
+// RemoveClusterNode returns an env.Func that retrieves the cluster stored in the context under clusterName and
+// removes the named node from it. The provider must implement support.E2EClusterProviderWithLifeCycle.
+func RemoveClusterNode(clusterName, nodeName string, args ...string) env.Func {
+	return clusterNodeOperation(clusterName, nodeName, support.RemoveNode, args...)
+}
+
+// StopClusterNode returns an env.Func that retrieves the cluster stored in the context under clusterName and
+// stops the named node, which can be used to simulate a node failure. The provider must implement
+// support.E2EClusterProviderWithLifeCycle.
+func StopClusterNode(clusterName, nodeName string, args ...string) env.Func {
+	return clusterNodeOperation(clusterName, nodeName, support.StopNode, args...)
+}
+
+// StartClusterNode returns an env.Func that retrieves the cluster stored in the context under clusterName and
+// starts the named node, stopped with StopClusterNode. The provider must implement
+// support.E2EClusterProviderWithLifeCycle.
+func StartClusterNode(clusterName, nodeName string, args ...string) env.Func {
+	return clusterNodeOperation(clusterName, nodeName, support.StartNode, args...)
+}
+
+func clusterNodeOperation(clusterName, nodeName string, action support.NodeOperation, args ...string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		cluster, err := clusterFromContextAs[support.E2EClusterProviderWithLifeCycle](ctx, clusterName, "node lifecycle operations")
+		if err != nil {
+			return ctx, err
+		}
+		node := &support.Node{Name: nodeName, Cluster: clusterName}
+		switch action {
+		case support.RemoveNode:
+			err = cluster.RemoveNode(ctx, node, args...)
+		case support.StopNode:
+			err = cluster.StopNode(ctx, node, args...)
+		case support.StartNode:
+			err = cluster.StartNode(ctx, node, args...)
+		default:
+			err = fmt.Errorf("unknown node operation: %s", action)
+		}
+		if err != nil {
+			return ctx, fmt.Errorf("%s node %s of cluster %s: %w", action, nodeName, clusterName, err)
+		}
+		return ctx, nil
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+type fakeLifecycleProvider struct {
+	support.E2EClusterProvider
+	operations []string
+	fail       bool
+}
+
+func (f *fakeLifecycleProvider) record(operation string, node *support.Node) error {
+	if f.fail {
+		return errors.New("provider failure")
+	}
+	f.operations = append(f.operations, operation+" "+node.Name+" "+node.Role)
+	return nil
+}
+
+func (f *fakeLifecycleProvider) AddNode(_ context.Context, node *support.Node, _ ...string) error {
+	return f.record("add", node)
+}
+
+func (f *fakeLifecycleProvider) RemoveNode(_ context.Context, node *support.Node, _ ...string) error {
+	return f.record("remove", node)
+}
+
+func (f *fakeLifecycleProvider) StartNode(_ context.Context, node *support.Node, _ ...string) error {
+	return f.record("start", node)
+}
+
+func (f *fakeLifecycleProvider) StopNode(_ context.Context, node *support.Node, _ ...string) error {
+	return f.record("stop", node)
+}
+
+func (f *fakeLifecycleProvider) ListNode(context.Context, ...string) ([]support.Node, error) {
+	return nil, nil
+}
+
+func TestClusterNodeFuncs(t *testing.T) {
+	provider := &fakeLifecycleProvider{}
+	ctx := context.WithValue(context.TODO(), support.ClusterNameContextKey("test"), support.E2EClusterProvider(provider))
+	cfg := envconf.New()
+
+	ctx, err := AddClusterNode("test", "worker")(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	added := AddedNodesFromContext(ctx, "test")
+	if len(added) != 1 || !strings.HasPrefix(added[0].Name, "test-worker-") || added[0].Role != "worker" {
+		t.Fatalf("unexpected added nodes: %v", added)
+	}
+	for _, fn := range []func(string, string, ...string) env.Func{
+		StopClusterNode, StartClusterNode, RemoveClusterNode,
+	} {
+		if _, err := fn("test", added[0].Name)(ctx, cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{"add " + added[0].Name + " worker", "stop " + added[0].Name + " ", "start " + added[0].Name + " ", "remove " + added[0].Name + " "}
+	if strings.Join(provider.operations, ",") != strings.Join(want, ",") {
+		t.Errorf("expected operations %v, got %v", want, provider.operations)
+	}
+
+	provider.fail = true
+	if _, err := StopClusterNode("test", "node")(ctx, cfg); err == nil || !strings.Contains(err.Error(), "provider failure") {
+		t.Errorf("expected the provider error, got %v", err)
+	}
+	if _, err := StopClusterNode("missing", "node")(ctx, cfg); err == nil {
+		t.Error("expected an error for a missing cluster")
+	}
+}
@@ -23,12 +23,19 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/pkg/ctxutil"
 	"sigs.k8s.io/e2e-framework/pkg/env"
 	"sigs.k8s.io/e2e-framework/pkg/envconf"
 )
 
 type NamespaceContextKey string
 
+// NamespaceFromContext returns the namespace created with CreateNamespace and stored in the
+// context under its name.
+func NamespaceFromContext(ctx context.Context, name string) (corev1.Namespace, bool) {
+	return ctxutil.Load[corev1.Namespace](ctx, NamespaceContextKey(name))
+}
+
 type CreateNamespaceOpts func(klient.Client, *corev1.Namespace)
 
 // WithLabels provides an option to set custom labels on the namespace.
@@ -78,11 +85,8 @@ func DeleteNamespace(name string) env.Func {
 		var namespace *corev1.Namespace
 
 		// attempt to retrieve from context
-		nsVal := ctx.Value(NamespaceContextKey(name))
-		if nsVal != nil {
-			if ns, ok := nsVal.(corev1.Namespace); ok {
-				namespace = &ns
-			}
+		if ns, ok := NamespaceFromContext(ctx, name); ok {
+			namespace = &ns
 		}
 
 		client, err := cfg.NewClient()
@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+// imageArchiveNameRegex matches the characters of an image reference that are replaced in the
+// name of its cached archive
+var imageArchiveNameRegex = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+type preloadOptions struct {
+	engine   ContainerEngine
+	registry string
+	cacheDir string
+	pullArgs []string
+}
+
+// PreloadOpts configures how PreloadImages fetches and distributes the images
+type PreloadOpts func(*preloadOptions)
+
+// WithPreloadEngine sets the container engine used to pull, tag and save the images. By default,
+// the engine selected with the --container-engine flag is used, or the first one found in the PATH.
+func WithPreloadEngine(engine ContainerEngine) PreloadOpts {
+	return func(o *preloadOptions) {
+		o.engine = engine
+	}
+}
+
+// WithPreloadRegistry retags the images for the registry, such as localhost:5000, and pushes them
+// to it instead of loading them into the cluster. This is meant to be used with a registry the
+// cluster pulls from, like the one created with CreateLocalRegistry.
+func WithPreloadRegistry(registry string) PreloadOpts {
+	return func(o *preloadOptions) {
+		o.registry = strings.TrimSuffix(registry, "/")
+	}
+}
+
+// WithImageCache caches the pulled images as TAR archives in dir. The images found in the cache
+// are loaded from their archive without being pulled, so the cache can be kept between runs or
+// shipped to airgapped environments.
+func WithImageCache(dir string) PreloadOpts {
+	return func(o *preloadOptions) {
+		o.cacheDir = dir
+	}
+}
+
+// WithPullArgs passes additional arguments to the pull command of the container engine
+func WithPullArgs(args ...string) PreloadOpts {
+	return func(o *preloadOptions) {
+		o.pullArgs = append(o.pullArgs, args...)
+	}
+}
+
+// ReadImageManifest reads the list of images from the manifest file at path, which contains one
+// image reference per line. Empty lines and lines starting with # are ignored.
+func ReadImageManifest(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("image manifest: %w", err)
+	}
+	defer f.Close()
+
+	var images []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		images = append(images, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("image manifest %s: %w", path, err)
+	}
+	return images, nil
+}
+
+// PreloadImagesFromFile returns an EnvFunc that preloads the images listed in the manifest file
+// into the cluster, see ReadImageManifest and PreloadImages.
+func PreloadImagesFromFile(clusterName, manifestPath string, opts ...PreloadOpts) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		images, err := ReadImageManifest(manifestPath)
+		if err != nil {
+			return ctx, fmt.Errorf("preload images func: %w", err)
+		}
+		return PreloadImages(clusterName, images, opts...)(ctx, cfg)
+	}
+}
+
+// PreloadImages returns an EnvFunc that makes the images available to the cluster previously created
+// with CreateCluster under clusterName before the tests start. Each image is pulled on the host, or
+// read from the cache set with WithImageCache, and then either loaded into the cluster or retagged and
+// pushed to the registry set with WithPreloadRegistry.
+func PreloadImages(clusterName string, images []string, opts ...PreloadOpts) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		options := &preloadOptions{}
+		for _, opt := range opts {
+			opt(options)
+		}
+
+		engine, err := containerEngine(cfg, &buildImageOptions{engine: options.engine})
+		if err != nil {
+			return ctx, fmt.Errorf("preload images func: %w", err)
+		}
+		distributor, ok := engine.(ImageDistributor)
+		if !ok {
+			return ctx, fmt.Errorf("preload images func: container engine %T does not support distributing images", engine)
+		}
+
+		var cluster support.E2EClusterProviderWithImageLoader
+		if options.registry == "" {
+			if cluster, err = clusterFromContextAs[support.E2EClusterProviderWithImageLoader](ctx, clusterName, "loading images"); err != nil {
+				return ctx, fmt.Errorf("preload images func: %w", err)
+			}
+		}
+		if options.cacheDir != "" {
+			if err := os.MkdirAll(options.cacheDir, 0o755); err != nil {
+				return ctx, fmt.Errorf("preload images func: image cache: %w", err)
+			}
+		}
+
+		for _, image := range images {
+			if err := preloadImage(ctx, distributor, cluster, image, options); err != nil {
+				return ctx, fmt.Errorf("preload image %s: %w", image, err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// preloadImage fetches a single image and makes it available to the cluster
+func preloadImage(ctx context.Context, engine ImageDistributor, cluster support.E2EClusterProviderWithImageLoader, image string, options *preloadOptions) error {
+	archive := ""
+	if options.cacheDir != "" {
+		archive = imageArchivePath(options.cacheDir, image)
+	}
+
+	cached := false
+	if archive != "" {
+		if _, err := os.Stat(archive); err == nil {
+			cached = true
+		}
+	}
+
+	// loading the archive into the cluster does not require the image to be on the host
+	if cached && cluster != nil {
+		log.V(4).InfoS("Loading cached image", "image", image, "archive", archive)
+		return cluster.LoadImageArchive(ctx, archive)
+	}
+
+	if cached {
+		log.V(4).InfoS("Loading cached image on the host", "image", image, "archive", archive)
+		if err := engine.LoadImageFromArchive(ctx, archive); err != nil {
+			return err
+		}
+	} else {
+		log.V(4).InfoS("Pulling image", "image", image)
+		if err := engine.PullImage(ctx, image, options.pullArgs...); err != nil {
+			return err
+		}
+		if archive != "" {
+			if err := saveImageArchive(ctx, engine, image, archive); err != nil {
+				return err
+			}
+		}
+	}
+
+	if options.registry != "" {
+		target := RegistryImage(options.registry, image)
+		log.V(4).InfoS("Pushing image to registry", "image", image, "target", target)
+		if err := engine.TagImage(ctx, image, target); err != nil {
+			return err
+		}
+		return engine.PushImage(ctx, target)
+	}
+
+	log.V(4).InfoS("Loading image", "image", image)
+	return cluster.LoadImage(ctx, image)
+}
+
+// imageArchivePath returns the path of the archive caching the image in cacheDir
+func imageArchivePath(cacheDir, image string) string {
+	return filepath.Join(cacheDir, imageArchiveNameRegex.ReplaceAllString(image, "_")+".tar")
+}
+
+// saveImageArchive saves the image into a temporary file of the cache directory, renamed to archive once
+// complete, so that an interrupted save does not leave a truncated archive that later runs would load
+func saveImageArchive(ctx context.Context, engine ImageDistributor, image, archive string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(archive), "."+filepath.Base(archive)+"-*")
+	if err != nil {
+		return fmt.Errorf("image cache: %w", err)
+	}
+	tmp.Close()
+
+	if err := engine.SaveImage(ctx, image, tmp.Name()); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), archive); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("image cache: %w", err)
+	}
+	return nil
+}
+
+// RegistryImage returns the reference of the image once retagged for the registry. The registry
+// host of the image, if any, is replaced by registry: docker.io/library/nginx:1.27 becomes
+// localhost:5000/library/nginx:1.27 and nginx:1.27 becomes localhost:5000/nginx:1.27.
+func RegistryImage(registry, image string) string {
+	if first, rest, found := strings.Cut(image, "/"); found &&
+		(strings.ContainsAny(first, ".:") || first == "localhost") {
+		image = rest
+	}
+	return registry + "/" + image
+}
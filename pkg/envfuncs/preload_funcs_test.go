@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type fakeImageDistributor struct {
+	ImageDistributor
+	saveErr error
+}
+
+// SaveImage writes a partial archive before failing with saveErr, if set
+func (f *fakeImageDistributor) SaveImage(_ context.Context, image, archivePath string) error {
+	if err := os.WriteFile(archivePath, []byte(image), 0o644); err != nil {
+		return err
+	}
+	return f.saveErr
+}
+
+func TestReadImageManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     []string
+	}{
+		{name: "empty manifest"},
+		{
+			name:     "images",
+			manifest: "nginx:1.27\nregistry.k8s.io/pause:3.10\n",
+			want:     []string{"nginx:1.27", "registry.k8s.io/pause:3.10"},
+		},
+		{
+			name:     "comments and blank lines",
+			manifest: "# web\nnginx:1.27\n\n   \n  # sidecars\n  busybox:1.37  \n",
+			want:     []string{"nginx:1.27", "busybox:1.37"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "images.txt")
+			if err := os.WriteFile(path, []byte(tt.manifest), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadImageManifest(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadImageManifest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ReadImageManifest(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected an error for a missing manifest")
+	}
+}
+
+func TestRegistryImage(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "nginx:1.27", want: "localhost:5000/nginx:1.27"},
+		{image: "bitnami/nginx:1.27", want: "localhost:5000/bitnami/nginx:1.27"},
+		{image: "docker.io/library/nginx:1.27", want: "localhost:5000/library/nginx:1.27"},
+		{image: "registry.k8s.io/pause:3.10", want: "localhost:5000/pause:3.10"},
+		{image: "localhost/app:dev", want: "localhost:5000/app:dev"},
+		{image: "localhost:5001/app:dev", want: "localhost:5000/app:dev"},
+		{image: "ghcr.io/org/app@sha256:abc", want: "localhost:5000/org/app@sha256:abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := RegistryImage("localhost:5000", tt.image); got != tt.want {
+				t.Errorf("RegistryImage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageArchivePath(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "nginx:1.27", want: "nginx_1.27.tar"},
+		{image: "registry.k8s.io/pause:3.10", want: "registry.k8s.io_pause_3.10.tar"},
+		{image: "ghcr.io/org/app@sha256:abc", want: "ghcr.io_org_app_sha256_abc.tar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := imageArchivePath("cache", tt.image); got != filepath.Join("cache", tt.want) {
+				t.Errorf("imageArchivePath() = %q, want %q", got, filepath.Join("cache", tt.want))
+			}
+		})
+	}
+}
+
+func TestSaveImageArchive(t *testing.T) {
+	t.Run("complete", func(t *testing.T) {
+		dir := t.TempDir()
+		archive := imageArchivePath(dir, "nginx:1.27")
+		if err := saveImageArchive(context.TODO(), &fakeImageDistributor{}, "nginx:1.27", archive); err != nil {
+			t.Fatal(err)
+		}
+		if data, err := os.ReadFile(archive); err != nil || string(data) != "nginx:1.27" {
+			t.Errorf("expected the archive to be saved, got %q: %v", data, err)
+		}
+		assertCacheEntries(t, dir, filepath.Base(archive))
+	})
+	t.Run("failed", func(t *testing.T) {
+		dir := t.TempDir()
+		archive := imageArchivePath(dir, "nginx:1.27")
+		saveErr := errors.New("no space left on device")
+		if err := saveImageArchive(context.TODO(), &fakeImageDistributor{saveErr: saveErr}, "nginx:1.27", archive); !errors.Is(err, saveErr) {
+			t.Fatalf("saveImageArchive() error = %v, want %v", err, saveErr)
+		}
+		assertCacheEntries(t, dir)
+	})
+}
+
+func assertCacheEntries(t *testing.T, dir string, want ...string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected cache entries %q, got %q", want, got)
+	}
+}
@@ -19,9 +19,14 @@ package envfuncs
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient"
 	"sigs.k8s.io/e2e-framework/pkg/utils"
 
+	"sigs.k8s.io/e2e-framework/pkg/ctxutil"
 	"sigs.k8s.io/e2e-framework/pkg/env"
 	"sigs.k8s.io/e2e-framework/pkg/envconf"
 	"sigs.k8s.io/e2e-framework/support"
@@ -32,12 +37,16 @@ var LoadDockerImageToCluster = LoadImageToCluster
 // GetClusterFromContext helps extract the E2EClusterProvider object from the context.
 // This can be used to setup and run tests of multi cluster e2e Prioviders.
 func GetClusterFromContext(ctx context.Context, clusterName string) (support.E2EClusterProvider, bool) {
-	c := ctx.Value(support.ClusterNameContextKey(clusterName))
-	if c == nil {
-		return nil, false
-	}
-	cluster, ok := c.(support.E2EClusterProvider)
-	return cluster, ok
+	return ClusterFromContext[support.E2EClusterProvider](ctx, clusterName)
+}
+
+// ClusterFromContext is the typed variant of GetClusterFromContext. It returns the cluster
+// created with CreateCluster and the likes as the concrete type of its provider, such as
+// *kind.Cluster or *k3d.Cluster, without having to know the context key used to store it.
+//
+//	cluster, ok := envfuncs.ClusterFromContext[*kind.Cluster](ctx, clusterName)
+func ClusterFromContext[T support.E2EClusterProvider](ctx context.Context, clusterName string) (T, bool) {
+	return ctxutil.Load[T](ctx, support.ClusterNameContextKey(clusterName))
 }
 
 // CreateCluster returns an env.Func that is used to
@@ -53,16 +62,25 @@ func CreateCluster(p support.E2EClusterProvider, clusterName string) env.Func {
 // CreateClusterWithOpts returns an env.Func that is used to
 // create an E2E provider cluster that is then injected in the context
 // using the name as a key. This can be provided with additional opts to extend the create
-// workflow of the cluster.
+// workflow of the cluster. Typed options set with support.WithProviderOption are validated
+// against the options supported by the provider before the cluster is created, and the
+// cluster logs are exported on setup failures when support.WithExportLogsOnFailure is set.
 //
 // NOTE: the returned function will update its env config with the
 // kubeconfig file for the config client.
 func CreateClusterWithOpts(p support.E2EClusterProvider, clusterName string, opts ...support.ClusterOpts) env.Func {
 	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
-		k := p.SetDefaults().WithName(clusterName).WithOpts(opts...)
+		if skipInCluster(cfg, "create cluster", clusterName) {
+			return ctx, nil
+		}
+		k := p.SetDefaults().WithName(clusterName)
+		if err := support.ApplyProviderOptions(k, opts...); err != nil {
+			return ctx, fmt.Errorf("cluster %s: %w", clusterName, err)
+		}
+		k = k.WithOpts(opts...)
 		kubecfg, err := k.Create(ctx)
 		if err != nil {
-			return ctx, err
+			return ctx, exportLogsOnFailure(ctx, cfg, k, clusterName, err, opts...)
 		}
 
 		// update envconfig  with kubeconfig
@@ -70,7 +88,7 @@ func CreateClusterWithOpts(p support.E2EClusterProvider, clusterName string, opt
 
 		// stall, wait for pods initializations
 		if err := k.WaitForControlPlane(ctx, cfg.Client()); err != nil {
-			return ctx, err
+			return ctx, exportLogsOnFailure(ctx, cfg, k, clusterName, err, opts...)
 		}
 
 		// store entire cluster value in ctx for future access using the cluster name
@@ -86,10 +104,17 @@ func CreateClusterWithOpts(p support.E2EClusterProvider, clusterName string, opt
 // kubeconfig file for the config client.
 func CreateClusterWithConfig(p support.E2EClusterProvider, clusterName, configFilePath string, opts ...support.ClusterOpts) env.Func {
 	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
-		k := p.SetDefaults().WithName(clusterName).WithOpts(opts...)
+		if skipInCluster(cfg, "create cluster", clusterName) {
+			return ctx, nil
+		}
+		k := p.SetDefaults().WithName(clusterName)
+		if err := support.ApplyProviderOptions(k, opts...); err != nil {
+			return ctx, fmt.Errorf("cluster %s: %w", clusterName, err)
+		}
+		k = k.WithOpts(opts...)
 		kubecfg, err := k.CreateWithConfig(ctx, configFilePath)
 		if err != nil {
-			return ctx, err
+			return ctx, exportLogsOnFailure(ctx, cfg, k, clusterName, err, opts...)
 		}
 
 		// update envconfig  with kubeconfig
@@ -97,7 +122,7 @@ func CreateClusterWithConfig(p support.E2EClusterProvider, clusterName, configFi
 
 		// stall, wait for pods initializations
 		if err := k.WaitForControlPlane(ctx, cfg.Client()); err != nil {
-			return ctx, err
+			return ctx, exportLogsOnFailure(ctx, cfg, k, clusterName, err, opts...)
 		}
 
 		// store entire cluster value in ctx for future access using the cluster name
@@ -105,12 +130,39 @@ func CreateClusterWithConfig(p support.E2EClusterProvider, clusterName, configFi
 	}
 }
 
+// exportLogsOnFailure exports the logs of the cluster when opts contain support.WithExportLogsOnFailure
+// and returns the original cluster setup error. Failures to export the logs are only logged.
+func exportLogsOnFailure(ctx context.Context, cfg *envconf.Config, p support.E2EClusterProvider, clusterName string, err error, opts ...support.ClusterOpts) error {
+	dir, enabled := support.ExportLogsOnFailureDir(opts...)
+	if !enabled {
+		return err
+	}
+	if dir == "" {
+		dir = cfg.ArtifactsDir()
+	}
+	if dir == "" {
+		log.V(4).InfoS("Skipping export of cluster logs after setup failure: no artifacts directory configured", "cluster", clusterName)
+		return err
+	}
+	dest := filepath.Join(dir, clusterName)
+	log.V(4).InfoS("Exporting cluster logs after setup failure", "cluster", clusterName, "dest", dest)
+	if exportErr := p.ExportLogs(ctx, dest); exportErr != nil {
+		log.ErrorS(exportErr, "failed to export cluster logs after setup failure", "cluster", clusterName)
+	} else if redactErr := cfg.Redactor().RedactDir(dest); redactErr != nil {
+		log.ErrorS(redactErr, "failed to redact cluster logs after setup failure", "cluster", clusterName)
+	}
+	return err
+}
+
 // DestroyCluster returns an EnvFunc that
 // retrieves a previously saved e2e provider Cluster in the context (using the name), then deletes it.
 //
 // NOTE: this should be used in a Environment.Finish step.
 func DestroyCluster(name string) env.Func {
 	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		if skipInCluster(cfg, "destroy cluster", name) {
+			return ctx, nil
+		}
 		clusterVal := ctx.Value(support.ClusterNameContextKey(name))
 		if clusterVal == nil {
 			return ctx, fmt.Errorf("destroy e2e provider cluster func: context cluster is nil")
@@ -131,17 +183,16 @@ func DestroyCluster(name string) env.Func {
 
 // LoadImageToCluster returns an EnvFunc that
 // retrieves a previously saved e2e provider Cluster in the context (using the name), and then loads a container image
-// from the host into the cluster.
+// from the host into the cluster. It works with any provider implementing support.E2EClusterProviderWithImageLoader
+// whose cluster was created with CreateCluster or one of its variants.
 func LoadImageToCluster(name, image string, args ...string) env.Func {
 	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
-		clusterVal := ctx.Value(support.ClusterNameContextKey(name))
-		if clusterVal == nil {
-			return ctx, fmt.Errorf("load image func: context cluster is nil")
+		if skipInCluster(cfg, "load image", name) {
+			return ctx, nil
 		}
-
-		cluster, ok := clusterVal.(support.E2EClusterProviderWithImageLoader)
-		if !ok {
-			return ctx, fmt.Errorf("load image archive func: cluster provider does not support LoadImage helper")
+		cluster, err := clusterFromContextAs[support.E2EClusterProviderWithImageLoader](ctx, name, "loading images")
+		if err != nil {
+			return ctx, fmt.Errorf("load image func: %w", err)
 		}
 
 		if err := cluster.LoadImage(ctx, image, args...); err != nil {
@@ -154,21 +205,193 @@ func LoadImageToCluster(name, image string, args ...string) env.Func {
 
 // LoadImageArchiveToCluster returns an EnvFunc that
 // retrieves a previously saved e2e provider Cluster in the context (using the name), and then loads a container image TAR archive
-// from the host into the cluster.
+// from the host into the cluster. It works with any provider implementing support.E2EClusterProviderWithImageLoader
+// whose cluster was created with CreateCluster or one of its variants.
 func LoadImageArchiveToCluster(name, imageArchive string, args ...string) env.Func {
 	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		if skipInCluster(cfg, "load image archive", name) {
+			return ctx, nil
+		}
+		cluster, err := clusterFromContextAs[support.E2EClusterProviderWithImageLoader](ctx, name, "loading image archives")
+		if err != nil {
+			return ctx, fmt.Errorf("load image archive func: %w", err)
+		}
+
+		if err := cluster.LoadImageArchive(ctx, imageArchive, args...); err != nil {
+			return ctx, fmt.Errorf("load image archive: %w", err)
+		}
+
+		return ctx, nil
+	}
+}
+
+// skipInCluster reports whether a cluster provider operation is skipped because the tests run in
+// in-cluster mode, against the cluster they are deployed in, which is not managed by a provider
+func skipInCluster(cfg *envconf.Config, operation, clusterName string) bool {
+	if !cfg.InClusterMode() {
+		return false
+	}
+	log.V(2).InfoS("Skipping cluster provider operation in in-cluster mode", "operation", operation, "cluster", clusterName)
+	return true
+}
+
+// clusterFromContextAs returns the cluster stored in the context under name as the optional provider
+// interface T, or an error explaining why it is not available
+func clusterFromContextAs[T support.E2EClusterProvider](ctx context.Context, name, operation string) (T, error) {
+	var none T
+	cluster, ok := GetClusterFromContext(ctx, name)
+	if !ok {
+		return none, fmt.Errorf("cluster %s not found in context, it must be created with CreateCluster or one of its variants", name)
+	}
+	provider, ok := cluster.(T)
+	if !ok {
+		return none, fmt.Errorf("cluster %s: provider %T does not support %s", name, cluster, operation)
+	}
+	return provider, nil
+}
+
+// CreateLocalRegistry returns an EnvFunc that
+// retrieves a previously saved e2e provider Cluster in the context (using the name), and then starts a container
+// registry listening on localhost:port of the host and configures the cluster nodes to pull images from it.
+func CreateLocalRegistry(name string, port int) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		if skipInCluster(cfg, "create local registry", name) {
+			return ctx, nil
+		}
 		clusterVal := ctx.Value(support.ClusterNameContextKey(name))
 		if clusterVal == nil {
-			return ctx, fmt.Errorf("load image archive func: context cluster is nil")
+			return ctx, fmt.Errorf("create local registry func: context cluster is nil")
 		}
 
-		cluster, ok := clusterVal.(support.E2EClusterProviderWithImageLoader)
+		cluster, ok := clusterVal.(support.E2EClusterProviderWithLocalRegistry)
 		if !ok {
-			return ctx, fmt.Errorf("load image archive func: cluster provider does not support LoadImageArchive helper")
+			return ctx, fmt.Errorf("create local registry func: cluster provider does not support SetupLocalRegistry helper")
 		}
 
-		if err := cluster.LoadImageArchive(ctx, imageArchive, args...); err != nil {
-			return ctx, fmt.Errorf("load image archive: %w", err)
+		if _, err := cluster.SetupLocalRegistry(ctx, port); err != nil {
+			return ctx, fmt.Errorf("create local registry: %w", err)
+		}
+
+		return ctx, nil
+	}
+}
+
+// PauseCluster returns an EnvFunc that
+// retrieves a previously saved e2e provider Cluster in the context (using the name), and then freezes all
+// of its nodes, including the control plane, until ResumeCluster is invoked.
+func PauseCluster(name string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		cluster, err := pausableCluster(ctx, name)
+		if err != nil {
+			return ctx, fmt.Errorf("pause cluster func: %w", err)
+		}
+
+		if err := cluster.Pause(ctx); err != nil {
+			return ctx, fmt.Errorf("pause cluster: %w", err)
+		}
+
+		return ctx, nil
+	}
+}
+
+// ResumeCluster returns an EnvFunc that
+// retrieves a previously saved e2e provider Cluster in the context (using the name), and then unfreezes all
+// of its nodes frozen by PauseCluster.
+func ResumeCluster(name string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		cluster, err := pausableCluster(ctx, name)
+		if err != nil {
+			return ctx, fmt.Errorf("resume cluster func: %w", err)
+		}
+
+		if err := cluster.Resume(ctx); err != nil {
+			return ctx, fmt.Errorf("resume cluster: %w", err)
+		}
+
+		return ctx, nil
+	}
+}
+
+func pausableCluster(ctx context.Context, name string) (support.E2EClusterProviderWithPause, error) {
+	clusterVal := ctx.Value(support.ClusterNameContextKey(name))
+	if clusterVal == nil {
+		return nil, fmt.Errorf("context cluster is nil")
+	}
+
+	cluster, ok := clusterVal.(support.E2EClusterProviderWithPause)
+	if !ok {
+		return nil, fmt.Errorf("cluster provider does not support Pause and Resume helpers")
+	}
+	return cluster, nil
+}
+
+// SnapshotCluster returns an EnvFunc that
+// retrieves a previously saved e2e provider Cluster in the context (using the name), and then saves the
+// state of the cluster into the path on the host. Whether the path is a file or a directory depends on
+// the provider, see support.E2EClusterProviderWithSnapshot.
+func SnapshotCluster(name, path string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		cluster, err := snapshotCluster(ctx, name)
+		if err != nil {
+			return ctx, fmt.Errorf("snapshot cluster func: %w", err)
+		}
+
+		if err := cluster.Snapshot(ctx, path); err != nil {
+			return ctx, fmt.Errorf("snapshot cluster: %w", err)
+		}
+
+		return ctx, nil
+	}
+}
+
+// RestoreCluster returns an EnvFunc that
+// retrieves a previously saved e2e provider Cluster in the context (using the name), and then resets the
+// state of the cluster to the snapshot saved into the path by SnapshotCluster.
+func RestoreCluster(name, path string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		cluster, err := snapshotCluster(ctx, name)
+		if err != nil {
+			return ctx, fmt.Errorf("restore cluster func: %w", err)
+		}
+
+		if err := cluster.Restore(ctx, path); err != nil {
+			return ctx, fmt.Errorf("restore cluster: %w", err)
+		}
+
+		return ctx, nil
+	}
+}
+
+func snapshotCluster(ctx context.Context, name string) (support.E2EClusterProviderWithSnapshot, error) {
+	clusterVal := ctx.Value(support.ClusterNameContextKey(name))
+	if clusterVal == nil {
+		return nil, fmt.Errorf("context cluster is nil")
+	}
+
+	cluster, ok := clusterVal.(support.E2EClusterProviderWithSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("cluster provider does not support Snapshot and Restore helpers")
+	}
+	return cluster, nil
+}
+
+// ScaleClusterNodes returns an EnvFunc that
+// retrieves a previously saved e2e provider Cluster in the context (using the name), and then changes the
+// number of nodes of the cluster to replicas.
+func ScaleClusterNodes(name string, replicas int, args ...string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		clusterVal := ctx.Value(support.ClusterNameContextKey(name))
+		if clusterVal == nil {
+			return ctx, fmt.Errorf("scale cluster nodes func: context cluster is nil")
+		}
+
+		cluster, ok := clusterVal.(support.E2EClusterProviderWithNodeScaling)
+		if !ok {
+			return ctx, fmt.Errorf("scale cluster nodes func: cluster provider does not support ScaleNodes helper")
+		}
+
+		if err := cluster.ScaleNodes(ctx, replicas, args...); err != nil {
+			return ctx, fmt.Errorf("scale cluster nodes: %w", err)
 		}
 
 		return ctx, nil
@@ -177,9 +400,14 @@ func LoadImageArchiveToCluster(name, imageArchive string, args ...string) env.Fu
 
 // ExportClusterLogs returns an EnvFunc that
 // retrieves a previously saved e2e provider Cluster in the context (using the name), and then export cluster logs
-// in the provided destination.
+// in the provided destination. An empty destination exports the logs into a sub-directory of the artifacts
+// directory of the environment configuration named after the cluster. The patterns of the redactor of the
+// configuration are removed from the exported logs.
 func ExportClusterLogs(name, dest string) env.Func {
 	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		if skipInCluster(cfg, "export cluster logs", name) {
+			return ctx, nil
+		}
 		clusterVal := ctx.Value(support.ClusterNameContextKey(name))
 		if clusterVal == nil {
 			return ctx, fmt.Errorf("export e2e provider cluster logs: context cluster is nil")
@@ -190,18 +418,67 @@ func ExportClusterLogs(name, dest string) env.Func {
 			return ctx, fmt.Errorf("export e2e provider cluster logs: unexpected type for cluster value")
 		}
 
+		if dest == "" {
+			path, err := cfg.ArtifactPath("", name)
+			if err != nil {
+				return ctx, fmt.Errorf("export e2e provider cluster logs: %w", err)
+			}
+			dest = path
+		}
+
 		if err := cluster.ExportLogs(ctx, dest); err != nil {
-			return ctx, fmt.Errorf("load image archive: %w", err)
+			return ctx, fmt.Errorf("export e2e provider cluster logs: %w", err)
+		}
+
+		if err := cfg.Redactor().RedactDir(dest); err != nil {
+			return ctx, fmt.Errorf("export e2e provider cluster logs: %w", err)
+		}
+
+		return ctx, nil
+	}
+}
+
+// UpgradeCluster returns an EnvFunc that retrieves a previously saved e2e provider Cluster in the context (using
+// the name) and upgrades it to targetVersion, e.g. v1.31.0, which can be used to validate workloads across
+// control plane upgrades in the middle of a test suite. The provider must implement
+// support.E2EClusterProviderWithUpgrade, and may recreate the cluster, see its documentation.
+//
+// NOTE: the returned function will update its env config with the
+// kubeconfig file and the client of the upgraded cluster.
+func UpgradeCluster(name, targetVersion string) env.Func {
+	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		cluster, err := clusterFromContextAs[support.E2EClusterProviderWithUpgrade](ctx, name, "upgrades")
+		if err != nil {
+			return ctx, err
 		}
+		kubecfg, err := cluster.Upgrade(ctx, targetVersion)
+		if err != nil {
+			return ctx, fmt.Errorf("upgrade cluster %s to %s: %w", name, targetVersion, err)
+		}
+
+		// the upgraded cluster may be served on another endpoint, drop the client of the previous one
+		cfg.WithKubeconfigFile(kubecfg)
+		client, err := klient.NewWithKubeConfigFile(kubecfg)
+		if err != nil {
+			return ctx, fmt.Errorf("upgrade cluster %s: %w", name, err)
+		}
+		cfg.WithClient(client)
 
+		if err := cluster.WaitForControlPlane(ctx, client); err != nil {
+			return ctx, fmt.Errorf("upgrade cluster %s: %w", name, err)
+		}
 		return ctx, nil
 	}
 }
 
 // PerformNodeOperation returns an EnvFunc that can be used to perform some node lifecycle operations.
-// This can be used to add/remove/start/stop nodes in the cluster.
+// This can be used to add/remove/start/stop nodes in the cluster. The node is operated on in the cluster
+// stored in the context under clusterName unless node.Cluster is set.
 func PerformNodeOperation(clusterName string, action support.NodeOperation, node *support.Node, args ...string) env.Func {
 	return func(ctx context.Context, cfg *envconf.Config) (context.Context, error) {
+		if node.Cluster == "" {
+			node.Cluster = clusterName
+		}
 		err := utils.PerformNodeLifecycleOperation(ctx, action, node, args...)
 		return ctx, err
 	}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:6443
+  name: upgraded
+contexts:
+- context:
+    cluster: upgraded
+    user: admin
+  name: upgraded
+current-context: upgraded
+users:
+- name: admin
+  user:
+    token: secret
+`
+
+type fakeUpgradableProvider struct {
+	support.E2EClusterProvider
+	kubeconfig string
+	version    string
+	waited     bool
+}
+
+func (f *fakeUpgradableProvider) Upgrade(_ context.Context, version string) (string, error) {
+	f.version = version
+	return f.kubeconfig, nil
+}
+
+func (f *fakeUpgradableProvider) WaitForControlPlane(context.Context, klient.Client) error {
+	f.waited = true
+	return nil
+}
+
+func TestUpgradeCluster(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(kubeconfig, []byte(testKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider := &fakeUpgradableProvider{kubeconfig: kubeconfig}
+	ctx := context.WithValue(context.TODO(), support.ClusterNameContextKey("test"), support.E2EClusterProvider(provider))
+	ctx = context.WithValue(ctx, support.ClusterNameContextKey("legacy"), support.E2EClusterProvider(&fakeLifecycleProvider{}))
+	cfg := envconf.New()
+
+	if _, err := UpgradeCluster("test", "v1.31.0")(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if provider.version != "v1.31.0" || !provider.waited {
+		t.Errorf("expected the cluster to be upgraded to v1.31.0 and waited for, got version %q, waited %v", provider.version, provider.waited)
+	}
+	if cfg.KubeconfigFile() != kubeconfig {
+		t.Errorf("expected the kubeconfig file to be updated, got %q", cfg.KubeconfigFile())
+	}
+	if host := cfg.Client().RESTConfig().Host; host != "https://127.0.0.1:6443" {
+		t.Errorf("expected the client of the upgraded cluster, got host %q", host)
+	}
+
+	if _, err := UpgradeCluster("legacy", "v1.31.0")(ctx, cfg); err == nil {
+		t.Error("expected an error for a provider without upgrade support")
+	}
+}
+
+type failingProvider struct {
+	support.E2EClusterProvider
+}
+
+func (f *failingProvider) SetDefaults() support.E2EClusterProvider    { return f }
+func (f *failingProvider) WithName(string) support.E2EClusterProvider { return f }
+func (f *failingProvider) Create(context.Context, ...string) (string, error) {
+	return "", errors.New("no provider in cluster")
+}
+
+func TestProviderFuncsInClusterMode(t *testing.T) {
+	cfg := envconf.New().WithInClusterMode()
+	for name, fn := range map[string]env.Func{
+		"create":     CreateCluster(&failingProvider{}, "test"),
+		"load image": LoadImageToCluster("test", "nginx"),
+		"destroy":    DestroyCluster("test"),
+	} {
+		if _, err := fn(context.TODO(), cfg); err != nil {
+			t.Errorf("%s: expected the provider func to be skipped in in-cluster mode, got %v", name, err)
+		}
+	}
+}
+
+type logExportingProvider struct {
+	support.E2EClusterProvider
+}
+
+func (p *logExportingProvider) ExportLogs(_ context.Context, dest string) error {
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dest, "apiserver.log"), []byte("token=s3cr3t\n"), 0o600)
+}
+
+func TestExportClusterLogsRedacted(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.WithValue(context.TODO(), support.ClusterNameContextKey("test"), support.E2EClusterProvider(&logExportingProvider{}))
+	cfg := envconf.New().WithArtifactsDir(dir).
+		WithRedactor(k8s.NewRedactor(k8s.RedactPatterns(regexp.MustCompile(`s3cr3t`))))
+
+	if _, err := ExportClusterLogs("test", "")(ctx, cfg); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "test", "apiserver.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "token="+k8s.Redacted+"\n" {
+		t.Errorf("expected the exported logs to be redacted, got %q", data)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/decoder"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// WaitStrategy decides how SetupWorkload waits for the objects it created. It receives all the objects that
+// were created, in the order of the manifests.
+type WaitStrategy func(ctx context.Context, r *resources.Resources, objs []k8s.Object) error
+
+// NoWait is a WaitStrategy that returns as soon as the objects are created
+func NoWait() WaitStrategy {
+	return func(ctx context.Context, r *resources.Resources, objs []k8s.Object) error {
+		return nil
+	}
+}
+
+// WaitForReady is a WaitStrategy that waits up to timeout for all the Deployments, StatefulSets and DaemonSets
+// among the created objects to be rolled out and ready. If some of them are not ready in time, the returned
+// error describes the state of each of them.
+func WaitForReady(timeout time.Duration) WaitStrategy {
+	return func(ctx context.Context, r *resources.Resources, objs []k8s.Object) error {
+		pending := map[k8s.Object]string{}
+		for _, obj := range objs {
+			switch obj.(type) {
+			case *appsv1.Deployment, *appsv1.StatefulSet, *appsv1.DaemonSet:
+				pending[obj] = "not observed yet"
+			}
+		}
+
+		err := wait.For(func(ctx context.Context) (bool, error) {
+			for obj := range pending {
+				if err := r.Get(ctx, obj.GetName(), obj.GetNamespace(), obj); err != nil {
+					pending[obj] = err.Error()
+					continue
+				}
+				if ready, reason := workloadReady(obj); ready {
+					log.V(4).InfoS("Workload ready", "kind", workloadKind(obj), "namespace", obj.GetNamespace(), "name", obj.GetName())
+					delete(pending, obj)
+				} else {
+					pending[obj] = reason
+				}
+			}
+			return len(pending) == 0, nil
+		}, wait.WithContext(ctx), wait.WithTimeout(timeout), wait.WithInterval(2*time.Second), wait.WithImmediate())
+		if err == nil {
+			return nil
+		}
+
+		errs := make([]error, 0, len(pending))
+		for _, obj := range objs {
+			if reason, ok := pending[obj]; ok {
+				errs = append(errs, fmt.Errorf("%s %s/%s is not ready: %s", workloadKind(obj), obj.GetNamespace(), obj.GetName(), reason))
+			}
+		}
+		return fmt.Errorf("workloads not ready after %s: %w", timeout, errors.Join(errs...))
+	}
+}
+
+// SetupWorkload returns an env.Func that creates the objects found in the manifests of dirPath matching the
+// pattern, then waits for them according to the wait strategy, typically WaitForReady. Decode options, such
+// as decoder.MutateNamespace, are applied to the objects before they are created.
+//
+//	testenv.Setup(
+//		envfuncs.CreateNamespace(namespace),
+//		envfuncs.SetupWorkload("testdata/app", "*.yaml", envfuncs.WaitForReady(2*time.Minute), decoder.MutateNamespace(namespace)),
+//	)
+func SetupWorkload(dirPath, pattern string, waitStrategy WaitStrategy, options ...decoder.DecodeOption) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		r, err := resources.New(c.Client().RESTConfig())
+		if err != nil {
+			return ctx, err
+		}
+
+		var objs []k8s.Object
+		create := decoder.CreateHandler(r)
+		err = decoder.DecodeEachFile(ctx, os.DirFS(dirPath), pattern, func(ctx context.Context, obj k8s.Object) error {
+			if err := create(ctx, obj); err != nil {
+				return err
+			}
+			objs = append(objs, obj)
+			return nil
+		}, options...)
+		if err != nil {
+			return ctx, fmt.Errorf("setup workload %s: %w", dirPath, err)
+		}
+
+		if waitStrategy == nil {
+			return ctx, nil
+		}
+		if err := waitStrategy(ctx, r, objs); err != nil {
+			return ctx, fmt.Errorf("setup workload %s: %w", dirPath, err)
+		}
+		return ctx, nil
+	}
+}
+
+// TeardownWorkload returns an env.Func that deletes the objects found in the manifests of dirPath matching
+// the pattern. It is the counterpart of SetupWorkload, and should be given the same decode options.
+func TeardownWorkload(dirPath, pattern string, options ...decoder.DecodeOption) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		r, err := resources.New(c.Client().RESTConfig())
+		if err != nil {
+			return ctx, err
+		}
+		return ctx, decoder.DeleteWithManifestDir(ctx, r, dirPath, pattern, []resources.DeleteOption{}, options...)
+	}
+}
+
+// workloadReady checks if a workload is rolled out and ready, and otherwise describes why it is not
+func workloadReady(obj k8s.Object) (bool, string) {
+	switch w := obj.(type) {
+	case *appsv1.Deployment:
+		replicas := int32(1)
+		if w.Spec.Replicas != nil {
+			replicas = *w.Spec.Replicas
+		}
+		status := w.Status
+		if status.ObservedGeneration < w.Generation {
+			return false, "spec update not observed yet"
+		}
+		if status.UpdatedReplicas < replicas || status.AvailableReplicas < replicas || status.Replicas > status.UpdatedReplicas {
+			return false, fmt.Sprintf("%d/%d replicas updated, %d/%d available%s",
+				status.UpdatedReplicas, replicas, status.AvailableReplicas, replicas, deploymentConditions(w))
+		}
+	case *appsv1.StatefulSet:
+		replicas := int32(1)
+		if w.Spec.Replicas != nil {
+			replicas = *w.Spec.Replicas
+		}
+		status := w.Status
+		if status.ObservedGeneration < w.Generation {
+			return false, "spec update not observed yet"
+		}
+		if status.ReadyReplicas < replicas || status.UpdatedReplicas < replicas {
+			return false, fmt.Sprintf("%d/%d replicas updated, %d/%d ready", status.UpdatedReplicas, replicas, status.ReadyReplicas, replicas)
+		}
+	case *appsv1.DaemonSet:
+		status := w.Status
+		if status.ObservedGeneration < w.Generation {
+			return false, "spec update not observed yet"
+		}
+		if status.UpdatedNumberScheduled < status.DesiredNumberScheduled || status.NumberAvailable < status.DesiredNumberScheduled {
+			return false, fmt.Sprintf("%d/%d pods updated, %d/%d available",
+				status.UpdatedNumberScheduled, status.DesiredNumberScheduled, status.NumberAvailable, status.DesiredNumberScheduled)
+		}
+	}
+	return true, ""
+}
+
+// deploymentConditions describes the conditions of a deployment that are not true
+func deploymentConditions(d *appsv1.Deployment) string {
+	var description string
+	for _, cond := range d.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue && cond.Message != "" {
+			description += fmt.Sprintf(", %s: %s", cond.Type, cond.Message)
+		}
+	}
+	return description
+}
+
+func workloadKind(obj k8s.Object) string {
+	switch obj.(type) {
+	case *appsv1.Deployment:
+		return "Deployment"
+	case *appsv1.StatefulSet:
+		return "StatefulSet"
+	case *appsv1.DaemonSet:
+		return "DaemonSet"
+	}
+	return fmt.Sprintf("%T", obj)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envfuncs
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+)
+
+func TestWorkloadReady(t *testing.T) {
+	replicas := int32(2)
+	tests := []struct {
+		name   string
+		obj    k8s.Object
+		ready  bool
+		reason string
+	}{
+		{
+			name: "deployment available",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			},
+			ready: true,
+		},
+		{
+			name: "deployment rolling out",
+			obj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+			},
+			reason: "2/2 replicas updated, 1/2 available",
+		},
+		{
+			name: "statefulset generation not observed",
+			obj: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.StatefulSetStatus{ObservedGeneration: 1},
+			},
+			reason: "spec update not observed yet",
+		},
+		{
+			name: "daemonset scheduling",
+			obj: &appsv1.DaemonSet{
+				Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 2},
+			},
+			reason: "3/3 pods updated, 2/3 available",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ready, reason := workloadReady(test.obj)
+			if ready != test.ready || reason != test.reason {
+				t.Errorf("workloadReady() = %v, %q, want %v, %q", ready, reason, test.ready, test.reason)
+			}
+		})
+	}
+}
@@ -42,6 +42,78 @@ func (b *FeatureBuilder) WithLabel(key, value string) *FeatureBuilder {
 	return b
 }
 
+// WithDescription sets the description of the feature, which provides a readable context for
+// the feature in the reports and in the catalog of the features
+func (b *FeatureBuilder) WithDescription(description string) *FeatureBuilder {
+	b.feat.description = description
+	return b
+}
+
+// WithID sets the identifier of the test case of the feature in a test management system
+func (b *FeatureBuilder) WithID(id string) *FeatureBuilder {
+	b.feat.metadata.ID = id
+	return b
+}
+
+// WithOwner adds the people or teams owning the feature
+func (b *FeatureBuilder) WithOwner(owners ...string) *FeatureBuilder {
+	b.feat.metadata.Owners = append(b.feat.metadata.Owners, owners...)
+	return b
+}
+
+// WithLink adds a link to a document related to the feature, such as a specification or an issue
+func (b *FeatureBuilder) WithLink(url string) *FeatureBuilder {
+	b.feat.metadata.Links = append(b.feat.metadata.Links, url)
+	return b
+}
+
+// WithOrder sets the execution order of the feature. When the environment is configured to
+// sort the features, features with a lower order are executed first. The default order is 0.
+func (b *FeatureBuilder) WithOrder(order int) *FeatureBuilder {
+	b.feat.order = order
+	return b
+}
+
+// WithPriority sets the execution priority of the feature. When the environment is configured
+// to sort the features, features with a higher priority are executed first. This is the
+// equivalent of WithOrder(-priority).
+func (b *FeatureBuilder) WithPriority(priority int) *FeatureBuilder {
+	return b.WithOrder(-priority)
+}
+
+// DependsOn declares that the feature depends on the features identified by names. When the
+// features are passed to the same Test or TestInParallel call, the feature is executed once its
+// dependencies are done and it is skipped if any of them fails or is skipped.
+func (b *FeatureBuilder) DependsOn(names ...string) *FeatureBuilder {
+	b.feat.dependencies = append(b.feat.dependencies, names...)
+	return b
+}
+
+// RequiresFixture declares that the feature requires the fixtures identified by names, which are
+// registered with the Fixture method of the environment. The fixtures are set up before the
+// BeforeEachFeature functions of the first feature requiring them, and torn down at the end of
+// the test suite, so expensive dependencies such as operators are only installed once.
+func (b *FeatureBuilder) RequiresFixture(names ...string) *FeatureBuilder {
+	b.feat.fixtures = append(b.feat.fixtures, names...)
+	return b
+}
+
+// WithMinKubeVersion sets the minimum Kubernetes version supported by the feature, such as
+// "1.29". The feature is skipped when run against a cluster with an older API server.
+func (b *FeatureBuilder) WithMinKubeVersion(version string) *FeatureBuilder {
+	b.feat.minVersion = version
+	return b
+}
+
+// WithMaxKubeVersion sets the maximum Kubernetes version supported by the feature, such as
+// "1.31". When only the major and minor versions are given, all the patch releases of that
+// minor version are supported. The feature is skipped when run against a cluster with a newer
+// API server.
+func (b *FeatureBuilder) WithMaxKubeVersion(version string) *FeatureBuilder {
+	b.feat.maxVersion = version
+	return b
+}
+
 // WithStep adds a new step that will be applied prior to feature test.
 func (b *FeatureBuilder) WithStep(name string, level Level, fn Func) *FeatureBuilder {
 	b.feat.steps = append(b.feat.steps, newStep(name, level, fn))
@@ -84,6 +156,42 @@ func (b *FeatureBuilder) AssessWithDescription(name, description string, fn Func
 	return b.WithStepDescription(name, description, LevelAssess, fn)
 }
 
+// Skip marks the last added assessment as skipped. The assessment is not executed and is
+// reported as skipped with the given reason, which allows known-broken assessments to stay
+// in the code instead of being commented out.
+func (b *FeatureBuilder) Skip(reason string) *FeatureBuilder {
+	return b.SkipIf(Always, reason)
+}
+
+// SkipIf marks the last added assessment to be skipped with the given reason when cond is
+// true. The condition is evaluated right before the assessment would run.
+func (b *FeatureBuilder) SkipIf(cond Condition, reason string) *FeatureBuilder {
+	if step := b.lastAssessment(); step != nil {
+		step.skipIf = cond
+		step.skipReason = reason
+	}
+	return b
+}
+
+// ExpectFail marks the last added assessment as a known failure. The assessment is not
+// executed and is reported as a skipped expected failure with the given reason.
+func (b *FeatureBuilder) ExpectFail(reason string) *FeatureBuilder {
+	if step := b.lastAssessment(); step != nil {
+		step.xfailReason = reason
+	}
+	return b
+}
+
+// lastAssessment returns the most recently added assessment step, if any
+func (b *FeatureBuilder) lastAssessment() *testStep {
+	for i := len(b.feat.steps) - 1; i >= 0; i-- {
+		if step, ok := b.feat.steps[i].(*testStep); ok && step.level == LevelAssess {
+			return step
+		}
+	}
+	return nil
+}
+
 // Feature returns a feature configured by builder.
 func (b *FeatureBuilder) Feature() types.Feature {
 	return b.feat
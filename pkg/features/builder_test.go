@@ -55,6 +55,45 @@ func TestFeatureBuilder(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "with order and priority",
+			setup: func(t *testing.T) types.Feature {
+				return New("ordered").WithOrder(3).WithPriority(5).Feature()
+			},
+			eval: func(t *testing.T, f types.Feature) {
+				ft, ok := f.(types.OrderedFeature)
+				if !ok {
+					t.Fatal("expected feature to implement types.OrderedFeature")
+				}
+				if ft.Order() != -5 {
+					t.Error("unexpected feature order:", ft.Order())
+				}
+			},
+		},
+		{
+			name: "with metadata",
+			setup: func(t *testing.T) types.Feature {
+				return New("traced").
+					WithDescription("checks the traced feature").
+					WithID("TC-42").
+					WithOwner("sig-testing", "qa").
+					WithLink("https://example.com/spec").
+					Feature()
+			},
+			eval: func(t *testing.T, f types.Feature) {
+				if desc := f.(types.DescribableFeature).Description(); desc != "checks the traced feature" {
+					t.Errorf("unexpected description %q", desc)
+				}
+				ft, ok := f.(types.MetadataFeature)
+				if !ok {
+					t.Fatal("expected feature to implement types.MetadataFeature")
+				}
+				md := ft.Metadata()
+				if md.ID != "TC-42" || len(md.Owners) != 2 || md.Owners[1] != "qa" || len(md.Links) != 1 || md.Links[0] != "https://example.com/spec" {
+					t.Errorf("unexpected metadata %+v", md)
+				}
+			},
+		},
 		{
 			name: "with labels",
 			setup: func(t *testing.T) types.Feature {
@@ -262,3 +301,31 @@ func TestFeatureBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestFeatureBuilder_SkipAndExpectFail(t *testing.T) {
+	noop := func(ctx context.Context, t *testing.T, _ *envconf.Config) context.Context { return ctx }
+	f := New("test").
+		Assess("skipped", noop).Skip("broken upstream").
+		Assess("conditional", noop).SkipIf(func(context.Context, *envconf.Config) bool { return false }, "never").
+		Assess("xfail", noop).ExpectFail("known bug").
+		Teardown(noop).Skip("ignored").
+		Feature()
+
+	steps := f.Steps()
+	if skip, reason := steps[0].(types.SkippableStep).ShouldSkip(context.TODO(), envconf.New()); !skip || reason != "broken upstream" {
+		t.Errorf("expected first assessment to be skipped, got %v %q", skip, reason)
+	}
+	if skip, _ := steps[1].(types.SkippableStep).ShouldSkip(context.TODO(), envconf.New()); skip {
+		t.Error("expected second assessment not to be skipped")
+	}
+	if reason := steps[2].(types.ExpectedFailureStep).ExpectedFailure(); reason != "known bug" {
+		t.Errorf("unexpected expected failure reason %q", reason)
+	}
+	// Skip applies to the last assessment, not to the teardown that follows it
+	if skip, reason := steps[2].(types.SkippableStep).ShouldSkip(context.TODO(), envconf.New()); !skip || reason != "ignored" {
+		t.Errorf("expected last assessment to be skipped, got %v %q", skip, reason)
+	}
+	if skip, _ := steps[3].(types.SkippableStep).ShouldSkip(context.TODO(), envconf.New()); skip {
+		t.Error("expected teardown not to be marked as skipped")
+	}
+}
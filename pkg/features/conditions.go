@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/version"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// Condition is evaluated right before an assessment runs to decide whether it is skipped
+type Condition func(ctx context.Context, cfg *envconf.Config) bool
+
+// Always is a Condition that is always true
+func Always(context.Context, *envconf.Config) bool {
+	return true
+}
+
+// KubernetesVersionLessThan returns a Condition that is true when the version of the API server
+// of the cluster is lower than the given version, such as "1.30". The condition is false when the
+// version of the server cannot be determined.
+func KubernetesVersionLessThan(v string) Condition {
+	minVersion := version.MustParseGeneric(v)
+	return func(_ context.Context, cfg *envconf.Config) bool {
+		serverVersion, err := cfg.ServerVersion()
+		if err != nil {
+			log.V(4).ErrorS(err, "Failed to determine the server version")
+			return false
+		}
+		return serverVersion.LessThan(minVersion)
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package features
 
 import (
+	"context"
 	"regexp"
 
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
 	"sigs.k8s.io/e2e-framework/pkg/types"
 )
 
@@ -40,10 +42,16 @@ const (
 )
 
 type defaultFeature struct {
-	name        string
-	description string
-	labels      types.Labels
-	steps       []types.Step
+	name         string
+	description  string
+	labels       types.Labels
+	steps        []types.Step
+	order        int
+	dependencies []string
+	fixtures     []string
+	minVersion   string
+	maxVersion   string
+	metadata     types.FeatureMetadata
 }
 
 func newDefaultFeature(name, description string) *defaultFeature {
@@ -66,11 +74,34 @@ func (f *defaultFeature) Description() string {
 	return f.description
 }
 
+func (f *defaultFeature) Order() int {
+	return f.order
+}
+
+func (f *defaultFeature) Dependencies() []string {
+	return f.dependencies
+}
+
+func (f *defaultFeature) Fixtures() []string {
+	return f.fixtures
+}
+
+func (f *defaultFeature) KubeVersionRange() (minVersion, maxVersion string) {
+	return f.minVersion, f.maxVersion
+}
+
+func (f *defaultFeature) Metadata() types.FeatureMetadata {
+	return f.metadata
+}
+
 type testStep struct {
 	name        string
 	description string
 	level       Level
 	fn          Func
+	skipIf      Condition
+	skipReason  string
+	xfailReason string
 }
 
 func newStep(name string, level Level, fn Func) *testStep {
@@ -102,6 +133,17 @@ func (s *testStep) Description() string {
 	return s.description
 }
 
+func (s *testStep) ShouldSkip(ctx context.Context, cfg *envconf.Config) (bool, string) {
+	if s.skipIf == nil || !s.skipIf(ctx, cfg) {
+		return false, ""
+	}
+	return true, s.skipReason
+}
+
+func (s *testStep) ExpectedFailure() string {
+	return s.xfailReason
+}
+
 func GetStepsByLevel(steps []types.Step, l types.Level) []types.Step {
 	if steps == nil {
 		return nil
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	log "k8s.io/klog/v2"
+)
+
+// Formats of the messages of the step loggers
+const (
+	// LogFormatText writes the messages as text prefixed with the feature and step names
+	LogFormatText = "text"
+	// LogFormatJSON writes each message as a JSON object, which is easier to ingest in CI systems
+	LogFormatJSON = "json"
+)
+
+type loggerContextKey struct{}
+
+// testLogger is the part of testing.T used by the loggers
+type testLogger interface {
+	Helper()
+	Log(args ...interface{})
+}
+
+// Logger writes the messages of a step through the testing.T running the step, so that they are
+// attributed to the feature and the assessment in the test output, even when features run in
+// parallel. The messages are prefixed with the names of the feature and the step.
+type Logger struct {
+	t       testLogger
+	feature string
+	step    string
+	json    bool
+	values  []interface{}
+}
+
+// NewLogger returns a Logger writing the messages of the step of the feature through t, in the
+// given format, LogFormatText or LogFormatJSON
+func NewLogger(t *testing.T, format, feature, step string) *Logger {
+	logger := &Logger{feature: feature, step: step, json: format == LogFormatJSON}
+	if t != nil {
+		logger.t = t
+	}
+	return logger
+}
+
+// WithLogger returns a copy of ctx holding the logger, which is returned by LoggerFrom
+func WithLogger(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// LoggerFrom returns the logger of the step running with ctx, set by the environment before
+// running each step. Outside of a step, the returned logger writes the messages with klog.
+func LoggerFrom(ctx context.Context) *Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*Logger); ok && logger != nil {
+		return logger
+	}
+	return &Logger{}
+}
+
+// WithValues returns a logger adding the key/value pairs to all its messages
+func (l *Logger) WithValues(keysAndValues ...interface{}) *Logger {
+	logger := *l
+	logger.values = append(append([]interface{}{}, l.values...), keysAndValues...)
+	return &logger
+}
+
+// Info logs a message with the key/value pairs
+func (l *Logger) Info(msg string, keysAndValues ...interface{}) {
+	if l.t == nil {
+		log.InfoS(msg, append(append([]interface{}{}, l.values...), keysAndValues...)...)
+		return
+	}
+	l.t.Helper()
+	l.t.Log(l.format("info", msg, keysAndValues))
+}
+
+// Error logs an error message with the key/value pairs. It does not fail the test.
+func (l *Logger) Error(err error, msg string, keysAndValues ...interface{}) {
+	if l.t == nil {
+		log.ErrorS(err, msg, append(append([]interface{}{}, l.values...), keysAndValues...)...)
+		return
+	}
+	l.t.Helper()
+	l.t.Log(l.format("error", msg, append([]interface{}{"err", err}, keysAndValues...)))
+}
+
+// format returns the message with the values of the logger and the key/value pairs
+func (l *Logger) format(level, msg string, keysAndValues []interface{}) string {
+	kvs := append(append([]interface{}{}, l.values...), keysAndValues...)
+	if len(kvs)%2 != 0 {
+		kvs = append(kvs, "(MISSING)")
+	}
+	if l.json {
+		return l.formatJSON(level, msg, kvs)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s/%s] ", l.feature, l.step)
+	if level != "info" {
+		fmt.Fprintf(&b, "%s: ", strings.ToUpper(level))
+	}
+	b.WriteString(msg)
+	for i := 0; i < len(kvs); i += 2 {
+		fmt.Fprintf(&b, " %v=%s", kvs[i], formatValue(kvs[i+1]))
+	}
+	return b.String()
+}
+
+func (l *Logger) formatJSON(level, msg string, kvs []interface{}) string {
+	var b bytes.Buffer
+	b.WriteString("{")
+	writeJSONField(&b, "level", level, true)
+	writeJSONField(&b, "feature", l.feature, false)
+	writeJSONField(&b, "step", l.step, false)
+	writeJSONField(&b, "msg", msg, false)
+	for i := 0; i < len(kvs); i += 2 {
+		writeJSONField(&b, fmt.Sprint(kvs[i]), kvs[i+1], false)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// writeJSONField writes the key/value pair of a JSON object, values that cannot be encoded as
+// JSON, such as errors, are written as strings
+func writeJSONField(b *bytes.Buffer, key string, value interface{}, first bool) {
+	if !first {
+		b.WriteString(",")
+	}
+	k, _ := json.Marshal(key)
+	b.Write(k)
+	b.WriteString(":")
+	if err, ok := value.(error); ok {
+		value = errorString(err)
+	}
+	v, err := json.Marshal(value)
+	if err != nil {
+		v, _ = json.Marshal(fmt.Sprint(value))
+	}
+	b.Write(v)
+}
+
+// formatValue formats a value of a text message, quoting strings that contain spaces
+func formatValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case error:
+		s = errorString(v)
+	case string:
+		s = v
+	default:
+		s = fmt.Sprint(v)
+	}
+	if strings.ContainsAny(s, " \t\n\"=") || s == "" {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// fakeT records the messages logged through it
+type fakeT struct {
+	messages []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Log(args ...interface{}) {
+	f.messages = append(f.messages, fmt.Sprint(args...))
+}
+
+func TestLogger(t *testing.T) {
+	tests := []struct {
+		name string
+		json bool
+		log  func(l *Logger)
+		want string
+	}{
+		{
+			name: "text",
+			log:  func(l *Logger) { l.Info("pod ready", "pod", "nginx", "phase", "Running now") },
+			want: `[deploy/wait/for pods] pod ready pod=nginx phase="Running now"`,
+		},
+		{
+			name: "text error with values",
+			log: func(l *Logger) {
+				l.WithValues("namespace", "e2e").Error(errors.New("not found"), "get failed", "attempt", 2)
+			},
+			want: `[deploy/wait/for pods] ERROR: get failed namespace=e2e err="not found" attempt=2`,
+		},
+		{
+			name: "text missing value",
+			log:  func(l *Logger) { l.Info("odd", "key") },
+			want: `[deploy/wait/for pods] odd key=(MISSING)`,
+		},
+		{
+			name: "json",
+			json: true,
+			log:  func(l *Logger) { l.Info("pod ready", "pod", "nginx", "replicas", 3) },
+			want: `{"level":"info","feature":"deploy","step":"wait/for pods","msg":"pod ready","pod":"nginx","replicas":3}`,
+		},
+		{
+			name: "json error",
+			json: true,
+			log:  func(l *Logger) { l.Error(errors.New("not found"), "get failed") },
+			want: `{"level":"error","feature":"deploy","step":"wait/for pods","msg":"get failed","err":"not found"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ft := &fakeT{}
+			test.log(&Logger{t: ft, feature: "deploy", step: "wait/for pods", json: test.json})
+			if len(ft.messages) != 1 || ft.messages[0] != test.want {
+				t.Errorf("unexpected messages %q, want %q", ft.messages, test.want)
+			}
+		})
+	}
+}
+
+func TestLoggerFrom(t *testing.T) {
+	if LoggerFrom(context.Background()).t != nil {
+		t.Error("expected a klog logger outside of a step")
+	}
+	if LoggerFrom(WithLogger(context.Background(), nil)).t != nil {
+		t.Error("expected a klog logger once the step logger is reset")
+	}
+	logger := NewLogger(t, LogFormatJSON, "feature", "step")
+	if LoggerFrom(WithLogger(context.Background(), logger)) != logger {
+		t.Error("expected the logger stored in the context")
+	}
+	// the klog logger does not fail without a testing.T
+	LoggerFrom(context.Background()).WithValues("key", "value").Info("message")
+}
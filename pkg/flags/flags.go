@@ -21,7 +21,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	klog "k8s.io/klog/v2"
 	"sigs.k8s.io/e2e-framework/pkg/featuregate"
 )
@@ -36,12 +39,41 @@ const (
 	flagSkipFeatureName         = "skip-features"
 	flagSkipAssessmentName      = "skip-assessment"
 	flagParallelTestsName       = "parallel"
+	flagParallelMaxName         = "parallel-max"
 	flagDryRunName              = "dry-run"
+	flagDryRunPlanName          = "dry-run-plan"
+	flagListFeatures            = "list-features"
 	flagFailFast                = "fail-fast"
 	flagDisableGracefulTeardown = "disable-graceful-teardown"
 	flagContext                 = "context"
+	flagKubeContext             = "kube-context"
+	flagArtifacts               = "artifacts"
+	flagSonobuoyResults         = "sonobuoy-results-dir"
+	flagShards                  = "shards"
+	flagShardIndex              = "shard-index"
+	flagRerunFailed             = "rerun-failed"
+	flagRepeat                  = "repeat"
+	flagSoakDuration            = "soak-duration"
+	flagSoakMaxFailures         = "soak-max-failures"
+	flagSetupTimeout            = "setup-timeout"
+	flagFeatureTimeout          = "feature-timeout"
+	flagTeardownTimeout         = "teardown-timeout"
+	flagSlowThreshold           = "slow-threshold"
+	flagContainerEngine         = "container-engine"
+	flagStepLogFormat           = "step-log-format"
+	flagHookErrorPolicy         = "hook-error-policy"
+	flagInCluster               = "in-cluster"
+	flagClientQPS               = "client-qps"
+	flagClientBurst             = "client-burst"
+	flagFeatureGates            = "feature-gates"
 )
 
+// EnvVarPrefix is the prefix of the environment variables that provide a value for the
+// framework flags that are not passed on the command line. The name of the variable is the
+// name of the flag in upper case with dashes replaced by underscores, such as E2E_LABELS
+// for --labels or E2E_SKIP_FEATURES for --skip-features.
+const EnvVarPrefix = "E2E_"
+
 // Supported flag definitions
 var (
 	featureFlag = flag.Flag{
@@ -54,7 +86,7 @@ var (
 	}
 	labelsFlag = flag.Flag{
 		Name:  flagLabelsName,
-		Usage: "Comma-separated key=value to filter features by labels",
+		Usage: "Comma-separated key=value, or a label selector such as 'env in (dev,staging),tier!=slow', to filter features by labels",
 	}
 	kubecfgFlag = flag.Flag{
 		Name:  flagKubecofigName,
@@ -80,22 +112,114 @@ var (
 		Name:  flagParallelTestsName,
 		Usage: "Run test features in parallel",
 	}
+	parallelMaxFlag = flag.Flag{
+		Name:  flagParallelMaxName,
+		Usage: "Maximum number of test features run concurrently when running in parallel (optional, 0 means unbounded)",
+	}
 	dryRunFlag = flag.Flag{
 		Name:  flagDryRunName,
 		Usage: "Run Test suite in dry-run mode. This will list the tests to be executed without actually running them",
 	}
+	dryRunPlanFlag = flag.Flag{
+		Name:  flagDryRunPlanName,
+		Usage: "Path of the file where the plan of a dry-run is written, as YAML for a .yaml or .yml extension and as JSON otherwise. Use - to write JSON to stdout (optional)",
+	}
+	listFeaturesFlag = flag.Flag{
+		Name:  flagListFeatures,
+		Usage: "Path of the file where the catalog of the features and their assessments is written as JSON, without running them. Use - to write to stdout (optional, enables dry-run mode)",
+	}
 	failFastFlag = flag.Flag{
 		Name:  flagFailFast,
 		Usage: "Fail immediately and stop running untested code",
 	}
 	disableGracefulTeardownFlag = flag.Flag{
 		Name:  flagDisableGracefulTeardown,
-		Usage: "Ignore panic recovery while running tests. This will prevent test finish steps from getting executed on panic",
+		Usage: "Ignore panic recovery while running tests. This will prevent test finish steps and feature teardown steps from getting executed on panic",
 	}
 	contextFlag = flag.Flag{
 		Name:  flagContext,
 		Usage: "The name of the kubeconfig context to use",
 	}
+	inClusterFlag = flag.Flag{
+		Name:  flagInCluster,
+		Usage: "Run the tests against the cluster they are deployed in, using the service account of their pod. Detected when no kubeconfig is available in a pod",
+	}
+	clientQPSFlag = flag.Flag{
+		Name:  flagClientQPS,
+		Usage: "Maximum number of queries per second of the clients created for the tests (optional, defaults to 50)",
+	}
+	clientBurstFlag = flag.Flag{
+		Name:  flagClientBurst,
+		Usage: "Maximum burst of queries above --client-qps of the clients created for the tests (optional, defaults to 100)",
+	}
+	kubeContextFlag = flag.Flag{
+		Name:  flagKubeContext,
+		Usage: "The name of the kubeconfig context to use (alias of --context)",
+	}
+	shardsFlag = flag.Flag{
+		Name:  flagShards,
+		Usage: "Number of shards the test features are partitioned into (optional, used with --shard-index)",
+	}
+	shardIndexFlag = flag.Flag{
+		Name:  flagShardIndex,
+		Usage: "Zero based index of the shard of test features to run (optional, used with --shards)",
+	}
+	setupTimeoutFlag = flag.Flag{
+		Name:  flagSetupTimeout,
+		Usage: "Maximum duration of each environment Setup function, such as 10m (optional)",
+	}
+	featureTimeoutFlag = flag.Flag{
+		Name:  flagFeatureTimeout,
+		Usage: "Maximum duration of the setup and assessment steps of each feature, such as 5m (optional)",
+	}
+	teardownTimeoutFlag = flag.Flag{
+		Name:  flagTeardownTimeout,
+		Usage: "Maximum duration of each feature teardown and environment Finish function, such as 10m (optional)",
+	}
+	slowThresholdFlag = flag.Flag{
+		Name:  flagSlowThreshold,
+		Usage: "Duration above which steps and environment functions are reported as slow, such as 1m (optional)",
+	}
+	containerEngineFlag = flag.Flag{
+		Name:  flagContainerEngine,
+		Usage: "Container engine used to build and pull images: docker, buildkit, podman or nerdctl. Detected from the PATH when not set (optional)",
+	}
+	stepLogFormatFlag = flag.Flag{
+		Name:  flagStepLogFormat,
+		Usage: "Format of the messages written with the loggers of the steps: text or json (optional, defaults to text)",
+	}
+	hookErrorPolicyFlag = flag.Flag{
+		Name:  flagHookErrorPolicy,
+		Usage: "What a failing before or after hook aborts: fail-test, fail-feature or continue (optional, defaults to fail-test)",
+	}
+	repeatFlag = flag.Flag{
+		Name:  flagRepeat,
+		Usage: "Number of times each selected feature is run, used to detect flaky features (optional)",
+	}
+	soakDurationFlag = flag.Flag{
+		Name:  flagSoakDuration,
+		Usage: "Wall-clock duration, such as 2h, during which the selected features of each test are run in a loop for stability testing (optional)",
+	}
+	soakMaxFailuresFlag = flag.Flag{
+		Name:  flagSoakMaxFailures,
+		Usage: "Number of failed iterations tolerated before a soak run stops (optional, defaults to 0 which stops on the first failed iteration)",
+	}
+	rerunFailedFlag = flag.Flag{
+		Name:  flagRerunFailed,
+		Usage: "Path to a file listing the names of the features to rerun, one per line, such as the failed-features.txt file written in the artifacts directory (optional)",
+	}
+	featureGatesFlag = flag.Flag{
+		Name:  flagFeatureGates,
+		Usage: "A set of key=value pairs that describe feature gates for alpha/experimental features",
+	}
+	artifactsFlag = flag.Flag{
+		Name:  flagArtifacts,
+		Usage: "Directory where logs, diagnostics and reports are written (optional, defaults to the ARTIFACTS environment variable)",
+	}
+	sonobuoyResultsFlag = flag.Flag{
+		Name:  flagSonobuoyResults,
+		Usage: "Directory where the results are written in the Sonobuoy plugin format when the suite runs as a Sonobuoy plugin (optional, defaults to the SONOBUOY_RESULTS_DIR environment variable)",
+	}
 )
 
 // EnvFlags surfaces all resolved flag values for the testing framework
@@ -103,16 +227,38 @@ type EnvFlags struct {
 	feature                 string
 	assess                  string
 	labels                  LabelsMap
+	labelSelector           string
 	kubeconfig              string
 	namespace               string
 	skiplabels              LabelsMap
 	skipFeatures            string
 	skipAssessments         string
 	parallelTests           bool
+	parallelMax             int
 	dryRun                  bool
+	dryRunPlan              string
+	listFeatures            string
 	failFast                bool
 	disableGracefulTeardown bool
 	kubeContext             string
+	inCluster               bool
+	clientQPS               float32
+	clientBurst             int
+	artifacts               string
+	sonobuoyResults         string
+	shards                  int
+	shardIndex              int
+	rerunFailed             string
+	repeat                  int
+	soakDuration            time.Duration
+	soakMaxFailures         int
+	setupTimeout            time.Duration
+	featureTimeout          time.Duration
+	teardownTimeout         time.Duration
+	slowThreshold           time.Duration
+	containerEngine         string
+	stepLogFormat           string
+	hookErrorPolicy         string
 }
 
 // Feature returns value for `-feature` flag
@@ -130,6 +276,12 @@ func (f *EnvFlags) Labels() LabelsMap {
 	return f.labels
 }
 
+// LabelSelector returns the label selector expression parsed from the `-labels` flag
+// when it uses set-based or inequality requirements
+func (f *EnvFlags) LabelSelector() string {
+	return f.labelSelector
+}
+
 // Namespace returns an optional namespace flag value
 func (f *EnvFlags) Namespace() string {
 	return f.namespace
@@ -165,10 +317,25 @@ func (f *EnvFlags) Parallel() bool {
 	return f.parallelTests
 }
 
+// ParallelMax returns the maximum number of test features run concurrently
+func (f *EnvFlags) ParallelMax() int {
+	return f.parallelMax
+}
+
 func (f *EnvFlags) DryRun() bool {
 	return f.dryRun
 }
 
+// DryRunPlan returns the path of the file where the plan of a dry-run is written
+func (f *EnvFlags) DryRunPlan() string {
+	return f.dryRunPlan
+}
+
+// ListFeatures returns the path of the file where the catalog of the features is written
+func (f *EnvFlags) ListFeatures() string {
+	return f.listFeatures
+}
+
 // FailFast is used to indicate if the failure of an assessment should continue
 // assessing the rest of the features or skip it and continue to the next one.
 // This is set to false by default.
@@ -187,11 +354,101 @@ func Parse() (*EnvFlags, error) {
 	return ParseArgs(os.Args[1:])
 }
 
+// InCluster returns true if the tests run against the cluster they are deployed in
+func (f *EnvFlags) InCluster() bool {
+	return f.inCluster
+}
+
+// ClientQPS returns the maximum number of queries per second of the clients, 0 when not set
+func (f *EnvFlags) ClientQPS() float32 {
+	return f.clientQPS
+}
+
+// ClientBurst returns the maximum burst of queries of the clients, 0 when not set
+func (f *EnvFlags) ClientBurst() int {
+	return f.clientBurst
+}
+
 // KubeContext returns an optional kubeconfig context to use
 func (f *EnvFlags) KubeContext() string {
 	return f.kubeContext
 }
 
+// Artifacts returns an optional directory where the test artifacts are written
+func (f *EnvFlags) Artifacts() string {
+	return f.artifacts
+}
+
+// SonobuoyResults returns an optional directory where the results are written in the Sonobuoy plugin format
+func (f *EnvFlags) SonobuoyResults() string {
+	return f.sonobuoyResults
+}
+
+// Shards returns the number of shards the test features are partitioned into
+func (f *EnvFlags) Shards() int {
+	return f.shards
+}
+
+// ShardIndex returns the index of the shard of test features to run
+func (f *EnvFlags) ShardIndex() int {
+	return f.shardIndex
+}
+
+// RerunFailed returns the path of a file listing the features to rerun
+func (f *EnvFlags) RerunFailed() string {
+	return f.rerunFailed
+}
+
+// Repeat returns the number of times each selected feature is run
+func (f *EnvFlags) Repeat() int {
+	return f.repeat
+}
+
+// SoakDuration returns the duration during which the features are run in a loop
+func (f *EnvFlags) SoakDuration() time.Duration {
+	return f.soakDuration
+}
+
+// SoakMaxFailures returns the number of failed iterations tolerated before a soak run stops
+func (f *EnvFlags) SoakMaxFailures() int {
+	return f.soakMaxFailures
+}
+
+// SetupTimeout returns the maximum duration of each environment Setup function
+func (f *EnvFlags) SetupTimeout() time.Duration {
+	return f.setupTimeout
+}
+
+// FeatureTimeout returns the maximum duration of the setup and assessment steps of each feature
+func (f *EnvFlags) FeatureTimeout() time.Duration {
+	return f.featureTimeout
+}
+
+// TeardownTimeout returns the maximum duration of each feature teardown and environment Finish function
+func (f *EnvFlags) TeardownTimeout() time.Duration {
+	return f.teardownTimeout
+}
+
+// SlowThreshold returns the duration above which steps and environment functions are reported as slow
+func (f *EnvFlags) SlowThreshold() time.Duration {
+	return f.slowThreshold
+}
+
+// ContainerEngine returns the name of the container engine used to build and pull images
+func (f *EnvFlags) ContainerEngine() string {
+	return f.containerEngine
+}
+
+// StepLogFormat returns the format of the messages of the step loggers, text or json
+func (f *EnvFlags) StepLogFormat() string {
+	return f.stepLogFormat
+}
+
+// HookErrorPolicy returns what a failing before or after hook aborts: fail-test, fail-feature or continue
+func (f *EnvFlags) HookErrorPolicy() string {
+	return f.hookErrorPolicy
+}
+
 // ParseArgs parses the specified args from global flag.CommandLine
 // and returns a set of environment flag values.
 func ParseArgs(args []string) (*EnvFlags, error) {
@@ -203,13 +460,34 @@ func ParseArgs(args []string) (*EnvFlags, error) {
 		skipFeature             string
 		skipAssessment          string
 		parallelTests           bool
+		parallelMax             int
 		dryRun                  bool
+		dryRunPlan              string
+		listFeatures            string
 		failFast                bool
 		disableGracefulTeardown bool
 		kubeContext             string
+		inCluster               bool
+		clientQPS               float64
+		clientBurst             int
+		artifacts               string
+		sonobuoyResults         string
+		shards                  int
+		shardIndex              int
+		rerunFailed             string
+		repeat                  int
+		soakDuration            time.Duration
+		soakMaxFailures         int
+		setupTimeout            time.Duration
+		featureTimeout          time.Duration
+		teardownTimeout         time.Duration
+		slowThreshold           time.Duration
+		containerEngine         string
+		stepLogFormat           string
+		hookErrorPolicy         string
 	)
 
-	labels := make(LabelsMap)
+	labelFilter := &labelsFilter{labels: make(LabelsMap)}
 	skipLabels := make(LabelsMap)
 
 	if flag.Lookup(featureFlag.Name) == nil {
@@ -229,7 +507,7 @@ func ParseArgs(args []string) (*EnvFlags, error) {
 	}
 
 	if flag.Lookup(labelsFlag.Name) == nil {
-		flag.Var(&labels, labelsFlag.Name, labelsFlag.Usage)
+		flag.Var(labelFilter, labelsFlag.Name, labelsFlag.Usage)
 	}
 
 	if flag.Lookup(skipLabelsFlag.Name) == nil {
@@ -248,10 +526,22 @@ func ParseArgs(args []string) (*EnvFlags, error) {
 		flag.BoolVar(&parallelTests, parallelTestsFlag.Name, false, parallelTestsFlag.Usage)
 	}
 
+	if flag.Lookup(parallelMaxFlag.Name) == nil {
+		flag.IntVar(&parallelMax, parallelMaxFlag.Name, 0, parallelMaxFlag.Usage)
+	}
+
 	if flag.Lookup(dryRunFlag.Name) == nil {
 		flag.BoolVar(&dryRun, dryRunFlag.Name, false, dryRunFlag.Usage)
 	}
 
+	if flag.Lookup(dryRunPlanFlag.Name) == nil {
+		flag.StringVar(&dryRunPlan, dryRunPlanFlag.Name, dryRunPlanFlag.DefValue, dryRunPlanFlag.Usage)
+	}
+
+	if flag.Lookup(listFeaturesFlag.Name) == nil {
+		flag.StringVar(&listFeatures, listFeaturesFlag.Name, listFeaturesFlag.DefValue, listFeaturesFlag.Usage)
+	}
+
 	if flag.Lookup(failFastFlag.Name) == nil {
 		flag.BoolVar(&failFast, failFastFlag.Name, false, failFastFlag.Usage)
 	}
@@ -264,7 +554,83 @@ func ParseArgs(args []string) (*EnvFlags, error) {
 		flag.StringVar(&kubeContext, contextFlag.Name, contextFlag.DefValue, contextFlag.Usage)
 	}
 
-	flag.Var(featuregate.FeatureGate, "feature-gates", "A set of key=value pairs that describe feature gates for alpha/experimental features. Options are: \n"+strings.Join(featuregate.FeatureGate.KnownFeatures(), "\n"))
+	if flag.Lookup(kubeContextFlag.Name) == nil {
+		flag.StringVar(&kubeContext, kubeContextFlag.Name, kubeContextFlag.DefValue, kubeContextFlag.Usage)
+	}
+
+	if flag.Lookup(inClusterFlag.Name) == nil {
+		flag.BoolVar(&inCluster, inClusterFlag.Name, false, inClusterFlag.Usage)
+	}
+
+	if flag.Lookup(clientQPSFlag.Name) == nil {
+		flag.Float64Var(&clientQPS, clientQPSFlag.Name, 0, clientQPSFlag.Usage)
+	}
+
+	if flag.Lookup(clientBurstFlag.Name) == nil {
+		flag.IntVar(&clientBurst, clientBurstFlag.Name, 0, clientBurstFlag.Usage)
+	}
+
+	if flag.Lookup(shardsFlag.Name) == nil {
+		flag.IntVar(&shards, shardsFlag.Name, 0, shardsFlag.Usage)
+	}
+
+	if flag.Lookup(shardIndexFlag.Name) == nil {
+		flag.IntVar(&shardIndex, shardIndexFlag.Name, 0, shardIndexFlag.Usage)
+	}
+
+	if flag.Lookup(setupTimeoutFlag.Name) == nil {
+		flag.DurationVar(&setupTimeout, setupTimeoutFlag.Name, 0, setupTimeoutFlag.Usage)
+	}
+
+	if flag.Lookup(featureTimeoutFlag.Name) == nil {
+		flag.DurationVar(&featureTimeout, featureTimeoutFlag.Name, 0, featureTimeoutFlag.Usage)
+	}
+
+	if flag.Lookup(teardownTimeoutFlag.Name) == nil {
+		flag.DurationVar(&teardownTimeout, teardownTimeoutFlag.Name, 0, teardownTimeoutFlag.Usage)
+	}
+
+	if flag.Lookup(slowThresholdFlag.Name) == nil {
+		flag.DurationVar(&slowThreshold, slowThresholdFlag.Name, 0, slowThresholdFlag.Usage)
+	}
+
+	if flag.Lookup(containerEngineFlag.Name) == nil {
+		flag.StringVar(&containerEngine, containerEngineFlag.Name, containerEngineFlag.DefValue, containerEngineFlag.Usage)
+	}
+
+	if flag.Lookup(stepLogFormatFlag.Name) == nil {
+		flag.StringVar(&stepLogFormat, stepLogFormatFlag.Name, stepLogFormatFlag.DefValue, stepLogFormatFlag.Usage)
+	}
+
+	if flag.Lookup(hookErrorPolicyFlag.Name) == nil {
+		flag.StringVar(&hookErrorPolicy, hookErrorPolicyFlag.Name, hookErrorPolicyFlag.DefValue, hookErrorPolicyFlag.Usage)
+	}
+
+	if flag.Lookup(repeatFlag.Name) == nil {
+		flag.IntVar(&repeat, repeatFlag.Name, 0, repeatFlag.Usage)
+	}
+
+	if flag.Lookup(soakDurationFlag.Name) == nil {
+		flag.DurationVar(&soakDuration, soakDurationFlag.Name, 0, soakDurationFlag.Usage)
+	}
+
+	if flag.Lookup(soakMaxFailuresFlag.Name) == nil {
+		flag.IntVar(&soakMaxFailures, soakMaxFailuresFlag.Name, 0, soakMaxFailuresFlag.Usage)
+	}
+
+	if flag.Lookup(rerunFailedFlag.Name) == nil {
+		flag.StringVar(&rerunFailed, rerunFailedFlag.Name, rerunFailedFlag.DefValue, rerunFailedFlag.Usage)
+	}
+
+	if flag.Lookup(artifactsFlag.Name) == nil {
+		flag.StringVar(&artifacts, artifactsFlag.Name, artifactsFlag.DefValue, artifactsFlag.Usage)
+	}
+
+	if flag.Lookup(sonobuoyResultsFlag.Name) == nil {
+		flag.StringVar(&sonobuoyResults, sonobuoyResultsFlag.Name, sonobuoyResultsFlag.DefValue, sonobuoyResultsFlag.Usage)
+	}
+
+	flag.Var(featuregate.FeatureGate, featureGatesFlag.Name, featureGatesFlag.Usage+". Options are: \n"+strings.Join(featuregate.FeatureGate.KnownFeatures(), "\n"))
 
 	// Enable klog/v2 flag integration
 	klog.InitFlags(nil)
@@ -273,12 +639,64 @@ func ParseArgs(args []string) (*EnvFlags, error) {
 		return nil, fmt.Errorf("flags parsing: %w", err)
 	}
 
+	// Flags passed on the command line take precedence over the environment variables
+	if err := setFromEnv(flag.CommandLine, frameworkFlagNames()); err != nil {
+		return nil, fmt.Errorf("flags parsing: %w", err)
+	}
+
 	// Hook into the default test.list of the `go test` and integrate that with the `--dry-run` behavior. Treat them the same way
 	if !dryRun && flag.Lookup("test.list") != nil && flag.Lookup("test.list").Value.String() == "true" {
 		klog.V(2).Info("Enabling dry-run mode as the tests were invoked in list mode")
 		dryRun = true
 	}
 
+	// Listing the features does not run them
+	if listFeatures != "" {
+		dryRun = true
+	}
+
+	if parallelMax < 0 {
+		return nil, fmt.Errorf("--parallel-max must not be negative")
+	}
+
+	if repeat < 0 {
+		return nil, fmt.Errorf("--repeat must not be negative")
+	}
+
+	if soakDuration < 0 || soakMaxFailures < 0 {
+		return nil, fmt.Errorf("--soak-duration and --soak-max-failures must not be negative")
+	}
+
+	if slowThreshold < 0 {
+		return nil, fmt.Errorf("--slow-threshold must not be negative")
+	}
+
+	if clientQPS < 0 || clientBurst < 0 {
+		return nil, fmt.Errorf("--client-qps and --client-burst must not be negative")
+	}
+
+	if shards < 0 || shardIndex < 0 || (shards > 0 && shardIndex >= shards) {
+		return nil, fmt.Errorf("--shard-index must be between 0 and --shards - 1, got %d for %d shards", shardIndex, shards)
+	}
+
+	switch containerEngine {
+	case "", "docker", "buildkit", "podman", "nerdctl":
+	default:
+		return nil, fmt.Errorf("--container-engine must be one of docker, buildkit, podman or nerdctl, got %q", containerEngine)
+	}
+
+	switch stepLogFormat {
+	case "", "text", "json":
+	default:
+		return nil, fmt.Errorf("--step-log-format must be one of text or json, got %q", stepLogFormat)
+	}
+
+	switch hookErrorPolicy {
+	case "", "fail-test", "fail-feature", "continue":
+	default:
+		return nil, fmt.Errorf("--hook-error-policy must be one of fail-test, fail-feature or continue, got %q", hookErrorPolicy)
+	}
+
 	if failFast && parallelTests {
 		panic(fmt.Errorf("--fail-fast and --parallel are mutually exclusive options"))
 	}
@@ -286,17 +704,39 @@ func ParseArgs(args []string) (*EnvFlags, error) {
 	return &EnvFlags{
 		feature:                 feature,
 		assess:                  assess,
-		labels:                  labels,
+		labels:                  labelFilter.labels,
+		labelSelector:           strings.Join(labelFilter.selectors, ","),
 		namespace:               namespace,
 		kubeconfig:              kubeconfig,
 		skiplabels:              skipLabels,
 		skipFeatures:            skipFeature,
 		skipAssessments:         skipAssessment,
 		parallelTests:           parallelTests,
+		parallelMax:             parallelMax,
 		dryRun:                  dryRun,
+		dryRunPlan:              dryRunPlan,
+		listFeatures:            listFeatures,
 		failFast:                failFast,
 		disableGracefulTeardown: disableGracefulTeardown,
 		kubeContext:             kubeContext,
+		inCluster:               inCluster,
+		clientQPS:               float32(clientQPS),
+		clientBurst:             clientBurst,
+		artifacts:               artifacts,
+		sonobuoyResults:         sonobuoyResults,
+		shards:                  shards,
+		shardIndex:              shardIndex,
+		rerunFailed:             rerunFailed,
+		repeat:                  repeat,
+		soakDuration:            soakDuration,
+		soakMaxFailures:         soakMaxFailures,
+		setupTimeout:            setupTimeout,
+		featureTimeout:          featureTimeout,
+		teardownTimeout:         teardownTimeout,
+		slowThreshold:           slowThreshold,
+		containerEngine:         containerEngine,
+		stepLogFormat:           stepLogFormat,
+		hookErrorPolicy:         hookErrorPolicy,
 	}, nil
 }
 
@@ -323,6 +763,130 @@ func (m LabelsMap) Set(val string) error {
 	return nil
 }
 
+// frameworkFlags returns the flags defined by the framework
+func frameworkFlags() []flag.Flag {
+	return []flag.Flag{
+		kubeNSFlag, kubecfgFlag, featureFlag, assessFlag, labelsFlag,
+		skipLabelsFlag, skipFeatureFlag, skipAssessmentFlag, parallelTestsFlag,
+		parallelMaxFlag, dryRunFlag, dryRunPlanFlag, listFeaturesFlag, failFastFlag, disableGracefulTeardownFlag,
+		contextFlag, kubeContextFlag, artifactsFlag, shardsFlag, shardIndexFlag, rerunFailedFlag, repeatFlag, soakDurationFlag, soakMaxFailuresFlag,
+		setupTimeoutFlag, featureTimeoutFlag, teardownTimeoutFlag, slowThresholdFlag, containerEngineFlag, stepLogFormatFlag, hookErrorPolicyFlag, inClusterFlag,
+		clientQPSFlag, clientBurstFlag, sonobuoyResultsFlag, featureGatesFlag,
+	}
+}
+
+// boolFlags are the names of the framework flags that are booleans
+var boolFlags = map[string]bool{
+	flagParallelTestsName:       true,
+	flagDryRunName:              true,
+	flagFailFast:                true,
+	flagDisableGracefulTeardown: true,
+	flagInCluster:               true,
+}
+
+// frameworkFlagNames returns the names of the flags defined by the framework
+func frameworkFlagNames() []string {
+	var names []string
+	for _, f := range frameworkFlags() {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// Definition describes a flag defined by the framework
+type Definition struct {
+	// Name is the name of the flag, without leading dashes
+	Name string
+	// Usage is the help message of the flag
+	Usage string
+	// Bool reports whether the flag is a boolean flag, which can be passed without a value
+	Bool bool
+}
+
+// Definitions returns the definitions of the flags registered by ParseArgs. Tools that pass
+// the framework flags through to test binaries, such as the kubetest2 tester, are generated
+// from them so that they support every flag of the framework.
+func Definitions() []Definition {
+	var defs []Definition
+	for _, f := range frameworkFlags() {
+		defs = append(defs, Definition{Name: f.Name, Usage: f.Usage, Bool: boolFlags[f.Name]})
+	}
+	return defs
+}
+
+// flagAliases maps the alias flags to the name of the flag they set
+var flagAliases = map[string]string{
+	flagKubeContext: flagContext,
+}
+
+// EnvVarName returns the name of the environment variable that provides a value for the flag
+func EnvVarName(flagName string) string {
+	return EnvVarPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// setFromEnv sets the flags identified by names that were not passed on the command line
+// from their environment variable, when it is defined
+func setFromEnv(fs *flag.FlagSet, names []string) error {
+	passed := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		passed[f.Name] = true
+	})
+	// a flag passed under its alias takes precedence over the environment variables of both names
+	for alias, name := range flagAliases {
+		if passed[alias] || passed[name] {
+			passed[alias], passed[name] = true, true
+		}
+	}
+	for _, name := range names {
+		if passed[name] || fs.Lookup(name) == nil {
+			continue
+		}
+		val, ok := os.LookupEnv(EnvVarName(name))
+		if !ok {
+			continue
+		}
+		if err := fs.Set(name, val); err != nil {
+			return fmt.Errorf("invalid value %q for %s: %w", val, EnvVarName(name), err)
+		}
+	}
+	return nil
+}
+
+// labelsFilter is the value of the `-labels` flag. It accepts either the key=value pairs of a
+// LabelsMap or a label selector expression using the Kubernetes label selector grammar.
+type labelsFilter struct {
+	labels    LabelsMap
+	selectors []string
+}
+
+func (f *labelsFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	if len(f.selectors) > 0 {
+		return strings.Join(f.selectors, ",")
+	}
+	return f.labels.String()
+}
+
+func (f *labelsFilter) Set(val string) error {
+	selector, err := labels.Parse(val)
+	if err != nil {
+		// not a valid selector, fall back to the key=value pairs
+		return f.labels.Set(val)
+	}
+	requirements, _ := selector.Requirements()
+	for _, req := range requirements {
+		if req.Operator() != selection.Equals && req.Operator() != selection.DoubleEquals {
+			f.selectors = append(f.selectors, val)
+			return nil
+		}
+	}
+	// equality based requirements keep using LabelsMap, where several values of a key
+	// match any of them
+	return f.labels.Set(val)
+}
+
 func (m LabelsMap) Contains(key, val string) bool {
 	for _, v := range m[key] {
 		if val == v {
@@ -331,3 +895,44 @@ func (m LabelsMap) Contains(key, val string) bool {
 	}
 	return false
 }
+
+// MatchesSelector reports whether the labels match the label selector expression, which uses
+// the Kubernetes label selector grammar. As a key can have several values, positive requirements
+// such as "env in (dev,staging)" match when any of the values of the key satisfies them, while
+// negative requirements such as "tier!=slow" match when none of the values is excluded.
+func (m LabelsMap) MatchesSelector(selector string) (bool, error) {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+	requirements, _ := parsed.Requirements()
+	for _, req := range requirements {
+		if !m.matchesRequirement(req) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (m LabelsMap) matchesRequirement(req labels.Requirement) bool {
+	values := m[req.Key()]
+	if len(values) == 0 {
+		return req.Matches(labels.Set{})
+	}
+	switch req.Operator() {
+	case selection.NotIn, selection.NotEquals, selection.DoesNotExist:
+		for _, v := range values {
+			if !req.Matches(labels.Set{req.Key(): v}) {
+				return false
+			}
+		}
+		return true
+	default:
+		for _, v := range values {
+			if req.Matches(labels.Set{req.Key(): v}) {
+				return true
+			}
+		}
+		return false
+	}
+}
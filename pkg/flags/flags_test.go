@@ -20,6 +20,9 @@ import (
 	"flag"
 	"reflect"
 	"testing"
+	"time"
+
+	klog "k8s.io/klog/v2"
 
 	"sigs.k8s.io/e2e-framework/pkg/featuregate"
 )
@@ -91,6 +94,222 @@ func TestParseFlags(t *testing.T) {
 	}
 }
 
+func TestParseFlags_Shards(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{name: "valid shard", args: []string{"--shards", "3", "--shard-index", "2"}},
+		{name: "sharding disabled", args: []string{}},
+		{name: "index out of range", args: []string{"--shards", "3", "--shard-index", "3"}, wantErr: true},
+		{name: "negative shards", args: []string{"--shards", "-1"}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag.CommandLine = &flag.FlagSet{}
+			testFlags, err := ParseArgs(test.args)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseArgs() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !test.wantErr && len(test.args) > 0 && (testFlags.Shards() != 3 || testFlags.ShardIndex() != 2) {
+				t.Errorf("unexpected shard %d/%d", testFlags.ShardIndex(), testFlags.Shards())
+			}
+		})
+	}
+}
+
+func TestParseFlags_Timeouts(t *testing.T) {
+	flag.CommandLine = &flag.FlagSet{}
+	testFlags, err := ParseArgs([]string{"--setup-timeout", "10m", "--feature-timeout", "90s", "--teardown-timeout", "5m"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if testFlags.SetupTimeout() != 10*time.Minute {
+		t.Errorf("unexpected setup timeout %s", testFlags.SetupTimeout())
+	}
+	if testFlags.FeatureTimeout() != 90*time.Second {
+		t.Errorf("unexpected feature timeout %s", testFlags.FeatureTimeout())
+	}
+	if testFlags.TeardownTimeout() != 5*time.Minute {
+		t.Errorf("unexpected teardown timeout %s", testFlags.TeardownTimeout())
+	}
+}
+
+func TestParseFlags_SlowThreshold(t *testing.T) {
+	flag.CommandLine = &flag.FlagSet{}
+	testFlags, err := ParseArgs([]string{"--slow-threshold", "30s"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if testFlags.SlowThreshold() != 30*time.Second {
+		t.Errorf("unexpected slow threshold %s", testFlags.SlowThreshold())
+	}
+
+	flag.CommandLine = &flag.FlagSet{}
+	if _, err := ParseArgs([]string{"--slow-threshold", "-1s"}); err == nil {
+		t.Error("expected an error for a negative slow threshold")
+	}
+}
+
+func TestParseFlags_LabelSelector(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantLabels   LabelsMap
+		wantSelector string
+	}{
+		{
+			name:       "key value pairs",
+			args:       []string{"--labels", "env=dev,env=staging"},
+			wantLabels: LabelsMap{"env": {"dev", "staging"}},
+		},
+		{
+			name:         "set based selector",
+			args:         []string{"--labels", "env in (dev,staging), tier!=slow"},
+			wantLabels:   LabelsMap{},
+			wantSelector: "env in (dev,staging), tier!=slow",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flag.CommandLine = &flag.FlagSet{}
+			testFlags, err := ParseArgs(test.args)
+			if err != nil {
+				t.Fatalf("ParseArgs() error = %v", err)
+			}
+			if !reflect.DeepEqual(testFlags.Labels(), test.wantLabels) {
+				t.Errorf("unexpected labels %v", testFlags.Labels())
+			}
+			if testFlags.LabelSelector() != test.wantSelector {
+				t.Errorf("unexpected label selector %q", testFlags.LabelSelector())
+			}
+		})
+	}
+}
+
+func TestLabelsMap_MatchesSelector(t *testing.T) {
+	labels := LabelsMap{"env": {"dev", "prod"}, "tier": {"fast"}}
+	tests := []struct {
+		selector string
+		want     bool
+		wantErr  bool
+	}{
+		{selector: "env in (dev,staging)", want: true},
+		{selector: "env in (staging)", want: false},
+		{selector: "env notin (staging)", want: true},
+		{selector: "env!=prod", want: false},
+		{selector: "tier!=slow", want: true},
+		{selector: "tier,!owner", want: true},
+		{selector: "owner", want: false},
+		{selector: "env in (dev", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			got, err := labels.MatchesSelector(test.selector)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("MatchesSelector() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("MatchesSelector() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseFlags_EnvVars(t *testing.T) {
+	t.Setenv("E2E_LABELS", "env=dev")
+	t.Setenv("E2E_PARALLEL", "true")
+	t.Setenv("E2E_NAMESPACE", "from-env")
+	t.Setenv("E2E_SKIP_FEATURES", "slow")
+
+	flag.CommandLine = &flag.FlagSet{}
+	testFlags, err := ParseArgs([]string{"--namespace", "from-flag"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if !reflect.DeepEqual(testFlags.Labels(), LabelsMap{"env": {"dev"}}) {
+		t.Errorf("unexpected labels %v", testFlags.Labels())
+	}
+	if !testFlags.Parallel() {
+		t.Error("expected parallel to be set from E2E_PARALLEL")
+	}
+	if testFlags.SkipFeatures() != "slow" {
+		t.Errorf("unexpected skip features %q", testFlags.SkipFeatures())
+	}
+	if testFlags.Namespace() != "from-flag" {
+		t.Errorf("expected the flag to take precedence over E2E_NAMESPACE, got %q", testFlags.Namespace())
+	}
+
+	t.Setenv("E2E_REPEAT", "many")
+	flag.CommandLine = &flag.FlagSet{}
+	if _, err := ParseArgs(nil); err == nil {
+		t.Error("expected an error for an invalid E2E_REPEAT value")
+	}
+}
+
+func TestParseFlags_ContainerEngine(t *testing.T) {
+	flag.CommandLine = &flag.FlagSet{}
+	testFlags, err := ParseArgs([]string{"--container-engine", "podman"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if testFlags.ContainerEngine() != "podman" {
+		t.Errorf("unexpected container engine %q", testFlags.ContainerEngine())
+	}
+
+	flag.CommandLine = &flag.FlagSet{}
+	if _, err := ParseArgs([]string{"--container-engine", "rkt"}); err == nil {
+		t.Error("expected an error for an unknown container engine")
+	}
+}
+
+func TestParseFlags_ListFeatures(t *testing.T) {
+	flag.CommandLine = &flag.FlagSet{}
+	testFlags, err := ParseArgs([]string{"--list-features", "catalog.json"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if testFlags.ListFeatures() != "catalog.json" {
+		t.Errorf("unexpected catalog path %q", testFlags.ListFeatures())
+	}
+	if !testFlags.DryRun() {
+		t.Error("expected listing the features to enable the dry-run mode")
+	}
+}
+
+func TestParseFlags_StepLogFormat(t *testing.T) {
+	flag.CommandLine = &flag.FlagSet{}
+	testFlags, err := ParseArgs([]string{"--step-log-format", "json"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if testFlags.StepLogFormat() != "json" {
+		t.Errorf("unexpected step log format %q", testFlags.StepLogFormat())
+	}
+
+	flag.CommandLine = &flag.FlagSet{}
+	if _, err := ParseArgs([]string{"--step-log-format", "yaml"}); err == nil {
+		t.Error("expected an error for an unknown step log format")
+	}
+}
+
+func TestParseFlags_HookErrorPolicy(t *testing.T) {
+	flag.CommandLine = &flag.FlagSet{}
+	testFlags, err := ParseArgs([]string{"--hook-error-policy", "fail-feature"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if testFlags.HookErrorPolicy() != "fail-feature" {
+		t.Errorf("unexpected hook error policy %q", testFlags.HookErrorPolicy())
+	}
+
+	flag.CommandLine = &flag.FlagSet{}
+	if _, err := ParseArgs([]string{"--hook-error-policy", "ignore"}); err == nil {
+		t.Error("expected an error for an unknown hook error policy")
+	}
+}
+
 func TestLabelsMap_Contains(t *testing.T) {
 	type args struct {
 		key string
@@ -175,3 +394,111 @@ func TestLabelsMap_Contains(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFlags_KubeContext(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want string
+	}{
+		{name: "context flag", args: []string{"--context", "kind-a"}, want: "kind-a"},
+		{name: "kube-context alias", args: []string{"--kube-context", "kind-b"}, want: "kind-b"},
+		{name: "alias from env", env: map[string]string{"E2E_KUBE_CONTEXT": "kind-c"}, want: "kind-c"},
+		{name: "flag over alias env", args: []string{"--context", "kind-a"}, env: map[string]string{"E2E_KUBE_CONTEXT": "kind-c"}, want: "kind-a"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+			flag.CommandLine = &flag.FlagSet{}
+			testFlags, err := ParseArgs(test.args)
+			if err != nil {
+				t.Fatalf("ParseArgs() error = %v", err)
+			}
+			if testFlags.KubeContext() != test.want {
+				t.Errorf("expected kube context %q, got %q", test.want, testFlags.KubeContext())
+			}
+		})
+	}
+}
+
+func TestParseFlags_InCluster(t *testing.T) {
+	flag.CommandLine = &flag.FlagSet{}
+	testFlags, err := ParseArgs([]string{"--in-cluster"})
+	if err != nil {
+		t.Fatalf("ParseArgs() error = %v", err)
+	}
+	if !testFlags.InCluster() {
+		t.Error("expected in-cluster mode to be enabled")
+	}
+}
+
+func TestParseFlags_ClientQPS(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		env       map[string]string
+		wantQPS   float32
+		wantBurst int
+		wantErr   bool
+	}{
+		{name: "not set"},
+		{name: "flags", args: []string{"--client-qps", "75.5", "--client-burst", "150"}, wantQPS: 75.5, wantBurst: 150},
+		{name: "env", env: map[string]string{"E2E_CLIENT_QPS": "30"}, wantQPS: 30},
+		{name: "negative", args: []string{"--client-burst", "-1"}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+			flag.CommandLine = &flag.FlagSet{}
+			testFlags, err := ParseArgs(test.args)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseArgs() error = %v", err)
+			}
+			if testFlags.ClientQPS() != test.wantQPS || testFlags.ClientBurst() != test.wantBurst {
+				t.Errorf("expected QPS %v and burst %d, got %v and %d", test.wantQPS, test.wantBurst, testFlags.ClientQPS(), testFlags.ClientBurst())
+			}
+		})
+	}
+}
+
+func TestDefinitions(t *testing.T) {
+	flag.CommandLine = &flag.FlagSet{}
+	if _, err := ParseArgs(nil); err != nil {
+		t.Fatal(err)
+	}
+	klogFlags := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(klogFlags)
+
+	defined := map[string]bool{}
+	for _, def := range Definitions() {
+		defined[def.Name] = true
+		f := flag.Lookup(def.Name)
+		if f == nil {
+			t.Errorf("flag %s is not registered by ParseArgs", def.Name)
+			continue
+		}
+		boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+		if isBool := ok && boolFlag.IsBoolFlag(); isBool != def.Bool {
+			t.Errorf("flag %s: expected bool to be %t, got %t", def.Name, isBool, def.Bool)
+		}
+		if def.Usage == "" {
+			t.Errorf("flag %s has no usage", def.Name)
+		}
+	}
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		if !defined[f.Name] && klogFlags.Lookup(f.Name) == nil {
+			t.Errorf("flag %s registered by ParseArgs has no definition", f.Name)
+		}
+	})
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loadgen runs concurrent copies of an operation, such as creating a resource and waiting for it
+// to be reconciled, and collects their latency and error statistics to be used in performance assessments.
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// DefaultConcurrency is the number of operations run at the same time when no concurrency is set
+const DefaultConcurrency = 10
+
+// Func is an operation of a load test. i is the index of the operation, from 0 to n-1, which can be used
+// to name the objects it creates.
+type Func func(ctx context.Context, cfg *envconf.Config, i int) error
+
+type options struct {
+	concurrency  int
+	rampUp       time.Duration
+	maxErrorRate float64
+}
+
+// Option configures how a load test is run
+type Option func(*options)
+
+// WithConcurrency sets the number of operations run at the same time, DefaultConcurrency by default
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.concurrency = n
+	}
+}
+
+// WithRampUp spreads the start of the concurrent workers over the given duration, so that the load
+// increases gradually instead of starting all the operations at once
+func WithRampUp(d time.Duration) Option {
+	return func(o *options) {
+		o.rampUp = d
+	}
+}
+
+// WithMaxErrorRate sets the ratio of failed operations, between 0 and 1, tolerated by Step before it fails
+// the assessment. No failed operation is tolerated by default.
+func WithMaxErrorRate(rate float64) Option {
+	return func(o *options) {
+		o.maxErrorRate = rate
+	}
+}
+
+// Sample is the outcome of an operation of a load test
+type Sample struct {
+	Index   int
+	Latency time.Duration
+	Err     error
+}
+
+// Result holds the samples of a load test
+type Result struct {
+	// Samples are the outcomes of the operations, ordered by index
+	Samples []Sample
+	// Duration is the wall-clock duration of the load test
+	Duration time.Duration
+}
+
+// Run runs n copies of fn, with the configured concurrency, and returns their samples. The operations not
+// started yet when ctx is done are not run.
+func Run(ctx context.Context, cfg *envconf.Config, n int, fn Func, opts ...Option) (*Result, error) {
+	o := &options{concurrency: DefaultConcurrency}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if n < 0 || o.concurrency <= 0 || o.rampUp < 0 {
+		return nil, fmt.Errorf("loadgen: invalid load test of %d operations with concurrency %d and ramp-up %s", n, o.concurrency, o.rampUp)
+	}
+	if fn == nil {
+		return nil, errors.New("loadgen: operation is nil")
+	}
+	workers := min(o.concurrency, n)
+	log.V(4).InfoS("Starting load test", "operations", n, "concurrency", workers, "rampUp", o.rampUp)
+
+	indexes := make(chan int)
+	samples := make([]Sample, n)
+	ran := make([]bool, n)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(delay time.Duration) {
+			defer wg.Done()
+			if delay > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+			}
+			for i := range indexes {
+				if ctx.Err() != nil {
+					continue
+				}
+				opStart := time.Now()
+				err := fn(ctx, cfg, i)
+				samples[i] = Sample{Index: i, Latency: time.Since(opStart), Err: err}
+				ran[i] = true
+			}
+		}(o.rampUp * time.Duration(w) / time.Duration(workers))
+	}
+
+dispatch:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	result := &Result{Duration: time.Since(start)}
+	for i := range samples {
+		if ran[i] {
+			result.Samples = append(result.Samples, samples[i])
+		}
+	}
+	log.V(4).InfoS("Load test done", "result", result.String())
+	return result, nil
+}
+
+// Operations returns the number of operations run
+func (r *Result) Operations() int {
+	return len(r.Samples)
+}
+
+// Errors returns the errors of the failed operations
+func (r *Result) Errors() []error {
+	var errs []error
+	for _, s := range r.Samples {
+		if s.Err != nil {
+			errs = append(errs, s.Err)
+		}
+	}
+	return errs
+}
+
+// ErrorRate returns the ratio of failed operations, between 0 and 1
+func (r *Result) ErrorRate() float64 {
+	if len(r.Samples) == 0 {
+		return 0
+	}
+	return float64(len(r.Errors())) / float64(len(r.Samples))
+}
+
+// Throughput returns the number of operations run per second
+func (r *Result) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(len(r.Samples)) / r.Duration.Seconds()
+}
+
+// latencies returns the sorted latencies of the successful operations
+func (r *Result) latencies() []time.Duration {
+	var latencies []time.Duration
+	for _, s := range r.Samples {
+		if s.Err == nil {
+			latencies = append(latencies, s.Latency)
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return latencies
+}
+
+// Percentile returns the latency under which the given percentage, between 0 and 100, of the successful
+// operations completed, using the nearest-rank method. It returns 0 when no operation succeeded.
+func (r *Result) Percentile(p float64) time.Duration {
+	latencies := r.latencies()
+	if len(latencies) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(latencies))))
+	rank = max(1, min(rank, len(latencies)))
+	return latencies[rank-1]
+}
+
+// Mean returns the mean latency of the successful operations
+func (r *Result) Mean() time.Duration {
+	latencies := r.latencies()
+	if len(latencies) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, l := range latencies {
+		total += l
+	}
+	return total / time.Duration(len(latencies))
+}
+
+// String summarizes the result
+func (r *Result) String() string {
+	return fmt.Sprintf("%d operations, %d failed, %.1f ops/s, latency mean %s p50 %s p90 %s p99 %s max %s",
+		r.Operations(), len(r.Errors()), r.Throughput(), round(r.Mean()), round(r.Percentile(50)),
+		round(r.Percentile(90)), round(r.Percentile(99)), round(r.Percentile(100)))
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(time.Microsecond)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+func TestRun(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	fn := func(ctx context.Context, cfg *envconf.Config, i int) error {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		if i%4 == 0 {
+			return errors.New("failed")
+		}
+		return nil
+	}
+
+	result, err := Run(context.Background(), envconf.New(), 20, fn, WithConcurrency(4), WithRampUp(4*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Operations() != 20 {
+		t.Errorf("expected 20 operations, got %d", result.Operations())
+	}
+	if got := len(result.Errors()); got != 5 {
+		t.Errorf("expected 5 failed operations, got %d", got)
+	}
+	if result.ErrorRate() != 0.25 {
+		t.Errorf("expected an error rate of 0.25, got %f", result.ErrorRate())
+	}
+	if got := maxInFlight.Load(); got > 4 {
+		t.Errorf("expected at most 4 concurrent operations, got %d", got)
+	}
+	for i, s := range result.Samples {
+		if s.Index != i {
+			t.Errorf("expected sample %d to have index %d, got %d", i, i, s.Index)
+		}
+	}
+}
+
+func TestRunInvalid(t *testing.T) {
+	noop := func(ctx context.Context, cfg *envconf.Config, i int) error { return nil }
+	tests := []struct {
+		name string
+		n    int
+		fn   Func
+		opts []Option
+	}{
+		{name: "negative operations", n: -1, fn: noop},
+		{name: "zero concurrency", n: 1, fn: noop, opts: []Option{WithConcurrency(0)}},
+		{name: "negative ramp-up", n: 1, fn: noop, opts: []Option{WithRampUp(-time.Second)}},
+		{name: "nil operation", n: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Run(context.Background(), nil, test.n, test.fn, test.opts...); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fn := func(ctx context.Context, cfg *envconf.Config, i int) error {
+		if i == 2 {
+			cancel()
+		}
+		return nil
+	}
+	result, err := Run(ctx, nil, 100, fn, WithConcurrency(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Operations() != 3 {
+		t.Errorf("expected 3 operations before the cancellation, got %d", result.Operations())
+	}
+}
+
+func TestResult_Latencies(t *testing.T) {
+	result := &Result{Duration: 2 * time.Second}
+	for i := 1; i <= 10; i++ {
+		result.Samples = append(result.Samples, Sample{Index: i - 1, Latency: time.Duration(i) * time.Millisecond})
+	}
+	result.Samples = append(result.Samples, Sample{Index: 10, Latency: time.Hour, Err: errors.New("failed")})
+
+	tests := []struct {
+		name string
+		got  time.Duration
+		want time.Duration
+	}{
+		{name: "p0", got: result.Percentile(0), want: time.Millisecond},
+		{name: "p50", got: result.Percentile(50), want: 5 * time.Millisecond},
+		{name: "p90", got: result.Percentile(90), want: 9 * time.Millisecond},
+		{name: "p100", got: result.Percentile(100), want: 10 * time.Millisecond},
+		{name: "mean", got: result.Mean(), want: 5500 * time.Microsecond},
+		{name: "empty", got: (&Result{}).Percentile(50), want: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.got != test.want {
+				t.Errorf("expected %s, got %s", test.want, test.got)
+			}
+		})
+	}
+	if result.Throughput() != 5.5 {
+		t.Errorf("expected 5.5 ops/s, got %f", result.Throughput())
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadgen
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/types"
+)
+
+type resultKey struct{}
+
+// Step returns a step function that runs n copies of fn, logs their statistics and stores the result in the
+// returned context, where later assessments can retrieve it with ResultFrom to check latency objectives.
+// The step fails when more operations failed than tolerated by WithMaxErrorRate.
+func Step(n int, fn Func, opts ...Option) types.StepFunc {
+	return func(ctx context.Context, t *testing.T, cfg *envconf.Config) context.Context {
+		t.Helper()
+		o := &options{}
+		for _, opt := range opts {
+			opt(o)
+		}
+
+		result, err := Run(ctx, cfg, n, fn, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("Load test: %s", result)
+		if rate := result.ErrorRate(); rate > o.maxErrorRate {
+			errs := result.Errors()
+			t.Errorf("load test error rate %.2f exceeds %.2f, first error: %v", rate, o.maxErrorRate, errs[0])
+		}
+		return context.WithValue(ctx, resultKey{}, result)
+	}
+}
+
+// ResultFrom returns the result stored in the context by Step, or nil when there is none
+func ResultFrom(ctx context.Context) *Result {
+	result, _ := ctx.Value(resultKey{}).(*Result)
+	return result
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadgen
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+func TestStep(t *testing.T) {
+	fn := func(ctx context.Context, cfg *envconf.Config, i int) error { return nil }
+	ctx := Step(5, fn, WithConcurrency(2))(context.Background(), t, envconf.New())
+	result := ResultFrom(ctx)
+	if result == nil {
+		t.Fatal("expected the result to be stored in the context")
+	}
+	if result.Operations() != 5 {
+		t.Errorf("expected 5 operations, got %d", result.Operations())
+	}
+	if ResultFrom(context.Background()) != nil {
+		t.Error("expected no result in an empty context")
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/pkg/envfuncs"
+)
+
+const (
+	// DefaultBaseImage is the distroless image the test binary is packaged on top of
+	DefaultBaseImage = "gcr.io/distroless/static:nonroot"
+	// DefaultImagePath is the path of the test binary in the image
+	DefaultImagePath = "/" + DefaultBinaryName
+)
+
+// imageFile is a file or directory of the host copied into the image
+type imageFile struct {
+	source string
+	target string
+}
+
+type imageOptions struct {
+	baseImage string
+	path      string
+	args      []string
+	files     []imageFile
+	buildArgs []string
+}
+
+// ImageOption configures the Dockerfile generated by Dockerfile and the image built by BuildImage
+type ImageOption func(*imageOptions)
+
+// WithBaseImage sets the base image of the test image, DefaultBaseImage by default
+func WithBaseImage(image string) ImageOption {
+	return func(o *imageOptions) {
+		o.baseImage = image
+	}
+}
+
+// WithImagePath sets the path of the test binary in the image, DefaultImagePath by default
+func WithImagePath(path string) ImageOption {
+	return func(o *imageOptions) {
+		o.path = path
+	}
+}
+
+// WithDefaultArgs sets the default arguments of the test binary in the image, such as -test.v or
+// --in-cluster, which can be overridden by the args of the container
+func WithDefaultArgs(args ...string) ImageOption {
+	return func(o *imageOptions) {
+		o.args = append(o.args, args...)
+	}
+}
+
+// WithFile copies the file or directory at source, such as the testdata directory of the suite,
+// to target in the image. A relative target is relative to the directory of the test binary.
+func WithFile(source, target string) ImageOption {
+	return func(o *imageOptions) {
+		o.files = append(o.files, imageFile{source: source, target: target})
+	}
+}
+
+// WithImageBuildArgs passes additional arguments to the build command of the container engine
+func WithImageBuildArgs(args ...string) ImageOption {
+	return func(o *imageOptions) {
+		o.buildArgs = append(o.buildArgs, args...)
+	}
+}
+
+func newImageOptions(opts []ImageOption) *imageOptions {
+	options := &imageOptions{baseImage: DefaultBaseImage, path: DefaultImagePath}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// Dockerfile returns a Dockerfile packaging the test binary named binary in the build context into
+// a distroless image, running as a non-root user, whose entrypoint is the test binary. The files
+// added with WithFile are expected in the build context under files/, as laid out by BuildImage.
+func Dockerfile(binary string, opts ...ImageOption) string {
+	return dockerfile(binary, newImageOptions(opts))
+}
+
+func dockerfile(binary string, options *imageOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "FROM %s\n", options.baseImage)
+	workDir := path.Dir(options.path)
+	fmt.Fprintf(&b, "WORKDIR %s\n", workDir)
+	fmt.Fprintf(&b, "COPY %s %s\n", binary, options.path)
+	for i, file := range options.files {
+		target := file.target
+		if !path.IsAbs(target) {
+			target = path.Join(workDir, target)
+		}
+		fmt.Fprintf(&b, "COPY %s %s\n", contextFile(i), target)
+	}
+	b.WriteString("USER 65532:65532\n")
+	fmt.Fprintf(&b, "ENTRYPOINT [%s]\n", strconv.Quote(options.path))
+	if len(options.args) > 0 {
+		args := make([]string, len(options.args))
+		for i, arg := range options.args {
+			args[i] = strconv.Quote(arg)
+		}
+		fmt.Fprintf(&b, "CMD [%s]\n", strings.Join(args, ", "))
+	}
+	return b.String()
+}
+
+// contextFile returns the path in the build context of the i-th file added with WithFile
+func contextFile(i int) string {
+	return fmt.Sprintf("files/%d", i)
+}
+
+// BuildImage builds the image tagged with tag packaging the test binary, built with Build for the
+// platform of the image, with the container engine. The build context is a temporary directory
+// holding the binary, the files added with WithFile and the Dockerfile returned by Dockerfile.
+func BuildImage(ctx context.Context, engine envfuncs.ContainerEngine, binary, tag string, opts ...ImageOption) error {
+	options := newImageOptions(opts)
+
+	buildCtx, err := os.MkdirTemp("", "e2e-image-")
+	if err != nil {
+		return fmt.Errorf("failed to create build context: %w", err)
+	}
+	defer os.RemoveAll(buildCtx)
+
+	name := filepath.Base(binary)
+	if err := copyFile(binary, filepath.Join(buildCtx, name)); err != nil {
+		return fmt.Errorf("failed to copy test binary to build context: %w", err)
+	}
+	for i, file := range options.files {
+		if err := copyPath(file.source, filepath.Join(buildCtx, filepath.FromSlash(contextFile(i)))); err != nil {
+			return fmt.Errorf("failed to copy %s to build context: %w", file.source, err)
+		}
+	}
+	dockerfilePath := filepath.Join(buildCtx, "Dockerfile")
+	if err := os.WriteFile(dockerfilePath, []byte(dockerfile(name, options)), 0o644); err != nil {
+		return fmt.Errorf("failed to write Dockerfile: %w", err)
+	}
+
+	log.V(4).InfoS("Building test image", "image", tag, "binary", binary)
+	if err := engine.BuildImage(ctx, buildCtx, dockerfilePath, tag, options.buildArgs...); err != nil {
+		return fmt.Errorf("build test image %s: %w", tag, err)
+	}
+	return nil
+}
+
+// copyPath copies the file or the directory tree at source to target
+func copyPath(source, target string) error {
+	return filepath.WalkDir(source, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(target, rel)
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0o755)
+		}
+		return copyFile(p, dest)
+	})
+}
+
+// copyFile copies the file at source to target, keeping its permissions
+func copyFile(source, target string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDockerfile(t *testing.T) {
+	tests := []struct {
+		name   string
+		binary string
+		opts   []ImageOption
+		want   string
+	}{
+		{
+			name:   "defaults",
+			binary: "e2e.test",
+			want: `FROM gcr.io/distroless/static:nonroot
+WORKDIR /
+COPY e2e.test /e2e.test
+USER 65532:65532
+ENTRYPOINT ["/e2e.test"]
+`,
+		},
+		{
+			name:   "all options",
+			binary: "suite.test",
+			opts: []ImageOption{
+				WithBaseImage("gcr.io/distroless/base:nonroot"),
+				WithImagePath("/e2e/suite.test"),
+				WithFile("testdata", "testdata"),
+				WithFile("manifests/crd.yaml", "/manifests/crd.yaml"),
+				WithDefaultArgs("--in-cluster", "-test.v"),
+			},
+			want: `FROM gcr.io/distroless/base:nonroot
+WORKDIR /e2e
+COPY suite.test /e2e/suite.test
+COPY files/0 /e2e/testdata
+COPY files/1 /manifests/crd.yaml
+USER 65532:65532
+ENTRYPOINT ["/e2e/suite.test"]
+CMD ["--in-cluster", "-test.v"]
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Dockerfile(test.binary, test.opts...); got != test.want {
+				t.Errorf("unexpected Dockerfile:\n%s\nexpected:\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+// fakeEngine records the build context of the image it is asked to build
+type fakeEngine struct {
+	files map[string]string
+	args  []string
+	err   error
+}
+
+func (e *fakeEngine) BuildImage(_ context.Context, contextPath, dockerfile, tag string, args ...string) error {
+	e.files = map[string]string{}
+	e.args = args
+	err := filepath.Walk(contextPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(contextPath, p)
+		e.files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if filepath.Dir(dockerfile) != contextPath {
+		return errors.New("dockerfile outside of the build context")
+	}
+	return e.err
+}
+
+func TestBuildImage(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "suite.test")
+	if err := os.WriteFile(binary, []byte("binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "testdata", "nested"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "testdata", "nested", "pod.yaml"), []byte("pod"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := &fakeEngine{}
+	err := BuildImage(context.Background(), engine, binary, "e2e:latest",
+		WithFile(filepath.Join(dir, "testdata"), "testdata"),
+		WithImageBuildArgs("--platform", "linux/amd64"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"suite.test":              "binary",
+		"files/0/nested/pod.yaml": "pod",
+		"Dockerfile":              Dockerfile("suite.test", WithFile("testdata", "testdata")),
+	}
+	for name, content := range want {
+		if engine.files[name] != content {
+			t.Errorf("unexpected content of %s in the build context: %q", name, engine.files[name])
+		}
+	}
+	if len(engine.files) != len(want) {
+		t.Errorf("unexpected files in the build context: %v", engine.files)
+	}
+	if len(engine.args) != 2 || engine.args[1] != "linux/amd64" {
+		t.Errorf("unexpected build args: %v", engine.args)
+	}
+
+	engine.err = errors.New("build failed")
+	if err := BuildImage(context.Background(), engine, binary, "e2e:latest"); !errors.Is(err, engine.err) {
+		t.Errorf("expected the error of the container engine, got %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runner compiles e2e test suites into standalone test binaries, packages them into container
+// images and runs them, so that the suites can be shipped to and executed on clusters or air-gapped CI
+// environments without a Go toolchain.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	log "k8s.io/klog/v2"
+)
+
+// DefaultBinaryName is the name of the test binary built by Build when no output is set
+const DefaultBinaryName = "e2e.test"
+
+type buildOptions struct {
+	goBinary string
+	output   string
+	dir      string
+	goos     string
+	goarch   string
+	tags     []string
+	flags    []string
+}
+
+// BuildOption configures how Build compiles a test binary
+type BuildOption func(*buildOptions)
+
+// WithGoBinary sets the path of the go binary used to compile the test binary, go from the PATH by default
+func WithGoBinary(path string) BuildOption {
+	return func(o *buildOptions) {
+		o.goBinary = path
+	}
+}
+
+// WithOutput sets the path of the built test binary, e2e.test in the build directory by default
+func WithOutput(path string) BuildOption {
+	return func(o *buildOptions) {
+		o.output = path
+	}
+}
+
+// WithBuildDir sets the directory the test binary is compiled from, the current directory by default
+func WithBuildDir(dir string) BuildOption {
+	return func(o *buildOptions) {
+		o.dir = dir
+	}
+}
+
+// WithPlatform sets the target operating system and architecture of the test binary, such as linux and
+// amd64 for a binary packaged into a container image. The host platform is used by default.
+func WithPlatform(goos, goarch string) BuildOption {
+	return func(o *buildOptions) {
+		o.goos = goos
+		o.goarch = goarch
+	}
+}
+
+// WithBuildTags sets the build tags used to compile the test binary
+func WithBuildTags(tags ...string) BuildOption {
+	return func(o *buildOptions) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// WithBuildFlags passes additional flags to `go test -c`, such as -trimpath
+func WithBuildFlags(flags ...string) BuildOption {
+	return func(o *buildOptions) {
+		o.flags = append(o.flags, flags...)
+	}
+}
+
+// Build compiles the tests of pkg into a standalone test binary with `go test -c` and returns the absolute
+// path of the binary. The binary is statically linked (CGO_ENABLED=0) so that it can run from a distroless
+// image.
+func Build(ctx context.Context, pkg string, opts ...BuildOption) (string, error) {
+	options := &buildOptions{goBinary: "go", output: DefaultBinaryName}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	goBinary, err := exec.LookPath(options.goBinary)
+	if err != nil {
+		return "", fmt.Errorf("go binary not found: %w", err)
+	}
+	output := options.output
+	if !filepath.IsAbs(output) {
+		dir, err := filepath.Abs(options.dir)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve build directory: %w", err)
+		}
+		output = filepath.Join(dir, output)
+	}
+
+	args := buildArgs(pkg, output, options)
+	log.V(4).InfoS("Building test binary", "package", pkg, "output", output, "args", args)
+	cmd := exec.CommandContext(ctx, goBinary, args...)
+	cmd.Dir = options.dir
+	cmd.Env = buildEnv(os.Environ(), options)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("go test -c %s failed: %w: %s", pkg, err, strings.TrimSpace(string(out)))
+	}
+	return output, nil
+}
+
+// buildArgs returns the arguments of the go command compiling the test binary of pkg to output
+func buildArgs(pkg, output string, options *buildOptions) []string {
+	args := []string{"test", "-c", "-o", output}
+	if len(options.tags) > 0 {
+		args = append(args, "-tags", strings.Join(options.tags, ","))
+	}
+	args = append(args, options.flags...)
+	return append(args, pkg)
+}
+
+// buildEnv returns the environment of the go command, which disables cgo and sets the target platform
+func buildEnv(environ []string, options *buildOptions) []string {
+	env := append(append([]string{}, environ...), "CGO_ENABLED=0")
+	if options.goos != "" {
+		env = append(env, "GOOS="+options.goos)
+	}
+	if options.goarch != "" {
+		env = append(env, "GOARCH="+options.goarch)
+	}
+	return env
+}
+
+type runOptions struct {
+	args   []string
+	dir    string
+	env    []string
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// RunOption configures how Run executes a test binary
+type RunOption func(*runOptions)
+
+// WithArgs passes arguments to the test binary, such as -test.v or -test.run
+func WithArgs(args ...string) RunOption {
+	return func(o *runOptions) {
+		o.args = append(o.args, args...)
+	}
+}
+
+// WithFlag passes the framework flag name with value to the test binary, as --name=value
+func WithFlag(name, value string) RunOption {
+	return func(o *runOptions) {
+		o.args = append(o.args, fmt.Sprintf("--%s=%s", name, value))
+	}
+}
+
+// WithRunDir sets the working directory of the test binary, the current directory by default
+func WithRunDir(dir string) RunOption {
+	return func(o *runOptions) {
+		o.dir = dir
+	}
+}
+
+// WithEnv adds environment variables, as key=value, to the environment of the test binary
+func WithEnv(env ...string) RunOption {
+	return func(o *runOptions) {
+		o.env = append(o.env, env...)
+	}
+}
+
+// WithStdout sets the writer receiving the standard output of the test binary, os.Stdout by default
+func WithStdout(w io.Writer) RunOption {
+	return func(o *runOptions) {
+		o.stdout = w
+	}
+}
+
+// WithStderr sets the writer receiving the standard error of the test binary, os.Stderr by default
+func WithStderr(w io.Writer) RunOption {
+	return func(o *runOptions) {
+		o.stderr = w
+	}
+}
+
+// Run executes the test binary built with Build, or `go test -c`, with the arguments and flags
+// set with the options. It returns an error when the binary fails, such as when a test fails.
+func Run(ctx context.Context, binary string, opts ...RunOption) error {
+	options := &runOptions{stdout: os.Stdout, stderr: os.Stderr}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	log.V(4).InfoS("Running test binary", "binary", binary, "args", options.args)
+	cmd := exec.CommandContext(ctx, binary, options.args...)
+	cmd.Dir = options.dir
+	cmd.Env = append(os.Environ(), options.env...)
+	cmd.Stdout = options.stdout
+	cmd.Stderr = options.stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("test binary %s failed: %w", binary, err)
+	}
+	return nil
+}
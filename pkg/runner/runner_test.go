@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeBinary writes an executable shell script running script to dir
+func fakeBinary(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake binaries are shell scripts")
+	}
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []BuildOption
+		wantArgs []string
+		wantEnv  []string
+	}{
+		{
+			name:     "defaults",
+			wantArgs: []string{"test", "-c", "-o", "e2e.test", "./e2e"},
+			wantEnv:  []string{"CGO_ENABLED=0"},
+		},
+		{
+			name: "all options",
+			opts: []BuildOption{
+				WithOutput("suite.test"),
+				WithPlatform("linux", "arm64"),
+				WithBuildTags("e2e", "slow"),
+				WithBuildFlags("-trimpath"),
+			},
+			wantArgs: []string{"test", "-c", "-o", "suite.test", "-tags", "e2e,slow", "-trimpath", "./e2e"},
+			wantEnv:  []string{"CGO_ENABLED=0", "GOOS=linux", "GOARCH=arm64"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			goBinary := fakeBinary(t, dir, "go", `for arg in "$@"; do echo "$arg"; done > args
+env | grep -E '^(CGO_ENABLED|GOOS|GOARCH)=' | sort > env
+`)
+			opts := append([]BuildOption{WithGoBinary(goBinary), WithBuildDir(dir)}, test.opts...)
+			output, err := Build(context.Background(), "./e2e", opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := filepath.Join(dir, test.wantArgs[3]); output != want {
+				t.Errorf("expected output %s, got %s", want, output)
+			}
+
+			args := readLines(t, filepath.Join(dir, "args"))
+			test.wantArgs[3] = output
+			if !reflect.DeepEqual(args, test.wantArgs) {
+				t.Errorf("expected args %q, got %q", test.wantArgs, args)
+			}
+			for _, env := range test.wantEnv {
+				if !strings.Contains(strings.Join(readLines(t, filepath.Join(dir, "env")), "\n"), env) {
+					t.Errorf("expected %s in the environment of the go command", env)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildFailure(t *testing.T) {
+	goBinary := fakeBinary(t, t.TempDir(), "go", "echo 'no Go files' >&2\nexit 1\n")
+	_, err := Build(context.Background(), "./e2e", WithGoBinary(goBinary))
+	if err == nil || !strings.Contains(err.Error(), "no Go files") {
+		t.Errorf("expected an error with the output of the go command, got %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		opts    []RunOption
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "args and flags",
+			script: `echo "$@"`,
+			opts:   []RunOption{WithArgs("-test.v"), WithFlag("namespace", "e2e"), WithFlag("parallel", "true")},
+			want:   "-test.v --namespace=e2e --parallel=true\n",
+		},
+		{
+			name:   "environment",
+			script: `echo "$E2E_RUNNER_TEST"`,
+			opts:   []RunOption{WithEnv("E2E_RUNNER_TEST=value")},
+			want:   "value\n",
+		},
+		{
+			name:    "failing tests",
+			script:  "echo FAIL\nexit 1\n",
+			want:    "FAIL\n",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			binary := fakeBinary(t, t.TempDir(), "e2e.test", test.script)
+			var stdout bytes.Buffer
+			err := Run(context.Background(), binary, append(test.opts, WithStdout(&stdout))...)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if stdout.String() != test.want {
+				t.Errorf("expected output %q, got %q", test.want, stdout.String())
+			}
+		})
+	}
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
@@ -41,6 +41,13 @@ type EnvFunc func(context.Context, *envconf.Config) (context.Context, error)
 // features.
 type FeatureEnvFunc func(context.Context, *envconf.Config, *testing.T, Feature) (context.Context, error)
 
+// AssessmentEnvFunc represents a user-defined operation that
+// can be used to customize the behavior of the
+// environment. Changes to context are expected to surface
+// to caller. Meant for use with before/after assessment hooks,
+// it receives the names of the feature and of the assessment.
+type AssessmentEnvFunc func(ctx context.Context, cfg *envconf.Config, t *testing.T, feature, assessment string) (context.Context, error)
+
 // TestEnvFunc represents a user-defined operation that
 // can be used to customize the behavior of the
 // environment. Changes to context are expected to surface
@@ -69,6 +76,19 @@ type Environment interface {
 	// after each feature is tested during an env.Test call.
 	AfterEachFeature(...FeatureEnvFunc) Environment
 
+	// BeforeEachAssessment registers functions that are executed
+	// before each assessment of a feature during an env.Test call.
+	BeforeEachAssessment(...AssessmentEnvFunc) Environment
+
+	// AfterEachAssessment registers functions that are executed
+	// after each assessment of a feature during an env.Test call.
+	AfterEachAssessment(...AssessmentEnvFunc) Environment
+
+	// Fixture registers a named fixture shared by the features that
+	// require it. It is set up the first time a feature requires it
+	// and torn down at the end of the test suite.
+	Fixture(name string, setup, teardown EnvFunc) Environment
+
 	// Test executes a test feature defined in a TestXXX function
 	// This method surfaces context for further updates.
 	Test(*testing.T, ...Feature) context.Context
@@ -83,7 +103,8 @@ type Environment interface {
 	AfterEachTest(...TestEnvFunc) Environment
 
 	// Finish registers funcs that are executed at the end of the
-	// test suite.
+	// test suite. Their errors are logged, and only fail the suite
+	// when they wrap env.ErrSuiteFailed.
 	Finish(...EnvFunc) Environment
 
 	// Run Launches the test suite from within a TestMain
@@ -95,6 +116,9 @@ type Environment interface {
 
 type Labels = flags.LabelsMap
 
+// Shard is the strategy used to partition the test features of a suite into shards
+type Shard = envconf.ShardStrategy
+
 type Feature interface {
 	// Name is a descriptive text for the feature
 	Name() string
@@ -133,6 +157,44 @@ type DescribableStep interface {
 	Description() string
 }
 
+// SkippableStep is a Step that can be skipped, either unconditionally or based on a condition
+// evaluated with the context and configuration the step would run with. It is honoured for
+// assessment steps.
+type SkippableStep interface {
+	Step
+
+	// ShouldSkip reports whether the step must be skipped and the reason why
+	ShouldSkip(ctx context.Context, cfg *envconf.Config) (bool, string)
+}
+
+// ExpectedFailureStep is a Step that is known to fail. It is honoured for assessment steps, which
+// are reported as skipped expected failures instead of being executed.
+type ExpectedFailureStep interface {
+	Step
+
+	// ExpectedFailure returns the reason the step is known to fail, or an empty string
+	ExpectedFailure() string
+}
+
+// FeatureMetadata holds the metadata of a feature used to trace it in test management systems
+type FeatureMetadata struct {
+	// ID is the identifier of the test case of the feature in a test management system
+	ID string `json:"id,omitempty"`
+	// Owners are the people or teams owning the feature
+	Owners []string `json:"owners,omitempty"`
+	// Links are links to documents related to the feature, such as specifications or issues
+	Links []string `json:"links,omitempty"`
+}
+
+// MetadataFeature is a Feature that carries metadata, which is exported in the catalog of the
+// features written with the --list-features flag
+type MetadataFeature interface {
+	Feature
+
+	// Metadata returns the metadata of the feature
+	Metadata() FeatureMetadata
+}
+
 type DescribableFeature interface {
 	Feature
 
@@ -142,6 +204,44 @@ type DescribableFeature interface {
 	Description() string
 }
 
+// OrderedFeature is a Feature that carries an execution order. When the environment is configured
+// to sort the features, features with a lower order are executed first.
+type OrderedFeature interface {
+	Feature
+
+	// Order returns the execution order of the feature
+	Order() int
+}
+
+// DependentFeature is a Feature that depends on the successful execution of other features
+// passed to the same Test or TestInParallel call. The feature is executed after its dependencies
+// and skipped if any of them fails or is skipped.
+type DependentFeature interface {
+	Feature
+
+	// Dependencies returns the names of the features this feature depends on
+	Dependencies() []string
+}
+
+// FixtureFeature is a Feature that requires fixtures registered with Environment.Fixture. The
+// fixtures are set up before the feature runs, the first time a feature requires them.
+type FixtureFeature interface {
+	Feature
+
+	// Fixtures returns the names of the fixtures the feature requires
+	Fixtures() []string
+}
+
+// VersionedFeature is a Feature that only supports a range of Kubernetes versions. The feature
+// is skipped when the version of the API server of the cluster is outside of the range.
+type VersionedFeature interface {
+	Feature
+
+	// KubeVersionRange returns the minimum and maximum supported Kubernetes versions, such as
+	// "1.29". An empty value means the range is unbounded on that side.
+	KubeVersionRange() (minVersion, maxVersion string)
+}
+
 type ClusterOpts func(c E2EClusterProvider)
 
 type Node struct {
@@ -277,3 +377,155 @@ type E2EClusterProviderWithLifeCycle interface {
 	// List of existing nodes on the cluster and their state before they can be operated on.
 	ListNode(ctx context.Context, args ...string) ([]Node, error)
 }
+
+// E2EClusterProviderWithLocalRegistry is an interface that extends the E2EClusterProvider interface for the providers
+// that can be wired to a container registry running on the host, which is used to push images built by the tests.
+type E2EClusterProviderWithLocalRegistry interface {
+	E2EClusterProvider
+
+	// SetupLocalRegistry starts a container registry listening on the given port of the host, if not already running,
+	// and configures the cluster nodes to pull images from it. It returns the endpoint images are pushed to.
+	SetupLocalRegistry(ctx context.Context, port int) (string, error)
+
+	// LocalRegistryEndpoint returns the endpoint of the local registry configured for the cluster, e.g. localhost:5001.
+	// An empty string is returned if no local registry has been configured.
+	LocalRegistryEndpoint() string
+}
+
+// E2EClusterProviderWithPause is an interface that extends the E2EClusterProvider interface for the providers
+// that can freeze all the nodes of a cluster, e.g. with docker pause. This can be used to simulate a full outage
+// of the cluster, including its control plane.
+type E2EClusterProviderWithPause interface {
+	E2EClusterProvider
+
+	// Pause freezes all the nodes of the cluster without stopping them
+	Pause(ctx context.Context) error
+
+	// Resume unfreezes all the nodes of the cluster frozen by Pause
+	Resume(ctx context.Context) error
+}
+
+// E2EClusterProviderWithSnapshot is an interface that extends the E2EClusterProvider interface for the providers
+// that can save the state of a cluster and restore it afterwards. This can be used to reset a cluster to a known
+// baseline between groups of features instead of recreating the cluster.
+//
+// What the path designates depends on the provider: the k3d provider saves the datastore directory of the
+// cluster into the path, which is a directory, while the kwok provider saves an etcd snapshot, which is a file.
+// The path given to Restore has to be the one given to Snapshot by the same provider.
+type E2EClusterProviderWithSnapshot interface {
+	E2EClusterProvider
+
+	// Snapshot saves the state of the cluster into the path on the host, replacing any previous snapshot saved into it
+	Snapshot(ctx context.Context, path string) error
+
+	// Restore resets the state of the cluster to the snapshot saved into the path on the host
+	Restore(ctx context.Context, path string) error
+}
+
+// E2EClusterProviderWithNodeScaling is an interface that extends the E2EClusterProvider interface for the providers
+// that can change the number of nodes of a cluster in a single operation, such as kwok with its simulated nodes.
+type E2EClusterProviderWithNodeScaling interface {
+	E2EClusterProvider
+
+	// ScaleNodes changes the number of nodes of the cluster to replicas
+	ScaleNodes(ctx context.Context, replicas int, args ...string) error
+}
+
+// E2EClusterProviderWithNetworkPartition is an interface that extends the E2EClusterProvider interface for the
+// providers that can cut a node off the network of the cluster while leaving it running. This can be used to
+// simulate a network partition, during which the node and its workloads keep running but are unreachable.
+type E2EClusterProviderWithNetworkPartition interface {
+	E2EClusterProvider
+
+	// PartitionNode disconnects the node from the network of the cluster
+	PartitionNode(ctx context.Context, node *Node) error
+
+	// HealNode reconnects a node disconnected by PartitionNode to the network of the cluster
+	HealNode(ctx context.Context, node *Node) error
+}
+
+// E2EClusterProviderWithUpgrade is an interface that extends the E2EClusterProvider interface for the providers
+// that can move a running cluster to another Kubernetes version. This can be used to validate the behavior of
+// workloads and operators across control plane upgrades.
+type E2EClusterProviderWithUpgrade interface {
+	E2EClusterProvider
+
+	// Upgrade moves the cluster to the given Kubernetes version, e.g. v1.31.0, and returns the path of the
+	// kubeconfig file of the upgraded cluster, which may differ from the one returned by Create. Providers
+	// that cannot upgrade a cluster in place recreate it, in which case the state of the cluster is lost.
+	Upgrade(ctx context.Context, version string) (string, error)
+}
+
+// ProviderOptionKey identifies a typed option that can be configured on a cluster provider
+// independently of the provider implementation in use.
+type ProviderOptionKey string
+
+const (
+	// ProviderOptionImage configures the node image used by the provider
+	ProviderOptionImage ProviderOptionKey = "image"
+	// ProviderOptionWorkers configures the number of worker nodes of the cluster
+	ProviderOptionWorkers ProviderOptionKey = "workers"
+	// ProviderOptionRegistry configures the port of a local image registry attached to the cluster
+	ProviderOptionRegistry ProviderOptionKey = "registry"
+	// ProviderOptionWait configures how long the provider waits for the control plane during create
+	ProviderOptionWait ProviderOptionKey = "wait"
+)
+
+// E2EClusterProviderWithOptions is an interface that extends the E2EClusterProvider interface for the providers
+// that declare the typed options they support. Options that are set using the support.WithProviderOption helper
+// are validated against SupportedOptions before the cluster is created so that unknown keys surface early.
+type E2EClusterProviderWithOptions interface {
+	E2EClusterProvider
+
+	// SupportedOptions returns the option keys that the provider understands
+	SupportedOptions() []ProviderOptionKey
+
+	// SetOption parses value and applies it to the option identified by key
+	SetOption(key ProviderOptionKey, value string) error
+}
+
+// Capability identifies an optional feature of a cluster provider
+type Capability string
+
+const (
+	// CapabilityImageLoad is reported by providers implementing E2EClusterProviderWithImageLoader
+	CapabilityImageLoad Capability = "image-load"
+	// CapabilityLifeCycle is reported by providers implementing E2EClusterProviderWithLifeCycle
+	CapabilityLifeCycle Capability = "lifecycle"
+	// CapabilityLocalRegistry is reported by providers implementing E2EClusterProviderWithLocalRegistry
+	CapabilityLocalRegistry Capability = "local-registry"
+	// CapabilityPause is reported by providers implementing E2EClusterProviderWithPause
+	CapabilityPause Capability = "pause"
+	// CapabilitySnapshot is reported by providers implementing E2EClusterProviderWithSnapshot
+	CapabilitySnapshot Capability = "snapshot"
+	// CapabilityNodeScaling is reported by providers implementing E2EClusterProviderWithNodeScaling
+	CapabilityNodeScaling Capability = "node-scaling"
+	// CapabilityNetworkPartition is reported by providers implementing E2EClusterProviderWithNetworkPartition
+	CapabilityNetworkPartition Capability = "network-partition"
+	// CapabilityUpgrade is reported by providers implementing E2EClusterProviderWithUpgrade
+	CapabilityUpgrade Capability = "upgrade"
+	// CapabilityOptions is reported by providers implementing E2EClusterProviderWithOptions
+	CapabilityOptions Capability = "options"
+	// CapabilityLogsExport is reported by providers whose ExportLogs implementation actually extracts
+	// the cluster logs. Every provider implements ExportLogs, so this has to be declared by the provider
+	// using E2EClusterProviderWithCapabilities.
+	CapabilityLogsExport Capability = "logs-export"
+)
+
+// Capabilities is the set of capabilities supported by a cluster provider
+type Capabilities map[Capability]bool
+
+// Has returns true if capability is part of the set
+func (c Capabilities) Has(capability Capability) bool {
+	return c[capability]
+}
+
+// E2EClusterProviderWithCapabilities is an interface that extends the E2EClusterProvider interface for the providers
+// that support capabilities which cannot be inferred from the optional interfaces they implement.
+type E2EClusterProviderWithCapabilities interface {
+	E2EClusterProvider
+
+	// Capabilities returns the capabilities declared by the provider. These are added to the capabilities
+	// inferred from the optional interfaces implemented by the provider.
+	Capabilities() Capabilities
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/vladimirvivien/gexe"
 	"github.com/vladimirvivien/gexe/exec"
@@ -98,6 +99,17 @@ func RunCommand(command string) *exec.Proc {
 	return commandRunner.RunProc(command)
 }
 
+// NewProcWithKubeconfig returns the process of command, not started yet, with the KUBECONFIG environment variable
+// set to kubeconfig when it is not empty. The variable is only set for the process, the environment of the tests
+// is left untouched.
+func NewProcWithKubeconfig(command, kubeconfig string) *exec.Proc {
+	p := commandRunner.NewProc(command)
+	if cmd := p.Command(); cmd != nil && kubeconfig != "" {
+		cmd.Env = append(os.Environ(), "KUBECONFIG="+kubeconfig)
+	}
+	return p
+}
+
 // RunCommandWithSeperatedOutput run command and returns the results to the provided
 // stdout and stderr io.Writer.
 func RunCommandWithSeperatedOutput(command string, stdout, stderr io.Writer) error {
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workloads provides builders of the workloads commonly created by tests, such as deployments, jobs, pods
+// and services, with sensible defaults that option functions customize:
+//
+//	deployment := workloads.NewDeployment(namespace, "web", "nginx", 2, workloads.WithPort(80))
+//	service := workloads.NewService(namespace, "web", 80)
+//	err := cfg.Client().Resources().Create(ctx, deployment)
+//
+// Objects are labeled app=<name> unless WithLabels sets other labels, and the selectors of deployments and services
+// match these labels, so a service selects the pods of the deployment of the same name. The builders return regular
+// API objects, which can be modified further before they are created.
+package workloads
+
+import (
+	"fmt"
+	"maps"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type options struct {
+	labels         map[string]string
+	annotations    map[string]string
+	containerName  string
+	command        []string
+	args           []string
+	env            []corev1.EnvVar
+	ports          []int32
+	serviceAccount string
+	podSpecFuncs   []func(*corev1.PodSpec)
+}
+
+// Option customizes the objects built by the package
+type Option func(*options)
+
+// WithLabels sets the labels of the object and of its pods, which are also used as selector by deployments and
+// services, instead of the default app=<name> label
+func WithLabels(labels map[string]string) Option {
+	return func(o *options) {
+		o.labels = labels
+	}
+}
+
+// WithAnnotations sets the annotations of the object and of its pods
+func WithAnnotations(annotations map[string]string) Option {
+	return func(o *options) {
+		o.annotations = annotations
+	}
+}
+
+// WithContainerName sets the name of the container, which defaults to the name of the object
+func WithContainerName(name string) Option {
+	return func(o *options) {
+		o.containerName = name
+	}
+}
+
+// WithCommand sets the command of the container, which overrides the entrypoint of its image
+func WithCommand(command ...string) Option {
+	return func(o *options) {
+		o.command = command
+	}
+}
+
+// WithArgs sets the arguments of the container
+func WithArgs(args ...string) Option {
+	return func(o *options) {
+		o.args = args
+	}
+}
+
+// WithEnv adds an environment variable to the container
+func WithEnv(name, value string) Option {
+	return func(o *options) {
+		o.env = append(o.env, corev1.EnvVar{Name: name, Value: value})
+	}
+}
+
+// WithPort adds a TCP port exposed by the container. For services, it adds a port, targeting the same port of the
+// pods, to the one passed to NewService.
+func WithPort(port int32) Option {
+	return func(o *options) {
+		o.ports = append(o.ports, port)
+	}
+}
+
+// WithServiceAccount sets the service account of the pods
+func WithServiceAccount(name string) Option {
+	return func(o *options) {
+		o.serviceAccount = name
+	}
+}
+
+// WithPodSpec registers a function modifying the pod spec once it is built, for the settings that are not covered
+// by the other options, such as resources, volumes or affinity
+func WithPodSpec(fn func(spec *corev1.PodSpec)) Option {
+	return func(o *options) {
+		o.podSpecFuncs = append(o.podSpecFuncs, fn)
+	}
+}
+
+func newOptions(name string, opts []Option) *options {
+	o := &options{containerName: name}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.labels == nil {
+		o.labels = map[string]string{"app": name}
+	}
+	return o
+}
+
+func (o *options) objectMeta(namespace, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: maps.Clone(o.labels), Annotations: maps.Clone(o.annotations)}
+}
+
+func (o *options) podTemplate(image string, restartPolicy corev1.RestartPolicy) corev1.PodTemplateSpec {
+	container := corev1.Container{
+		Name:    o.containerName,
+		Image:   image,
+		Command: o.command,
+		Args:    o.args,
+		Env:     o.env,
+	}
+	for _, port := range o.ports {
+		container.Ports = append(container.Ports, corev1.ContainerPort{ContainerPort: port, Protocol: corev1.ProtocolTCP})
+	}
+	spec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      restartPolicy,
+		ServiceAccountName: o.serviceAccount,
+	}
+	for _, fn := range o.podSpecFuncs {
+		fn(&spec)
+	}
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: maps.Clone(o.labels), Annotations: maps.Clone(o.annotations)},
+		Spec:       spec,
+	}
+}
+
+// NewPod builds a pod running a single container of image
+func NewPod(namespace, name, image string, opts ...Option) *corev1.Pod {
+	o := newOptions(name, opts)
+	return &corev1.Pod{
+		ObjectMeta: o.objectMeta(namespace, name),
+		Spec:       o.podTemplate(image, "").Spec,
+	}
+}
+
+// NewDeployment builds a deployment of replicas pods running a single container of image
+func NewDeployment(namespace, name, image string, replicas int32, opts ...Option) *appsv1.Deployment {
+	o := newOptions(name, opts)
+	return &appsv1.Deployment{
+		ObjectMeta: o.objectMeta(namespace, name),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: maps.Clone(o.labels)},
+			Template: o.podTemplate(image, ""),
+		},
+	}
+}
+
+// NewJob builds a job running a single container of image to completion. Its pods are not restarted when they
+// fail, the job retries them according to its default backoff limit.
+func NewJob(namespace, name, image string, opts ...Option) *batchv1.Job {
+	o := newOptions(name, opts)
+	return &batchv1.Job{
+		ObjectMeta: o.objectMeta(namespace, name),
+		Spec: batchv1.JobSpec{
+			Template: o.podTemplate(image, corev1.RestartPolicyNever),
+		},
+	}
+}
+
+// NewService builds a ClusterIP service exposing port, and the ports added with WithPort, of the pods selected by
+// its labels, which are the pods of the workloads of the same name by default
+func NewService(namespace, name string, port int32, opts ...Option) *corev1.Service {
+	o := newOptions(name, opts)
+	service := &corev1.Service{
+		ObjectMeta: o.objectMeta(namespace, name),
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: maps.Clone(o.labels),
+		},
+	}
+	for _, p := range append([]int32{port}, o.ports...) {
+		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+			Name:       fmt.Sprintf("tcp-%d", p),
+			Protocol:   corev1.ProtocolTCP,
+			Port:       p,
+			TargetPort: intstr.FromInt32(p),
+		})
+	}
+	return service
+}
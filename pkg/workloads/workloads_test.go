@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNewDeployment(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []Option
+		labels        map[string]string
+		containerName string
+	}{
+		{
+			name:          "defaults",
+			labels:        map[string]string{"app": "web"},
+			containerName: "web",
+		},
+		{
+			name:          "options",
+			opts:          []Option{WithLabels(map[string]string{"app": "test-app"}), WithContainerName("nginx")},
+			labels:        map[string]string{"app": "test-app"},
+			containerName: "nginx",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deployment := NewDeployment("default", "web", "nginx", 3, test.opts...)
+			if deployment.Name != "web" || deployment.Namespace != "default" || *deployment.Spec.Replicas != 3 {
+				t.Errorf("unexpected deployment %s/%s with %d replicas", deployment.Namespace, deployment.Name, *deployment.Spec.Replicas)
+			}
+			for _, labels := range []map[string]string{deployment.Labels, deployment.Spec.Selector.MatchLabels, deployment.Spec.Template.Labels} {
+				if !reflect.DeepEqual(labels, test.labels) {
+					t.Errorf("expected labels %v, got %v", test.labels, labels)
+				}
+			}
+			containers := deployment.Spec.Template.Spec.Containers
+			if len(containers) != 1 || containers[0].Name != test.containerName || containers[0].Image != "nginx" {
+				t.Errorf("unexpected containers %v", containers)
+			}
+			deployment.Labels["changed"] = "true"
+			if _, ok := deployment.Spec.Selector.MatchLabels["changed"]; ok {
+				t.Error("expected the labels of the deployment and its selector to be distinct maps")
+			}
+		})
+	}
+}
+
+func TestNewJob(t *testing.T) {
+	job := NewJob("default", "migrate", "busybox", WithCommand("sh", "-c"), WithArgs("exit 0"), WithEnv("MODE", "test"),
+		WithServiceAccount("runner"), WithPodSpec(func(spec *corev1.PodSpec) {
+			spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+		}))
+	spec := job.Spec.Template.Spec
+	if spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("expected pods that are never restarted, got %q", spec.RestartPolicy)
+	}
+	if spec.ServiceAccountName != "runner" || spec.NodeSelector["kubernetes.io/os"] != "linux" {
+		t.Errorf("expected the pod spec options to be applied, got %+v", spec)
+	}
+	container := spec.Containers[0]
+	if !reflect.DeepEqual(container.Command, []string{"sh", "-c"}) || !reflect.DeepEqual(container.Args, []string{"exit 0"}) {
+		t.Errorf("unexpected command %v and args %v", container.Command, container.Args)
+	}
+	if !reflect.DeepEqual(container.Env, []corev1.EnvVar{{Name: "MODE", Value: "test"}}) {
+		t.Errorf("unexpected environment %v", container.Env)
+	}
+}
+
+func TestNewPod(t *testing.T) {
+	pod := NewPod("default", "client", "curlimages/curl", WithAnnotations(map[string]string{"note": "test"}), WithPort(8080))
+	if pod.Labels["app"] != "client" || pod.Annotations["note"] != "test" {
+		t.Errorf("unexpected metadata %+v", pod.ObjectMeta)
+	}
+	if pod.Spec.RestartPolicy != "" {
+		t.Errorf("expected the default restart policy, got %q", pod.Spec.RestartPolicy)
+	}
+	if ports := pod.Spec.Containers[0].Ports; len(ports) != 1 || ports[0].ContainerPort != 8080 {
+		t.Errorf("unexpected ports %v", ports)
+	}
+}
+
+func TestNewService(t *testing.T) {
+	service := NewService("default", "web", 80, WithPort(443))
+	if !reflect.DeepEqual(service.Spec.Selector, map[string]string{"app": "web"}) {
+		t.Errorf("expected the service to select the pods of the web workloads, got %v", service.Spec.Selector)
+	}
+	want := []corev1.ServicePort{
+		{Name: "tcp-80", Protocol: corev1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt32(80)},
+		{Name: "tcp-443", Protocol: corev1.ProtocolTCP, Port: 443, TargetPort: intstr.FromInt32(443)},
+	}
+	if !reflect.DeepEqual(service.Spec.Ports, want) {
+		t.Errorf("expected ports %v, got %v", want, service.Spec.Ports)
+	}
+}
@@ -0,0 +1,32 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aks
+
+import (
+	tptaks "sigs.k8s.io/e2e-framework/third_party/aks"
+)
+
+type Cluster = tptaks.Cluster
+
+var (
+	NewCluster        = tptaks.NewCluster
+	NewProvider       = tptaks.NewProvider
+	WithPath          = tptaks.WithPath
+	WithNodes         = tptaks.WithNodes
+	WithArgs          = tptaks.WithArgs
+	WithResourceGroup = tptaks.WithResourceGroup
+)
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package support
+
+// CapabilitiesOf returns the set of capabilities supported by the provider p. Capabilities are
+// inferred from the optional interfaces implemented by the provider and extended with the ones
+// declared through E2EClusterProviderWithCapabilities. This can be used by test suites to skip
+// features that rely on a capability that is not supported by the selected provider.
+func CapabilitiesOf(p E2EClusterProvider) Capabilities {
+	caps := Capabilities{}
+	if p == nil {
+		return caps
+	}
+	if _, ok := p.(E2EClusterProviderWithImageLoader); ok {
+		caps[CapabilityImageLoad] = true
+	}
+	if _, ok := p.(E2EClusterProviderWithLifeCycle); ok {
+		caps[CapabilityLifeCycle] = true
+	}
+	if _, ok := p.(E2EClusterProviderWithLocalRegistry); ok {
+		caps[CapabilityLocalRegistry] = true
+	}
+	if _, ok := p.(E2EClusterProviderWithPause); ok {
+		caps[CapabilityPause] = true
+	}
+	if _, ok := p.(E2EClusterProviderWithSnapshot); ok {
+		caps[CapabilitySnapshot] = true
+	}
+	if _, ok := p.(E2EClusterProviderWithNodeScaling); ok {
+		caps[CapabilityNodeScaling] = true
+	}
+	if _, ok := p.(E2EClusterProviderWithNetworkPartition); ok {
+		caps[CapabilityNetworkPartition] = true
+	}
+	if _, ok := p.(E2EClusterProviderWithUpgrade); ok {
+		caps[CapabilityUpgrade] = true
+	}
+	if _, ok := p.(E2EClusterProviderWithOptions); ok {
+		caps[CapabilityOptions] = true
+	}
+	if d, ok := p.(E2EClusterProviderWithCapabilities); ok {
+		for capability, supported := range d.Capabilities() {
+			if supported {
+				caps[capability] = true
+			}
+		}
+	}
+	return caps
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package support
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeProvider struct {
+	E2EClusterProvider
+}
+
+type fakePausableProvider struct {
+	fakeProvider
+}
+
+func (f *fakePausableProvider) Pause(ctx context.Context) error  { return nil }
+func (f *fakePausableProvider) Resume(ctx context.Context) error { return nil }
+
+func (f *fakePausableProvider) PartitionNode(ctx context.Context, node *Node) error { return nil }
+func (f *fakePausableProvider) HealNode(ctx context.Context, node *Node) error      { return nil }
+
+func (f *fakePausableProvider) Capabilities() Capabilities {
+	return Capabilities{CapabilityLogsExport: true, CapabilitySnapshot: false}
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider E2EClusterProvider
+		want     []Capability
+		notWant  []Capability
+	}{
+		{
+			name:     "no optional interfaces",
+			provider: &fakeProvider{},
+			notWant:  []Capability{CapabilityImageLoad, CapabilityPause, CapabilityNetworkPartition, CapabilityLogsExport},
+		},
+		{
+			name:     "inferred and declared capabilities",
+			provider: &fakePausableProvider{},
+			want:     []Capability{CapabilityPause, CapabilityNetworkPartition, CapabilityLogsExport},
+			notWant:  []Capability{CapabilityLifeCycle, CapabilitySnapshot, CapabilityUpgrade},
+		},
+		{
+			name:    "nil provider",
+			notWant: []Capability{CapabilityLogsExport},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caps := CapabilitiesOf(tt.provider)
+			for _, c := range tt.want {
+				if !caps.Has(c) {
+					t.Errorf("expected capability %q to be supported", c)
+				}
+			}
+			for _, c := range tt.notWant {
+				if caps.Has(c) {
+					t.Errorf("expected capability %q not to be supported", c)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k3s
+
+import (
+	tptk3s "sigs.k8s.io/e2e-framework/third_party/k3s"
+)
+
+type Cluster = tptk3s.Cluster
+
+var (
+	NewCluster  = tptk3s.NewCluster
+	NewProvider = tptk3s.NewProvider
+	WithPath    = tptk3s.WithPath
+	WithImage   = tptk3s.WithImage
+	WithArgs    = tptk3s.WithArgs
+)
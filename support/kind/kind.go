@@ -23,8 +23,13 @@ import (
 type Cluster = tptkind.Cluster
 
 var (
-	NewCluster  = tptkind.NewCluster
-	NewProvider = tptkind.NewProvider
-	WithImage   = tptkind.WithImage
-	WithPath    = tptkind.WithPath
+	NewCluster            = tptkind.NewCluster
+	NewProvider           = tptkind.NewProvider
+	WithImage             = tptkind.WithImage
+	WithPath              = tptkind.WithPath
+	WithWorkers           = tptkind.WithWorkers
+	WithControlPlanes     = tptkind.WithControlPlanes
+	WithLocalRegistry     = tptkind.WithLocalRegistry
+	WithLocalRegistryName = tptkind.WithLocalRegistryName
+	DeleteLocalRegistry   = tptkind.DeleteLocalRegistry
 )
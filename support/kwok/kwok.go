@@ -20,11 +20,19 @@ import (
 	tptkwok "sigs.k8s.io/e2e-framework/third_party/kwok"
 )
 
-type Cluster = tptkwok.Cluster
+type (
+	Cluster    = tptkwok.Cluster
+	NodeOption = tptkwok.NodeOption
+)
 
 var (
 	NewCluster       = tptkwok.NewCluster
 	NewProvider      = tptkwok.NewProvider
 	WithPath         = tptkwok.WithPath
 	WithWaitDuration = tptkwok.WithWaitDuration
+	WithNodeCapacity = tptkwok.WithNodeCapacity
+	WithNodeLabels   = tptkwok.WithNodeLabels
+	WithoutNodeTaint = tptkwok.WithoutNodeTaint
+	CreateNodes      = tptkwok.CreateNodes
+	ApplyStages      = tptkwok.ApplyStages
 )
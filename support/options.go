@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package support
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// providerOption is a single typed option recorded from a set of ClusterOpts
+type providerOption struct {
+	key   ProviderOptionKey
+	value string
+}
+
+// optionRecorder is a placeholder provider that captures the typed and framework options set by a
+// set of ClusterOpts without applying them. Provider specific ClusterOpts type assert the concrete
+// provider they belong to and are ignored by the recorder.
+type optionRecorder struct {
+	E2EClusterProvider
+	options       []providerOption
+	exportLogs    bool
+	exportLogsDir string
+}
+
+// recordOptions applies opts to a new optionRecorder
+func recordOptions(opts ...ClusterOpts) *optionRecorder {
+	recorder := &optionRecorder{}
+	for _, opt := range opts {
+		opt(recorder)
+	}
+	return recorder
+}
+
+func (r *optionRecorder) SupportedOptions() []ProviderOptionKey {
+	return nil
+}
+
+func (r *optionRecorder) SetOption(key ProviderOptionKey, value string) error {
+	r.options = append(r.options, providerOption{key: key, value: value})
+	return nil
+}
+
+// WithProviderOption returns a ClusterOpts that configures the typed option identified by key
+// on providers implementing E2EClusterProviderWithOptions. Unlike provider specific options,
+// the option is validated by ApplyProviderOptions so that misspelled or unsupported keys are
+// reported before the cluster is created.
+func WithProviderOption(key ProviderOptionKey, value string) ClusterOpts {
+	return func(c E2EClusterProvider) {
+		p, ok := c.(E2EClusterProviderWithOptions)
+		if ok {
+			_ = p.SetOption(key, value)
+		}
+	}
+}
+
+// ApplyProviderOptions validates the typed options found in opts against the options supported
+// by the provider and applies them, returning an error for every unknown key or invalid value.
+func ApplyProviderOptions(p E2EClusterProvider, opts ...ClusterOpts) error {
+	recorder := recordOptions(opts...)
+	if len(recorder.options) == 0 {
+		return nil
+	}
+
+	provider, ok := p.(E2EClusterProviderWithOptions)
+	if !ok {
+		return fmt.Errorf("cluster provider does not support typed provider options")
+	}
+
+	supported := provider.SupportedOptions()
+	var errs []error
+	for _, opt := range recorder.options {
+		if !slices.Contains(supported, opt.key) {
+			errs = append(errs, fmt.Errorf("unknown provider option %q: supported options are %v", opt.key, supported))
+			continue
+		}
+		if err := provider.SetOption(opt.key, opt.value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %q for provider option %q: %w", opt.value, opt.key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithExportLogsOnFailure returns a ClusterOpts that makes the envfuncs cluster creation helpers export
+// the logs of the cluster into a sub-directory of dir, named after the cluster, when the cluster fails
+// to be created or its control plane does not become ready. An empty dir exports the logs into the
+// artifacts directory of the environment configuration. The option is ignored by the providers.
+func WithExportLogsOnFailure(dir string) ClusterOpts {
+	return func(c E2EClusterProvider) {
+		r, ok := c.(*optionRecorder)
+		if ok {
+			r.exportLogs = true
+			r.exportLogsDir = dir
+		}
+	}
+}
+
+// ExportLogsOnFailureDir returns the directory configured in opts using WithExportLogsOnFailure and
+// whether the option was set at all
+func ExportLogsOnFailureDir(opts ...ClusterOpts) (string, bool) {
+	r := recordOptions(opts...)
+	return r.exportLogsDir, r.exportLogs
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package support
+
+import "testing"
+
+func TestExportLogsOnFailureDir(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []ClusterOpts
+		want        string
+		wantEnabled bool
+	}{
+		{
+			name: "not configured",
+			opts: []ClusterOpts{WithProviderOption(ProviderOptionImage, "kindest/node")},
+		},
+		{
+			name:        "configured",
+			opts:        []ClusterOpts{WithProviderOption(ProviderOptionImage, "kindest/node"), WithExportLogsOnFailure("/tmp/artifacts")},
+			want:        "/tmp/artifacts",
+			wantEnabled: true,
+		},
+		{
+			name:        "default directory",
+			opts:        []ClusterOpts{WithExportLogsOnFailure("")},
+			wantEnabled: true,
+		},
+		{
+			name:        "last value wins",
+			opts:        []ClusterOpts{WithExportLogsOnFailure("/tmp/first"), WithExportLogsOnFailure("/tmp/second")},
+			want:        "/tmp/second",
+			wantEnabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, enabled := ExportLogsOnFailureDir(tt.opts...)
+			if got != tt.want || enabled != tt.wantEnabled {
+				t.Errorf("ExportLogsOnFailureDir() = %q, %v, want %q, %v", got, enabled, tt.want, tt.wantEnabled)
+			}
+		})
+	}
+}
+
+func TestApplyProviderOptionsUnsupportedProvider(t *testing.T) {
+	if err := ApplyProviderOptions(&fakeProvider{}, WithExportLogsOnFailure("/tmp/artifacts")); err != nil {
+		t.Errorf("expected framework options to be accepted, got %v", err)
+	}
+	if err := ApplyProviderOptions(&fakeProvider{}, WithProviderOption(ProviderOptionWorkers, "1")); err == nil {
+		t.Error("expected an error for a provider that does not support typed options")
+	}
+}
@@ -21,13 +21,24 @@ import (
 )
 
 type (
-	ClusterOpts                       = types.ClusterOpts
-	Node                              = types.Node
-	NodeOperation                     = types.NodeOperation
-	ClusterNameContextKey             = types.ClusterNameContextKey
-	E2EClusterProvider                = types.E2EClusterProvider
-	E2EClusterProviderWithImageLoader = types.E2EClusterProviderWithImageLoader
-	E2EClusterProviderWithLifeCycle   = types.E2EClusterProviderWithLifeCycle
+	ClusterOpts                            = types.ClusterOpts
+	Node                                   = types.Node
+	NodeOperation                          = types.NodeOperation
+	ClusterNameContextKey                  = types.ClusterNameContextKey
+	E2EClusterProvider                     = types.E2EClusterProvider
+	E2EClusterProviderWithImageLoader      = types.E2EClusterProviderWithImageLoader
+	E2EClusterProviderWithLifeCycle        = types.E2EClusterProviderWithLifeCycle
+	E2EClusterProviderWithLocalRegistry    = types.E2EClusterProviderWithLocalRegistry
+	E2EClusterProviderWithPause            = types.E2EClusterProviderWithPause
+	E2EClusterProviderWithSnapshot         = types.E2EClusterProviderWithSnapshot
+	E2EClusterProviderWithNodeScaling      = types.E2EClusterProviderWithNodeScaling
+	E2EClusterProviderWithNetworkPartition = types.E2EClusterProviderWithNetworkPartition
+	E2EClusterProviderWithUpgrade          = types.E2EClusterProviderWithUpgrade
+	E2EClusterProviderWithOptions          = types.E2EClusterProviderWithOptions
+	E2EClusterProviderWithCapabilities     = types.E2EClusterProviderWithCapabilities
+	ProviderOptionKey                      = types.ProviderOptionKey
+	Capability                             = types.Capability
+	Capabilities                           = types.Capabilities
 )
 
 const (
@@ -35,4 +46,20 @@ const (
 	RemoveNode = types.RemoveNode
 	StartNode  = types.StartNode
 	StopNode   = types.StopNode
+
+	ProviderOptionImage    = types.ProviderOptionImage
+	ProviderOptionWorkers  = types.ProviderOptionWorkers
+	ProviderOptionRegistry = types.ProviderOptionRegistry
+	ProviderOptionWait     = types.ProviderOptionWait
+
+	CapabilityImageLoad        = types.CapabilityImageLoad
+	CapabilityLifeCycle        = types.CapabilityLifeCycle
+	CapabilityLocalRegistry    = types.CapabilityLocalRegistry
+	CapabilityPause            = types.CapabilityPause
+	CapabilitySnapshot         = types.CapabilitySnapshot
+	CapabilityNodeScaling      = types.CapabilityNodeScaling
+	CapabilityNetworkPartition = types.CapabilityNetworkPartition
+	CapabilityUpgrade          = types.CapabilityUpgrade
+	CapabilityOptions          = types.CapabilityOptions
+	CapabilityLogsExport       = types.CapabilityLogsExport
 )
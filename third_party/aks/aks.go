@@ -0,0 +1,329 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package aks provides a cluster provider that manages Azure Kubernetes Service clusters using the
+// az CLI. The node lifecycle operations of the provider operate on AKS node pools.
+package aks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vladimirvivien/gexe"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/klient/conf"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+type Cluster struct {
+	path          string
+	name          string
+	kubecfgFile   string
+	version       string
+	resourceGroup string
+	nodes         int
+	args          []string
+	rc            *rest.Config
+}
+
+// nodePool is a subset of the `az aks nodepool list --output json` output
+type nodePool struct {
+	Name       string `json:"name"`
+	Mode       string `json:"mode"`
+	PowerState struct {
+		Code string `json:"code"`
+	} `json:"powerState"`
+}
+
+var (
+	_ support.E2EClusterProvider              = &Cluster{}
+	_ support.E2EClusterProviderWithLifeCycle = &Cluster{}
+)
+
+func NewCluster(name string) *Cluster {
+	return &Cluster{name: name}
+}
+
+func NewProvider() support.E2EClusterProvider {
+	return &Cluster{}
+}
+
+// WithResourceGroup is used to configure the Azure resource group of the cluster
+func WithResourceGroup(resourceGroup string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		a, ok := c.(*Cluster)
+		if ok {
+			a.resourceGroup = resourceGroup
+		}
+	}
+}
+
+// WithNodes is used to configure the number of nodes of the default node pool of the cluster
+func WithNodes(nodes int) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		a, ok := c.(*Cluster)
+		if ok {
+			a.nodes = nodes
+		}
+	}
+}
+
+// WithArgs is used to pass additional arguments to the `az aks create` command
+func WithArgs(args ...string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		a, ok := c.(*Cluster)
+		if ok {
+			a.args = append(a.args, args...)
+		}
+	}
+}
+
+func WithPath(path string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		a, ok := c.(*Cluster)
+		if ok {
+			a.path = path
+		}
+	}
+}
+
+func (c *Cluster) WithName(name string) support.E2EClusterProvider {
+	c.name = name
+	return c
+}
+
+// WithVersion is used to configure the kubernetes version of the AKS cluster
+func (c *Cluster) WithVersion(version string) support.E2EClusterProvider {
+	c.version = version
+	return c
+}
+
+func (c *Cluster) WithPath(path string) support.E2EClusterProvider {
+	c.path = path
+	return c
+}
+
+func (c *Cluster) WithOpts(opts ...support.ClusterOpts) support.E2EClusterProvider {
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+func (c *Cluster) SetDefaults() support.E2EClusterProvider {
+	if c.path == "" {
+		c.path = "az"
+	}
+	return c
+}
+
+// run invokes an az command and returns its stdout
+func (c *Cluster) run(command string) (string, error) {
+	if gexe.ProgAvail(c.path) == "" {
+		return "", fmt.Errorf("'%s' command is missing. Please ensure the tool exists before using the aks provider", c.path)
+	}
+	var stdout, stderr bytes.Buffer
+	p := gexe.New().NewProc(command)
+	p.SetStdout(&stdout)
+	p.SetStderr(&stderr)
+	p.Run()
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return "", fmt.Errorf("%s: %s", p.Err(), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (c *Cluster) clusterExists(name string) bool {
+	_, err := c.run(fmt.Sprintf("%s aks show --resource-group %s --name %s --output json", c.path, c.resourceGroup, name))
+	return err == nil
+}
+
+func (c *Cluster) Create(ctx context.Context, args ...string) (string, error) {
+	log.V(4).InfoS("Creating AKS cluster", "name", c.name, "resourceGroup", c.resourceGroup)
+	if c.resourceGroup == "" {
+		return "", fmt.Errorf("aks: resource group of cluster %q is not configured. Please use the WithResourceGroup option", c.name)
+	}
+	if c.clusterExists(c.name) {
+		log.V(4).InfoS("Skipping AKS cluster creation. Cluster already exists", "name", c.name)
+		return c.getKubeconfig()
+	}
+
+	command := c.createCommand(args...)
+	log.V(4).InfoS("Launching AKS cluster", "command", command)
+	if _, err := c.run(command); err != nil {
+		return "", fmt.Errorf("aks: failed to create cluster %q: %w", c.name, err)
+	}
+	return c.getKubeconfig()
+}
+
+// createCommand returns the az command creating the cluster with the configured version, number
+// of nodes and extra arguments
+func (c *Cluster) createCommand(args ...string) string {
+	if c.version != "" {
+		args = append(args, "--kubernetes-version", c.version)
+	}
+	if c.nodes > 0 {
+		args = append(args, "--node-count", fmt.Sprint(c.nodes))
+	}
+	args = append(args, c.args...)
+
+	command := fmt.Sprintf("%s aks create --resource-group %s --name %s --generate-ssh-keys", c.path, c.resourceGroup, c.name)
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	return command
+}
+
+// CreateWithConfig creates the cluster using the az CLI arguments file provided, which is
+// passed to `az aks create` using the @file syntax
+func (c *Cluster) CreateWithConfig(ctx context.Context, configFile string) (string, error) {
+	var args []string
+	if configFile != "" {
+		args = append(args, "@"+configFile)
+	}
+	return c.Create(ctx, args...)
+}
+
+func (c *Cluster) getKubeconfig() (string, error) {
+	file, err := os.CreateTemp("", fmt.Sprintf("aks-cluster-%s-kubecfg", c.name))
+	if err != nil {
+		return "", fmt.Errorf("aks kubeconfig file: %w", err)
+	}
+	file.Close()
+	c.kubecfgFile = file.Name()
+
+	command := fmt.Sprintf("%s aks get-credentials --resource-group %s --name %s --file %s --overwrite-existing", c.path, c.resourceGroup, c.name, c.kubecfgFile)
+	if _, err := c.run(command); err != nil {
+		return "", fmt.Errorf("aks: failed to get credentials of cluster %q: %w", c.name, err)
+	}
+	cfg, err := conf.New(c.kubecfgFile)
+	if err != nil {
+		return "", err
+	}
+	c.rc = cfg
+	return c.kubecfgFile, nil
+}
+
+func (c *Cluster) GetKubeconfig() string {
+	return c.kubecfgFile
+}
+
+func (c *Cluster) GetKubectlContext() string {
+	cfg, err := clientcmd.LoadFromFile(c.kubecfgFile)
+	if err != nil {
+		return ""
+	}
+	return cfg.CurrentContext
+}
+
+func (c *Cluster) ExportLogs(ctx context.Context, dest string) error {
+	log.Warning("ExportLogs not implemented for aks. Please use regular kubectl like commands or Azure Monitor to extract the logs from the cluster")
+	return nil
+}
+
+func (c *Cluster) Destroy(ctx context.Context) error {
+	log.V(4).InfoS("Destroying AKS cluster", "name", c.name)
+	if _, err := c.run(fmt.Sprintf("%s aks delete --resource-group %s --name %s --yes", c.path, c.resourceGroup, c.name)); err != nil {
+		return fmt.Errorf("aks: failed to delete cluster %q: %w", c.name, err)
+	}
+
+	log.V(4).InfoS("Removing kubeconfig file", "configFile", c.kubecfgFile)
+	if err := os.RemoveAll(c.kubecfgFile); err != nil {
+		return fmt.Errorf("aks: failed to remove kubeconfig file %q: %w", c.kubecfgFile, err)
+	}
+	return nil
+}
+
+func (c *Cluster) WaitForControlPlane(ctx context.Context, client klient.Client) error {
+	log.V(4).Info("aks provider doesn't implement a WaitForControlPlane as az waits for the control plane")
+	return nil
+}
+
+func (c *Cluster) KubernetesRestConfig() *rest.Config {
+	return c.rc
+}
+
+// AddNode creates a node pool named after the node with a single node
+func (c *Cluster) AddNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodePoolOperation("add", node.Name, append([]string{"--node-count", "1"}, args...)...)
+}
+
+// RemoveNode deletes the node pool named after the node
+func (c *Cluster) RemoveNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodePoolOperation("delete", node.Name, args...)
+}
+
+// StartNode starts the stopped node pool named after the node
+func (c *Cluster) StartNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodePoolOperation("start", node.Name, args...)
+}
+
+// StopNode stops the node pool named after the node
+func (c *Cluster) StopNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodePoolOperation("stop", node.Name, args...)
+}
+
+// ScaleNodePool scales the node pool to the given number of nodes
+func (c *Cluster) ScaleNodePool(ctx context.Context, name string, nodes int, args ...string) error {
+	return c.nodePoolOperation("scale", name, append([]string{"--node-count", fmt.Sprint(nodes)}, args...)...)
+}
+
+func (c *Cluster) nodePoolOperation(operation, name string, args ...string) error {
+	command := fmt.Sprintf("%s aks nodepool %s --resource-group %s --cluster-name %s --name %s", c.path, operation, c.resourceGroup, c.name, name)
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	log.V(4).InfoS("Performing node pool operation on AKS cluster", "command", command)
+	if _, err := c.run(command); err != nil {
+		return fmt.Errorf("aks: failed to %s node pool %q in cluster %q: %w", operation, name, c.name, err)
+	}
+	return nil
+}
+
+// ListNode returns the node pools of the cluster
+func (c *Cluster) ListNode(ctx context.Context, args ...string) ([]support.Node, error) {
+	out, err := c.run(fmt.Sprintf("%s aks nodepool list --resource-group %s --cluster-name %s --output json", c.path, c.resourceGroup, c.name))
+	if err != nil {
+		return nil, fmt.Errorf("aks: failed to list node pools: %w", err)
+	}
+	return parseNodePools(c.name, out)
+}
+
+// parseNodePools converts the output of `az aks nodepool list --output json` into nodes
+func parseNodePools(cluster, out string) ([]support.Node, error) {
+	var pools []nodePool
+	if err := json.Unmarshal([]byte(out), &pools); err != nil {
+		return nil, fmt.Errorf("aks: failed to unmarshal node pool list: %w", err)
+	}
+	nodes := make([]support.Node, 0, len(pools))
+	for _, p := range pools {
+		nodes = append(nodes, support.Node{
+			Name:    p.Name,
+			Role:    strings.ToLower(p.Mode),
+			State:   p.PowerState.Code,
+			Cluster: cluster,
+		})
+	}
+	return nodes, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+func TestClusterCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *Cluster
+		args    []string
+		want    string
+	}{
+		{
+			name:    "defaults",
+			cluster: &Cluster{path: "az", name: "e2e", resourceGroup: "e2e-rg"},
+			want:    "az aks create --resource-group e2e-rg --name e2e --generate-ssh-keys",
+		},
+		{
+			name:    "version and nodes",
+			cluster: &Cluster{path: "az", name: "e2e", resourceGroup: "e2e-rg", version: "1.32.0", nodes: 3},
+			want:    "az aks create --resource-group e2e-rg --name e2e --generate-ssh-keys --kubernetes-version 1.32.0 --node-count 3",
+		},
+		{
+			name:    "arguments file and provider arguments",
+			cluster: &Cluster{path: "az", name: "e2e", resourceGroup: "e2e-rg", args: []string{"--node-vm-size", "Standard_DS2_v2"}},
+			args:    []string{"@aks-args.txt"},
+			want:    "az aks create --resource-group e2e-rg --name e2e --generate-ssh-keys @aks-args.txt --node-vm-size Standard_DS2_v2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cluster.createCommand(tt.args...); got != tt.want {
+				t.Errorf("createCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterCreateWithoutResourceGroup(t *testing.T) {
+	if _, err := NewCluster("e2e").Create(context.TODO()); err == nil {
+		t.Error("expected an error when the resource group is not configured")
+	}
+}
+
+func TestParseNodePools(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     string
+		want    []support.Node
+		wantErr bool
+	}{
+		{name: "no node pools", out: "[]", want: []support.Node{}},
+		{
+			name: "node pools",
+			out:  `[{"name":"nodepool1","mode":"System","powerState":{"code":"Running"}},{"name":"user1","mode":"User","powerState":{"code":"Stopped"}}]`,
+			want: []support.Node{
+				{Name: "nodepool1", Role: "system", State: "Running", Cluster: "e2e"},
+				{Name: "user1", Role: "user", State: "Stopped", Cluster: "e2e"},
+			},
+		},
+		{name: "invalid output", out: "ERROR: (ResourceNotFound)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNodePools("e2e", tt.out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNodePools() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNodePools() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterGetKubectlContext(t *testing.T) {
+	kubecfg := filepath.Join(t.TempDir(), "kubeconfig")
+	data := `apiVersion: v1
+kind: Config
+clusters:
+- name: e2e
+  cluster:
+    server: https://e2e-dns.hcp.westeurope.azmk8s.io:443
+contexts:
+- name: e2e
+  context:
+    cluster: e2e
+    user: clusterUser_e2e-rg_e2e
+current-context: e2e
+users:
+- name: clusterUser_e2e-rg_e2e
+  user:
+    token: secret
+`
+	if err := os.WriteFile(kubecfg, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		kubecfgFile string
+		want        string
+	}{
+		{name: "kubeconfig", kubecfgFile: kubecfg, want: "e2e"},
+		{name: "missing kubeconfig", kubecfgFile: filepath.Join(t.TempDir(), "missing")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cluster{kubecfgFile: tt.kubecfgFile}
+			if got := c.GetKubectlContext(); got != tt.want {
+				t.Errorf("GetKubectlContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+const (
+	// InClusterServer is the address Argo CD uses for the cluster it runs in
+	InClusterServer = "https://kubernetes.default.svc"
+	// ResourcesFinalizer makes Argo CD delete the resources of an
+	// Application before the Application itself
+	ResourcesFinalizer = "resources-finalizer.argocd.argoproj.io"
+	// refreshAnnotation requests Argo CD to compare the Application with its
+	// source again
+	refreshAnnotation = "argocd.argoproj.io/refresh"
+)
+
+var ApplicationGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Application"}
+
+// ApplicationOption customizes the Application returned by Application
+type ApplicationOption func(app *unstructured.Unstructured)
+
+// WithTargetRevision sets the branch, tag or commit of the source that is
+// deployed. Defaults to HEAD.
+func WithTargetRevision(revision string) ApplicationOption {
+	return func(app *unstructured.Unstructured) {
+		_ = unstructured.SetNestedField(app.Object, revision, "spec", "source", "targetRevision")
+	}
+}
+
+// WithDestinationNamespace sets the namespace the resources of the
+// Application are deployed to when they do not set one
+func WithDestinationNamespace(namespace string) ApplicationOption {
+	return func(app *unstructured.Unstructured) {
+		_ = unstructured.SetNestedField(app.Object, namespace, "spec", "destination", "namespace")
+	}
+}
+
+// WithProject sets the Argo CD project of the Application. Defaults to the
+// default project.
+func WithProject(project string) ApplicationOption {
+	return func(app *unstructured.Unstructured) {
+		_ = unstructured.SetNestedField(app.Object, project, "spec", "project")
+	}
+}
+
+// WithAutomatedSync makes Argo CD sync the Application whenever its source
+// changes, pruning the resources removed from the source when prune is set
+// and reverting changes made in the cluster when selfHeal is set
+func WithAutomatedSync(prune, selfHeal bool) ApplicationOption {
+	return func(app *unstructured.Unstructured) {
+		_ = unstructured.SetNestedMap(app.Object, map[string]interface{}{"prune": prune, "selfHeal": selfHeal}, "spec", "syncPolicy", "automated")
+	}
+}
+
+// WithSyncOptions sets the sync options of the Application, such as
+// CreateNamespace=true or ServerSideApply=true
+func WithSyncOptions(options ...string) ApplicationOption {
+	return func(app *unstructured.Unstructured) {
+		_ = unstructured.SetNestedStringSlice(app.Object, options, "spec", "syncPolicy", "syncOptions")
+	}
+}
+
+// WithDirectoryRecurse makes Argo CD read the manifests of the
+// subdirectories of the source path as well
+func WithDirectoryRecurse() ApplicationOption {
+	return func(app *unstructured.Unstructured) {
+		_ = unstructured.SetNestedField(app.Object, true, "spec", "source", "directory", "recurse")
+	}
+}
+
+// WithCascadeDelete makes the deletion of the Application delete the
+// resources it deployed as well
+func WithCascadeDelete() ApplicationOption {
+	return func(app *unstructured.Unstructured) {
+		app.SetFinalizers(append(app.GetFinalizers(), ResourcesFinalizer))
+	}
+}
+
+// Application returns an Application, in the argocd namespace, deploying the
+// manifests found at path of the git repository repoURL to the cluster Argo
+// CD runs in
+func Application(name, repoURL, path string, opts ...ApplicationOption) *unstructured.Unstructured {
+	app := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"project": "default",
+			"source": map[string]interface{}{
+				"repoURL":        repoURL,
+				"path":           path,
+				"targetRevision": "HEAD",
+			},
+			"destination": map[string]interface{}{
+				"server": InClusterServer,
+			},
+		},
+	}}
+	app.SetGroupVersionKind(ApplicationGVK)
+	app.SetName(name)
+	app.SetNamespace(Namespace)
+	for _, opt := range opts {
+		opt(app)
+	}
+	return app
+}
+
+// AppOfApps returns a root Application deploying the Application manifests
+// found at path of the git repository repoURL, following the app-of-apps
+// pattern. The child Applications are created in the argocd namespace. The
+// root Application is synced automatically, pruning the child Applications
+// removed from the repository, so that the tree follows the repository
+// without syncing the root Application explicitly.
+func AppOfApps(name, repoURL, path string, opts ...ApplicationOption) *unstructured.Unstructured {
+	opts = append([]ApplicationOption{WithDestinationNamespace(Namespace), WithAutomatedSync(true, false)}, opts...)
+	return Application(name, repoURL, path, opts...)
+}
+
+// CreateApplication returns an env.Func that creates the given Applications,
+// such as the ones returned by Application or AppOfApps, in order
+func CreateApplication(apps ...*unstructured.Unstructured) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		for _, app := range apps {
+			if err := client.Resources().Create(ctx, app.DeepCopy()); err != nil {
+				return ctx, fmt.Errorf("creation of application %s failed: %w", app.GetName(), err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// DeleteApplication returns an env.Func that deletes the Applications
+// identified by name. Applications that do not exist are ignored.
+func DeleteApplication(names ...string) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		for _, name := range names {
+			if err := client.Resources().Delete(ctx, newApplication(name)); err != nil && !apierrors.IsNotFound(err) {
+				return ctx, fmt.Errorf("deletion of application %s failed: %w", name, err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// SyncApplication returns an env.Func that triggers the sync of the
+// Application identified by name to the latest revision of its source, the
+// same way `argocd app sync` does. Use WaitForApplicationHealthy to wait for
+// the sync to complete.
+func SyncApplication(name string) env.Func {
+	return patchApplication(name, map[string]interface{}{
+		"operation": map[string]interface{}{
+			"initiatedBy": map[string]interface{}{"username": "e2e-framework"},
+			"sync":        map[string]interface{}{},
+		},
+	})
+}
+
+// RefreshApplication returns an env.Func that makes Argo CD compare the
+// Application identified by name with its source again without waiting for
+// the next polling of the repository
+func RefreshApplication(name string) env.Func {
+	return patchApplication(name, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{refreshAnnotation: "normal"},
+		},
+	})
+}
+
+func patchApplication(name string, patch map[string]interface{}) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return ctx, err
+		}
+		if err := client.Resources().Patch(ctx, newApplication(name), k8s.Patch{PatchType: types.MergePatchType, Data: data}); err != nil {
+			return ctx, fmt.Errorf("patching application %s failed: %w", name, err)
+		}
+		return ctx, nil
+	}
+}
+
+func newApplication(name string) *unstructured.Unstructured {
+	app := &unstructured.Unstructured{}
+	app.SetGroupVersionKind(ApplicationGVK)
+	app.SetName(name)
+	app.SetNamespace(Namespace)
+	return app
+}
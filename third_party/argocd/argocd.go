@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package argocd provides env funcs to install Argo CD into the cluster under
+// test, to create and sync Applications, including app-of-apps, and to wait
+// for them to be synced and healthy.
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/decoder"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+const (
+	// DefaultVersion is the Argo CD release installed when no version is
+	// provided via WithVersion
+	DefaultVersion = "v2.13.2"
+	// Namespace is the namespace Argo CD is installed into. The release
+	// manifest binds the Argo CD service accounts of this namespace, and
+	// Applications must be created in it.
+	Namespace = "argocd"
+	// DefaultTimeout is the time Install waits for the Argo CD components
+	// to become ready
+	DefaultTimeout = 5 * time.Minute
+
+	releaseURL = "https://raw.githubusercontent.com/argoproj/argo-cd/%s/manifests/install.yaml"
+)
+
+type Opts struct {
+	// Version is the Argo CD release to install
+	Version string
+	// ManifestURL overrides the location of the release manifest, for
+	// instance to install the high availability or core manifests. It
+	// takes precedence over Version
+	ManifestURL string
+	// Timeout is the time to wait for the Argo CD components to become ready
+	Timeout time.Duration
+}
+
+type Option func(*Opts)
+
+// WithVersion sets the Argo CD release to install, such as v2.13.2
+func WithVersion(version string) Option {
+	return func(opts *Opts) {
+		opts.Version = version
+	}
+}
+
+// WithManifestURL sets the URL of the manifest used to install Argo CD
+func WithManifestURL(url string) Option {
+	return func(opts *Opts) {
+		opts.ManifestURL = url
+	}
+}
+
+// WithTimeout sets the time to wait for the Argo CD components to become
+// ready
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *Opts) {
+		opts.Timeout = timeout
+	}
+}
+
+func processOpts(opts ...Option) *Opts {
+	o := &Opts{Version: DefaultVersion, Timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// manifestURL returns the URL of the release manifest selected by the options
+func (o *Opts) manifestURL() string {
+	if o.ManifestURL != "" {
+		return o.ManifestURL
+	}
+	return fmt.Sprintf(releaseURL, o.Version)
+}
+
+// defaultNamespace places the namespaced objects of the release manifest,
+// which do not set a namespace, in the Argo CD namespace
+var defaultNamespace = decoder.MutateOption(func(obj k8s.Object) error {
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(Namespace)
+	}
+	return nil
+})
+
+// Install returns an env.Func that creates the argocd namespace, applies the
+// Argo CD release manifest and waits for the deployments and stateful sets
+// of the release to be ready. Objects of the manifest that already exist are
+// left untouched.
+func Install(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		o := processOpts(opts...)
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		r := client.Resources()
+
+		ns := &corev1.Namespace{}
+		ns.SetName(Namespace)
+		if err := r.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+			return ctx, fmt.Errorf("installation of argocd failed: %w", err)
+		}
+
+		url := o.manifestURL()
+		log.V(4).InfoS("Installing Argo CD", "manifest", url)
+		var workloads []k8s.Object
+		create := decoder.CreateIgnoreAlreadyExists(r)
+		err = decoder.DecodeURL(ctx, url, func(ctx context.Context, obj k8s.Object) error {
+			switch obj.(type) {
+			case *appsv1.Deployment, *appsv1.StatefulSet:
+				workloads = append(workloads, obj)
+			}
+			return create(ctx, obj)
+		}, defaultNamespace)
+		if err != nil {
+			return ctx, fmt.Errorf("installation of argocd failed: %w", err)
+		}
+
+		cond := conditions.New(r)
+		for _, obj := range workloads {
+			if err := wait.For(cond.ResourceMatch(obj, isWorkloadReady), wait.WithContext(ctx), wait.WithTimeout(o.Timeout), wait.WithImmediate()); err != nil {
+				return ctx, fmt.Errorf("argocd component %s did not become ready: %w", obj.GetName(), err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// Uninstall returns an env.Func that deletes the objects of the Argo CD
+// release manifest and the argocd namespace. The options must select the
+// same manifest as the one used by Install. Applications should be deleted
+// beforehand, as their finalizers can not be processed once Argo CD is gone.
+func Uninstall(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		o := processOpts(opts...)
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		r := client.Resources()
+
+		url := o.manifestURL()
+		log.V(4).InfoS("Uninstalling Argo CD", "manifest", url)
+		if err := decoder.DecodeURL(ctx, url, decoder.DeleteIgnoreNotFound(r), defaultNamespace); err != nil {
+			return ctx, fmt.Errorf("uninstallation of argocd failed: %w", err)
+		}
+		ns := &corev1.Namespace{}
+		ns.SetName(Namespace)
+		if err := r.Delete(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
+			return ctx, fmt.Errorf("uninstallation of argocd failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// isWorkloadReady checks if all the replicas of a deployment or a stateful
+// set are updated and ready
+func isWorkloadReady(object k8s.Object) bool {
+	var replicas *int32
+	var generation, observedGeneration int64
+	var ready, updated int32
+	switch obj := object.(type) {
+	case *appsv1.Deployment:
+		replicas, generation, observedGeneration = obj.Spec.Replicas, obj.Generation, obj.Status.ObservedGeneration
+		ready, updated = obj.Status.ReadyReplicas, obj.Status.UpdatedReplicas
+	case *appsv1.StatefulSet:
+		replicas, generation, observedGeneration = obj.Spec.Replicas, obj.Generation, obj.Status.ObservedGeneration
+		ready, updated = obj.Status.ReadyReplicas, obj.Status.UpdatedReplicas
+	default:
+		return false
+	}
+	want := int32(1)
+	if replicas != nil {
+		want = *replicas
+	}
+	return observedGeneration >= generation && ready >= want && updated >= want
+}
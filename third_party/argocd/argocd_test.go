@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package argocd
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+)
+
+func TestManifestURL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default", want: "https://raw.githubusercontent.com/argoproj/argo-cd/" + DefaultVersion + "/manifests/install.yaml"},
+		{name: "version", opts: []Option{WithVersion("v2.12.0")}, want: "https://raw.githubusercontent.com/argoproj/argo-cd/v2.12.0/manifests/install.yaml"},
+		{name: "manifest", opts: []Option{WithManifestURL("https://mirror.example.com/core-install.yaml")}, want: "https://mirror.example.com/core-install.yaml"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := processOpts(tc.opts...).manifestURL(); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestApplication(t *testing.T) {
+	tests := []struct {
+		name  string
+		app   *unstructured.Unstructured
+		field []string
+		want  interface{}
+	}{
+		{name: "source", app: Application("web", "https://github.com/example/apps", "web"), field: []string{"spec", "source"}, want: map[string]interface{}{"repoURL": "https://github.com/example/apps", "path": "web", "targetRevision": "HEAD"}},
+		{name: "destination", app: Application("web", "https://github.com/example/apps", "web", WithDestinationNamespace("apps")), field: []string{"spec", "destination"}, want: map[string]interface{}{"server": InClusterServer, "namespace": "apps"}},
+		{name: "revision", app: Application("web", "https://github.com/example/apps", "web", WithTargetRevision("v1.0.0")), field: []string{"spec", "source", "targetRevision"}, want: "v1.0.0"},
+		{name: "project", app: Application("web", "https://github.com/example/apps", "web", WithProject("e2e")), field: []string{"spec", "project"}, want: "e2e"},
+		{name: "automated sync", app: Application("web", "https://github.com/example/apps", "web", WithAutomatedSync(false, true)), field: []string{"spec", "syncPolicy", "automated"}, want: map[string]interface{}{"prune": false, "selfHeal": true}},
+		{name: "sync options", app: Application("web", "https://github.com/example/apps", "web", WithSyncOptions("CreateNamespace=true")), field: []string{"spec", "syncPolicy", "syncOptions"}, want: []interface{}{"CreateNamespace=true"}},
+		{name: "recurse", app: Application("web", "https://github.com/example/apps", "web", WithDirectoryRecurse()), field: []string{"spec", "source", "directory", "recurse"}, want: true},
+		{name: "cascade delete", app: Application("web", "https://github.com/example/apps", "web", WithCascadeDelete()), field: []string{"metadata", "finalizers"}, want: []interface{}{ResourcesFinalizer}},
+		{name: "app of apps", app: AppOfApps("root", "https://github.com/example/apps", "apps"), field: []string{"spec", "destination", "namespace"}, want: Namespace},
+		{name: "app of apps sync", app: AppOfApps("root", "https://github.com/example/apps", "apps", WithAutomatedSync(true, true)), field: []string{"spec", "syncPolicy", "automated"}, want: map[string]interface{}{"prune": true, "selfHeal": true}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.app.GroupVersionKind() != ApplicationGVK || tc.app.GetNamespace() != Namespace {
+				t.Fatalf("unexpected application %s in namespace %s", tc.app.GroupVersionKind(), tc.app.GetNamespace())
+			}
+			got, _, err := unstructured.NestedFieldNoCopy(tc.app.Object, tc.field...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestHasStatus(t *testing.T) {
+	app := func(health, sync, phase string, operation bool) *unstructured.Unstructured {
+		obj := newApplication("web")
+		_ = unstructured.SetNestedField(obj.Object, health, "status", "health", "status")
+		_ = unstructured.SetNestedField(obj.Object, sync, "status", "sync", "status")
+		if phase != "" {
+			_ = unstructured.SetNestedField(obj.Object, phase, "status", "operationState", "phase")
+		}
+		if operation {
+			_ = unstructured.SetNestedMap(obj.Object, map[string]interface{}{"sync": map[string]interface{}{}}, "operation")
+		}
+		return obj
+	}
+	tests := []struct {
+		name   string
+		app    *unstructured.Unstructured
+		health string
+		sync   string
+		want   bool
+	}{
+		{name: "healthy", app: app(HealthHealthy, SyncSynced, "Succeeded", false), health: HealthHealthy, sync: SyncSynced, want: true},
+		{name: "out of sync", app: app(HealthHealthy, SyncOutOfSync, "", false), health: HealthHealthy, sync: SyncSynced},
+		{name: "progressing", app: app(HealthProgressing, SyncSynced, "Succeeded", false), health: HealthHealthy, sync: SyncSynced},
+		{name: "sync running", app: app(HealthHealthy, SyncSynced, "Running", false), health: HealthHealthy, sync: SyncSynced},
+		{name: "sync requested", app: app(HealthHealthy, SyncSynced, "Succeeded", true), health: HealthHealthy, sync: SyncSynced},
+		{name: "any sync status", app: app(HealthDegraded, SyncOutOfSync, "Failed", false), health: HealthDegraded, want: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := hasStatus(tc.app, tc.health, tc.sync); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsWorkloadReady(t *testing.T) {
+	two := int32(2)
+	deployment := func(generation, observed int64, ready int32) *appsv1.Deployment {
+		d := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &two}}
+		d.Generation = generation
+		d.Status = appsv1.DeploymentStatus{ObservedGeneration: observed, ReadyReplicas: ready, UpdatedReplicas: ready}
+		return d
+	}
+	statefulSet := func(generation, observed int64, ready int32) *appsv1.StatefulSet {
+		s := &appsv1.StatefulSet{}
+		s.Generation = generation
+		s.Status = appsv1.StatefulSetStatus{ObservedGeneration: observed, ReadyReplicas: ready, UpdatedReplicas: ready}
+		return s
+	}
+	tests := []struct {
+		name     string
+		workload k8s.Object
+		want     bool
+	}{
+		{name: "deployment ready", workload: deployment(2, 2, 2), want: true},
+		{name: "deployment unready replicas", workload: deployment(2, 2, 1)},
+		{name: "stateful set defaults to one replica", workload: statefulSet(3, 3, 1), want: true},
+		{name: "stateful set old generation", workload: statefulSet(3, 2, 1)},
+		{name: "not a workload", workload: newApplication("web")},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isWorkloadReady(tc.workload); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// Health statuses reported by Argo CD for Applications
+const (
+	HealthHealthy     = "Healthy"
+	HealthProgressing = "Progressing"
+	HealthDegraded    = "Degraded"
+	HealthSuspended   = "Suspended"
+	HealthMissing     = "Missing"
+)
+
+// Sync statuses reported by Argo CD for Applications
+const (
+	SyncSynced    = "Synced"
+	SyncOutOfSync = "OutOfSync"
+)
+
+// ApplicationHealthy returns a condition that is met once the Application
+// identified by name is Synced and Healthy and no sync operation is running
+func ApplicationHealthy(r *resources.Resources, name string) apimachinerywait.ConditionWithContextFunc {
+	return ApplicationStatusMatch(r, name, HealthHealthy, SyncSynced)
+}
+
+// ApplicationStatusMatch returns a condition that is met once the Application
+// identified by name reports the given health and sync statuses and no sync
+// operation is running. An empty status matches any value, e.g. to wait for
+// an Application to become Degraded regardless of its sync status.
+func ApplicationStatusMatch(r *resources.Resources, name, health, sync string) apimachinerywait.ConditionWithContextFunc {
+	return conditions.New(r).ResourceMatch(newApplication(name), func(object k8s.Object) bool {
+		return hasStatus(object, health, sync)
+	})
+}
+
+// WaitForApplicationHealthy returns an env.Func that blocks until the
+// Application identified by name is Synced and Healthy. The wait is aborted
+// with an error once the timeout expires.
+func WaitForApplicationHealthy(name string, timeout time.Duration) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		if err := wait.For(ApplicationHealthy(client.Resources(), name), wait.WithContext(ctx), wait.WithTimeout(timeout), wait.WithImmediate()); err != nil {
+			return ctx, fmt.Errorf("application %s did not become healthy: %w", name, err)
+		}
+		return ctx, nil
+	}
+}
+
+// hasStatus checks the health and sync statuses of an Application, and that
+// no sync operation is pending or running
+func hasStatus(object k8s.Object, health, sync string) bool {
+	app, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	if _, pending, _ := unstructured.NestedMap(app.Object, "operation"); pending {
+		return false
+	}
+	phase, _, _ := unstructured.NestedString(app.Object, "status", "operationState", "phase")
+	if phase == "Running" || phase == "Terminating" {
+		return false
+	}
+	healthStatus, _, _ := unstructured.NestedString(app.Object, "status", "health", "status")
+	syncStatus, _, _ := unstructured.NestedString(app.Object, "status", "sync", "status")
+	log.V(4).InfoS("Application status", "name", app.GetName(), "health", healthStatus, "sync", syncStatus, "operation", phase)
+	return (health == "" || healthStatus == health) && (sync == "" || syncStatus == sync)
+}
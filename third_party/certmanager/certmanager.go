@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certmanager provides env funcs to install cert-manager into the
+// cluster under test, to create Issuers and Certificates and to wait for
+// them to become ready.
+package certmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/decoder"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+const (
+	// DefaultVersion is the cert-manager release installed when no version
+	// is provided via WithVersion
+	DefaultVersion = "v1.16.2"
+	// DefaultNamespace is the namespace the cert-manager release manifest
+	// installs its components into
+	DefaultNamespace = "cert-manager"
+	// DefaultTimeout is the time Install waits for the cert-manager
+	// components to become available
+	DefaultTimeout = 5 * time.Minute
+
+	releaseURL  = "https://github.com/cert-manager/cert-manager/releases/download/%s/cert-manager.yaml"
+	webhookName = "cert-manager-webhook"
+)
+
+// deployments are the cert-manager components that must be available before
+// Issuers and Certificates can be created
+var deployments = []string{"cert-manager", "cert-manager-cainjector", "cert-manager-webhook"}
+
+type Opts struct {
+	// Version is the cert-manager release to install
+	Version string
+	// ManifestURL overrides the location of the release manifest, for
+	// instance to install from a mirror. It takes precedence over Version
+	ManifestURL string
+	// Timeout is the time to wait for the cert-manager components to become
+	// available
+	Timeout time.Duration
+}
+
+type Option func(*Opts)
+
+// WithVersion sets the cert-manager release to install, such as v1.16.2
+func WithVersion(version string) Option {
+	return func(opts *Opts) {
+		opts.Version = version
+	}
+}
+
+// WithManifestURL sets the URL of the manifest used to install cert-manager
+func WithManifestURL(url string) Option {
+	return func(opts *Opts) {
+		opts.ManifestURL = url
+	}
+}
+
+// WithTimeout sets the time to wait for the cert-manager components to
+// become available
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *Opts) {
+		opts.Timeout = timeout
+	}
+}
+
+func processOpts(opts ...Option) *Opts {
+	o := &Opts{Version: DefaultVersion, Timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// manifestURL returns the URL of the release manifest selected by the options
+func (o *Opts) manifestURL() string {
+	if o.ManifestURL != "" {
+		return o.ManifestURL
+	}
+	return fmt.Sprintf(releaseURL, o.Version)
+}
+
+// Install returns an env.Func that applies the cert-manager release manifest
+// and waits for the cert-manager components to be available and for the
+// webhook to be served with its CA bundle, so that Issuers and Certificates
+// can be created as soon as it returns. Objects of the manifest that already
+// exist are left untouched.
+func Install(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		o := processOpts(opts...)
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		r := client.Resources()
+
+		url := o.manifestURL()
+		log.V(4).InfoS("Installing cert-manager", "manifest", url)
+		if err := decoder.DecodeURL(ctx, url, decoder.CreateIgnoreAlreadyExists(r)); err != nil {
+			return ctx, fmt.Errorf("installation of cert-manager failed: %w", err)
+		}
+
+		cond := conditions.New(r)
+		for _, name := range deployments {
+			if err := wait.For(cond.DeploymentAvailable(name, DefaultNamespace), wait.WithContext(ctx), wait.WithTimeout(o.Timeout)); err != nil {
+				return ctx, fmt.Errorf("cert-manager deployment %s did not become available: %w", name, err)
+			}
+		}
+
+		webhook := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		webhook.SetName(webhookName)
+		if err := wait.For(cond.ResourceMatch(webhook, hasCABundle), wait.WithContext(ctx), wait.WithTimeout(o.Timeout), wait.WithImmediate()); err != nil {
+			return ctx, fmt.Errorf("cert-manager webhook was not injected a CA bundle: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// Uninstall returns an env.Func that deletes the objects of the cert-manager
+// release manifest. The options must select the same manifest as the one
+// used by Install.
+func Uninstall(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		o := processOpts(opts...)
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+
+		url := o.manifestURL()
+		log.V(4).InfoS("Uninstalling cert-manager", "manifest", url)
+		if err := decoder.DecodeURL(ctx, url, decoder.DeleteIgnoreNotFound(client.Resources(), resources.WithDeletePropagation("Background"))); err != nil {
+			return ctx, fmt.Errorf("uninstallation of cert-manager failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// hasCABundle checks if the cainjector has populated the CA bundle of every
+// webhook of the cert-manager webhook configuration
+func hasCABundle(object k8s.Object) bool {
+	config, ok := object.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+	if !ok || len(config.Webhooks) == 0 {
+		return false
+	}
+	for _, webhook := range config.Webhooks {
+		if len(webhook.ClientConfig.CABundle) == 0 {
+			return false
+		}
+	}
+	return true
+}
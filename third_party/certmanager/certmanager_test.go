@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	"reflect"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestManifestURL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default", want: "https://github.com/cert-manager/cert-manager/releases/download/" + DefaultVersion + "/cert-manager.yaml"},
+		{name: "version", opts: []Option{WithVersion("v1.15.0")}, want: "https://github.com/cert-manager/cert-manager/releases/download/v1.15.0/cert-manager.yaml"},
+		{name: "manifest", opts: []Option{WithVersion("v1.15.0"), WithManifestURL("https://mirror.example.com/cert-manager.yaml")}, want: "https://mirror.example.com/cert-manager.yaml"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := processOpts(tc.opts...).manifestURL(); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCertificate(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []CertificateOption
+		field     []string
+		want      interface{}
+		wantFound bool
+	}{
+		{name: "default secret", field: []string{"spec", "secretName"}, want: "web", wantFound: true},
+		{name: "secret", opts: []CertificateOption{WithSecretName("web-tls")}, field: []string{"spec", "secretName"}, want: "web-tls", wantFound: true},
+		{name: "issuer", field: []string{"spec", "issuerRef"}, want: map[string]interface{}{"name": "selfsigned", "kind": "Issuer", "group": Group}, wantFound: true},
+		{name: "cluster issuer", opts: []CertificateOption{WithClusterIssuer()}, field: []string{"spec", "issuerRef", "kind"}, want: "ClusterIssuer", wantFound: true},
+		{name: "dns names", opts: []CertificateOption{WithDNSNames("web.apps.svc", "web")}, field: []string{"spec", "dnsNames"}, want: []interface{}{"web.apps.svc", "web"}, wantFound: true},
+		{name: "common name", opts: []CertificateOption{WithCommonName("web")}, field: []string{"spec", "commonName"}, want: "web", wantFound: true},
+		{name: "ca", opts: []CertificateOption{WithIsCA()}, field: []string{"spec", "isCA"}, want: true, wantFound: true},
+		{name: "not ca", field: []string{"spec", "isCA"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cert := Certificate("web", "apps", "selfsigned", tc.opts...)
+			if cert.GroupVersionKind() != CertificateGVK || cert.GetName() != "web" || cert.GetNamespace() != "apps" {
+				t.Fatalf("unexpected certificate %s %s/%s", cert.GroupVersionKind(), cert.GetNamespace(), cert.GetName())
+			}
+			got, found, err := unstructured.NestedFieldNoCopy(cert.Object, tc.field...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found != tc.wantFound || !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v (found %t), got %v (found %t)", tc.want, tc.wantFound, got, found)
+			}
+		})
+	}
+}
+
+func TestIssuers(t *testing.T) {
+	tests := []struct {
+		name      string
+		issuer    *unstructured.Unstructured
+		kind      string
+		namespace string
+		spec      map[string]interface{}
+	}{
+		{name: "self-signed", issuer: SelfSignedIssuer("issuer", "apps"), kind: "Issuer", namespace: "apps", spec: map[string]interface{}{"selfSigned": map[string]interface{}{}}},
+		{name: "self-signed cluster", issuer: SelfSignedClusterIssuer("issuer"), kind: "ClusterIssuer", spec: map[string]interface{}{"selfSigned": map[string]interface{}{}}},
+		{name: "ca", issuer: CAIssuer("issuer", "apps", "ca-tls"), kind: "Issuer", namespace: "apps", spec: map[string]interface{}{"ca": map[string]interface{}{"secretName": "ca-tls"}}},
+		{name: "ca cluster", issuer: CAClusterIssuer("issuer", "ca-tls"), kind: "ClusterIssuer", spec: map[string]interface{}{"ca": map[string]interface{}{"secretName": "ca-tls"}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.issuer.GetKind() != tc.kind || tc.issuer.GetAPIVersion() != "cert-manager.io/v1" {
+				t.Errorf("expected %s, got %s %s", tc.kind, tc.issuer.GetAPIVersion(), tc.issuer.GetKind())
+			}
+			if tc.issuer.GetName() != "issuer" || tc.issuer.GetNamespace() != tc.namespace {
+				t.Errorf("expected issuer in namespace %q, got %s/%s", tc.namespace, tc.issuer.GetNamespace(), tc.issuer.GetName())
+			}
+			if !reflect.DeepEqual(tc.issuer.Object["spec"], tc.spec) {
+				t.Errorf("expected spec %v, got %v", tc.spec, tc.issuer.Object["spec"])
+			}
+		})
+	}
+}
+
+func TestHasCABundle(t *testing.T) {
+	webhook := func(caBundle []byte) admissionregistrationv1.ValidatingWebhook {
+		return admissionregistrationv1.ValidatingWebhook{ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: caBundle}}
+	}
+	tests := []struct {
+		name     string
+		webhooks []admissionregistrationv1.ValidatingWebhook
+		want     bool
+	}{
+		{name: "no webhooks"},
+		{name: "not injected", webhooks: []admissionregistrationv1.ValidatingWebhook{webhook(nil)}},
+		{name: "partially injected", webhooks: []admissionregistrationv1.ValidatingWebhook{webhook([]byte("ca")), webhook(nil)}},
+		{name: "injected", webhooks: []admissionregistrationv1.ValidatingWebhook{webhook([]byte("ca"))}, want: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &admissionregistrationv1.ValidatingWebhookConfiguration{Webhooks: tc.webhooks}
+			if got := hasCABundle(config); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
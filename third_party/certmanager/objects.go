@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// Group is the API group of the cert-manager objects
+const Group = "cert-manager.io"
+
+var (
+	IssuerGVK        = schema.GroupVersionKind{Group: Group, Version: "v1", Kind: "Issuer"}
+	ClusterIssuerGVK = schema.GroupVersionKind{Group: Group, Version: "v1", Kind: "ClusterIssuer"}
+	CertificateGVK   = schema.GroupVersionKind{Group: Group, Version: "v1", Kind: "Certificate"}
+)
+
+// SelfSignedIssuer returns an Issuer that signs certificates with their own
+// private key
+func SelfSignedIssuer(name, namespace string) *unstructured.Unstructured {
+	return newIssuer(IssuerGVK, name, namespace, map[string]interface{}{"selfSigned": map[string]interface{}{}})
+}
+
+// SelfSignedClusterIssuer returns a ClusterIssuer that signs certificates
+// with their own private key
+func SelfSignedClusterIssuer(name string) *unstructured.Unstructured {
+	return newIssuer(ClusterIssuerGVK, name, "", map[string]interface{}{"selfSigned": map[string]interface{}{}})
+}
+
+// CAIssuer returns an Issuer that signs certificates with the CA key pair
+// stored in the secret identified by secretName, in the namespace of the
+// Issuer. The secret is typically issued by a Certificate created with
+// WithIsCA.
+func CAIssuer(name, namespace, secretName string) *unstructured.Unstructured {
+	return newIssuer(IssuerGVK, name, namespace, map[string]interface{}{"ca": map[string]interface{}{"secretName": secretName}})
+}
+
+// CAClusterIssuer returns a ClusterIssuer that signs certificates with the
+// CA key pair stored in the secret identified by secretName. The secret is
+// looked up in the cluster resource namespace of cert-manager, which is
+// cert-manager by default.
+func CAClusterIssuer(name, secretName string) *unstructured.Unstructured {
+	return newIssuer(ClusterIssuerGVK, name, "", map[string]interface{}{"ca": map[string]interface{}{"secretName": secretName}})
+}
+
+func newIssuer(gvk schema.GroupVersionKind, name, namespace string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return obj
+}
+
+// CertificateOption customizes the Certificate returned by Certificate
+type CertificateOption func(spec map[string]interface{})
+
+// WithSecretName sets the name of the secret the certificate is stored in.
+// It defaults to the name of the Certificate.
+func WithSecretName(secretName string) CertificateOption {
+	return func(spec map[string]interface{}) {
+		spec["secretName"] = secretName
+	}
+}
+
+// WithDNSNames sets the DNS subject alternative names of the certificate
+func WithDNSNames(dnsNames ...string) CertificateOption {
+	return func(spec map[string]interface{}) {
+		names := make([]interface{}, 0, len(dnsNames))
+		for _, name := range dnsNames {
+			names = append(names, name)
+		}
+		spec["dnsNames"] = names
+	}
+}
+
+// WithCommonName sets the common name of the certificate
+func WithCommonName(commonName string) CertificateOption {
+	return func(spec map[string]interface{}) {
+		spec["commonName"] = commonName
+	}
+}
+
+// WithIsCA marks the certificate as a CA certificate, which can be used by
+// a CAIssuer or CAClusterIssuer to sign other certificates
+func WithIsCA() CertificateOption {
+	return func(spec map[string]interface{}) {
+		spec["isCA"] = true
+	}
+}
+
+// WithClusterIssuer makes the certificate reference a ClusterIssuer instead
+// of an Issuer in the namespace of the Certificate
+func WithClusterIssuer() CertificateOption {
+	return func(spec map[string]interface{}) {
+		issuerRef := spec["issuerRef"].(map[string]interface{}) // nolint: errcheck
+		issuerRef["kind"] = ClusterIssuerGVK.Kind
+	}
+}
+
+// Certificate returns a Certificate issued by the Issuer identified by
+// issuerName, in the same namespace, and stored in a secret named after the
+// Certificate.
+func Certificate(name, namespace, issuerName string, opts ...CertificateOption) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"secretName": name,
+		"issuerRef": map[string]interface{}{
+			"name":  issuerName,
+			"kind":  IssuerGVK.Kind,
+			"group": Group,
+		},
+	}
+	for _, opt := range opts {
+		opt(spec)
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(CertificateGVK)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return obj
+}
+
+// Create returns an env.Func that creates the given cert-manager objects,
+// such as the ones returned by SelfSignedIssuer or Certificate, in order
+func Create(objs ...*unstructured.Unstructured) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		for _, obj := range objs {
+			if err := client.Resources().Create(ctx, obj.DeepCopy()); err != nil {
+				return ctx, fmt.Errorf("creation of %s %s failed: %w", obj.GetKind(), obj.GetName(), err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// Delete returns an env.Func that deletes the given cert-manager objects in
+// reverse order. Objects that do not exist are ignored.
+func Delete(objs ...*unstructured.Unstructured) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		for i := len(objs) - 1; i >= 0; i-- {
+			obj := objs[i]
+			if err := client.Resources().Delete(ctx, obj.DeepCopy()); err != nil && !apierrors.IsNotFound(err) {
+				return ctx, fmt.Errorf("deletion of %s %s failed: %w", obj.GetKind(), obj.GetName(), err)
+			}
+		}
+		return ctx, nil
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// CertificateReady returns a condition that is met once the Certificate
+// identified by name and namespace reports a Ready status condition for its
+// latest generation, meaning the certificate has been issued and stored in
+// its secret. It is meant to be used with wait.For in assessments.
+//
+//	wait.For(certmanager.CertificateReady(cfg.Client().Resources(), "web", namespace), wait.WithTimeout(time.Minute))
+func CertificateReady(r *resources.Resources, name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return readyCondition(r, CertificateGVK, name, namespace)
+}
+
+// WaitForCertificateReady returns an env.Func that blocks until the
+// Certificate identified by name and namespace is Ready. The wait is aborted
+// with an error once the timeout expires.
+func WaitForCertificateReady(name, namespace string, timeout time.Duration) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := waitForReady(ctx, c, CertificateGVK, name, namespace, timeout); err != nil {
+			return ctx, fmt.Errorf("certificate %s did not become ready: %w", name, err)
+		}
+		return ctx, nil
+	}
+}
+
+// WaitForIssuerReady returns an env.Func that blocks until the Issuer
+// identified by name and namespace is Ready. An empty namespace waits for
+// the ClusterIssuer identified by name instead. The wait is aborted with an
+// error once the timeout expires.
+func WaitForIssuerReady(name, namespace string, timeout time.Duration) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		gvk := IssuerGVK
+		if namespace == "" {
+			gvk = ClusterIssuerGVK
+		}
+		if err := waitForReady(ctx, c, gvk, name, namespace, timeout); err != nil {
+			return ctx, fmt.Errorf("%s %s did not become ready: %w", gvk.Kind, name, err)
+		}
+		return ctx, nil
+	}
+}
+
+func waitForReady(ctx context.Context, c *envconf.Config, gvk schema.GroupVersionKind, name, namespace string, timeout time.Duration) error {
+	client, err := c.NewClient()
+	if err != nil {
+		return err
+	}
+	return wait.For(
+		readyCondition(client.Resources(), gvk, name, namespace),
+		wait.WithContext(ctx),
+		wait.WithTimeout(timeout),
+		wait.WithImmediate(),
+	)
+}
+
+// readyCondition checks the Ready status condition of the cert-manager
+// object of the given kind
+func readyCondition(r *resources.Resources, gvk schema.GroupVersionKind, name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return conditions.New(r).ResourceConditionMatch(obj, "Ready", "True")
+}
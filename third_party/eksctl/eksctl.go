@@ -0,0 +1,337 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eksctl provides a cluster provider that manages Amazon EKS clusters using the eksctl CLI.
+// The node lifecycle operations of the provider operate on EKS managed nodegroups.
+package eksctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vladimirvivien/gexe"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/klient/conf"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+type Cluster struct {
+	path        string
+	name        string
+	kubecfgFile string
+	version     string
+	region      string
+	nodes       int
+	args        []string
+	rc          *rest.Config
+}
+
+// nodeGroup is a subset of the `eksctl get nodegroup -o json` output
+type nodeGroup struct {
+	Name            string `json:"Name"`
+	Status          string `json:"Status"`
+	DesiredCapacity int    `json:"DesiredCapacity"`
+}
+
+var (
+	_ support.E2EClusterProvider              = &Cluster{}
+	_ support.E2EClusterProviderWithLifeCycle = &Cluster{}
+)
+
+func NewCluster(name string) *Cluster {
+	return &Cluster{name: name}
+}
+
+func NewProvider() support.E2EClusterProvider {
+	return &Cluster{}
+}
+
+// WithRegion is used to configure the AWS region of the cluster
+func WithRegion(region string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		e, ok := c.(*Cluster)
+		if ok {
+			e.region = region
+		}
+	}
+}
+
+// WithNodes is used to configure the number of nodes of the default nodegroup of the cluster
+func WithNodes(nodes int) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		e, ok := c.(*Cluster)
+		if ok {
+			e.nodes = nodes
+		}
+	}
+}
+
+// WithArgs is used to pass additional arguments to the `eksctl create cluster` command
+func WithArgs(args ...string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		e, ok := c.(*Cluster)
+		if ok {
+			e.args = append(e.args, args...)
+		}
+	}
+}
+
+func WithPath(path string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		e, ok := c.(*Cluster)
+		if ok {
+			e.path = path
+		}
+	}
+}
+
+func (c *Cluster) WithName(name string) support.E2EClusterProvider {
+	c.name = name
+	return c
+}
+
+// WithVersion is used to configure the kubernetes version of the EKS cluster
+func (c *Cluster) WithVersion(version string) support.E2EClusterProvider {
+	c.version = version
+	return c
+}
+
+func (c *Cluster) WithPath(path string) support.E2EClusterProvider {
+	c.path = path
+	return c
+}
+
+func (c *Cluster) WithOpts(opts ...support.ClusterOpts) support.E2EClusterProvider {
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+func (c *Cluster) SetDefaults() support.E2EClusterProvider {
+	if c.path == "" {
+		c.path = "eksctl"
+	}
+	return c
+}
+
+// run invokes an eksctl command and returns its stdout
+func (c *Cluster) run(command string) (string, error) {
+	if gexe.ProgAvail(c.path) == "" {
+		return "", fmt.Errorf("'%s' command is missing. Please ensure the tool exists before using the eksctl provider", c.path)
+	}
+	var stdout, stderr bytes.Buffer
+	p := gexe.New().NewProc(command)
+	p.SetStdout(&stdout)
+	p.SetStderr(&stderr)
+	p.Run()
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return "", fmt.Errorf("%s: %s", p.Err(), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (c *Cluster) regionArgs() string {
+	if c.region == "" {
+		return ""
+	}
+	return " --region " + c.region
+}
+
+func (c *Cluster) clusterExists(name string) bool {
+	_, err := c.run(fmt.Sprintf("%s get cluster --name %s%s", c.path, name, c.regionArgs()))
+	return err == nil
+}
+
+func (c *Cluster) Create(ctx context.Context, args ...string) (string, error) {
+	log.V(4).InfoS("Creating EKS cluster", "name", c.name, "region", c.region)
+	if c.clusterExists(c.name) {
+		log.V(4).InfoS("Skipping EKS cluster creation. Cluster already exists", "name", c.name)
+		return c.getKubeconfig()
+	}
+
+	return c.create(c.createCommand(args...))
+}
+
+// createCommand returns the eksctl command creating the cluster with the configured version, number
+// of nodes and extra arguments
+func (c *Cluster) createCommand(args ...string) string {
+	if c.version != "" {
+		args = append(args, "--version", c.version)
+	}
+	if c.nodes > 0 {
+		args = append(args, "--nodes", fmt.Sprint(c.nodes))
+	}
+	args = append(args, c.args...)
+
+	command := fmt.Sprintf("%s create cluster --name %s%s --write-kubeconfig=false", c.path, c.name, c.regionArgs())
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	return command
+}
+
+// CreateWithConfig creates the cluster using an eksctl ClusterConfig file. The name and region
+// of the cluster have to match the ones configured in the file.
+func (c *Cluster) CreateWithConfig(ctx context.Context, configFile string) (string, error) {
+	if configFile == "" {
+		return c.Create(ctx)
+	}
+	if c.clusterExists(c.name) {
+		log.V(4).InfoS("Skipping EKS cluster creation. Cluster already exists", "name", c.name)
+		return c.getKubeconfig()
+	}
+	return c.create(fmt.Sprintf("%s create cluster --config-file %s --write-kubeconfig=false", c.path, configFile))
+}
+
+func (c *Cluster) create(command string) (string, error) {
+	log.V(4).InfoS("Launching EKS cluster", "command", command)
+	if _, err := c.run(command); err != nil {
+		return "", fmt.Errorf("eksctl: failed to create cluster %q: %w", c.name, err)
+	}
+	return c.getKubeconfig()
+}
+
+func (c *Cluster) getKubeconfig() (string, error) {
+	file, err := os.CreateTemp("", fmt.Sprintf("eksctl-cluster-%s-kubecfg", c.name))
+	if err != nil {
+		return "", fmt.Errorf("eksctl kubeconfig file: %w", err)
+	}
+	file.Close()
+	c.kubecfgFile = file.Name()
+
+	command := fmt.Sprintf("%s utils write-kubeconfig --cluster %s%s --kubeconfig %s", c.path, c.name, c.regionArgs(), c.kubecfgFile)
+	if _, err := c.run(command); err != nil {
+		return "", fmt.Errorf("eksctl: failed to write kubeconfig of cluster %q: %w", c.name, err)
+	}
+	cfg, err := conf.New(c.kubecfgFile)
+	if err != nil {
+		return "", err
+	}
+	c.rc = cfg
+	return c.kubecfgFile, nil
+}
+
+func (c *Cluster) GetKubeconfig() string {
+	return c.kubecfgFile
+}
+
+func (c *Cluster) GetKubectlContext() string {
+	cfg, err := clientcmd.LoadFromFile(c.kubecfgFile)
+	if err != nil {
+		return ""
+	}
+	return cfg.CurrentContext
+}
+
+func (c *Cluster) ExportLogs(ctx context.Context, dest string) error {
+	log.Warning("ExportLogs not implemented for eksctl. Please use regular kubectl like commands or CloudWatch to extract the logs from the cluster")
+	return nil
+}
+
+func (c *Cluster) Destroy(ctx context.Context) error {
+	log.V(4).InfoS("Destroying EKS cluster", "name", c.name)
+	if _, err := c.run(fmt.Sprintf("%s delete cluster --name %s%s --wait", c.path, c.name, c.regionArgs())); err != nil {
+		return fmt.Errorf("eksctl: failed to delete cluster %q: %w", c.name, err)
+	}
+
+	log.V(4).InfoS("Removing kubeconfig file", "configFile", c.kubecfgFile)
+	if err := os.RemoveAll(c.kubecfgFile); err != nil {
+		return fmt.Errorf("eksctl: failed to remove kubeconfig file %q: %w", c.kubecfgFile, err)
+	}
+	return nil
+}
+
+func (c *Cluster) WaitForControlPlane(ctx context.Context, client klient.Client) error {
+	log.V(4).Info("eksctl provider doesn't implement a WaitForControlPlane as eksctl waits for the control plane")
+	return nil
+}
+
+func (c *Cluster) KubernetesRestConfig() *rest.Config {
+	return c.rc
+}
+
+// AddNode creates a managed nodegroup named after the node with a single node
+func (c *Cluster) AddNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodeGroupOperation(fmt.Sprintf("create nodegroup --name %s --nodes 1", node.Name), node, args...)
+}
+
+// RemoveNode deletes the managed nodegroup named after the node
+func (c *Cluster) RemoveNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodeGroupOperation(fmt.Sprintf("delete nodegroup --name %s --wait", node.Name), node, args...)
+}
+
+// StartNode scales the managed nodegroup named after the node to a single node
+func (c *Cluster) StartNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.ScaleNodePool(ctx, node.Name, 1, args...)
+}
+
+// StopNode scales the managed nodegroup named after the node to zero nodes
+func (c *Cluster) StopNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.ScaleNodePool(ctx, node.Name, 0, args...)
+}
+
+// ScaleNodePool scales the managed nodegroup to the given number of nodes
+func (c *Cluster) ScaleNodePool(ctx context.Context, name string, nodes int, args ...string) error {
+	return c.nodeGroupOperation(fmt.Sprintf("scale nodegroup --name %s --nodes %d --nodes-min 0", name, nodes), &support.Node{Name: name}, args...)
+}
+
+func (c *Cluster) nodeGroupOperation(operation string, node *support.Node, args ...string) error {
+	command := fmt.Sprintf("%s %s --cluster %s%s", c.path, operation, c.name, c.regionArgs())
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	log.V(4).InfoS("Performing nodegroup operation on EKS cluster", "command", command)
+	if _, err := c.run(command); err != nil {
+		return fmt.Errorf("eksctl: nodegroup operation on %q in cluster %q failed: %w", node.Name, c.name, err)
+	}
+	return nil
+}
+
+// ListNode returns the managed nodegroups of the cluster
+func (c *Cluster) ListNode(ctx context.Context, args ...string) ([]support.Node, error) {
+	out, err := c.run(fmt.Sprintf("%s get nodegroup --cluster %s%s --output json", c.path, c.name, c.regionArgs()))
+	if err != nil {
+		return nil, fmt.Errorf("eksctl: failed to list nodegroups: %w", err)
+	}
+	return parseNodeGroups(c.name, out)
+}
+
+// parseNodeGroups converts the output of `eksctl get nodegroup --output json` into nodes
+func parseNodeGroups(cluster, out string) ([]support.Node, error) {
+	var groups []nodeGroup
+	if err := json.Unmarshal([]byte(out), &groups); err != nil {
+		return nil, fmt.Errorf("eksctl: failed to unmarshal nodegroup list: %w", err)
+	}
+	nodes := make([]support.Node, 0, len(groups))
+	for _, g := range groups {
+		nodes = append(nodes, support.Node{
+			Name:    g.Name,
+			Role:    "nodegroup",
+			State:   g.Status,
+			Cluster: cluster,
+		})
+	}
+	return nodes, nil
+}
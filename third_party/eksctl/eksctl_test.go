@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eksctl
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+func TestClusterRegionArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		region string
+		want   string
+	}{
+		{name: "no region"},
+		{name: "region", region: "eu-west-1", want: " --region eu-west-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cluster{region: tt.region}
+			if got := c.regionArgs(); got != tt.want {
+				t.Errorf("regionArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *Cluster
+		args    []string
+		want    string
+	}{
+		{
+			name:    "defaults",
+			cluster: &Cluster{path: "eksctl", name: "e2e"},
+			want:    "eksctl create cluster --name e2e --write-kubeconfig=false",
+		},
+		{
+			name:    "version, nodes and region",
+			cluster: &Cluster{path: "eksctl", name: "e2e", version: "1.32", nodes: 2, region: "us-west-2"},
+			want:    "eksctl create cluster --name e2e --region us-west-2 --write-kubeconfig=false --version 1.32 --nodes 2",
+		},
+		{
+			name:    "call and provider arguments",
+			cluster: &Cluster{path: "eksctl", name: "e2e", args: []string{"--node-type", "m5.large"}},
+			args:    []string{"--managed"},
+			want:    "eksctl create cluster --name e2e --write-kubeconfig=false --managed --node-type m5.large",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cluster.createCommand(tt.args...); got != tt.want {
+				t.Errorf("createCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNodeGroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     string
+		want    []support.Node
+		wantErr bool
+	}{
+		{name: "no nodegroups", out: "[]", want: []support.Node{}},
+		{
+			name: "nodegroups",
+			out:  `[{"Cluster":"e2e","Name":"ng-1","Status":"ACTIVE","DesiredCapacity":2},{"Cluster":"e2e","Name":"ng-2","Status":"CREATING","DesiredCapacity":1}]`,
+			want: []support.Node{
+				{Name: "ng-1", Role: "nodegroup", State: "ACTIVE", Cluster: "e2e"},
+				{Name: "ng-2", Role: "nodegroup", State: "CREATING", Cluster: "e2e"},
+			},
+		},
+		{name: "invalid output", out: "Error: cluster not found", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNodeGroups("e2e", tt.out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNodeGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNodeGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterGetKubectlContext(t *testing.T) {
+	kubecfg := filepath.Join(t.TempDir(), "kubeconfig")
+	data := `apiVersion: v1
+kind: Config
+clusters:
+- name: e2e.us-west-2.eksctl.io
+  cluster:
+    server: https://example.eks.amazonaws.com
+contexts:
+- name: e2e@e2e.us-west-2.eksctl.io
+  context:
+    cluster: e2e.us-west-2.eksctl.io
+    user: e2e
+current-context: e2e@e2e.us-west-2.eksctl.io
+users:
+- name: e2e
+  user:
+    token: secret
+`
+	if err := os.WriteFile(kubecfg, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		kubecfgFile string
+		want        string
+	}{
+		{name: "kubeconfig", kubecfgFile: kubecfg, want: "e2e@e2e.us-west-2.eksctl.io"},
+		{name: "missing kubeconfig", kubecfgFile: filepath.Join(t.TempDir(), "missing")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cluster{kubecfgFile: tt.kubecfgFile}
+			if got := c.GetKubectlContext(); got != tt.want {
+				t.Errorf("GetKubectlContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
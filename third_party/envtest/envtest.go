@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envtest provides a cluster provider backed by the controller-runtime envtest package,
+// which runs a local kube-apiserver and etcd without any nodes. It can be used by suites that
+// only need API server semantics, such as CRD validation or webhook tests, and runs without docker.
+package envtest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/client-go/rest"
+	log "k8s.io/klog/v2"
+	crenvtest "sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+type Cluster struct {
+	name         string
+	version      string
+	path         string
+	crdPaths     []string
+	startTimeout time.Duration
+	kubecfgFile  string
+	env          *crenvtest.Environment
+	rc           *rest.Config
+}
+
+// Enforce Type check always to avoid future breaks
+var _ support.E2EClusterProvider = &Cluster{}
+
+func NewCluster(name string) *Cluster {
+	return &Cluster{name: name}
+}
+
+func NewProvider() support.E2EClusterProvider {
+	return &Cluster{}
+}
+
+// WithCRDPaths is used to configure the files or directories containing CustomResourceDefinitions
+// that are installed in the control plane once it is started
+func WithCRDPaths(paths ...string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		e, ok := c.(*Cluster)
+		if ok {
+			e.crdPaths = append(e.crdPaths, paths...)
+		}
+	}
+}
+
+// WithStartTimeout is used to configure how long the provider waits for the control plane to start
+func WithStartTimeout(timeout time.Duration) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		e, ok := c.(*Cluster)
+		if ok {
+			e.startTimeout = timeout
+		}
+	}
+}
+
+func (c *Cluster) WithName(name string) support.E2EClusterProvider {
+	c.name = name
+	return c
+}
+
+// WithVersion is used to record the kubernetes version of the control plane binaries. The binaries
+// are not downloaded by the provider, the version has to match the binaries found via WithPath.
+func (c *Cluster) WithVersion(ver string) support.E2EClusterProvider {
+	c.version = ver
+	return c
+}
+
+// WithPath is used to configure the directory containing the kube-apiserver and etcd binaries.
+// When not configured, the KUBEBUILDER_ASSETS environment variable is used.
+func (c *Cluster) WithPath(path string) support.E2EClusterProvider {
+	c.path = path
+	return c
+}
+
+func (c *Cluster) WithOpts(opts ...support.ClusterOpts) support.E2EClusterProvider {
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+func (c *Cluster) SetDefaults() support.E2EClusterProvider {
+	if c.path == "" {
+		c.path = os.Getenv("KUBEBUILDER_ASSETS")
+	}
+	return c
+}
+
+// Create starts the control plane and returns the path of a kubeconfig file granting admin access
+// to it. Starting an already started control plane is a no-op. The args are ignored.
+func (c *Cluster) Create(ctx context.Context, args ...string) (string, error) {
+	if c.env != nil {
+		log.V(4).InfoS("Skipping envtest control plane start: already started", "name", c.name)
+		return c.kubecfgFile, nil
+	}
+	log.V(4).InfoS("Starting envtest control plane", "name", c.name, "assets", c.path, "version", c.version)
+
+	env := &crenvtest.Environment{
+		BinaryAssetsDirectory:    c.path,
+		CRDDirectoryPaths:        c.crdPaths,
+		ErrorIfCRDPathMissing:    len(c.crdPaths) > 0,
+		ControlPlaneStartTimeout: c.startTimeout,
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		return "", fmt.Errorf("envtest: failed to start control plane %q: %w", c.name, err)
+	}
+	c.env = env
+	c.rc = cfg
+
+	kubecfg, err := c.writeKubeconfig()
+	if err != nil {
+		_ = c.Destroy(ctx)
+		return "", err
+	}
+	return kubecfg, nil
+}
+
+// writeKubeconfig writes the kubeconfig of an admin user of the control plane to a temporary file
+func (c *Cluster) writeKubeconfig() (string, error) {
+	user, err := c.env.AddUser(crenvtest.User{Name: "envtest-admin", Groups: []string{"system:masters"}}, nil)
+	if err != nil {
+		return "", fmt.Errorf("envtest: failed to provision admin user: %w", err)
+	}
+	data, err := user.KubeConfig()
+	if err != nil {
+		return "", fmt.Errorf("envtest: failed to generate kubeconfig: %w", err)
+	}
+
+	file, err := os.CreateTemp("", fmt.Sprintf("envtest-cluster-%s-kubecfg", c.name))
+	if err != nil {
+		return "", fmt.Errorf("envtest kubeconfig file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		return "", fmt.Errorf("envtest: failed to write kubeconfig %q: %w", file.Name(), err)
+	}
+	c.kubecfgFile = file.Name()
+	return file.Name(), nil
+}
+
+// CreateWithConfig starts the control plane using configFile as an additional CRD file or directory
+func (c *Cluster) CreateWithConfig(ctx context.Context, configFile string) (string, error) {
+	if configFile != "" {
+		c.crdPaths = append(c.crdPaths, configFile)
+	}
+	return c.Create(ctx)
+}
+
+func (c *Cluster) GetKubeconfig() string {
+	return c.kubecfgFile
+}
+
+// GetKubectlContext returns the context name used by the kubeconfig generated by envtest
+func (c *Cluster) GetKubectlContext() string {
+	return "envtest"
+}
+
+func (c *Cluster) ExportLogs(ctx context.Context, dest string) error {
+	log.Warning("ExportLogs not implemented for envtest. The control plane has no nodes or pods to extract logs from")
+	return nil
+}
+
+// Destroy stops the control plane and removes the kubeconfig file generated by Create
+func (c *Cluster) Destroy(ctx context.Context) error {
+	log.V(4).InfoS("Stopping envtest control plane", "name", c.name)
+	if c.env != nil {
+		if err := c.env.Stop(); err != nil {
+			return fmt.Errorf("envtest: failed to stop control plane %q: %w", c.name, err)
+		}
+		c.env = nil
+	}
+	if c.kubecfgFile != "" {
+		if err := os.RemoveAll(c.kubecfgFile); err != nil {
+			return fmt.Errorf("envtest: failed to remove kubeconfig %q: %w", c.kubecfgFile, err)
+		}
+		c.kubecfgFile = ""
+	}
+	return nil
+}
+
+// WaitForControlPlane is a no-op as Create only returns once the API server is serving
+func (c *Cluster) WaitForControlPlane(ctx context.Context, client klient.Client) error {
+	return nil
+}
+
+func (c *Cluster) KubernetesRestConfig() *rest.Config {
+	return c.rc
+}
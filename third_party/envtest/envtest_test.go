@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	crenvtest "sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+func TestCluster_WithOpts(t *testing.T) {
+	c := NewCluster("test")
+	c.WithOpts(WithCRDPaths("crds/a.yaml"), WithCRDPaths("crds/b"), WithStartTimeout(time.Minute))
+	if want := []string{"crds/a.yaml", "crds/b"}; !reflect.DeepEqual(c.crdPaths, want) {
+		t.Errorf("crdPaths = %v, want %v", c.crdPaths, want)
+	}
+	if c.startTimeout != time.Minute {
+		t.Errorf("startTimeout = %v, want %v", c.startTimeout, time.Minute)
+	}
+}
+
+func TestCluster_SetDefaults(t *testing.T) {
+	t.Setenv("KUBEBUILDER_ASSETS", "/usr/local/kubebuilder/bin")
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "assets from the environment", want: "/usr/local/kubebuilder/bin"},
+		{name: "configured path", path: "/opt/envtest", want: "/opt/envtest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCluster("test").WithPath(tt.path).SetDefaults().(*Cluster)
+			if c.path != tt.want {
+				t.Errorf("path = %v, want %v", c.path, tt.want)
+			}
+		})
+	}
+}
+
+func TestCluster_CreateStarted(t *testing.T) {
+	// a started control plane is not started again
+	c := NewCluster("test").WithOpts(WithCRDPaths("crds/a.yaml")).(*Cluster)
+	c.env = &crenvtest.Environment{}
+	c.kubecfgFile = "/tmp/envtest-kubecfg"
+
+	kubecfg, err := c.CreateWithConfig(context.TODO(), "crds/b")
+	if err != nil || kubecfg != c.kubecfgFile {
+		t.Errorf("CreateWithConfig() = %v, %v, want %v", kubecfg, err, c.kubecfgFile)
+	}
+	if want := []string{"crds/a.yaml", "crds/b"}; !reflect.DeepEqual(c.crdPaths, want) {
+		t.Errorf("crdPaths = %v, want %v", c.crdPaths, want)
+	}
+}
+
+func TestCluster_DestroyRemovesKubeconfig(t *testing.T) {
+	kubecfg := filepath.Join(t.TempDir(), "kubecfg")
+	if err := os.WriteFile(kubecfg, []byte("apiVersion: v1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c := NewCluster("test")
+	c.kubecfgFile = kubecfg
+	if err := c.Destroy(context.TODO()); err != nil {
+		t.Fatalf("Destroy() error = %v", err)
+	}
+	if _, err := os.Stat(kubecfg); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the kubeconfig to be removed, got %v", err)
+	}
+	if c.GetKubeconfig() != "" {
+		t.Errorf("GetKubeconfig() = %v, want empty", c.GetKubeconfig())
+	}
+}
+
+func TestCluster_Lifecycle(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set, skipping envtest control plane test")
+	}
+	c := NewCluster("lifecycle").SetDefaults().(*Cluster)
+	kubecfg, err := c.Create(context.TODO())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if again, err := c.Create(context.TODO()); err != nil || again != kubecfg {
+		t.Errorf("second Create() = %v, %v, want %v", again, err, kubecfg)
+	}
+	if c.KubernetesRestConfig() == nil {
+		t.Error("expected the rest config of the control plane")
+	}
+	if err := c.Destroy(context.TODO()); err != nil {
+		t.Fatalf("Destroy() error = %v", err)
+	}
+	if _, err := os.Stat(kubecfg); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the kubeconfig to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package external provides a cluster provider that wraps an already existing cluster, such as
+// a managed EKS or GKE cluster, identified by a kubeconfig file and context. The provider never
+// creates or deletes the cluster, it only runs preflight checks against it.
+package external
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/klient/conf"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+type Cluster struct {
+	name          string
+	kubecfgFile   string
+	kubeContext   string
+	minVersion    string
+	maxNamespaces int
+	generatedFile string
+	rc            *rest.Config
+}
+
+// Enforce Type check always to avoid future breaks
+var _ support.E2EClusterProvider = &Cluster{}
+
+func NewCluster(name string) *Cluster {
+	return &Cluster{name: name}
+}
+
+func NewProvider() support.E2EClusterProvider {
+	return &Cluster{}
+}
+
+// WithKubeconfig is used to configure the kubeconfig file of the existing cluster. When not
+// configured, the kubeconfig is resolved from the --kubeconfig flag, the KUBECONFIG environment
+// variable or $HOME/.kube/config, in that order.
+func WithKubeconfig(kubeconfig string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		e, ok := c.(*Cluster)
+		if ok {
+			e.kubecfgFile = kubeconfig
+		}
+	}
+}
+
+// WithKubeContext is used to configure the kubeconfig context of the existing cluster. When not
+// configured, the current context of the kubeconfig is used.
+func WithKubeContext(kubeContext string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		e, ok := c.(*Cluster)
+		if ok {
+			e.kubeContext = kubeContext
+		}
+	}
+}
+
+// WithMaxNamespaces is used to configure a guardrail that fails the preflight checks if the
+// existing cluster already contains max or more namespaces. This can be used to detect namespaces
+// leaked by previous test runs on shared clusters before adding more.
+func WithMaxNamespaces(max int) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		e, ok := c.(*Cluster)
+		if ok {
+			e.maxNamespaces = max
+		}
+	}
+}
+
+func (c *Cluster) WithName(name string) support.E2EClusterProvider {
+	c.name = name
+	return c
+}
+
+// WithVersion is used to configure the minimum kubernetes version, e.g. v1.30, the existing cluster
+// is expected to run. The preflight checks fail if the cluster runs an older version.
+func (c *Cluster) WithVersion(ver string) support.E2EClusterProvider {
+	c.minVersion = ver
+	return c
+}
+
+// WithPath is a no-op as the provider is not backed by any executable
+func (c *Cluster) WithPath(path string) support.E2EClusterProvider {
+	return c
+}
+
+func (c *Cluster) WithOpts(opts ...support.ClusterOpts) support.E2EClusterProvider {
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+func (c *Cluster) SetDefaults() support.E2EClusterProvider {
+	if c.kubecfgFile == "" {
+		c.kubecfgFile = conf.ResolveKubeConfigFile()
+	}
+	if c.kubeContext == "" {
+		c.kubeContext = conf.ResolveClusterContext()
+	}
+	return c
+}
+
+// Create connects to the existing cluster and runs the preflight checks against it. No cluster
+// is created and the args are ignored.
+func (c *Cluster) Create(ctx context.Context, args ...string) (string, error) {
+	log.V(4).InfoS("Connecting to existing cluster", "name", c.name, "kubeconfig", c.kubecfgFile, "context", c.kubeContext)
+	if c.kubecfgFile == "" {
+		return "", fmt.Errorf("external: no kubeconfig found for cluster %q", c.name)
+	}
+
+	cfg, err := conf.NewWithContextName(c.kubecfgFile, c.kubeContext)
+	if err != nil {
+		return "", fmt.Errorf("external: failed to load kubeconfig %q: %w", c.kubecfgFile, err)
+	}
+	c.rc = cfg
+
+	if err := c.preflight(ctx); err != nil {
+		return "", err
+	}
+
+	if c.kubeContext == "" {
+		return c.kubecfgFile, nil
+	}
+	// the kubeconfig returned is used as is by the framework, so the configured
+	// context has to be the current context of the returned file
+	return c.writeContextKubeconfig()
+}
+
+// writeContextKubeconfig writes a copy of the kubeconfig whose current context is the configured context
+func (c *Cluster) writeContextKubeconfig() (string, error) {
+	cfg, err := clientcmd.LoadFromFile(c.kubecfgFile)
+	if err != nil {
+		return "", fmt.Errorf("external: failed to load kubeconfig %q: %w", c.kubecfgFile, err)
+	}
+	cfg.CurrentContext = c.kubeContext
+
+	file, err := os.CreateTemp("", fmt.Sprintf("external-cluster-%s-kubecfg", c.name))
+	if err != nil {
+		return "", fmt.Errorf("external kubeconfig file: %w", err)
+	}
+	file.Close()
+
+	if err := clientcmd.WriteToFile(*cfg, file.Name()); err != nil {
+		return "", fmt.Errorf("external: failed to write kubeconfig %q: %w", file.Name(), err)
+	}
+	c.generatedFile = file.Name()
+	return file.Name(), nil
+}
+
+// CreateWithConfig connects to the existing cluster using the configFile as kubeconfig
+func (c *Cluster) CreateWithConfig(ctx context.Context, configFile string) (string, error) {
+	if configFile != "" {
+		c.kubecfgFile = configFile
+	}
+	return c.Create(ctx)
+}
+
+// preflight checks the version of the existing cluster and the namespace guardrail
+func (c *Cluster) preflight(ctx context.Context) error {
+	if c.minVersion != "" {
+		dc, err := discovery.NewDiscoveryClientForConfig(c.rc)
+		if err != nil {
+			return fmt.Errorf("external: failed to create discovery client: %w", err)
+		}
+		info, err := dc.ServerVersion()
+		if err != nil {
+			return fmt.Errorf("external: failed to get server version of cluster %q: %w", c.name, err)
+		}
+		if err := checkVersion(info.GitVersion, c.minVersion); err != nil {
+			return fmt.Errorf("external: cluster %q preflight failed: %w", c.name, err)
+		}
+	}
+
+	if c.maxNamespaces > 0 {
+		r, err := resources.New(c.rc)
+		if err != nil {
+			return err
+		}
+		var namespaces corev1.NamespaceList
+		if err := r.List(ctx, &namespaces); err != nil {
+			return fmt.Errorf("external: failed to list namespaces of cluster %q: %w", c.name, err)
+		}
+		if len(namespaces.Items) >= c.maxNamespaces {
+			return fmt.Errorf("external: cluster %q preflight failed: %d namespaces found, the limit is %d", c.name, len(namespaces.Items), c.maxNamespaces)
+		}
+	}
+	return nil
+}
+
+// checkVersion returns an error if serverVersion is older than minVersion
+func checkVersion(serverVersion, minVersion string) error {
+	server, err := version.ParseGeneric(serverVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse server version %q: %w", serverVersion, err)
+	}
+	minimum, err := version.ParseGeneric(minVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse minimum version %q: %w", minVersion, err)
+	}
+	if server.LessThan(minimum) {
+		return fmt.Errorf("server version %s is older than the minimum version %s", serverVersion, minVersion)
+	}
+	return nil
+}
+
+func (c *Cluster) GetKubeconfig() string {
+	if c.generatedFile != "" {
+		return c.generatedFile
+	}
+	return c.kubecfgFile
+}
+
+func (c *Cluster) GetKubectlContext() string {
+	if c.kubeContext != "" {
+		return c.kubeContext
+	}
+	cfg, err := clientcmd.LoadFromFile(c.kubecfgFile)
+	if err != nil {
+		return ""
+	}
+	return cfg.CurrentContext
+}
+
+func (c *Cluster) ExportLogs(ctx context.Context, dest string) error {
+	log.Warning("ExportLogs not implemented for external clusters. Please use regular kubectl like commands to extract the logs from the cluster")
+	return nil
+}
+
+// Destroy never deletes the existing cluster, it only removes the kubeconfig file generated by Create
+func (c *Cluster) Destroy(ctx context.Context) error {
+	log.V(4).InfoS("Skipping destruction of existing cluster", "name", c.name)
+	if c.generatedFile == "" {
+		return nil
+	}
+	if err := os.RemoveAll(c.generatedFile); err != nil {
+		return fmt.Errorf("external: failed to remove kubeconfig file %q: %w", c.generatedFile, err)
+	}
+	c.generatedFile = ""
+	return nil
+}
+
+// WaitForControlPlane waits for the /readyz endpoint of the api server to report the control plane as ready
+func (c *Cluster) WaitForControlPlane(ctx context.Context, client klient.Client) error {
+	dc := client.Discovery()
+	return wait.For(func(ctx context.Context) (bool, error) {
+		if err := dc.RESTClient().Get().AbsPath("/readyz").Do(ctx).Error(); err != nil {
+			log.V(4).InfoS("Waiting for the control plane to be ready", "cluster", c.name, "error", err)
+			return false, nil
+		}
+		return true, nil
+	}, wait.WithContext(ctx))
+}
+
+func (c *Cluster) KubernetesRestConfig() *rest.Config {
+	return c.rc
+}
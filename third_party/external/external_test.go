@@ -0,0 +1,40 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import "testing"
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		serverVersion string
+		minVersion    string
+		wantErr       bool
+	}{
+		{name: "equal", serverVersion: "v1.30.0", minVersion: "v1.30", wantErr: false},
+		{name: "newer with vendor suffix", serverVersion: "v1.31.2-eks-7f9249a", minVersion: "v1.30", wantErr: false},
+		{name: "older", serverVersion: "v1.29.8-gke.1000", minVersion: "1.30.0", wantErr: true},
+		{name: "invalid minimum", serverVersion: "v1.30.0", minVersion: "latest", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkVersion(tt.serverVersion, tt.minVersion); (err != nil) != tt.wantErr {
+				t.Errorf("checkVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -25,18 +25,30 @@ import (
 )
 
 type Opts struct {
-	name      string
-	source    string
-	namespace string
-	mode      string
-	url       string
-	branch    string
-	tag       string
-	commit    string
-	path      string
-	interval  string
-	chart     string
-	args      []string
+	name       string
+	source     string
+	namespace  string
+	mode       string
+	url        string
+	branch     string
+	tag        string
+	commit     string
+	path       string
+	interval   string
+	chart      string
+	secretRef  string
+	username   string
+	password   string
+	bucketName string
+	endpoint   string
+	provider   string
+	region     string
+	accessKey  string
+	secretKey  string
+	owner      string
+	repository string
+	personal   bool
+	args       []string
 }
 
 type Source string
@@ -48,6 +60,14 @@ const (
 	Oci    Source = "oci"
 )
 
+// BootstrapProvider identifies the git hosting provider used by `flux bootstrap`
+type BootstrapProvider string
+
+const (
+	BootstrapProviderGit    BootstrapProvider = "git"
+	BootstrapProviderGitHub BootstrapProvider = "github"
+)
+
 type Manager struct {
 	e          *gexe.Echo
 	kubeConfig string
@@ -106,6 +126,70 @@ func WithInterval(interval string) Option {
 	}
 }
 
+// WithSecretRef is used to reference an existing secret containing the authentication
+// credentials of a source
+func WithSecretRef(secretRef string) Option {
+	return func(opts *Opts) {
+		opts.secretRef = secretRef
+	}
+}
+
+// WithBasicAuth is used to configure the username and password used to authenticate against
+// a git or OCI repository. Used while bootstrapping flux or creating a secret with CreateSecret
+func WithBasicAuth(username, password string) Option {
+	return func(opts *Opts) {
+		opts.username = username
+		opts.password = password
+	}
+}
+
+// WithBucketEndpoint is used to configure the S3 compatible storage endpoint of a bucket source
+func WithBucketEndpoint(endpoint string) Option {
+	return func(opts *Opts) {
+		opts.endpoint = endpoint
+	}
+}
+
+// WithBucketProvider is used to configure the provider of a bucket source (generic, aws, gcp or azure)
+func WithBucketProvider(provider string) Option {
+	return func(opts *Opts) {
+		opts.provider = provider
+	}
+}
+
+// WithBucketRegion is used to configure the region of a bucket source
+func WithBucketRegion(region string) Option {
+	return func(opts *Opts) {
+		opts.region = region
+	}
+}
+
+// WithBucketCredentials is used to configure the access and secret key used by a bucket source.
+// Flux stores them in a secret that is referenced by the bucket
+func WithBucketCredentials(accessKey, secretKey string) Option {
+	return func(opts *Opts) {
+		opts.accessKey = accessKey
+		opts.secretKey = secretKey
+	}
+}
+
+// WithRepository is used to configure the owner and repository name used to bootstrap flux
+// against a git hosting provider such as GitHub
+func WithRepository(owner, repository string) Option {
+	return func(opts *Opts) {
+		opts.owner = owner
+		opts.repository = repository
+	}
+}
+
+// WithPersonal is used to indicate that the owner used while bootstrapping flux is a user account
+// and not an organization
+func WithPersonal() Option {
+	return func(opts *Opts) {
+		opts.personal = true
+	}
+}
+
 // WithArgs is used to pass any additional parameter to Flux command
 func WithArgs(args ...string) Option {
 	return func(opts *Opts) {
@@ -124,7 +208,7 @@ func (m *Manager) run(opts *Opts) (err error) {
 	}
 	command := m.getCommand(opts)
 
-	log.V(4).InfoS("Running Flux Operation", "command", command)
+	log.V(4).InfoS("Running Flux Operation", "command", m.getCommand(opts.redacted()))
 	proc := m.e.RunProc(command)
 	result := proc.Result()
 	log.V(4).Info("Flux Command output \n", result)
@@ -135,6 +219,21 @@ func (m *Manager) run(opts *Opts) (err error) {
 	}
 }
 
+// redactedValue replaces the credentials in the logged commands
+const redactedValue = "REDACTED"
+
+// redacted returns a copy of the options whose credentials are replaced, to log the command safely
+func (o *Opts) redacted() *Opts {
+	r := *o
+	if r.password != "" {
+		r.password = redactedValue
+	}
+	if r.secretKey != "" {
+		r.secretKey = redactedValue
+	}
+	return &r
+}
+
 func New(kubeConfig string) *Manager {
 	return &Manager{e: gexe.New(), kubeConfig: kubeConfig}
 }
@@ -172,6 +271,42 @@ func (m *Manager) getCommand(opt *Opts) string {
 	if opt.chart != "" {
 		commandParts = append(commandParts, "--chart", opt.chart)
 	}
+	if opt.secretRef != "" {
+		commandParts = append(commandParts, "--secret-ref", opt.secretRef)
+	}
+	if opt.username != "" {
+		commandParts = append(commandParts, "--username", opt.username)
+	}
+	if opt.password != "" {
+		commandParts = append(commandParts, "--password", opt.password)
+	}
+	if opt.bucketName != "" {
+		commandParts = append(commandParts, "--bucket-name", opt.bucketName)
+	}
+	if opt.endpoint != "" {
+		commandParts = append(commandParts, "--endpoint", opt.endpoint)
+	}
+	if opt.provider != "" {
+		commandParts = append(commandParts, "--provider", opt.provider)
+	}
+	if opt.region != "" {
+		commandParts = append(commandParts, "--region", opt.region)
+	}
+	if opt.accessKey != "" {
+		commandParts = append(commandParts, "--access-key", opt.accessKey)
+	}
+	if opt.secretKey != "" {
+		commandParts = append(commandParts, "--secret-key", opt.secretKey)
+	}
+	if opt.owner != "" {
+		commandParts = append(commandParts, "--owner", opt.owner)
+	}
+	if opt.repository != "" {
+		commandParts = append(commandParts, "--repository", opt.repository)
+	}
+	if opt.personal {
+		commandParts = append(commandParts, "--personal")
+	}
 
 	commandParts = append(commandParts, opt.args...)
 	commandParts = append(commandParts, "--kubeconfig", m.kubeConfig)
@@ -184,6 +319,17 @@ func (m *Manager) installFlux(opts ...Option) error {
 	return m.run(o)
 }
 
+func (m *Manager) bootstrap(provider BootstrapProvider, url string, opts ...Option) error {
+	return m.run(m.bootstrapOpts(provider, url, opts...))
+}
+
+func (m *Manager) bootstrapOpts(provider BootstrapProvider, url string, opts ...Option) *Opts {
+	o := m.processOpts(opts...)
+	o.mode = string("bootstrap " + provider)
+	o.url = url
+	return o
+}
+
 func (m *Manager) uninstallFlux(opts ...Option) error {
 	o := m.processOpts(opts...)
 	o.mode = "uninstall -s"
@@ -198,6 +344,33 @@ func (m *Manager) createSource(sourceType Source, name, url string, opts ...Opti
 	return m.run(o)
 }
 
+func (m *Manager) createBucket(name, bucketName string, opts ...Option) error {
+	return m.run(m.bucketOpts(name, bucketName, opts...))
+}
+
+func (m *Manager) bucketOpts(name, bucketName string, opts ...Option) *Opts {
+	o := m.processOpts(opts...)
+	o.mode = string("create source " + Bucket)
+	o.name = name
+	o.bucketName = bucketName
+	return o
+}
+
+func (m *Manager) createSecret(sourceType Source, name, url string, opts ...Option) error {
+	return m.run(m.secretOpts(sourceType, name, url, opts...))
+}
+
+func (m *Manager) secretOpts(sourceType Source, name, url string, opts ...Option) *Opts {
+	o := m.processOpts(opts...)
+	o.mode = string("create secret " + sourceType)
+	o.name = name
+	// `flux create secret helm` does not accept the --url flag
+	if sourceType != Helm {
+		o.url = url
+	}
+	return o
+}
+
 func (m *Manager) deleteSource(sourceType Source, name string, opts ...Option) error {
 	o := m.processOpts(opts...)
 	o.mode = string("delete source " + sourceType + " -s")
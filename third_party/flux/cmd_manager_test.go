@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flux
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestManager_getCommand(t *testing.T) {
+	m := New("/tmp/kubeconfig")
+	tests := []struct {
+		name string
+		opts *Opts
+		want string
+	}{
+		{
+			name: "bootstrap git",
+			opts: m.bootstrapOpts(BootstrapProviderGit, "ssh://git@gitea:22/e2e/fleet.git", WithBranch("main"), WithPath("clusters/e2e")),
+			want: "flux bootstrap git --url ssh://git@gitea:22/e2e/fleet.git --branch main --path clusters/e2e --kubeconfig /tmp/kubeconfig",
+		},
+		{
+			name: "bootstrap github",
+			opts: m.bootstrapOpts(BootstrapProviderGitHub, "", WithRepository("e2e-org", "fleet"), WithPersonal()),
+			want: "flux bootstrap github --owner e2e-org --repository fleet --personal --kubeconfig /tmp/kubeconfig",
+		},
+		{
+			name: "bucket source",
+			opts: m.bucketOpts("charts", "e2e-charts", WithBucketEndpoint("minio:9000"), WithBucketProvider("generic"), WithBucketRegion("us-east-1"), WithBucketCredentials("access", "secret"), WithInterval("1m")),
+			want: "flux create source bucket charts --interval 1m --bucket-name e2e-charts --endpoint minio:9000 --provider generic --region us-east-1 --access-key access --secret-key secret --kubeconfig /tmp/kubeconfig",
+		},
+		{
+			name: "git secret",
+			opts: m.secretOpts(Git, "git-auth", "https://github.com/e2e/fleet", WithBasicAuth("bot", "token"), WithNamespace("flux-system")),
+			want: "flux create secret git git-auth --url https://github.com/e2e/fleet --namespace flux-system --username bot --password token --kubeconfig /tmp/kubeconfig",
+		},
+		{
+			name: "oci secret",
+			opts: m.secretOpts(Oci, "oci-auth", "oci://ghcr.io/e2e/manifests", WithBasicAuth("bot", "token")),
+			want: "flux create secret oci oci-auth --url oci://ghcr.io/e2e/manifests --username bot --password token --kubeconfig /tmp/kubeconfig",
+		},
+		{
+			name: "helm secret without url",
+			opts: m.secretOpts(Helm, "helm-auth", "https://charts.example.com", WithBasicAuth("bot", "token")),
+			want: "flux create secret helm helm-auth --username bot --password token --kubeconfig /tmp/kubeconfig",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.getCommand(tt.opts); got != tt.want {
+				t.Errorf("getCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpts_redacted(t *testing.T) {
+	m := New("/tmp/kubeconfig")
+	opts := m.processOpts(WithBasicAuth("bot", "token"), WithBucketCredentials("access", "s3cr3t"))
+	command := m.getCommand(opts.redacted())
+	if strings.Contains(command, "token") || strings.Contains(command, "s3cr3t") {
+		t.Errorf("expected the credentials to be redacted, got %q", command)
+	}
+	if !strings.Contains(command, "--password REDACTED") || !strings.Contains(command, "--secret-key REDACTED") {
+		t.Errorf("expected the credential flags to be kept, got %q", command)
+	}
+	if opts.password != "token" || opts.secretKey != "s3cr3t" {
+		t.Error("expected the options used to run the command to be left untouched")
+	}
+}
@@ -167,3 +167,103 @@ func DeleteHelmRepo(name string, opts ...Option) env.Func {
 		return ctx, nil
 	}
 }
+
+// Bootstrap installs flux into the cluster and configures it to sync with the given git repository. Credentials
+// for the repository could be provided with flux.WithBasicAuth(). Use flux.BootstrapGitHub for repositories hosted on GitHub.
+func Bootstrap(url string, opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		manager = New(c.KubeconfigFile())
+		err := manager.bootstrap(BootstrapProviderGit, url, opts...)
+		if err != nil {
+			return ctx, fmt.Errorf("bootstrap of flux failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// BootstrapGitHub installs flux into the cluster and configures it to sync with a GitHub repository. The GITHUB_TOKEN
+// environment variable has to be set. Use flux.WithPersonal() if the owner is a user account.
+func BootstrapGitHub(owner, repository string, opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		manager = New(c.KubeconfigFile())
+		err := manager.bootstrap(BootstrapProviderGitHub, "", append(opts, WithRepository(owner, repository))...)
+		if err != nil {
+			return ctx, fmt.Errorf("bootstrap of flux failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// CreateOCIRepository creates a reference to an OCI artifact repository, it is a source for Kustomization or HelmRelease.
+// Authentication could be configured with flux.WithSecretRef()
+func CreateOCIRepository(name, url string, opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if manager == nil {
+			return ctx, errors.New(NoFluxInstallationFoundMsg)
+		}
+		err := manager.createSource(Oci, name, url, opts...)
+		if err != nil {
+			return ctx, fmt.Errorf("oci repository creation failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// CreateBucket creates a reference to an S3 compatible bucket, it is a source for Kustomization. The endpoint is configured
+// with flux.WithBucketEndpoint() and authentication with flux.WithBucketCredentials() or flux.WithSecretRef()
+func CreateBucket(name, bucketName string, opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if manager == nil {
+			return ctx, errors.New(NoFluxInstallationFoundMsg)
+		}
+		err := manager.createBucket(name, bucketName, opts...)
+		if err != nil {
+			return ctx, fmt.Errorf("bucket creation failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// CreateSecret creates a secret with the authentication credentials of a source. The source type is one of
+// flux.Git, flux.Helm or flux.Oci and the credentials are provided with flux.WithBasicAuth(). The secret could be
+// referenced afterwards with flux.WithSecretRef(). The url is ignored for flux.Helm secrets, which are not bound to a url.
+func CreateSecret(sourceType Source, name, url string, opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if manager == nil {
+			return ctx, errors.New(NoFluxInstallationFoundMsg)
+		}
+		err := manager.createSecret(sourceType, name, url, opts...)
+		if err != nil {
+			return ctx, fmt.Errorf("secret creation failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// DeleteOCIRepository removes a specific OCIRepository object from the cluster
+func DeleteOCIRepository(name string, opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if manager == nil {
+			return ctx, errors.New(NoFluxInstallationFoundMsg)
+		}
+		err := manager.deleteSource(Oci, name, opts...)
+		if err != nil {
+			return ctx, fmt.Errorf("oci repository deletion failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// DeleteBucket removes a specific Bucket object from the cluster
+func DeleteBucket(name string, opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if manager == nil {
+			return ctx, errors.New(NoFluxInstallationFoundMsg)
+		}
+		err := manager.deleteSource(Bucket, name, opts...)
+		if err != nil {
+			return ctx, fmt.Errorf("bucket deletion failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flux
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// DefaultNamespace is the namespace used by flux to create its objects when no
+// namespace is provided via WithNamespace
+const DefaultNamespace = "flux-system"
+
+var (
+	kustomizationGVK = schema.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"}
+	helmReleaseGVK   = schema.GroupVersionKind{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"}
+)
+
+// WaitForKustomizationReady returns an env.Func that blocks until the Kustomization identified by name
+// and namespace reports a Ready status condition for its latest generation. An empty namespace
+// defaults to flux-system. The wait is aborted with an error once the timeout expires.
+func WaitForKustomizationReady(name, namespace string, timeout time.Duration) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := waitForReady(ctx, c, kustomizationGVK, name, namespace, timeout); err != nil {
+			return ctx, fmt.Errorf("kustomization %s did not become ready: %w", name, err)
+		}
+		return ctx, nil
+	}
+}
+
+// WaitForHelmReleaseReady returns an env.Func that blocks until the HelmRelease identified by name
+// and namespace reports a Ready status condition for its latest generation. An empty namespace
+// defaults to flux-system. The wait is aborted with an error once the timeout expires.
+func WaitForHelmReleaseReady(name, namespace string, timeout time.Duration) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := waitForReady(ctx, c, helmReleaseGVK, name, namespace, timeout); err != nil {
+			return ctx, fmt.Errorf("helmrelease %s did not become ready: %w", name, err)
+		}
+		return ctx, nil
+	}
+}
+
+// waitForReady polls the flux object of the given kind until its Ready condition is reported as True
+func waitForReady(ctx context.Context, c *envconf.Config, gvk schema.GroupVersionKind, name, namespace string, timeout time.Duration) error {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	client, err := c.NewClient()
+	if err != nil {
+		return err
+	}
+
+	return wait.For(
+		conditions.New(client.Resources()).ResourceMatch(obj, isReady),
+		wait.WithContext(ctx),
+		wait.WithTimeout(timeout),
+		wait.WithImmediate(),
+	)
+}
+
+// isReady checks if the Ready condition of a flux object is True and has been computed for the
+// current generation of the object
+func isReady(object k8s.Object) bool {
+	obj, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	observedGeneration, found, err := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if err == nil && found && observedGeneration < obj.GetGeneration() {
+		return false
+	}
+	conds, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false
+	}
+	for _, cond := range conds {
+		condition, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == "Ready" {
+			log.V(4).InfoS("Flux object Ready condition", "kind", obj.GetKind(), "name", obj.GetName(), "status", condition["status"], "message", condition["message"])
+			return condition["status"] == "True"
+		}
+	}
+	return false
+}
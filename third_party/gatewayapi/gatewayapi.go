@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gatewayapi provides env funcs to install the Gateway API CRDs into
+// the cluster under test, conditions to wait for Gateways and routes to be
+// accepted by their controller, and helpers to check the traffic served
+// through Gateways and Ingresses.
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/decoder"
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// Channel is a release channel of the Gateway API
+type Channel string
+
+const (
+	// StandardChannel contains the resources and fields that are generally
+	// available or in beta
+	StandardChannel Channel = "standard"
+	// ExperimentalChannel additionally contains the experimental resources
+	// and fields, such as TCPRoute or UDPRoute
+	ExperimentalChannel Channel = "experimental"
+)
+
+const (
+	// DefaultVersion is the Gateway API release installed when no version is
+	// provided via WithVersion
+	DefaultVersion = "v1.2.1"
+	// DefaultTimeout is the time InstallCRDs waits for the CRDs to be
+	// established
+	DefaultTimeout = 2 * time.Minute
+
+	releaseURL = "https://github.com/kubernetes-sigs/gateway-api/releases/download/%s/%s-install.yaml"
+)
+
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+type Opts struct {
+	// Version is the Gateway API release to install
+	Version string
+	// Channel is the release channel to install
+	Channel Channel
+	// ManifestURL overrides the location of the release manifest, for
+	// instance to install from a mirror. It takes precedence over Version
+	// and Channel
+	ManifestURL string
+	// Timeout is the time to wait for the CRDs to be established
+	Timeout time.Duration
+}
+
+type Option func(*Opts)
+
+// WithVersion sets the Gateway API release to install, such as v1.2.1
+func WithVersion(version string) Option {
+	return func(opts *Opts) {
+		opts.Version = version
+	}
+}
+
+// WithChannel sets the release channel to install. Defaults to StandardChannel
+func WithChannel(channel Channel) Option {
+	return func(opts *Opts) {
+		opts.Channel = channel
+	}
+}
+
+// WithManifestURL sets the URL of the manifest used to install the CRDs
+func WithManifestURL(url string) Option {
+	return func(opts *Opts) {
+		opts.ManifestURL = url
+	}
+}
+
+// WithTimeout sets the time to wait for the CRDs to be established
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *Opts) {
+		opts.Timeout = timeout
+	}
+}
+
+func processOpts(opts ...Option) *Opts {
+	o := &Opts{Version: DefaultVersion, Channel: StandardChannel, Timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// manifestURL returns the URL of the release manifest selected by the options
+func (o *Opts) manifestURL() string {
+	if o.ManifestURL != "" {
+		return o.ManifestURL
+	}
+	return fmt.Sprintf(releaseURL, o.Version, o.Channel)
+}
+
+// InstallCRDs returns an env.Func that applies the Gateway API release
+// manifest of the selected channel and version, and waits for its CRDs to be
+// established. CRDs that already exist, for instance because they are
+// bundled with the Gateway controller, are left untouched.
+func InstallCRDs(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		o := processOpts(opts...)
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		r := client.Resources()
+
+		url := o.manifestURL()
+		log.V(4).InfoS("Installing Gateway API CRDs", "manifest", url)
+		var crds []string
+		create := decoder.CreateIgnoreAlreadyExists(r)
+		err = decoder.DecodeURL(ctx, url, func(ctx context.Context, obj k8s.Object) error {
+			if obj.GetObjectKind().GroupVersionKind() == crdGVK {
+				crds = append(crds, obj.GetName())
+			}
+			return create(ctx, obj)
+		})
+		if err != nil {
+			return ctx, fmt.Errorf("installation of Gateway API CRDs failed: %w", err)
+		}
+
+		cond := conditions.New(r)
+		for _, name := range crds {
+			crd := &unstructured.Unstructured{}
+			crd.SetGroupVersionKind(crdGVK)
+			crd.SetName(name)
+			if err := wait.For(cond.ResourceConditionMatch(crd, "Established", "True"), wait.WithContext(ctx), wait.WithTimeout(o.Timeout), wait.WithImmediate()); err != nil {
+				return ctx, fmt.Errorf("CRD %s was not established: %w", name, err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// UninstallCRDs returns an env.Func that deletes the CRDs of the Gateway API
+// release manifest, along with all the Gateway API objects of the cluster.
+// The options must select the same manifest as the one used by InstallCRDs.
+func UninstallCRDs(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		o := processOpts(opts...)
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+
+		url := o.manifestURL()
+		log.V(4).InfoS("Uninstalling Gateway API CRDs", "manifest", url)
+		if err := decoder.DecodeURL(ctx, url, decoder.DeleteIgnoreNotFound(client.Resources())); err != nil {
+			return ctx, fmt.Errorf("uninstallation of Gateway API CRDs failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
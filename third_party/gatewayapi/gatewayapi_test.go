@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+)
+
+func TestManifestURL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default", want: "https://github.com/kubernetes-sigs/gateway-api/releases/download/" + DefaultVersion + "/standard-install.yaml"},
+		{name: "experimental", opts: []Option{WithVersion("v1.1.0"), WithChannel(ExperimentalChannel)}, want: "https://github.com/kubernetes-sigs/gateway-api/releases/download/v1.1.0/experimental-install.yaml"},
+		{name: "manifest", opts: []Option{WithChannel(ExperimentalChannel), WithManifestURL("https://mirror.example.com/gateway-api.yaml")}, want: "https://mirror.example.com/gateway-api.yaml"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := processOpts(tc.opts...).manifestURL(); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsRouteAccepted(t *testing.T) {
+	condition := func(conditionType, status string, observedGeneration int64) interface{} {
+		return map[string]interface{}{"type": conditionType, "status": status, "observedGeneration": observedGeneration}
+	}
+	parent := func(conds ...interface{}) interface{} {
+		return map[string]interface{}{"parentRef": map[string]interface{}{"name": "gateway"}, "conditions": conds}
+	}
+	accepted := parent(condition("Accepted", "True", 2), condition("ResolvedRefs", "True", 2))
+
+	tests := []struct {
+		name    string
+		parents []interface{}
+		want    bool
+	}{
+		{name: "no parents"},
+		{name: "accepted", parents: []interface{}{accepted}, want: true},
+		{name: "not accepted", parents: []interface{}{parent(condition("Accepted", "False", 2), condition("ResolvedRefs", "True", 2))}},
+		{name: "unresolved refs", parents: []interface{}{parent(condition("Accepted", "True", 2), condition("ResolvedRefs", "False", 2))}},
+		{name: "missing condition", parents: []interface{}{parent(condition("Accepted", "True", 2))}},
+		{name: "stale", parents: []interface{}{parent(condition("Accepted", "True", 1), condition("ResolvedRefs", "True", 1))}},
+		{name: "one parent not accepted", parents: []interface{}{accepted, parent()}},
+		{name: "all parents accepted", parents: []interface{}{accepted, accepted}, want: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			route := newObject(HTTPRouteGVK, "web", "apps")
+			route.SetGeneration(2)
+			if tc.parents != nil {
+				if err := unstructured.SetNestedSlice(route.Object, tc.parents, "status", "parents"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := isRouteAccepted(route); got != tc.want {
+				t.Errorf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGatewayAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []interface{}
+		want      string
+	}{
+		{name: "no address"},
+		{name: "ip", addresses: []interface{}{map[string]interface{}{"type": "IPAddress", "value": "172.18.0.10"}}, want: "172.18.0.10"},
+		{name: "first non empty", addresses: []interface{}{map[string]interface{}{"type": "Hostname"}, map[string]interface{}{"type": "Hostname", "value": "gateway.example.com"}}, want: "gateway.example.com"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gateway := newObject(GatewayGVK, "gateway", "apps")
+			if tc.addresses != nil {
+				if err := unstructured.SetNestedSlice(gateway.Object, tc.addresses, "status", "addresses"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := gatewayAddress(gateway); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestServesHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "web.example.com" || r.URL.Path != "/app" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+	host, portStr, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, _ := strconv.Atoi(portStr)
+	address := func(context.Context) (string, error) { return host, nil }
+
+	tests := []struct {
+		name    string
+		host    string
+		path    string
+		opts    []conditions.HTTPOption
+		address func(context.Context) (string, error)
+		want    bool
+	}{
+		{name: "served", host: "web.example.com", path: "/app", address: address, want: true},
+		{name: "path without slash", host: "web.example.com", path: "app", address: address, want: true},
+		{name: "unknown host", host: "other.example.com", path: "/app", address: address},
+		{name: "expected status", host: "other.example.com", path: "/app", opts: []conditions.HTTPOption{conditions.WithExpectedStatus(http.StatusNotFound)}, address: address, want: true},
+		{name: "body", host: "web.example.com", path: "/app", opts: []conditions.HTTPOption{conditions.WithBodyContains("goodbye")}, address: address},
+		{name: "no address", host: "web.example.com", path: "/app", address: func(context.Context) (string, error) { return "", errNoAddress }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			done, err := servesHTTP(port, tc.host, tc.path, tc.opts, tc.address)(context.TODO())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if done != tc.want {
+				t.Errorf("expected done to be %t, got %t", tc.want, done)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+)
+
+// errNoAddress is returned when a Gateway or an Ingress has not been assigned
+// an address yet
+var errNoAddress = errors.New("no address assigned")
+
+// GatewayAddress returns the first address, an IP address or a hostname,
+// assigned to the Gateway identified by name and namespace
+func GatewayAddress(ctx context.Context, r *resources.Resources, name, namespace string) (string, error) {
+	gateway := newObject(GatewayGVK, name, namespace)
+	if err := r.Get(ctx, name, namespace, gateway); err != nil {
+		return "", err
+	}
+	address := gatewayAddress(gateway)
+	if address == "" {
+		return "", fmt.Errorf("gateway %s/%s: %w", namespace, name, errNoAddress)
+	}
+	return address, nil
+}
+
+// IngressAddress returns the first address, an IP address or a hostname,
+// assigned to the Ingress identified by name and namespace
+func IngressAddress(ctx context.Context, r *resources.Resources, name, namespace string) (string, error) {
+	var ingress networkingv1.Ingress
+	if err := r.Get(ctx, name, namespace, &ingress); err != nil {
+		return "", err
+	}
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			return lb.IP, nil
+		}
+		if lb.Hostname != "" {
+			return lb.Hostname, nil
+		}
+	}
+	return "", fmt.Errorf("ingress %s/%s: %w", namespace, name, errNoAddress)
+}
+
+// GatewayServesHTTP returns a condition that is met once a GET request to
+// path, sent to port of the address of the Gateway identified by name and
+// namespace with the given Host, answers with a 200 status unless
+// conditions.WithExpectedStatus is used. An empty host sends the address of
+// the Gateway as Host. The address must be reachable from where the tests
+// run, which for kind clusters usually requires a load balancer
+// implementation such as cloud-provider-kind.
+//
+//	wait.For(gatewayapi.GatewayServesHTTP(r, "gateway", namespace, 80, "web.example.com", "/", conditions.WithBodyContains("hello")))
+func GatewayServesHTTP(r *resources.Resources, name, namespace string, port int, host, path string, opts ...conditions.HTTPOption) apimachinerywait.ConditionWithContextFunc {
+	return servesHTTP(port, host, path, opts, func(ctx context.Context) (string, error) {
+		return GatewayAddress(ctx, r, name, namespace)
+	})
+}
+
+// IngressServesHTTP is the equivalent of GatewayServesHTTP for the Ingress
+// identified by name and namespace
+func IngressServesHTTP(r *resources.Resources, name, namespace string, port int, host, path string, opts ...conditions.HTTPOption) apimachinerywait.ConditionWithContextFunc {
+	return servesHTTP(port, host, path, opts, func(ctx context.Context) (string, error) {
+		return IngressAddress(ctx, r, name, namespace)
+	})
+}
+
+// servesHTTP resolves the address on every poll, as it is assigned
+// asynchronously and can change while the Gateway or Ingress is programmed
+func servesHTTP(port int, host, path string, opts []conditions.HTTPOption, address func(context.Context) (string, error)) apimachinerywait.ConditionWithContextFunc {
+	if host != "" {
+		opts = append([]conditions.HTTPOption{conditions.WithHTTPHost(host)}, opts...)
+	}
+	return func(ctx context.Context) (done bool, err error) {
+		addr, err := address(ctx)
+		if err != nil {
+			log.V(4).InfoS("No address to send traffic to", "error", err)
+			return false, nil
+		}
+		url := fmt.Sprintf("http://%s/%s", net.JoinHostPort(addr, strconv.Itoa(port)), strings.TrimPrefix(path, "/"))
+		return conditions.HTTPGetSucceeds(url, http.StatusOK, opts...)(ctx)
+	}
+}
+
+// gatewayAddress returns the first address reported in the status of the
+// Gateway
+func gatewayAddress(gateway *unstructured.Unstructured) string {
+	addresses, _, err := unstructured.NestedSlice(gateway.Object, "status", "addresses")
+	if err != nil {
+		return ""
+	}
+	for _, item := range addresses {
+		address, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := address["value"].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// Group is the API group of the Gateway API objects
+const Group = "gateway.networking.k8s.io"
+
+var (
+	GatewayClassGVK = schema.GroupVersionKind{Group: Group, Version: "v1", Kind: "GatewayClass"}
+	GatewayGVK      = schema.GroupVersionKind{Group: Group, Version: "v1", Kind: "Gateway"}
+	HTTPRouteGVK    = schema.GroupVersionKind{Group: Group, Version: "v1", Kind: "HTTPRoute"}
+	GRPCRouteGVK    = schema.GroupVersionKind{Group: Group, Version: "v1", Kind: "GRPCRoute"}
+)
+
+// GatewayClassAccepted returns a condition that is met once the controller of
+// the GatewayClass identified by name has accepted it
+func GatewayClassAccepted(r *resources.Resources, name string) apimachinerywait.ConditionWithContextFunc {
+	return conditions.New(r).ResourceConditionMatch(newObject(GatewayClassGVK, name, ""), "Accepted", "True")
+}
+
+// GatewayAccepted returns a condition that is met once the Gateway identified
+// by name and namespace has been accepted by the controller of its class
+func GatewayAccepted(r *resources.Resources, name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return conditions.New(r).ResourceConditionMatch(newObject(GatewayGVK, name, namespace), "Accepted", "True")
+}
+
+// GatewayProgrammed returns a condition that is met once the Gateway
+// identified by name and namespace has been programmed into the data plane
+// and is ready to serve traffic
+func GatewayProgrammed(r *resources.Resources, name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return conditions.New(r).ResourceConditionMatch(newObject(GatewayGVK, name, namespace), "Programmed", "True")
+}
+
+// HTTPRouteAccepted returns a condition that is met once every parent
+// Gateway of the HTTPRoute identified by name and namespace has accepted the
+// route and resolved all of its references
+func HTTPRouteAccepted(r *resources.Resources, name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return RouteAccepted(r, HTTPRouteGVK, name, namespace)
+}
+
+// RouteAccepted is the generic version of HTTPRouteAccepted, for routes of
+// any kind reporting their status per parent, such as GRPCRoute or the
+// experimental TCPRoute
+func RouteAccepted(r *resources.Resources, gvk schema.GroupVersionKind, name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return conditions.New(r).ResourceMatch(newObject(gvk, name, namespace), isRouteAccepted)
+}
+
+// WaitForGatewayProgrammed returns an env.Func that blocks until the Gateway
+// identified by name and namespace is programmed. The wait is aborted with an
+// error once the timeout expires.
+func WaitForGatewayProgrammed(name, namespace string, timeout time.Duration) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := waitFor(ctx, c, timeout, func(r *resources.Resources) apimachinerywait.ConditionWithContextFunc {
+			return GatewayProgrammed(r, name, namespace)
+		}); err != nil {
+			return ctx, fmt.Errorf("gateway %s was not programmed: %w", name, err)
+		}
+		return ctx, nil
+	}
+}
+
+// WaitForHTTPRouteAccepted returns an env.Func that blocks until the
+// HTTPRoute identified by name and namespace is accepted by all of its
+// parents. The wait is aborted with an error once the timeout expires.
+func WaitForHTTPRouteAccepted(name, namespace string, timeout time.Duration) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := waitFor(ctx, c, timeout, func(r *resources.Resources) apimachinerywait.ConditionWithContextFunc {
+			return HTTPRouteAccepted(r, name, namespace)
+		}); err != nil {
+			return ctx, fmt.Errorf("httproute %s was not accepted: %w", name, err)
+		}
+		return ctx, nil
+	}
+}
+
+func waitFor(ctx context.Context, c *envconf.Config, timeout time.Duration, condition func(*resources.Resources) apimachinerywait.ConditionWithContextFunc) error {
+	client, err := c.NewClient()
+	if err != nil {
+		return err
+	}
+	return wait.For(condition(client.Resources()), wait.WithContext(ctx), wait.WithTimeout(timeout), wait.WithImmediate())
+}
+
+func newObject(gvk schema.GroupVersionKind, name, namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return obj
+}
+
+// isRouteAccepted checks if the route reports at least one parent and if the
+// Accepted and ResolvedRefs conditions of every parent are True for the
+// current generation of the route
+func isRouteAccepted(object k8s.Object) bool {
+	obj, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	parents, _, err := unstructured.NestedSlice(obj.Object, "status", "parents")
+	if err != nil || len(parents) == 0 {
+		return false
+	}
+	for _, item := range parents {
+		parent, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		conds, _, err := unstructured.NestedSlice(parent, "conditions")
+		if err != nil {
+			return false
+		}
+		for _, conditionType := range []string{"Accepted", "ResolvedRefs"} {
+			if !hasCondition(conds, conditionType, obj.GetGeneration()) {
+				log.V(4).InfoS("Route not accepted by parent", "kind", obj.GetKind(), "name", obj.GetName(), "parentRef", parent["parentRef"], "condition", conditionType)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasCondition checks if the condition of the given type is True and has
+// not been computed for an older generation
+func hasCondition(conds []interface{}, conditionType string, generation int64) bool {
+	for _, item := range conds {
+		cond, ok := item.(map[string]interface{})
+		if !ok || cond["type"] != conditionType {
+			continue
+		}
+		if observed, found, _ := unstructured.NestedInt64(cond, "observedGeneration"); found && observed < generation {
+			return false
+		}
+		return cond["status"] == "True"
+	}
+	return false
+}
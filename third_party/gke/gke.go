@@ -0,0 +1,353 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gke provides a cluster provider that manages Google Kubernetes Engine clusters using the
+// gcloud CLI. The node lifecycle operations of the provider operate on GKE node pools.
+package gke
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vladimirvivien/gexe"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/klient/conf"
+	"sigs.k8s.io/e2e-framework/pkg/utils"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+type Cluster struct {
+	path        string
+	name        string
+	kubecfgFile string
+	version     string
+	project     string
+	location    string
+	nodes       int
+	args        []string
+	rc          *rest.Config
+}
+
+// nodePool is a subset of the `gcloud container node-pools list --format json` output
+type nodePool struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+var (
+	_ support.E2EClusterProvider              = &Cluster{}
+	_ support.E2EClusterProviderWithLifeCycle = &Cluster{}
+)
+
+func NewCluster(name string) *Cluster {
+	return &Cluster{name: name}
+}
+
+func NewProvider() support.E2EClusterProvider {
+	return &Cluster{}
+}
+
+// WithProject is used to configure the Google Cloud project of the cluster
+func WithProject(project string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		g, ok := c.(*Cluster)
+		if ok {
+			g.project = project
+		}
+	}
+}
+
+// WithLocation is used to configure the zone or region of the cluster
+func WithLocation(location string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		g, ok := c.(*Cluster)
+		if ok {
+			g.location = location
+		}
+	}
+}
+
+// WithNodes is used to configure the number of nodes of the default node pool of the cluster
+func WithNodes(nodes int) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		g, ok := c.(*Cluster)
+		if ok {
+			g.nodes = nodes
+		}
+	}
+}
+
+// WithArgs is used to pass additional arguments to the `gcloud container clusters create` command
+func WithArgs(args ...string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		g, ok := c.(*Cluster)
+		if ok {
+			g.args = append(g.args, args...)
+		}
+	}
+}
+
+func WithPath(path string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		g, ok := c.(*Cluster)
+		if ok {
+			g.path = path
+		}
+	}
+}
+
+func (c *Cluster) WithName(name string) support.E2EClusterProvider {
+	c.name = name
+	return c
+}
+
+// WithVersion is used to configure the kubernetes version of the GKE cluster
+func (c *Cluster) WithVersion(version string) support.E2EClusterProvider {
+	c.version = version
+	return c
+}
+
+func (c *Cluster) WithPath(path string) support.E2EClusterProvider {
+	c.path = path
+	return c
+}
+
+func (c *Cluster) WithOpts(opts ...support.ClusterOpts) support.E2EClusterProvider {
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+func (c *Cluster) SetDefaults() support.E2EClusterProvider {
+	if c.path == "" {
+		c.path = "gcloud"
+	}
+	return c
+}
+
+// run invokes a gcloud command and returns its stdout. The KUBECONFIG environment variable of the
+// gcloud process points to the kubeconfig file of the cluster so that the user's default kubeconfig
+// is left untouched. The environment of the test process is not modified.
+func (c *Cluster) run(command string) (string, error) {
+	if gexe.ProgAvail(c.path) == "" {
+		return "", fmt.Errorf("'%s' command is missing. Please ensure the tool exists before using the gke provider", c.path)
+	}
+	var stdout, stderr bytes.Buffer
+	p := utils.NewProcWithKubeconfig(command, c.kubecfgFile)
+	p.SetStdout(&stdout)
+	p.SetStderr(&stderr)
+	p.Run()
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return "", fmt.Errorf("%s: %s", p.Err(), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// locationArgs returns the arguments identifying the project and location of the cluster.
+// Locations with two dashes, such as us-central1-a, are zones and the others are regions.
+func (c *Cluster) locationArgs() string {
+	var args string
+	if c.location != "" {
+		if strings.Count(c.location, "-") > 1 {
+			args += " --zone " + c.location
+		} else {
+			args += " --region " + c.location
+		}
+	}
+	if c.project != "" {
+		args += " --project " + c.project
+	}
+	return args
+}
+
+func (c *Cluster) clusterExists(name string) bool {
+	_, err := c.run(fmt.Sprintf("%s container clusters describe %s%s --format json", c.path, name, c.locationArgs()))
+	return err == nil
+}
+
+func (c *Cluster) Create(ctx context.Context, args ...string) (string, error) {
+	log.V(4).InfoS("Creating GKE cluster", "name", c.name, "location", c.location)
+	if c.clusterExists(c.name) {
+		log.V(4).InfoS("Skipping GKE cluster creation. Cluster already exists", "name", c.name)
+		return c.getKubeconfig()
+	}
+
+	command := c.createCommand(args...)
+	log.V(4).InfoS("Launching GKE cluster", "command", command)
+	if _, err := c.run(command); err != nil {
+		return "", fmt.Errorf("gke: failed to create cluster %q: %w", c.name, err)
+	}
+	return c.getKubeconfig()
+}
+
+// createCommand returns the gcloud command creating the cluster with the configured version, number
+// of nodes and extra arguments
+func (c *Cluster) createCommand(args ...string) string {
+	if c.version != "" {
+		args = append(args, "--cluster-version", c.version)
+	}
+	if c.nodes > 0 {
+		args = append(args, "--num-nodes", fmt.Sprint(c.nodes))
+	}
+	args = append(args, c.args...)
+
+	command := fmt.Sprintf("%s container clusters create %s%s --quiet", c.path, c.name, c.locationArgs())
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	return command
+}
+
+// CreateWithConfig creates the cluster using the flags file provided, which is passed to gcloud
+// with the --flags-file argument
+func (c *Cluster) CreateWithConfig(ctx context.Context, configFile string) (string, error) {
+	var args []string
+	if configFile != "" {
+		args = append(args, "--flags-file", configFile)
+	}
+	return c.Create(ctx, args...)
+}
+
+func (c *Cluster) getKubeconfig() (string, error) {
+	file, err := os.CreateTemp("", fmt.Sprintf("gke-cluster-%s-kubecfg", c.name))
+	if err != nil {
+		return "", fmt.Errorf("gke kubeconfig file: %w", err)
+	}
+	file.Close()
+	c.kubecfgFile = file.Name()
+
+	if _, err := c.run(fmt.Sprintf("%s container clusters get-credentials %s%s", c.path, c.name, c.locationArgs())); err != nil {
+		return "", fmt.Errorf("gke: failed to get credentials of cluster %q: %w", c.name, err)
+	}
+	cfg, err := conf.New(c.kubecfgFile)
+	if err != nil {
+		return "", err
+	}
+	c.rc = cfg
+	return c.kubecfgFile, nil
+}
+
+func (c *Cluster) GetKubeconfig() string {
+	return c.kubecfgFile
+}
+
+func (c *Cluster) GetKubectlContext() string {
+	cfg, err := clientcmd.LoadFromFile(c.kubecfgFile)
+	if err != nil {
+		return ""
+	}
+	return cfg.CurrentContext
+}
+
+func (c *Cluster) ExportLogs(ctx context.Context, dest string) error {
+	log.Warning("ExportLogs not implemented for gke. Please use regular kubectl like commands or Cloud Logging to extract the logs from the cluster")
+	return nil
+}
+
+func (c *Cluster) Destroy(ctx context.Context) error {
+	log.V(4).InfoS("Destroying GKE cluster", "name", c.name)
+	if _, err := c.run(fmt.Sprintf("%s container clusters delete %s%s --quiet", c.path, c.name, c.locationArgs())); err != nil {
+		return fmt.Errorf("gke: failed to delete cluster %q: %w", c.name, err)
+	}
+
+	log.V(4).InfoS("Removing kubeconfig file", "configFile", c.kubecfgFile)
+	if err := os.RemoveAll(c.kubecfgFile); err != nil {
+		return fmt.Errorf("gke: failed to remove kubeconfig file %q: %w", c.kubecfgFile, err)
+	}
+	return nil
+}
+
+func (c *Cluster) WaitForControlPlane(ctx context.Context, client klient.Client) error {
+	log.V(4).Info("gke provider doesn't implement a WaitForControlPlane as gcloud waits for the control plane")
+	return nil
+}
+
+func (c *Cluster) KubernetesRestConfig() *rest.Config {
+	return c.rc
+}
+
+// AddNode creates a node pool named after the node with a single node
+func (c *Cluster) AddNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodePoolOperation(fmt.Sprintf("container node-pools create %s --cluster %s --num-nodes 1", node.Name, c.name), node, args...)
+}
+
+// RemoveNode deletes the node pool named after the node
+func (c *Cluster) RemoveNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodePoolOperation(fmt.Sprintf("container node-pools delete %s --cluster %s", node.Name, c.name), node, args...)
+}
+
+// StartNode resizes the node pool named after the node to a single node
+func (c *Cluster) StartNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.ScaleNodePool(ctx, node.Name, 1, args...)
+}
+
+// StopNode resizes the node pool named after the node to zero nodes
+func (c *Cluster) StopNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.ScaleNodePool(ctx, node.Name, 0, args...)
+}
+
+// ScaleNodePool resizes the node pool to the given number of nodes
+func (c *Cluster) ScaleNodePool(ctx context.Context, name string, nodes int, args ...string) error {
+	return c.nodePoolOperation(fmt.Sprintf("container clusters resize %s --node-pool %s --num-nodes %d", c.name, name, nodes), &support.Node{Name: name}, args...)
+}
+
+func (c *Cluster) nodePoolOperation(operation string, node *support.Node, args ...string) error {
+	command := fmt.Sprintf("%s %s%s --quiet", c.path, operation, c.locationArgs())
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	log.V(4).InfoS("Performing node pool operation on GKE cluster", "command", command)
+	if _, err := c.run(command); err != nil {
+		return fmt.Errorf("gke: node pool operation on %q in cluster %q failed: %w", node.Name, c.name, err)
+	}
+	return nil
+}
+
+// ListNode returns the node pools of the cluster
+func (c *Cluster) ListNode(ctx context.Context, args ...string) ([]support.Node, error) {
+	out, err := c.run(fmt.Sprintf("%s container node-pools list --cluster %s%s --format json", c.path, c.name, c.locationArgs()))
+	if err != nil {
+		return nil, fmt.Errorf("gke: failed to list node pools: %w", err)
+	}
+	return parseNodePools(c.name, out)
+}
+
+// parseNodePools converts the output of `gcloud container node-pools list --format json` into nodes
+func parseNodePools(cluster, out string) ([]support.Node, error) {
+	var pools []nodePool
+	if err := json.Unmarshal([]byte(out), &pools); err != nil {
+		return nil, fmt.Errorf("gke: failed to unmarshal node pool list: %w", err)
+	}
+	nodes := make([]support.Node, 0, len(pools))
+	for _, p := range pools {
+		nodes = append(nodes, support.Node{
+			Name:    p.Name,
+			Role:    "nodepool",
+			State:   p.Status,
+			Cluster: cluster,
+		})
+	}
+	return nodes, nil
+}
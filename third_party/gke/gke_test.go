@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gke
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: e2e
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: gke_project_us-central1-a_e2e
+  context:
+    cluster: e2e
+    user: e2e
+current-context: gke_project_us-central1-a_e2e
+users:
+- name: e2e
+  user:
+    token: secret
+`
+
+func TestClusterLocationArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		project  string
+		want     string
+	}{
+		{name: "no location"},
+		{name: "region", location: "us-central1", want: " --region us-central1"},
+		{name: "zone", location: "us-central1-a", want: " --zone us-central1-a"},
+		{name: "project", project: "e2e-project", want: " --project e2e-project"},
+		{name: "zone and project", location: "europe-west1-b", project: "e2e-project", want: " --zone europe-west1-b --project e2e-project"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cluster{location: tt.location, project: tt.project}
+			if got := c.locationArgs(); got != tt.want {
+				t.Errorf("locationArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *Cluster
+		args    []string
+		want    string
+	}{
+		{
+			name:    "defaults",
+			cluster: &Cluster{path: "gcloud", name: "e2e"},
+			want:    "gcloud container clusters create e2e --quiet",
+		},
+		{
+			name:    "version, nodes and location",
+			cluster: &Cluster{path: "gcloud", name: "e2e", version: "1.32", nodes: 3, location: "us-central1"},
+			want:    "gcloud container clusters create e2e --region us-central1 --quiet --cluster-version 1.32 --num-nodes 3",
+		},
+		{
+			name:    "call and provider arguments",
+			cluster: &Cluster{path: "gcloud", name: "e2e", args: []string{"--machine-type", "e2-standard-4"}},
+			args:    []string{"--flags-file", "flags.yaml"},
+			want:    "gcloud container clusters create e2e --quiet --flags-file flags.yaml --machine-type e2-standard-4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cluster.createCommand(tt.args...); got != tt.want {
+				t.Errorf("createCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNodePools(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     string
+		want    []support.Node
+		wantErr bool
+	}{
+		{name: "no node pools", out: "[]", want: []support.Node{}},
+		{
+			name: "node pools",
+			out:  `[{"name":"default-pool","status":"RUNNING","config":{"machineType":"e2-medium"}},{"name":"gpu","status":"PROVISIONING"}]`,
+			want: []support.Node{
+				{Name: "default-pool", Role: "nodepool", State: "RUNNING", Cluster: "e2e"},
+				{Name: "gpu", Role: "nodepool", State: "PROVISIONING", Cluster: "e2e"},
+			},
+		},
+		{name: "invalid output", out: "ERROR: not found", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNodePools("e2e", tt.out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNodePools() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNodePools() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterGetKubectlContext(t *testing.T) {
+	kubecfg := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(kubecfg, []byte(testKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		kubecfgFile string
+		want        string
+	}{
+		{name: "kubeconfig", kubecfgFile: kubecfg, want: "gke_project_us-central1-a_e2e"},
+		{name: "missing kubeconfig", kubecfgFile: filepath.Join(t.TempDir(), "missing")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cluster{kubecfgFile: tt.kubecfgFile}
+			if got := c.GetKubectlContext(); got != tt.want {
+				t.Errorf("GetKubectlContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterRunKubeconfig(t *testing.T) {
+	kubecfg := filepath.Join(t.TempDir(), "kubeconfig")
+	t.Setenv("KUBECONFIG", "/home/user/.kube/config")
+	c := &Cluster{path: "env", kubecfgFile: kubecfg}
+	out, err := c.run("env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "KUBECONFIG="+kubecfg) {
+		t.Errorf("expected the command to use the kubeconfig of the cluster, got environment:\n%s", out)
+	}
+	if got := os.Getenv("KUBECONFIG"); got != "/home/user/.kube/config" {
+		t.Errorf("expected the KUBECONFIG of the test process to be left untouched, got %q", got)
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istioctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+const (
+	// InjectionLabel is the namespace label enabling the sidecar injection
+	// of the default control plane revision
+	InjectionLabel = "istio-injection"
+	// RevisionLabel is the namespace label enabling the sidecar injection of
+	// a given control plane revision
+	RevisionLabel = "istio.io/rev"
+)
+
+// InstallIstio returns an env.Func that installs the Istio control plane
+// into the cluster, e.g. with istioctl.WithProfile("demo")
+func InstallIstio(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := New(c.KubeconfigFile()).Install(ctx, opts...); err != nil {
+			return ctx, fmt.Errorf("installation of istio failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// VerifyIstio returns an env.Func that checks that the Istio control plane
+// is installed and ready
+func VerifyIstio(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := New(c.KubeconfigFile()).Verify(ctx, opts...); err != nil {
+			return ctx, fmt.Errorf("verification of istio failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// UninstallIstio returns an env.Func that removes the Istio control plane
+// revision selected by WithRevision, or all of Istio when no revision is
+// provided
+func UninstallIstio(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := New(c.KubeconfigFile()).Uninstall(ctx, opts...); err != nil {
+			return ctx, fmt.Errorf("uninstallation of istio failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// InjectionLabels returns the namespace labels enabling the sidecar
+// injection of the given control plane revision, or of the default revision
+// when revision is empty. The labels can be used when creating a namespace
+// with envfuncs.WithLabels.
+func InjectionLabels(revision string) map[string]string {
+	if revision == "" {
+		return map[string]string{InjectionLabel: "enabled"}
+	}
+	return map[string]string{RevisionLabel: revision}
+}
+
+// EnableSidecarInjection returns an env.Func that labels the namespace so
+// that the pods created in it get an Istio sidecar injected by the control
+// plane revision, or by the default revision when revision is empty. The
+// label selecting another revision is removed as the two labels conflict.
+// Pods that already exist are not changed.
+func EnableSidecarInjection(namespace, revision string) env.Func {
+	labels := map[string]interface{}{InjectionLabel: nil, RevisionLabel: nil}
+	for key, value := range InjectionLabels(revision) {
+		labels[key] = value
+	}
+	return labelNamespace(namespace, labels)
+}
+
+// DisableSidecarInjection returns an env.Func that labels the namespace so
+// that the pods created in it do not get an Istio sidecar injected
+func DisableSidecarInjection(namespace string) env.Func {
+	return labelNamespace(namespace, map[string]interface{}{InjectionLabel: "disabled", RevisionLabel: nil})
+}
+
+// labelNamespace merge patches the labels of the namespace, a nil value
+// removing the label
+func labelNamespace(namespace string, labels map[string]interface{}) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		data, err := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"labels": labels}})
+		if err != nil {
+			return ctx, err
+		}
+		ns := &corev1.Namespace{}
+		ns.SetName(namespace)
+		if err := client.Resources().Patch(ctx, ns, k8s.Patch{PatchType: types.MergePatchType, Data: data}); err != nil {
+			return ctx, fmt.Errorf("labeling namespace %s for sidecar injection failed: %w", namespace, err)
+		}
+		return ctx, nil
+	}
+}
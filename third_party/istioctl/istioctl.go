@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istioctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	log "k8s.io/klog/v2"
+)
+
+type Opts struct {
+	// Profile is the configuration profile installed, such as default,
+	// minimal or demo
+	Profile string
+	// Revision is the control plane revision installed or uninstalled. It
+	// allows several control planes to run side by side, for instance to
+	// test canary upgrades
+	Revision string
+	// IstioNamespace is the namespace of the control plane. Defaults to
+	// istio-system
+	IstioNamespace string
+	// Version is the Istio version the istioctl binary is expected to
+	// have. The control plane installed by istioctl always has the version
+	// of the binary, so the version is pinned by checking the binary before
+	// installing
+	Version string
+	// Sets are the `key=value` overrides passed with `--set`
+	Sets []string
+	// Files are the IstioOperator files passed with `--filename`
+	Files []string
+	// Args is used to pass any additional arguments to the istioctl command
+	Args []string
+	// mode is the istioctl sub command being run
+	mode []string
+}
+
+type Manager struct {
+	kubeConfig string
+	path       string
+}
+
+type Option func(*Opts)
+
+const (
+	missingIstioctl = "'istioctl' command is missing. Please ensure the tool exists before using the istioctl manager"
+)
+
+// WithProfile is used to configure the configuration profile installed
+func WithProfile(profile string) Option {
+	return func(opts *Opts) {
+		opts.Profile = profile
+	}
+}
+
+// WithRevision is used to configure the control plane revision installed,
+// verified or uninstalled
+func WithRevision(revision string) Option {
+	return func(opts *Opts) {
+		opts.Revision = revision
+	}
+}
+
+// WithIstioNamespace is used to configure the namespace of the control plane
+func WithIstioNamespace(namespace string) Option {
+	return func(opts *Opts) {
+		opts.IstioNamespace = namespace
+	}
+}
+
+// WithVersion is used to pin the Istio version installed. Install fails
+// when the istioctl binary does not have this version. A leading v is
+// ignored.
+func WithVersion(version string) Option {
+	return func(opts *Opts) {
+		opts.Version = version
+	}
+}
+
+// WithSet is used to override a value of the installation, such as
+// meshConfig.accessLogFile=/dev/stdout
+func WithSet(key, value string) Option {
+	return func(opts *Opts) {
+		opts.Sets = append(opts.Sets, key+"="+value)
+	}
+}
+
+// WithFile is used to provide an IstioOperator file describing the
+// installation
+func WithFile(path string) Option {
+	return func(opts *Opts) {
+		opts.Files = append(opts.Files, path)
+	}
+}
+
+// WithArgs is used to pass any additional arguments to the istioctl command.
+// Each argument is passed as is without any shell processing.
+func WithArgs(args ...string) Option {
+	return func(opts *Opts) {
+		opts.Args = append(opts.Args, args...)
+	}
+}
+
+// processOpts is used to generate the Opts resource that will be used to
+// generate the actual istioctl command to be run using the getArgs helper
+func (m *Manager) processOpts(opts ...Option) *Opts {
+	option := &Opts{}
+	for _, op := range opts {
+		op(option)
+	}
+	return option
+}
+
+// getArgs is used to convert the Opts into the arguments passed to istioctl
+func (m *Manager) getArgs(opt *Opts) []string {
+	args := append([]string{}, opt.mode...)
+	if opt.Profile != "" {
+		args = append(args, "--set", "profile="+opt.Profile)
+	}
+	if opt.Revision != "" {
+		args = append(args, "--revision", opt.Revision)
+	}
+	for _, set := range opt.Sets {
+		args = append(args, "--set", set)
+	}
+	for _, file := range opt.Files {
+		args = append(args, "--filename", file)
+	}
+	if opt.IstioNamespace != "" {
+		args = append(args, "--istioNamespace", opt.IstioNamespace)
+	}
+	args = append(args, opt.Args...)
+	if m.kubeConfig != "" {
+		args = append(args, "--kubeconfig", m.kubeConfig)
+	}
+	return args
+}
+
+// Install installs the Istio control plane with `istioctl install`. The
+// command waits for the control plane components to be ready. When a version
+// is pinned with WithVersion, the version of the istioctl binary is checked
+// first.
+func (m *Manager) Install(ctx context.Context, opts ...Option) error {
+	o := m.processOpts(opts...)
+	if o.Version != "" {
+		version, err := m.Version(ctx)
+		if err != nil {
+			return err
+		}
+		if strings.TrimPrefix(version, "v") != strings.TrimPrefix(o.Version, "v") {
+			return fmt.Errorf("istioctl version %s does not match the pinned version %s", version, o.Version)
+		}
+	}
+	o.mode = []string{"install", "--skip-confirmation"}
+	_, err := m.run(ctx, o)
+	return err
+}
+
+// Verify checks that the control plane selected by the options is installed
+// and that its components are ready with `istioctl verify-install`
+func (m *Manager) Verify(ctx context.Context, opts ...Option) error {
+	o := m.processOpts(opts...)
+	o.mode = []string{"verify-install"}
+	o.Profile, o.Sets = "", nil
+	_, err := m.run(ctx, o)
+	return err
+}
+
+// Uninstall removes the control plane revision selected by WithRevision or,
+// when no revision is provided, all the Istio resources of the cluster with
+// `istioctl uninstall`
+func (m *Manager) Uninstall(ctx context.Context, opts ...Option) error {
+	o := m.processOpts(opts...)
+	o.mode = []string{"uninstall", "--skip-confirmation"}
+	if o.Revision == "" {
+		o.mode = append(o.mode, "--purge")
+	}
+	o.Profile, o.Sets, o.Files = "", nil, nil
+	_, err := m.run(ctx, o)
+	return err
+}
+
+// Version returns the version of the istioctl binary
+func (m *Manager) Version(ctx context.Context) (string, error) {
+	o := m.processOpts(WithArgs("--remote=false", "--output", "json"))
+	o.mode = []string{"version"}
+	stdout, err := m.run(ctx, o)
+	if err != nil {
+		return "", err
+	}
+	return parseVersion([]byte(stdout))
+}
+
+// Run invokes istioctl with the arguments configured with WithArgs and
+// returns its standard output
+func (m *Manager) Run(ctx context.Context, opts ...Option) (string, error) {
+	return m.run(ctx, m.processOpts(opts...))
+}
+
+// parseVersion extracts the client version from the output of
+// `istioctl version --output json`
+func parseVersion(output []byte) (string, error) {
+	var info struct {
+		ClientVersion struct {
+			Version string `json:"version"`
+		} `json:"clientVersion"`
+	}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return "", fmt.Errorf("failed to decode istioctl version: %w", err)
+	}
+	if info.ClientVersion.Version == "" {
+		return "", errors.New("istioctl did not report its version")
+	}
+	return info.ClientVersion.Version, nil
+}
+
+// run method is used to invoke an istioctl command to perform a suitable
+// operation. Please make sure to configure the right Opts using the Option
+// helpers
+func (m *Manager) run(ctx context.Context, opts *Opts) (string, error) {
+	log.V(4).InfoS("Determining if istioctl binary is available or not", "executable", m.path)
+	executable, err := exec.LookPath(m.path)
+	if err != nil {
+		return "", errors.New(missingIstioctl)
+	}
+	if len(opts.mode) == 0 && len(opts.Args) == 0 {
+		return "", errors.New("missing istioctl sub command. Please use the WithArgs option while invoking the run")
+	}
+	args := m.getArgs(opts)
+
+	log.V(4).InfoS("Running Istioctl Operation", "command", m.path+" "+strings.Join(args, " "))
+	cmd := exec.CommandContext(ctx, executable, args...)
+
+	var stderr bytes.Buffer
+	var stdout bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdout = &stdout
+
+	err = cmd.Run()
+	log.V(4).Info("Istioctl Command output \n", stdout.String())
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSuffix(stderr.String(), "\n"), err)
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+// WithPath is used to provide a custom path where the `istioctl` executable
+// command can be found. This is useful in case if your binary is in a non
+// standard location and you want to framework to use that instead of
+// returning an error.
+func (m *Manager) WithPath(path string) *Manager {
+	m.path = path
+	return m
+}
+
+// New creates an istioctl Manager that runs all commands against the cluster
+// identified by the kubeConfig file.
+func New(kubeConfig string) *Manager {
+	return &Manager{
+		kubeConfig: kubeConfig,
+		path:       "istioctl",
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istioctl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestManager_getArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		kubeConfig string
+		mode       []string
+		opts       []Option
+		want       []string
+	}{
+		{
+			name: "args only",
+			opts: []Option{WithArgs("proxy-status")},
+			want: []string{"proxy-status"},
+		},
+		{
+			name:       "install profile and overrides",
+			kubeConfig: "/tmp/kubeconfig",
+			mode:       []string{"install", "--skip-confirmation"},
+			opts:       []Option{WithProfile("demo"), WithSet("meshConfig.accessLogFile", "/dev/stdout"), WithFile("operator.yaml")},
+			want:       []string{"install", "--skip-confirmation", "--set", "profile=demo", "--set", "meshConfig.accessLogFile=/dev/stdout", "--filename", "operator.yaml", "--kubeconfig", "/tmp/kubeconfig"},
+		},
+		{
+			name: "revision and namespace",
+			mode: []string{"verify-install"},
+			opts: []Option{WithRevision("canary"), WithIstioNamespace("istio-canary")},
+			want: []string{"verify-install", "--revision", "canary", "--istioNamespace", "istio-canary"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(tt.kubeConfig)
+			o := m.processOpts(tt.opts...)
+			o.mode = tt.mode
+			if got := m.getArgs(o); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{name: "client version", output: `{"clientVersion":{"version":"1.24.1","revision":"abc","status":"Clean"}}`, want: "1.24.1"},
+		{name: "missing version", output: `{"meshVersion":[]}`, wantErr: true},
+		{name: "not json", output: "client version: 1.24.1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVersion([]byte(tt.output))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_InstallPinnedVersion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a shell script")
+	}
+	// the fake istioctl reports its version and records the install arguments
+	dir := t.TempDir()
+	record := filepath.Join(dir, "args")
+	script := "#!/bin/sh\nif [ \"$1\" = version ]; then echo '{\"clientVersion\":{\"version\":\"1.24.1\"}}'; exit 0; fi\necho \"$@\" > " + record + "\n"
+	path := filepath.Join(dir, "istioctl")
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	m := New("").WithPath(path)
+
+	if err := m.Install(context.TODO(), WithVersion("1.23.0")); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("expected a version mismatch error, got %v", err)
+	}
+	if _, err := os.Stat(record); !os.IsNotExist(err) {
+		t.Fatal("expected istioctl install not to run on a version mismatch")
+	}
+	if err := m.Install(context.TODO(), WithVersion("v1.24.1"), WithProfile("minimal")); err != nil {
+		t.Fatal(err)
+	}
+	args, err := os.ReadFile(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(args)); got != "install --skip-confirmation --set profile=minimal" {
+		t.Errorf("unexpected install arguments %q", got)
+	}
+}
+
+func TestManager_RunMissingBinary(t *testing.T) {
+	m := New("").WithPath("istioctl-does-not-exist")
+	if _, err := m.Run(context.TODO(), WithArgs("version")); err == nil || err.Error() != missingIstioctl {
+		t.Errorf("expected missing istioctl error, got %v", err)
+	}
+}
+
+func TestInjectionLabels(t *testing.T) {
+	if got := InjectionLabels(""); !reflect.DeepEqual(got, map[string]string{InjectionLabel: "enabled"}) {
+		t.Errorf("unexpected default revision labels %v", got)
+	}
+	if got := InjectionLabels("canary"); !reflect.DeepEqual(got, map[string]string{RevisionLabel: "canary"}) {
+		t.Errorf("unexpected canary revision labels %v", got)
+	}
+}
@@ -23,8 +23,12 @@ import (
 	"io"
 	"net"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/json"
 
 	"k8s.io/client-go/rest"
@@ -39,23 +43,31 @@ import (
 
 var k3dVersion = "v5.7.2"
 
+// k3dClusterLabel is the runtime label k3d sets on the node containers with the name of their cluster
+const k3dClusterLabel = "k3d.cluster"
+
 type Cluster struct {
 	path           string
 	name           string
 	kubeConfigFile string
 	version        string
 	image          string
+	agents         int
+	timeout        time.Duration
 	rc             *rest.Config
 	args           []string
+	// createArgs keeps the arguments the cluster was created with, to recreate it on Upgrade
+	createArgs []string
 }
 
 // k3dNode is a struct containing a subset of values that are part of the k3d node list -o json
 // data. This currently contains only those fields that are of interest to generate the
 // support.Node struct in return for performing node operations.
 type k3dNode struct {
-	Name string `json:"name"`
-	Role string `json:"role"`
-	IP   struct {
+	Name          string            `json:"name"`
+	Role          string            `json:"role"`
+	RuntimeLabels map[string]string `json:"runtimeLabels"`
+	IP            struct {
 		IP string `json:"IP"`
 	} `json:"IP"`
 	State struct {
@@ -67,6 +79,9 @@ type k3dNode struct {
 var (
 	_ support.E2EClusterProviderWithImageLoader = &Cluster{}
 	_ support.E2EClusterProviderWithLifeCycle   = &Cluster{}
+	_ support.E2EClusterProviderWithPause       = &Cluster{}
+	_ support.E2EClusterProviderWithSnapshot    = &Cluster{}
+	_ support.E2EClusterProviderWithOptions     = &Cluster{}
 )
 
 func WithArgs(args ...string) support.ClusterOpts {
@@ -203,10 +218,19 @@ func (c *Cluster) Create(ctx context.Context, args ...string) (string, error) {
 		return kConfig, c.initKubernetesAccessClients()
 	}
 
+	c.createArgs = append([]string(nil), args...)
 	if c.image != "" {
 		args = append(args, "--image", c.image)
 	}
 
+	if c.agents > 0 {
+		args = append(args, "--agents", strconv.Itoa(c.agents))
+	}
+
+	if c.timeout > 0 {
+		args = append(args, "--timeout", c.timeout.String())
+	}
+
 	args = append(args, c.args...)
 	cmd := fmt.Sprintf("%s cluster create %s", c.path, c.name)
 
@@ -376,8 +400,28 @@ func (c *Cluster) StopNode(ctx context.Context, node *support.Node, args ...stri
 	return nil
 }
 
+// ListNode returns the nodes of the k3d cluster. The args are passed to the `k3d node list` command.
+// Use ListNodeBySelector to only return the nodes matching labels.
 func (c *Cluster) ListNode(ctx context.Context, args ...string) ([]support.Node, error) {
+	return c.listNodes(labels.Everything(), args...)
+}
+
+// ListNodeBySelector returns the nodes of the k3d cluster matching the label selector, e.g. "k3d.role=server",
+// which is matched against the runtime labels k3d sets on the node containers. The args are passed to the
+// `k3d node list` command.
+func (c *Cluster) ListNodeBySelector(ctx context.Context, selector string, args ...string) ([]support.Node, error) {
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("k3d: invalid node selector %q: %w", selector, err)
+	}
+	return c.listNodes(s, args...)
+}
+
+func (c *Cluster) listNodes(selector labels.Selector, args ...string) ([]support.Node, error) {
 	cmd := fmt.Sprintf("%s node list -o json", c.path)
+	if len(args) > 0 {
+		cmd = fmt.Sprintf("%s %s", cmd, strings.Join(args, " "))
+	}
 	p := utils.RunCommand(cmd)
 	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
 		return nil, fmt.Errorf("k3d: failed to list nodes: %s: %s", p.Err(), p.Result())
@@ -386,8 +430,19 @@ func (c *Cluster) ListNode(ctx context.Context, args ...string) ([]support.Node,
 	if err := json.Unmarshal([]byte(p.Result()), &nodeInfo); err != nil {
 		return nil, fmt.Errorf("k3d: failed to unmarshal node list: %s", err)
 	}
-	nodes := make([]support.Node, len(nodeInfo))
+	return c.filterNodes(nodeInfo, selector), nil
+}
+
+// filterNodes converts the k3d nodes belonging to the cluster and matching the selector into support.Node
+func (c *Cluster) filterNodes(nodeInfo []k3dNode, selector labels.Selector) []support.Node {
+	nodes := make([]support.Node, 0, len(nodeInfo))
 	for _, n := range nodeInfo {
+		if n.RuntimeLabels[k3dClusterLabel] != c.name {
+			continue
+		}
+		if !selector.Matches(labels.Set(n.RuntimeLabels)) {
+			continue
+		}
 		nodes = append(nodes, support.Node{
 			Name:    n.Name,
 			Role:    n.Role,
@@ -396,5 +451,130 @@ func (c *Cluster) ListNode(ctx context.Context, args ...string) ([]support.Node,
 			Cluster: c.name,
 		})
 	}
-	return nodes, nil
+	return nodes
+}
+
+// Pause freezes all the docker containers of the k3d cluster, including its load balancer
+func (c *Cluster) Pause(ctx context.Context) error {
+	return c.containersOperation("pause")
+}
+
+// Resume unfreezes all the docker containers of the k3d cluster frozen by Pause
+func (c *Cluster) Resume(ctx context.Context) error {
+	return c.containersOperation("unpause")
+}
+
+func (c *Cluster) containersOperation(operation string) error {
+	p, stdout, stderr := utils.FetchSeperatedCommandOutput(c.listContainersCommand())
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return fmt.Errorf("k3d: failed to list containers of cluster %q: %s: %s", c.name, p.Err(), stderr.String())
+	}
+	containers := strings.Fields(stdout.String())
+	if len(containers) == 0 {
+		return fmt.Errorf("k3d: no containers found for cluster %q", c.name)
+	}
+	cmd := containersCommand(operation, containers)
+	log.V(4).InfoS("Performing operation on k3d cluster containers", "command", cmd)
+	p = utils.RunCommand(cmd)
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return fmt.Errorf("k3d: failed to %s cluster %q: %s: %s", operation, c.name, p.Err(), p.Result())
+	}
+	return nil
+}
+
+// listContainersCommand returns the docker command listing the names of the containers of the cluster
+func (c *Cluster) listContainersCommand() string {
+	return fmt.Sprintf("docker ps --all --filter label=%s=%s --format {{.Names}}", k3dClusterLabel, c.name)
+}
+
+// containersCommand returns the docker command performing the operation on the containers
+func containersCommand(operation string, containers []string) string {
+	return fmt.Sprintf("docker %s %s", operation, strings.Join(containers, " "))
+}
+
+// k3sDataDir is the directory of the k3s server containing the datastore of the cluster
+const k3sDataDir = "/var/lib/rancher/k3s/server/db"
+
+// Snapshot copies the datastore directory of the first server node of the k3d cluster into the path, which
+// is a directory created if needed. A snapshot previously saved into the path is replaced. The cluster is
+// stopped while the copy is taken to keep the datastore consistent. Clusters with several server nodes are
+// not supported, as only the datastore of the first server node is saved.
+func (c *Cluster) Snapshot(ctx context.Context, path string) error {
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return fmt.Errorf("k3d: failed to create snapshot directory %q: %w", path, err)
+	}
+	// docker cp merges into an existing directory, the files of a previous snapshot would be kept
+	snapshot := snapshotDir(path)
+	if err := os.RemoveAll(snapshot); err != nil {
+		return fmt.Errorf("k3d: failed to remove previous snapshot %q: %w", snapshot, err)
+	}
+	return c.withStoppedCluster(func(server string) error {
+		return c.runSnapshotCommand(saveDataDirCommand(server, path))
+	})
+}
+
+// Restore replaces the datastore directory of the first server node of the k3d cluster with the one saved
+// into the path directory by Snapshot, and restarts the cluster.
+func (c *Cluster) Restore(ctx context.Context, path string) error {
+	snapshot := snapshotDir(path)
+	if _, err := os.Stat(snapshot); err != nil {
+		return fmt.Errorf("k3d: snapshot not found in %q: %w", path, err)
+	}
+	return c.withStoppedCluster(func(server string) error {
+		// docker cp merges into the existing datastore, the files created after the snapshot would be kept
+		if err := c.removeDataDir(server); err != nil {
+			return err
+		}
+		return c.runSnapshotCommand(restoreDataDirCommand(server, snapshot))
+	})
+}
+
+// snapshotDir returns the directory holding the datastore saved into the path by Snapshot
+func snapshotDir(path string) string {
+	return filepath.Join(path, filepath.Base(k3sDataDir))
+}
+
+// saveDataDirCommand returns the docker command copying the datastore of the server node into the path
+func saveDataDirCommand(server, path string) string {
+	return fmt.Sprintf("docker cp %s:%s %s", server, k3sDataDir, path)
+}
+
+// restoreDataDirCommand returns the docker command copying the saved datastore back into the server node
+func restoreDataDirCommand(server, snapshot string) string {
+	return fmt.Sprintf("docker cp %s %s:%s", snapshot, server, filepath.Dir(k3sDataDir))
+}
+
+// removeDataDir deletes the datastore directory of the stopped server node. As docker exec requires a running
+// container, the directory is deleted by a container of the server image sharing the volumes of the server node.
+func (c *Cluster) removeDataDir(server string) error {
+	p, stdout, stderr := utils.FetchSeperatedCommandOutput(fmt.Sprintf("docker inspect --format {{.Config.Image}} %s", server))
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return fmt.Errorf("k3d: failed to inspect server node %q: %s: %s", server, p.Err(), stderr.String())
+	}
+	image := strings.TrimSpace(stdout.String())
+	return c.runSnapshotCommand(fmt.Sprintf("docker run --rm --volumes-from %s --entrypoint rm %s -rf %s", server, image, k3sDataDir))
+}
+
+// withStoppedCluster stops the cluster, invokes fn with the name of the first server node container and
+// starts the cluster back whatever the outcome of fn
+func (c *Cluster) withStoppedCluster(fn func(server string) error) (err error) {
+	server := fmt.Sprintf("k3d-%s-server-0", c.name)
+	if err := c.runSnapshotCommand(fmt.Sprintf("%s cluster stop %s", c.path, c.name)); err != nil {
+		return err
+	}
+	defer func() {
+		if startErr := c.startCluster(c.name); startErr != nil && err == nil {
+			err = startErr
+		}
+	}()
+	return fn(server)
+}
+
+func (c *Cluster) runSnapshotCommand(cmd string) error {
+	log.V(4).InfoS("Performing k3d snapshot operation", "command", cmd)
+	p := utils.RunCommand(cmd)
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return fmt.Errorf("k3d: snapshot operation on cluster %q failed: %s: %s", c.name, p.Err(), p.Result())
+	}
+	return nil
 }
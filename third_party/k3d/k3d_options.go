@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k3d
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+// SupportedOptions returns the typed provider options understood by the k3d provider
+func (c *Cluster) SupportedOptions() []support.ProviderOptionKey {
+	return []support.ProviderOptionKey{
+		support.ProviderOptionImage,
+		support.ProviderOptionWorkers,
+		support.ProviderOptionWait,
+	}
+}
+
+// SetOption applies the typed provider option identified by key to the k3d cluster. Workers
+// are mapped to k3d agents and wait to the timeout of the cluster create command.
+func (c *Cluster) SetOption(key support.ProviderOptionKey, value string) error {
+	switch key {
+	case support.ProviderOptionImage:
+		c.image = value
+	case support.ProviderOptionWorkers:
+		agents, err := strconv.Atoi(value)
+		if err != nil || agents < 0 {
+			return fmt.Errorf("workers must be a non-negative integer")
+		}
+		c.agents = agents
+	case support.ProviderOptionWait:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		c.timeout = d
+	default:
+		return fmt.Errorf("unsupported option %q", key)
+	}
+	return nil
+}
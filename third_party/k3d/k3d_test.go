@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k3d
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestCluster_filterNodes(t *testing.T) {
+	nodeInfo := []k3dNode{
+		{Name: "k3d-one-server-0", Role: "server", RuntimeLabels: map[string]string{k3dClusterLabel: "one", "k3d.role": "server"}},
+		{Name: "k3d-one-agent-0", Role: "agent", RuntimeLabels: map[string]string{k3dClusterLabel: "one", "k3d.role": "agent"}},
+		{Name: "k3d-two-server-0", Role: "server", RuntimeLabels: map[string]string{k3dClusterLabel: "two", "k3d.role": "server"}},
+	}
+	tests := []struct {
+		name     string
+		selector string
+		want     []string
+	}{
+		{name: "all nodes of the cluster", selector: "", want: []string{"k3d-one-server-0", "k3d-one-agent-0"}},
+		{name: "nodes matching the selector", selector: "k3d.role=agent", want: []string{"k3d-one-agent-0"}},
+		{name: "no matching nodes", selector: "k3d.role=loadbalancer", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := labels.Parse(tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := NewCluster("one")
+			nodes := c.filterNodes(nodeInfo, selector)
+			if len(nodes) != len(tt.want) {
+				t.Fatalf("expected %d nodes, got %d: %v", len(tt.want), len(nodes), nodes)
+			}
+			for i, node := range nodes {
+				if node.Name != tt.want[i] || node.Cluster != "one" {
+					t.Errorf("unexpected node at index %d: %+v", i, node)
+				}
+			}
+		})
+	}
+}
+
+func TestCluster_ListNodeBySelectorInvalid(t *testing.T) {
+	// the selector is validated before k3d is invoked
+	_, err := NewCluster("one").ListNodeBySelector(context.TODO(), "k3d.role in (server")
+	if err == nil || !strings.Contains(err.Error(), "invalid node selector") {
+		t.Errorf("expected an error for an invalid selector, got %v", err)
+	}
+}
+
+func TestK3sImageFor(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "v1.31.0+k3s1", want: "rancher/k3s:v1.31.0-k3s1"},
+		{version: "1.30.4", want: "rancher/k3s:v1.30.4-k3s1"},
+		{version: "v1.29.8-k3s2", want: "rancher/k3s:v1.29.8-k3s2"},
+		{version: "registry.local/k3s:v1.31.0-k3s1", want: "registry.local/k3s:v1.31.0-k3s1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := k3sImageFor(tt.version); got != tt.want {
+				t.Errorf("expected image %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCluster_containersCommands(t *testing.T) {
+	c := NewCluster("one")
+	if got, want := c.listContainersCommand(), "docker ps --all --filter label=k3d.cluster=one --format {{.Names}}"; got != want {
+		t.Errorf("listContainersCommand() = %v, want %v", got, want)
+	}
+	containers := []string{"k3d-one-server-0", "k3d-one-agent-0", "k3d-one-serverlb"}
+	tests := []struct {
+		operation string
+		want      string
+	}{
+		{operation: "pause", want: "docker pause k3d-one-server-0 k3d-one-agent-0 k3d-one-serverlb"},
+		{operation: "unpause", want: "docker unpause k3d-one-server-0 k3d-one-agent-0 k3d-one-serverlb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			if got := containersCommand(tt.operation, containers); got != tt.want {
+				t.Errorf("containersCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotCommands(t *testing.T) {
+	server := "k3d-one-server-0"
+	if got, want := snapshotDir("/tmp/snapshots/one"), "/tmp/snapshots/one/db"; got != want {
+		t.Errorf("snapshotDir() = %v, want %v", got, want)
+	}
+	if got, want := saveDataDirCommand(server, "/tmp/snapshots/one"), "docker cp k3d-one-server-0:/var/lib/rancher/k3s/server/db /tmp/snapshots/one"; got != want {
+		t.Errorf("saveDataDirCommand() = %v, want %v", got, want)
+	}
+	if got, want := restoreDataDirCommand(server, "/tmp/snapshots/one/db"), "docker cp /tmp/snapshots/one/db k3d-one-server-0:/var/lib/rancher/k3s/server"; got != want {
+		t.Errorf("restoreDataDirCommand() = %v, want %v", got, want)
+	}
+}
+
+func TestCluster_RestoreMissingSnapshot(t *testing.T) {
+	// the snapshot is checked before the cluster is stopped
+	err := NewCluster("one").Restore(context.TODO(), t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "snapshot not found") {
+		t.Errorf("expected an error for a missing snapshot, got %v", err)
+	}
+}
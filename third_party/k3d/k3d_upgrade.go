@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k3d
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+var _ support.E2EClusterProviderWithUpgrade = &Cluster{}
+
+// k3sImage is the repository of the k3s images used for the k3d nodes
+const k3sImage = "rancher/k3s"
+
+// Upgrade moves the k3d cluster to the given Kubernetes version. The k3d node containers cannot change their
+// image in place, so the cluster is deleted and created again, with the same arguments, from the k3s image of
+// the version. The state of the cluster is lost. version is either a k3s version, e.g. v1.31.0+k3s1, or a full
+// image reference.
+func (c *Cluster) Upgrade(ctx context.Context, version string) (string, error) {
+	if version == "" {
+		return "", fmt.Errorf("k3d: upgrade of cluster %q: no version provided", c.name)
+	}
+	image := k3sImageFor(version)
+	log.V(4).InfoS("Upgrading k3d cluster by recreating it", "name", c.name, "image", image)
+	if err := c.Destroy(ctx); err != nil {
+		return "", fmt.Errorf("k3d: upgrade of cluster %q: %w", c.name, err)
+	}
+	c.image = image
+	kubecfg, err := c.Create(ctx, c.createArgs...)
+	if err != nil {
+		return "", fmt.Errorf("k3d: upgrade of cluster %q to %s: %w", c.name, version, err)
+	}
+	return kubecfg, nil
+}
+
+// k3sImageFor returns the k3s image of a version, or version itself when it is already an image. The + of
+// the k3s versions is not valid in image tags and is replaced by a -, as done for the published images.
+func k3sImageFor(version string) string {
+	if strings.ContainsAny(version, ":/@") {
+		return version
+	}
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !strings.Contains(version, "k3s") {
+		version += "-k3s1"
+	}
+	return fmt.Sprintf("%s:%s", k3sImage, strings.ReplaceAll(version, "+", "-"))
+}
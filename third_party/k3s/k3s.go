@@ -0,0 +1,364 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package k3s provides a cluster provider that runs a single k3s server inside a
+// docker container, without requiring the k3d tooling to be installed.
+package k3s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/klient/conf"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+	"sigs.k8s.io/e2e-framework/pkg/utils"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+const (
+	k3sImage          = "rancher/k3s"
+	k3sKubeconfigPath = "/etc/rancher/k3s/k3s.yaml"
+	k3sConfigPath     = "/etc/rancher/k3s/config.yaml"
+	k3sAPIServerPort  = "6443/tcp"
+)
+
+var k3sVersion = "v1.31.4-k3s1"
+
+type Cluster struct {
+	path        string
+	name        string
+	kubecfgFile string
+	version     string
+	image       string
+	configFile  string
+	args        []string
+	rc          *rest.Config
+}
+
+var _ support.E2EClusterProviderWithImageLoader = &Cluster{}
+
+func NewCluster(name string) *Cluster {
+	return &Cluster{name: name}
+}
+
+func NewProvider() support.E2EClusterProvider {
+	return &Cluster{}
+}
+
+// WithImage is used to configure the full k3s container image reference. It takes
+// precedence over the version configured with WithVersion.
+func WithImage(image string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		k, ok := c.(*Cluster)
+		if ok {
+			k.image = image
+		}
+	}
+}
+
+// WithArgs is used to pass additional arguments to the `k3s server` command
+func WithArgs(args ...string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		k, ok := c.(*Cluster)
+		if ok {
+			k.args = append(k.args, args...)
+		}
+	}
+}
+
+// WithPath is used to configure the path of the docker executable used to run the k3s server
+func WithPath(path string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		k, ok := c.(*Cluster)
+		if ok {
+			k.path = path
+		}
+	}
+}
+
+func (c *Cluster) WithName(name string) support.E2EClusterProvider {
+	c.name = name
+	return c
+}
+
+// WithVersion is used to configure the k3s version, which is used as the tag of the k3s image
+func (c *Cluster) WithVersion(version string) support.E2EClusterProvider {
+	c.version = version
+	return c
+}
+
+func (c *Cluster) WithPath(path string) support.E2EClusterProvider {
+	c.path = path
+	return c
+}
+
+func (c *Cluster) WithOpts(opts ...support.ClusterOpts) support.E2EClusterProvider {
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+func (c *Cluster) SetDefaults() support.E2EClusterProvider {
+	if c.path == "" {
+		c.path = "docker"
+	}
+	return c
+}
+
+func (c *Cluster) getImage() string {
+	if c.image != "" {
+		return c.image
+	}
+	if c.version != "" {
+		return fmt.Sprintf("%s:%s", k3sImage, c.version)
+	}
+	return fmt.Sprintf("%s:%s", k3sImage, k3sVersion)
+}
+
+// run invokes a docker command and returns its stdout
+func (c *Cluster) run(command string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	p := utils.RunCommandWithCustomWriter(command, &stdout, &stderr)
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return "", fmt.Errorf("%s: %s", p.Err(), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (c *Cluster) clusterExists(name string) bool {
+	out, err := c.run(fmt.Sprintf("%s ps --all --filter name=^%s$ --format {{.Names}}", c.path, name))
+	return err == nil && out == name
+}
+
+func (c *Cluster) Create(ctx context.Context, args ...string) (string, error) {
+	log.V(4).InfoS("Creating k3s cluster", "name", c.name)
+	if utils.FetchCommandOutput(fmt.Sprintf("%s version --format {{.Server.Version}}", c.path)) == "" {
+		return "", fmt.Errorf("k3s: '%s' command is missing or not functional. Please ensure docker is available before using the k3s provider", c.path)
+	}
+
+	if c.clusterExists(c.name) {
+		log.V(4).InfoS("Skipping k3s cluster creation. Cluster already exists", "name", c.name)
+		if _, err := c.run(fmt.Sprintf("%s start %s", c.path, c.name)); err != nil {
+			return "", fmt.Errorf("k3s: failed to start cluster %q: %w", c.name, err)
+		}
+	} else {
+		command := c.createCommand(args)
+		log.V(4).InfoS("Launching k3s cluster", "command", command)
+		if _, err := c.run(command); err != nil {
+			return "", fmt.Errorf("k3s: failed to create cluster %q: %w", c.name, err)
+		}
+	}
+
+	kConfig, err := c.getKubeconfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	return kConfig, c.initKubernetesAccessClients()
+}
+
+// createCommand returns the docker command running the k3s server container, with the args passed
+// to Create followed by the ones configured with WithArgs
+func (c *Cluster) createCommand(args []string) string {
+	args = append(args, c.args...)
+	command := fmt.Sprintf("%s run --detach --privileged --name %s --hostname %s --publish 127.0.0.1::6443", c.path, c.name, c.name)
+	if c.configFile != "" {
+		command = fmt.Sprintf("%s --volume %s:%s:ro", command, c.configFile, k3sConfigPath)
+	}
+	command = fmt.Sprintf("%s %s server --tls-san 127.0.0.1", command, c.getImage())
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	return command
+}
+
+// rewriteServer points the kubeconfig written by the k3s server to the address published on the host
+func rewriteServer(kubeconfig, hostPort string) string {
+	return strings.ReplaceAll(kubeconfig, "https://127.0.0.1:6443", "https://"+hostPort)
+}
+
+// getKubeconfig waits for the k3s server to write its kubeconfig, rewrites the server address
+// to the port published on the host and stores it into a temporary file
+func (c *Cluster) getKubeconfig(ctx context.Context) (string, error) {
+	hostPort, err := c.run(fmt.Sprintf("%s port %s %s", c.path, c.name, k3sAPIServerPort))
+	if err != nil {
+		return "", fmt.Errorf("k3s: failed to get api server port of cluster %q: %w", c.name, err)
+	}
+	// docker port can report one address per IP family
+	hostPort = strings.Split(hostPort, "\n")[0]
+
+	var kubeconfig string
+	err = wait.For(func(ctx context.Context) (bool, error) {
+		out, err := c.run(fmt.Sprintf("%s exec %s cat %s", c.path, c.name, k3sKubeconfigPath))
+		if err != nil {
+			log.V(4).InfoS("Waiting for k3s kubeconfig", "cluster", c.name, "error", err)
+			return false, nil
+		}
+		kubeconfig = out
+		return true, nil
+	}, wait.WithContext(ctx), wait.WithTimeout(2*time.Minute), wait.WithInterval(time.Second))
+	if err != nil {
+		return "", fmt.Errorf("k3s: kubeconfig of cluster %q not available: %w", c.name, err)
+	}
+	kubeconfig = rewriteServer(kubeconfig, hostPort)
+
+	file, err := os.CreateTemp("", fmt.Sprintf("k3s-cluster-%s-kubecfg", c.name))
+	if err != nil {
+		return "", fmt.Errorf("k3s kubeconfig file: %w", err)
+	}
+	defer file.Close()
+
+	c.kubecfgFile = file.Name()
+
+	if n, err := io.WriteString(file, kubeconfig); n == 0 || err != nil {
+		return "", fmt.Errorf("k3s kubeconfig file: bytes copied: %d: %w", n, err)
+	}
+	return file.Name(), nil
+}
+
+func (c *Cluster) initKubernetesAccessClients() error {
+	cfg, err := conf.New(c.kubecfgFile)
+	if err != nil {
+		return err
+	}
+	c.rc = cfg
+	return nil
+}
+
+// CreateWithConfig creates the cluster using the k3s config file provided, which is mounted
+// into the container as /etc/rancher/k3s/config.yaml
+func (c *Cluster) CreateWithConfig(ctx context.Context, configFile string) (string, error) {
+	if configFile == "" {
+		return c.Create(ctx)
+	}
+	absPath, err := filepath.Abs(configFile)
+	if err != nil {
+		return "", fmt.Errorf("k3s: failed to resolve config file %q: %w", configFile, err)
+	}
+	c.configFile = absPath
+	return c.Create(ctx)
+}
+
+func (c *Cluster) GetKubeconfig() string {
+	return c.kubecfgFile
+}
+
+func (c *Cluster) GetKubectlContext() string {
+	return "default"
+}
+
+// Capabilities declares the capabilities of the k3s provider that are not inferred by support.CapabilitiesOf
+func (c *Cluster) Capabilities() support.Capabilities {
+	return support.Capabilities{support.CapabilityLogsExport: true}
+}
+
+// ExportLogs writes the logs of the k3s server container into a k3s.log file in the dest directory
+func (c *Cluster) ExportLogs(ctx context.Context, dest string) error {
+	log.V(4).InfoS("Exporting k3s cluster logs", "name", c.name, "dest", dest)
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return fmt.Errorf("k3s: failed to create logs directory %q: %w", dest, err)
+	}
+	var stdout, stderr bytes.Buffer
+	if err := utils.RunCommandWithSeperatedOutput(fmt.Sprintf("%s logs %s", c.path, c.name), &stdout, &stderr); err != nil {
+		return fmt.Errorf("k3s: export cluster %v logs failed: %w", c.name, err)
+	}
+	// k3s logs to stderr
+	if err := os.WriteFile(filepath.Join(dest, "k3s.log"), append(stdout.Bytes(), stderr.Bytes()...), 0o644); err != nil {
+		return fmt.Errorf("k3s: failed to write cluster %v logs: %w", c.name, err)
+	}
+	return nil
+}
+
+func (c *Cluster) Destroy(ctx context.Context) error {
+	log.V(4).InfoS("Destroying k3s cluster", "name", c.name)
+	if !c.clusterExists(c.name) {
+		log.V(4).InfoS("Skipping k3s cluster destruction. Cluster does not exist", "name", c.name)
+		return nil
+	}
+	if _, err := c.run(fmt.Sprintf("%s rm --force --volumes %s", c.path, c.name)); err != nil {
+		return fmt.Errorf("k3s: failed to delete cluster %q: %w", c.name, err)
+	}
+
+	log.V(4).InfoS("Removing kubeconfig file", "configFile", c.kubecfgFile)
+	if err := os.RemoveAll(c.kubecfgFile); err != nil {
+		return fmt.Errorf("k3s: failed to remove kubeconfig file %q: %w", c.kubecfgFile, err)
+	}
+	return nil
+}
+
+// WaitForControlPlane waits for the k3s server node to be registered with the api server
+func (c *Cluster) WaitForControlPlane(ctx context.Context, client klient.Client) error {
+	r, err := resources.New(client.RESTConfig())
+	if err != nil {
+		return err
+	}
+	return wait.For(conditions.New(r).ResourceListN(&v1.NodeList{}, 1), wait.WithContext(ctx))
+}
+
+func (c *Cluster) KubernetesRestConfig() *rest.Config {
+	return c.rc
+}
+
+// LoadImage saves the image from the local docker daemon and imports it into the k3s
+// containerd image store using `k3s ctr images import`
+func (c *Cluster) LoadImage(ctx context.Context, image string, args ...string) error {
+	log.V(4).InfoS("Performing Image load operation", "cluster", c.name, "image", image, "args", args)
+	archive, err := os.CreateTemp("", "k3s-image-*.tar")
+	if err != nil {
+		return fmt.Errorf("k3s: failed to create image archive: %w", err)
+	}
+	archive.Close()
+	defer os.Remove(archive.Name())
+
+	if _, err := c.run(fmt.Sprintf("%s save --output %s %s", c.path, archive.Name(), image)); err != nil {
+		return fmt.Errorf("k3s: save image %v failed: %w", image, err)
+	}
+	return c.LoadImageArchive(ctx, archive.Name(), args...)
+}
+
+// LoadImageArchive copies the image archive into the k3s container and imports it into the
+// k3s containerd image store using `k3s ctr images import`
+func (c *Cluster) LoadImageArchive(ctx context.Context, imageArchive string, args ...string) error {
+	log.V(4).InfoS("Performing Image archive load operation", "cluster", c.name, "archive", imageArchive, "args", args)
+	target := "/tmp/" + filepath.Base(imageArchive)
+	if _, err := c.run(fmt.Sprintf("%s cp %s %s:%s", c.path, imageArchive, c.name, target)); err != nil {
+		return fmt.Errorf("k3s: copy image archive %v failed: %w", imageArchive, err)
+	}
+	command := fmt.Sprintf("%s exec %s k3s ctr images import", c.path, c.name)
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	if _, err := c.run(fmt.Sprintf("%s %s", command, target)); err != nil {
+		return fmt.Errorf("k3s: import image archive %v failed: %w", imageArchive, err)
+	}
+	if _, err := c.run(fmt.Sprintf("%s exec %s rm -f %s", c.path, c.name, target)); err != nil {
+		log.V(4).InfoS("Failed to remove image archive from k3s container", "archive", target, "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k3s
+
+import (
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+func TestCluster_getImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *Cluster
+		want    string
+	}{
+		{
+			name:    "default version",
+			cluster: NewCluster("test"),
+			want:    "rancher/k3s:" + k3sVersion,
+		},
+		{
+			name:    "version",
+			cluster: NewCluster("test").WithVersion("v1.30.8-k3s1").(*Cluster),
+			want:    "rancher/k3s:v1.30.8-k3s1",
+		},
+		{
+			name:    "image takes precedence over the version",
+			cluster: NewCluster("test").WithVersion("v1.30.8-k3s1").WithOpts(WithImage("registry.local/k3s:dev")).(*Cluster),
+			want:    "registry.local/k3s:dev",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cluster.getImage(); got != tt.want {
+				t.Errorf("getImage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteServer(t *testing.T) {
+	kubeconfig := "clusters:\n- cluster:\n    server: https://127.0.0.1:6443\n  name: default\n"
+	want := "clusters:\n- cluster:\n    server: https://127.0.0.1:55001\n  name: default\n"
+	if got := rewriteServer(kubeconfig, "127.0.0.1:55001"); got != want {
+		t.Errorf("rewriteServer() = %v, want %v", got, want)
+	}
+}
+
+func TestCluster_createCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []support.ClusterOpts
+		configFile string
+		args       []string
+		want       string
+	}{
+		{
+			name: "defaults",
+			want: "docker run --detach --privileged --name test --hostname test --publish 127.0.0.1::6443 rancher/k3s:" + k3sVersion + " server --tls-san 127.0.0.1",
+		},
+		{
+			name: "path, image and args",
+			opts: []support.ClusterOpts{WithPath("podman"), WithImage("rancher/k3s:v1.30.8-k3s1"), WithArgs("--disable", "traefik")},
+			args: []string{"--debug"},
+			want: "podman run --detach --privileged --name test --hostname test --publish 127.0.0.1::6443 rancher/k3s:v1.30.8-k3s1 server --tls-san 127.0.0.1 --debug --disable traefik",
+		},
+		{
+			name:       "config file",
+			configFile: "/work/k3s.yaml",
+			want:       "docker run --detach --privileged --name test --hostname test --publish 127.0.0.1::6443 --volume /work/k3s.yaml:/etc/rancher/k3s/config.yaml:ro rancher/k3s:" + k3sVersion + " server --tls-san 127.0.0.1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCluster("test").WithOpts(tt.opts...).SetDefaults().(*Cluster)
+			c.configFile = tt.configFile
+			if got := c.createCommand(tt.args); got != tt.want {
+				t.Errorf("createCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
@@ -41,16 +42,29 @@ import (
 var kindVersion = "v0.26.0"
 
 type Cluster struct {
-	path        string
-	name        string
-	kubecfgFile string
-	version     string
-	image       string
-	rc          *rest.Config
+	path          string
+	name          string
+	kubecfgFile   string
+	version       string
+	image         string
+	workers       int
+	controlPlanes int
+	registryPort  int
+	registryName  string
+	waitDuration  time.Duration
+	rc            *rest.Config
+	// createArgs keeps the arguments the cluster was created with, to recreate it on Upgrade
+	createArgs []string
+	// partitionedIPs keeps the IP address of the nodes disconnected by PartitionNode
+	partitionedIPs map[string]string
 }
 
 // Enforce Type check always to avoid future breaks
-var _ support.E2EClusterProvider = &Cluster{}
+var (
+	_ support.E2EClusterProviderWithImageLoader = &Cluster{}
+	_ support.E2EClusterProviderWithLifeCycle   = &Cluster{}
+	_ support.E2EClusterProviderWithOptions     = &Cluster{}
+)
 
 func NewCluster(name string) *Cluster {
 	return &Cluster{name: name}
@@ -78,6 +92,28 @@ func WithPath(path string) support.ClusterOpts {
 	}
 }
 
+// WithWorkers is used to configure the number of worker nodes of the cluster. The kind
+// config file for the topology is generated when the cluster is created without one.
+func WithWorkers(workers int) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		k, ok := c.(*Cluster)
+		if ok {
+			k.workers = workers
+		}
+	}
+}
+
+// WithControlPlanes is used to configure the number of control plane nodes of the cluster. The
+// kind config file for the topology is generated when the cluster is created without one.
+func WithControlPlanes(controlPlanes int) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		k, ok := c.(*Cluster)
+		if ok {
+			k.controlPlanes = controlPlanes
+		}
+	}
+}
+
 func (k *Cluster) SetDefaults() support.E2EClusterProvider {
 	if k.path == "" {
 		k.path = "kind"
@@ -165,10 +201,37 @@ func (k *Cluster) Create(ctx context.Context, args ...string) (string, error) {
 		return kConfig, k.initKubernetesAccessClients()
 	}
 
+	k.createArgs = append([]string(nil), args...)
 	if k.image != "" {
 		args = append(args, "--image", k.image)
 	}
 
+	if k.waitDuration > 0 {
+		args = append(args, "--wait", k.waitDuration.String())
+	}
+
+	if k.needsGeneratedConfig() {
+		switch {
+		case hasConfigArg(args) && k.registryPort != 0:
+			// the nodes can't use the local registry without the containerd patch
+			configFile, err := writeRegistryConfig(k.name, configArg(args))
+			if err != nil {
+				return "", err
+			}
+			defer os.Remove(configFile)
+			args = replaceConfigArg(args, configFile)
+		case hasConfigArg(args):
+			log.V(4).Info("Skipping kind config generation: a config file was provided for cluster ", k.name)
+		default:
+			configFile, err := k.writeGeneratedConfig()
+			if err != nil {
+				return "", err
+			}
+			defer os.Remove(configFile)
+			args = append(args, "--config", configFile)
+		}
+	}
+
 	command := fmt.Sprintf(`%s create cluster --name %s`, k.path, k.name)
 	if len(args) > 0 {
 		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
@@ -192,7 +255,15 @@ func (k *Cluster) Create(ctx context.Context, args ...string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return kConfig, k.initKubernetesAccessClients()
+	if err := k.initKubernetesAccessClients(); err != nil {
+		return "", err
+	}
+	if k.registryPort != 0 {
+		if _, err := k.SetupLocalRegistry(ctx, k.registryPort); err != nil {
+			return "", err
+		}
+	}
+	return kConfig, nil
 }
 
 func (k *Cluster) initKubernetesAccessClients() error {
@@ -212,6 +283,11 @@ func (k *Cluster) GetKubectlContext() string {
 	return fmt.Sprintf("kind-%s", k.name)
 }
 
+// Capabilities declares the capabilities of the kind provider that are not inferred by support.CapabilitiesOf
+func (k *Cluster) Capabilities() support.Capabilities {
+	return support.Capabilities{support.CapabilityLogsExport: true}
+}
+
 // ExportLogs export all cluster logs to the provided path.
 func (k *Cluster) ExportLogs(ctx context.Context, dest string) error {
 	log.V(4).Info("Exporting kind cluster logs to ", dest)
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// needsGeneratedConfig reports if the options configured on the cluster require a kind
+// config file to be generated
+func (k *Cluster) needsGeneratedConfig() bool {
+	return k.workers > 0 || k.controlPlanes > 1 || k.registryPort != 0
+}
+
+// hasConfigArg reports if a kind config file is part of the create arguments
+func hasConfigArg(args []string) bool {
+	for _, arg := range args {
+		if arg == "--config" || strings.HasPrefix(arg, "--config=") {
+			return true
+		}
+	}
+	return false
+}
+
+// configArg returns the kind config file of the create arguments
+func configArg(args []string) string {
+	for i, arg := range args {
+		if arg == "--config" && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(arg, "--config=") {
+			return strings.TrimPrefix(arg, "--config=")
+		}
+	}
+	return ""
+}
+
+// replaceConfigArg returns a copy of the create arguments using the kind config file provided
+func replaceConfigArg(args []string, file string) []string {
+	args = append([]string(nil), args...)
+	for i, arg := range args {
+		if arg == "--config" && i+1 < len(args) {
+			args[i+1] = file
+			break
+		}
+		if strings.HasPrefix(arg, "--config=") {
+			args[i] = "--config=" + file
+			break
+		}
+	}
+	return args
+}
+
+// mergeRegistryConfig adds the containerd patch required by the local registry to a kind config,
+// unless one of its patches already configures the registry config_path
+func mergeRegistryConfig(data []byte) ([]byte, error) {
+	var cfg map[string]interface{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("kind config file: %w", err)
+	}
+	if cfg == nil {
+		cfg = map[string]interface{}{}
+	}
+	patches, ok := cfg["containerdConfigPatches"].([]interface{})
+	if !ok && cfg["containerdConfigPatches"] != nil {
+		return nil, fmt.Errorf("kind config file: containerdConfigPatches is not a list")
+	}
+	for _, patch := range patches {
+		if s, ok := patch.(string); ok && strings.Contains(s, "config_path") {
+			return data, nil
+		}
+	}
+	cfg["containerdConfigPatches"] = append(patches, containerdRegistryConfig)
+	return yaml.Marshal(cfg)
+}
+
+// writeRegistryConfig writes the kind config file merged with the containerd patch required by the
+// local registry into a temporary file and returns its path
+func writeRegistryConfig(name, configFile string) (string, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return "", fmt.Errorf("kind config file: %w", err)
+	}
+	merged, err := mergeRegistryConfig(data)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp("", fmt.Sprintf("kind-cluster-%s-config-*.yaml", name))
+	if err != nil {
+		return "", fmt.Errorf("kind config file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(merged); err != nil {
+		return "", fmt.Errorf("kind config file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// generateConfig renders the kind config matching the options configured on the cluster
+func (k *Cluster) generateConfig() string {
+	controlPlanes := k.controlPlanes
+	if controlPlanes < 1 {
+		controlPlanes = 1
+	}
+
+	var sb strings.Builder
+	sb.WriteString("kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\n")
+	if k.registryPort != 0 {
+		sb.WriteString(containerdRegistryConfigPatch)
+	}
+	sb.WriteString("nodes:\n")
+	for i := 0; i < controlPlanes; i++ {
+		sb.WriteString("- role: control-plane\n")
+	}
+	for i := 0; i < k.workers; i++ {
+		sb.WriteString("- role: worker\n")
+	}
+	return sb.String()
+}
+
+// writeGeneratedConfig writes the generated kind config into a temporary file and returns its path
+func (k *Cluster) writeGeneratedConfig() (string, error) {
+	file, err := os.CreateTemp("", fmt.Sprintf("kind-cluster-%s-config-*.yaml", k.name))
+	if err != nil {
+		return "", fmt.Errorf("kind config file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(k.generateConfig()); err != nil {
+		return "", fmt.Errorf("kind config file: %w", err)
+	}
+	return file.Name(), nil
+}
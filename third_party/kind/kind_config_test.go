@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+func TestCluster_generateConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		workers       int
+		controlPlanes int
+		want          string
+	}{
+		{
+			name:    "workers only",
+			workers: 2,
+			want:    "kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\nnodes:\n- role: control-plane\n- role: worker\n- role: worker\n",
+		},
+		{
+			name:          "highly available control plane",
+			controlPlanes: 3,
+			workers:       1,
+			want:          "kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\nnodes:\n- role: control-plane\n- role: control-plane\n- role: control-plane\n- role: worker\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := NewCluster("test")
+			k.WithOpts(WithWorkers(tt.workers), WithControlPlanes(tt.controlPlanes))
+			if !k.needsGeneratedConfig() {
+				t.Fatal("expected a generated config to be needed")
+			}
+			if got := k.generateConfig(); got != tt.want {
+				t.Errorf("generateConfig() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCluster_generateConfigWithLocalRegistry(t *testing.T) {
+	k := NewCluster("test")
+	k.WithOpts(WithLocalRegistry(5001))
+	if !k.needsGeneratedConfig() {
+		t.Fatal("expected a generated config to be needed")
+	}
+	want := "kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\n" + containerdRegistryConfigPatch + "nodes:\n- role: control-plane\n"
+	if got := k.generateConfig(); got != want {
+		t.Errorf("generateConfig() = %q, want %q", got, want)
+	}
+	if got := k.LocalRegistryEndpoint(); got != "localhost:5001" {
+		t.Errorf("LocalRegistryEndpoint() = %q, want %q", got, "localhost:5001")
+	}
+}
+
+func TestCluster_LocalRegistryName(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []support.ClusterOpts
+		want string
+	}{
+		{name: "no registry"},
+		{name: "named after the port", opts: []support.ClusterOpts{WithLocalRegistry(5001)}, want: "kind-registry-5001"},
+		{name: "configured name", opts: []support.ClusterOpts{WithLocalRegistry(5001), WithLocalRegistryName("shared-registry")}, want: "shared-registry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := NewCluster("test")
+			k.WithOpts(tt.opts...)
+			if got := k.LocalRegistryName(); got != tt.want {
+				t.Errorf("LocalRegistryName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigArg(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+		file string
+	}{
+		{
+			name: "separate value",
+			args: []string{"--config", "kind.yaml", "--image", "kindest/node"},
+			want: []string{"--config", "merged.yaml", "--image", "kindest/node"},
+			file: "kind.yaml",
+		},
+		{
+			name: "inline value",
+			args: []string{"--config=kind.yaml"},
+			want: []string{"--config=merged.yaml"},
+			file: "kind.yaml",
+		},
+		{
+			name: "no config",
+			args: []string{"--image", "kindest/node"},
+			want: []string{"--image", "kindest/node"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := configArg(tt.args); got != tt.file {
+				t.Errorf("configArg() = %q, want %q", got, tt.file)
+			}
+			if got := replaceConfigArg(tt.args, "merged.yaml"); strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("replaceConfigArg() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeRegistryConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		wantPatches []string
+		wantErr     bool
+	}{
+		{
+			name:        "config without patches",
+			config:      "kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\nnodes:\n- role: control-plane\n",
+			wantPatches: []string{containerdRegistryConfig},
+		},
+		{
+			name:        "config with other patches",
+			config:      "kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\ncontainerdConfigPatches:\n- |-\n  [plugins.\"io.containerd.grpc.v1.cri\".containerd]\n    snapshotter = \"native\"\n",
+			wantPatches: []string{"[plugins.\"io.containerd.grpc.v1.cri\".containerd]\n  snapshotter = \"native\"", containerdRegistryConfig},
+		},
+		{
+			name:        "config with the registry patch",
+			config:      "kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\n" + containerdRegistryConfigPatch,
+			wantPatches: []string{containerdRegistryConfig},
+		},
+		{
+			name:    "invalid patches",
+			config:  "kind: Cluster\ncontainerdConfigPatches: native\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := mergeRegistryConfig([]byte(tt.config))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mergeRegistryConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var cfg struct {
+				Kind                    string   `json:"kind"`
+				ContainerdConfigPatches []string `json:"containerdConfigPatches"`
+			}
+			if err := yaml.Unmarshal(merged, &cfg); err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Kind != "Cluster" {
+				t.Errorf("expected the merged config to keep its kind, got %q", cfg.Kind)
+			}
+			if strings.Join(cfg.ContainerdConfigPatches, "|") != strings.Join(tt.wantPatches, "|") {
+				t.Errorf("mergeRegistryConfig() patches = %q, want %q", cfg.ContainerdConfigPatches, tt.wantPatches)
+			}
+		})
+	}
+}
+
+func TestHasConfigArg(t *testing.T) {
+	if !hasConfigArg([]string{"--image", "kindest/node", "--config", "kind.yaml"}) {
+		t.Error("expected --config to be detected")
+	}
+	if !hasConfigArg([]string{"--config=kind.yaml"}) {
+		t.Error("expected --config= to be detected")
+	}
+	if hasConfigArg([]string{"--image", "kindest/node"}) {
+		t.Error("expected no config to be detected")
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/pkg/utils"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+var (
+	_ support.E2EClusterProviderWithPause            = &Cluster{}
+	_ support.E2EClusterProviderWithNetworkPartition = &Cluster{}
+)
+
+const (
+	kindClusterLabel = "io.x-k8s.kind.cluster"
+	kindRoleLabel    = "io.x-k8s.kind.role"
+	kindNetwork      = "kind"
+)
+
+// kindNodeContainer is a subset of the `ps --format json` output of the container runtime for a kind node container
+type kindNodeContainer struct {
+	Names  string `json:"Names"`
+	State  string `json:"State"`
+	Labels string `json:"Labels"`
+}
+
+// role extracts the kind node role from the comma separated container labels
+func (n kindNodeContainer) role() string {
+	for _, label := range strings.Split(n.Labels, ",") {
+		if value, found := strings.CutPrefix(label, kindRoleLabel+"="); found {
+			return value
+		}
+	}
+	return ""
+}
+
+// defaultRuntime is the container runtime running the nodes of kind clusters when none is selected
+const defaultRuntime = "docker"
+
+// containerRuntime returns the CLI of the container runtime running the nodes of kind clusters. Like the kind
+// CLI, it is selected with the KIND_EXPERIMENTAL_PROVIDER environment variable, such as podman or nerdctl.
+func containerRuntime() string {
+	if runtime := os.Getenv("KIND_EXPERIMENTAL_PROVIDER"); runtime != "" {
+		return runtime
+	}
+	return defaultRuntime
+}
+
+// runRuntime invokes a command of the container runtime and returns its stdout
+func runRuntime(command string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	p := utils.RunCommandWithCustomWriter(command, &stdout, &stderr)
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return "", fmt.Errorf("%s: %s", p.Err(), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// ListNode returns the containers backing the nodes of the kind cluster
+func (k *Cluster) ListNode(ctx context.Context, args ...string) ([]support.Node, error) {
+	out, err := runRuntime(fmt.Sprintf("%s ps --all --filter label=%s=%s --format json", containerRuntime(), kindClusterLabel, k.name))
+	if err != nil {
+		return nil, fmt.Errorf("kind: failed to list nodes of cluster %q: %w", k.name, err)
+	}
+	var nodes []support.Node
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		var container kindNodeContainer
+		if err := json.Unmarshal([]byte(line), &container); err != nil {
+			return nil, fmt.Errorf("kind: failed to unmarshal node list: %w", err)
+		}
+		ip, _ := runRuntime(fmt.Sprintf("%s inspect --format {{range.NetworkSettings.Networks}}{{.IPAddress}}{{end}} %s", containerRuntime(), container.Names))
+		nodes = append(nodes, support.Node{
+			Name:    container.Names,
+			Role:    container.role(),
+			State:   container.State,
+			IP:      net.ParseIP(ip),
+			Cluster: k.name,
+		})
+	}
+	return nodes, nil
+}
+
+// StartNode starts the stopped container backing the kind node
+func (k *Cluster) StartNode(ctx context.Context, node *support.Node, args ...string) error {
+	return k.nodeOperation("start", node, args...)
+}
+
+// StopNode stops the container backing the kind node
+func (k *Cluster) StopNode(ctx context.Context, node *support.Node, args ...string) error {
+	return k.nodeOperation("stop", node, args...)
+}
+
+// RemoveNode deletes the Node object from the cluster and removes the container backing the kind node
+func (k *Cluster) RemoveNode(ctx context.Context, node *support.Node, args ...string) error {
+	if k.rc != nil {
+		r, err := resources.New(k.rc)
+		if err != nil {
+			return err
+		}
+		if err := r.Delete(ctx, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: node.Name}}); err != nil {
+			log.V(4).InfoS("Failed to delete kind node object", "node", node.Name, "error", err)
+		}
+	}
+	return k.nodeOperation("rm --force --volumes", node, args...)
+}
+
+func (k *Cluster) nodeOperation(operation string, node *support.Node, args ...string) error {
+	command := nodeCommand(operation, node, args...)
+	log.V(4).InfoS("Performing node operation on kind cluster", "command", command)
+	if _, err := runRuntime(command); err != nil {
+		return fmt.Errorf("kind: failed to %s node %q of cluster %q: %w", operation, node.Name, k.name, err)
+	}
+	return nil
+}
+
+// nodeCommand returns the container runtime command performing the operation on the container backing the node
+func nodeCommand(operation string, node *support.Node, args ...string) string {
+	command := fmt.Sprintf("%s %s", containerRuntime(), operation)
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	return fmt.Sprintf("%s %s", command, node.Name)
+}
+
+// AddNode adds a new worker node to the kind cluster. kind does not support adding nodes to an
+// existing cluster, so a new container is started from the node image of the control plane and
+// joined to the cluster with `kubeadm join`. Only the worker role is supported.
+func (k *Cluster) AddNode(ctx context.Context, node *support.Node, args ...string) error {
+	if node.Role != "" && node.Role != "worker" {
+		return fmt.Errorf("kind: adding nodes with role %q is not supported, only worker nodes can be added", node.Role)
+	}
+	controlPlane := fmt.Sprintf("%s-control-plane", k.name)
+	image, err := runRuntime(fmt.Sprintf("%s inspect --format {{.Config.Image}} %s", containerRuntime(), controlPlane))
+	if err != nil {
+		return fmt.Errorf("kind: failed to find node image of cluster %q: %w", k.name, err)
+	}
+
+	command := fmt.Sprintf("%s run --detach --name %s --hostname %s --network %s --privileged "+
+		"--security-opt seccomp=unconfined --security-opt apparmor=unconfined --tmpfs /tmp --tmpfs /run "+
+		"--volume /var --volume /lib/modules:/lib/modules:ro --restart on-failure:1 "+
+		"--label %s=%s --label %s=worker",
+		containerRuntime(), node.Name, node.Name, kindNetwork, kindClusterLabel, k.name, kindRoleLabel)
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	command = fmt.Sprintf("%s %s", command, image)
+	log.V(4).InfoS("Adding node to kind cluster", "command", command)
+	if _, err := runRuntime(command); err != nil {
+		return fmt.Errorf("kind: failed to start node %q of cluster %q: %w", node.Name, k.name, err)
+	}
+
+	joinCommand, err := runRuntime(fmt.Sprintf("%s exec %s kubeadm token create --print-join-command", containerRuntime(), controlPlane))
+	if err != nil {
+		return fmt.Errorf("kind: failed to create join token for cluster %q: %w", k.name, err)
+	}
+
+	// the node container needs to finish booting systemd before it is able to join the cluster
+	return wait.For(func(ctx context.Context) (bool, error) {
+		out, err := runRuntime(fmt.Sprintf("%s exec %s %s --ignore-preflight-errors=all", containerRuntime(), node.Name, joinCommand))
+		if err != nil {
+			log.V(4).InfoS("Waiting for kind node to join the cluster", "node", node.Name, "error", err, "output", out)
+			return false, nil
+		}
+		return true, nil
+	}, wait.WithContext(ctx), wait.WithTimeout(3*time.Minute), wait.WithInterval(5*time.Second))
+}
+
+// Pause freezes the containers backing all the nodes of the kind cluster
+func (k *Cluster) Pause(ctx context.Context) error {
+	return k.allNodesOperation(ctx, "pause")
+}
+
+// Resume unfreezes the containers backing all the nodes of the kind cluster
+func (k *Cluster) Resume(ctx context.Context) error {
+	return k.allNodesOperation(ctx, "unpause")
+}
+
+// PartitionNode disconnects the container backing the kind node from the kind network. The node keeps
+// running but can no longer reach, or be reached by, the other nodes of the cluster.
+func (k *Cluster) PartitionNode(ctx context.Context, node *support.Node) error {
+	ip, err := runRuntime(fmt.Sprintf("%s inspect --format {{.NetworkSettings.Networks.%s.IPAddress}} %s", containerRuntime(), kindNetwork, node.Name))
+	if err != nil {
+		return fmt.Errorf("kind: failed to find the address of node %q of cluster %q: %w", node.Name, k.name, err)
+	}
+	if err := k.nodeOperation(fmt.Sprintf("network disconnect %s", kindNetwork), node); err != nil {
+		return err
+	}
+	if k.partitionedIPs == nil {
+		k.partitionedIPs = map[string]string{}
+	}
+	k.partitionedIPs[node.Name] = ip
+	return nil
+}
+
+// HealNode reconnects the container backing the kind node to the kind network, with the IP address it had
+// before PartitionNode so that the kubelet and the certificates of the node remain valid.
+func (k *Cluster) HealNode(ctx context.Context, node *support.Node) error {
+	operation := fmt.Sprintf("network connect %s", kindNetwork)
+	if ip := k.partitionedIPs[node.Name]; ip != "" {
+		operation = fmt.Sprintf("network connect --ip %s %s", ip, kindNetwork)
+	}
+	if err := k.nodeOperation(operation, node); err != nil {
+		return err
+	}
+	delete(k.partitionedIPs, node.Name)
+	return nil
+}
+
+func (k *Cluster) allNodesOperation(ctx context.Context, operation string) error {
+	nodes, err := k.ListNode(ctx)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("kind: no nodes found for cluster %q", k.name)
+	}
+	for i := range nodes {
+		if err := k.nodeOperation(operation, &nodes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+func TestNodeCommand(t *testing.T) {
+	t.Setenv("KIND_EXPERIMENTAL_PROVIDER", "")
+	node := &support.Node{Name: "test-worker"}
+	tests := []struct {
+		name      string
+		operation string
+		args      []string
+		want      string
+	}{
+		{name: "pause", operation: "pause", want: "docker pause test-worker"},
+		{name: "resume", operation: "unpause", want: "docker unpause test-worker"},
+		{name: "stop with args", operation: "stop", args: []string{"--time", "5"}, want: "docker stop --time 5 test-worker"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeCommand(tt.operation, node, tt.args...); got != tt.want {
+				t.Errorf("nodeCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodeCommandRuntime(t *testing.T) {
+	t.Setenv("KIND_EXPERIMENTAL_PROVIDER", "podman")
+	if got, want := nodeCommand("pause", &support.Node{Name: "test-worker"}), "podman pause test-worker"; got != want {
+		t.Errorf("nodeCommand() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+// SupportedOptions returns the typed provider options understood by the kind provider
+func (k *Cluster) SupportedOptions() []support.ProviderOptionKey {
+	return []support.ProviderOptionKey{
+		support.ProviderOptionImage,
+		support.ProviderOptionWorkers,
+		support.ProviderOptionRegistry,
+		support.ProviderOptionWait,
+	}
+}
+
+// SetOption applies the typed provider option identified by key to the kind cluster
+func (k *Cluster) SetOption(key support.ProviderOptionKey, value string) error {
+	switch key {
+	case support.ProviderOptionImage:
+		k.image = value
+	case support.ProviderOptionWorkers:
+		workers, err := strconv.Atoi(value)
+		if err != nil || workers < 0 {
+			return fmt.Errorf("workers must be a non-negative integer")
+		}
+		k.workers = workers
+	case support.ProviderOptionRegistry:
+		port, err := strconv.Atoi(value)
+		if err != nil || port <= 0 || port > 65535 {
+			return fmt.Errorf("registry must be a valid port number")
+		}
+		k.registryPort = port
+	case support.ProviderOptionWait:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		k.waitDuration = d
+	default:
+		return fmt.Errorf("unsupported option %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"testing"
+	"time"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+func TestApplyProviderOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []support.ClusterOpts
+		wantErr bool
+	}{
+		{
+			name: "supported options",
+			opts: []support.ClusterOpts{
+				support.WithProviderOption(support.ProviderOptionImage, "kindest/node:v1.32.0"),
+				support.WithProviderOption(support.ProviderOptionWorkers, "2"),
+				support.WithProviderOption(support.ProviderOptionRegistry, "5001"),
+				support.WithProviderOption(support.ProviderOptionWait, "2m"),
+			},
+		},
+		{
+			name: "provider specific options are ignored",
+			opts: []support.ClusterOpts{WithImage("kindest/node:v1.32.0"), WithWorkers(1)},
+		},
+		{
+			name:    "unknown option",
+			opts:    []support.ClusterOpts{support.WithProviderOption("wrokers", "2")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			opts:    []support.ClusterOpts{support.WithProviderOption(support.ProviderOptionWorkers, "two")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := support.ApplyProviderOptions(NewCluster("test"), tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ApplyProviderOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCluster_SetOption(t *testing.T) {
+	k := NewCluster("test")
+	k.WithOpts(
+		support.WithProviderOption(support.ProviderOptionImage, "kindest/node:v1.32.0"),
+		support.WithProviderOption(support.ProviderOptionWorkers, "3"),
+		support.WithProviderOption(support.ProviderOptionRegistry, "5001"),
+		support.WithProviderOption(support.ProviderOptionWait, "90s"),
+	)
+	if k.image != "kindest/node:v1.32.0" || k.workers != 3 || k.registryPort != 5001 || k.waitDuration != 90*time.Second {
+		t.Errorf("unexpected cluster configuration: image=%q workers=%d registry=%d wait=%s", k.image, k.workers, k.registryPort, k.waitDuration)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "k8s.io/klog/v2"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+const (
+	// DefaultRegistryName is the prefix of the name of the registry containers created by WithLocalRegistry,
+	// which are suffixed with their port unless a name is configured with WithLocalRegistryName
+	DefaultRegistryName = "kind-registry"
+	registryImage       = "registry:2"
+	containerdCertsDir  = "/etc/containerd/certs.d"
+)
+
+var _ support.E2EClusterProviderWithLocalRegistry = &Cluster{}
+
+// WithLocalRegistry is used to configure a local registry listening on localhost:port of the host. The
+// registry container is created along with the cluster, if not already running, and the cluster nodes
+// are configured to pull the images pushed to localhost:port from it. The container is named
+// kind-registry-<port>, so that registries listening on different ports don't clash.
+func WithLocalRegistry(port int) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		k, ok := c.(*Cluster)
+		if ok {
+			k.registryPort = port
+		}
+	}
+}
+
+// WithLocalRegistryName is used to configure the name of the registry container created by WithLocalRegistry
+func WithLocalRegistryName(name string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		k, ok := c.(*Cluster)
+		if ok {
+			k.registryName = name
+		}
+	}
+}
+
+// containerdRegistryConfig enables the containerd registry host configuration directory on the nodes
+const containerdRegistryConfig = `[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "` + containerdCertsDir + `"`
+
+// containerdRegistryConfigPatch is the kind config section holding containerdRegistryConfig
+const containerdRegistryConfigPatch = `containerdConfigPatches:
+- |-
+  [plugins."io.containerd.grpc.v1.cri".registry]
+    config_path = "` + containerdCertsDir + `"
+`
+
+// LocalRegistryName returns the name of the registry container of the cluster, or an empty string
+// when no local registry is configured
+func (k *Cluster) LocalRegistryName() string {
+	if k.registryName != "" {
+		return k.registryName
+	}
+	if k.registryPort == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", DefaultRegistryName, k.registryPort)
+}
+
+// LocalRegistryEndpoint returns the endpoint images are pushed to, e.g. localhost:5001
+func (k *Cluster) LocalRegistryEndpoint() string {
+	if k.registryPort == 0 {
+		return ""
+	}
+	return fmt.Sprintf("localhost:%d", k.registryPort)
+}
+
+// SetupLocalRegistry starts the registry container and configures the nodes of the cluster to use it, following
+// https://kind.sigs.k8s.io/docs/user/local-registry/. The nodes have to be created with the containerd config_path
+// patch, which is part of the config generated when WithLocalRegistry is used, or merged into the config
+// file provided with --config.
+func (k *Cluster) SetupLocalRegistry(ctx context.Context, port int) (string, error) {
+	k.registryPort = port
+	endpoint, name := k.LocalRegistryEndpoint(), k.LocalRegistryName()
+	log.V(4).InfoS("Setting up local registry for kind cluster", "cluster", k.name, "endpoint", endpoint, "name", name)
+
+	if err := startRegistry(name, port); err != nil {
+		return "", err
+	}
+
+	nodes, err := k.ListNode(ctx)
+	if err != nil {
+		return "", err
+	}
+	hostsFile, err := os.CreateTemp("", "kind-registry-hosts-*.toml")
+	if err != nil {
+		return "", fmt.Errorf("kind registry hosts file: %w", err)
+	}
+	defer os.Remove(hostsFile.Name())
+	if _, err := fmt.Fprintf(hostsFile, "[host.\"http://%s:5000\"]\n", name); err != nil {
+		hostsFile.Close()
+		return "", fmt.Errorf("kind registry hosts file: %w", err)
+	}
+	hostsFile.Close()
+
+	registryDir := fmt.Sprintf("%s/%s", containerdCertsDir, endpoint)
+	for _, node := range nodes {
+		if _, err := runRuntime(fmt.Sprintf("%s exec %s mkdir -p %s", containerRuntime(), node.Name, registryDir)); err != nil {
+			return "", fmt.Errorf("kind: failed to configure registry on node %q: %w", node.Name, err)
+		}
+		if _, err := runRuntime(fmt.Sprintf("%s cp %s %s:%s/hosts.toml", containerRuntime(), hostsFile.Name(), node.Name, registryDir)); err != nil {
+			return "", fmt.Errorf("kind: failed to configure registry on node %q: %w", node.Name, err)
+		}
+	}
+
+	if _, err := runRuntime(fmt.Sprintf("%s network connect %s %s", containerRuntime(), kindNetwork, name)); err != nil && !strings.Contains(err.Error(), "already exists") {
+		return "", fmt.Errorf("kind: failed to connect registry to the kind network: %w", err)
+	}
+
+	if err := k.documentLocalRegistry(ctx, endpoint); err != nil {
+		return "", err
+	}
+	return endpoint, nil
+}
+
+// documentLocalRegistry creates the local-registry-hosting ConfigMap described in KEP-1755, which
+// lets tooling discover the registry of the cluster
+func (k *Cluster) documentLocalRegistry(ctx context.Context, endpoint string) error {
+	if k.rc == nil {
+		return nil
+	}
+	r, err := resources.New(k.rc)
+	if err != nil {
+		return err
+	}
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "local-registry-hosting", Namespace: "kube-public"},
+		Data: map[string]string{
+			"localRegistryHosting.v1": fmt.Sprintf("host: %q\nhelp: \"https://kind.sigs.k8s.io/docs/user/local-registry/\"\n", endpoint),
+		},
+	}
+	if err := r.Create(ctx, cm); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("kind: failed to create local-registry-hosting config map: %w", err)
+	}
+	return nil
+}
+
+// startRegistry starts the registry container listening on localhost:port, unless it is already running
+func startRegistry(name string, port int) error {
+	running, _ := runRuntime(fmt.Sprintf("%s inspect --format {{.State.Running}} %s", containerRuntime(), name))
+	if running == "true" {
+		log.V(4).InfoS("Skipping local registry creation. Registry already running", "name", name)
+		return nil
+	}
+	if running == "false" {
+		if _, err := runRuntime(fmt.Sprintf("%s start %s", containerRuntime(), name)); err != nil {
+			return fmt.Errorf("kind: failed to start local registry %q: %w", name, err)
+		}
+		return nil
+	}
+	command := fmt.Sprintf("%s run --detach --restart always --publish 127.0.0.1:%d:5000 --network bridge --name %s %s", containerRuntime(), port, name, registryImage)
+	log.V(4).InfoS("Creating local registry", "command", command)
+	if _, err := runRuntime(command); err != nil {
+		return fmt.Errorf("kind: failed to create local registry %q: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteLocalRegistry removes the registry container created by WithLocalRegistry, whose name is
+// returned by Cluster.LocalRegistryName. The registry is not removed along with the cluster, as it
+// can be shared by several clusters.
+func DeleteLocalRegistry(name string) error {
+	if _, err := runRuntime(fmt.Sprintf("%s rm --force %s", containerRuntime(), name)); err != nil {
+		return fmt.Errorf("kind: failed to delete local registry %q: %w", name, err)
+	}
+	return nil
+}
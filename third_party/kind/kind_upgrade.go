@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+var _ support.E2EClusterProviderWithUpgrade = &Cluster{}
+
+// kindNodeImage is the repository of the kind node images published for each Kubernetes version
+const kindNodeImage = "kindest/node"
+
+// Upgrade moves the kind cluster to the given Kubernetes version. kind cannot upgrade the control plane of a
+// cluster in place, so the cluster is deleted and created again, with the same arguments, from the node image of
+// the version. The state of the cluster is lost. version is either a Kubernetes version, e.g. v1.31.0, or a full
+// node image reference.
+func (k *Cluster) Upgrade(ctx context.Context, version string) (string, error) {
+	if version == "" {
+		return "", fmt.Errorf("kind: upgrade of cluster %q: no version provided", k.name)
+	}
+	image := nodeImageFor(version)
+	log.V(4).InfoS("Upgrading kind cluster by recreating it", "name", k.name, "image", image)
+	if err := k.Destroy(ctx); err != nil {
+		return "", fmt.Errorf("kind: upgrade of cluster %q: %w", k.name, err)
+	}
+	k.image = image
+	k.partitionedIPs = nil
+	kubecfg, err := k.Create(ctx, k.createArgs...)
+	if err != nil {
+		return "", fmt.Errorf("kind: upgrade of cluster %q to %s: %w", k.name, version, err)
+	}
+	return kubecfg, nil
+}
+
+// nodeImageFor returns the kind node image of a Kubernetes version, or version itself when it is already an image
+func nodeImageFor(version string) string {
+	if strings.ContainsAny(version, ":/@") {
+		return version
+	}
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return fmt.Sprintf("%s:%s", kindNodeImage, version)
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kind
+
+import "testing"
+
+func TestNodeImageFor(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "v1.31.0", want: "kindest/node:v1.31.0"},
+		{version: "1.30.4", want: "kindest/node:v1.30.4"},
+		{version: "kindest/node:v1.31.0@sha256:abc", want: "kindest/node:v1.31.0@sha256:abc"},
+		{version: "registry.local/node:v1.31.0", want: "registry.local/node:v1.31.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := nodeImageFor(tt.version); got != tt.want {
+				t.Errorf("expected image %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
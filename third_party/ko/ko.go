@@ -37,6 +37,12 @@ type Opts struct {
 	Platforms []string
 	// ConfigFile is used to indicate the ko config file path.
 	ConfigFile string
+	// DockerRepo is used to indicate the registry repository to publish to.
+	DockerRepo string
+	// Tags is used to indicate the tags to publish the images with.
+	Tags []string
+	// Bare is used to publish images to the DockerRepo without appending the import path.
+	Bare bool
 
 	baseCmdAndOpt string
 }
@@ -78,6 +84,30 @@ func WithLocalKindName(name string) Option {
 	}
 }
 
+// WithDockerRepo is used to configure the registry repository the images are
+// published to, e.g. ghcr.io/org/repo. It takes precedence over WithLocalKindName.
+func WithDockerRepo(repo string) Option {
+	return func(opts *Opts) {
+		opts.DockerRepo = repo
+	}
+}
+
+// WithTags is used to configure the tags the images are published with.
+// ko defaults to the latest tag when none are provided.
+func WithTags(tags ...string) Option {
+	return func(opts *Opts) {
+		opts.Tags = append(opts.Tags, tags...)
+	}
+}
+
+// WithBare is used to publish the images to the configured docker repo
+// as is, without appending the import path to the image name.
+func WithBare() Option {
+	return func(opts *Opts) {
+		opts.Bare = true
+	}
+}
+
 // processOpts is used to generate the Opts resource that will be used to generate
 // the actual helm command to be run using the getCommand helper
 func (m *Manager) processOpts(opts ...Option) *Opts {
@@ -96,6 +126,14 @@ func (m *Manager) getCommand(opt *Opts) string {
 		commandParts = append(commandParts, "--platform", strings.Join(opt.Platforms, ","))
 	}
 
+	if len(opt.Tags) != 0 {
+		commandParts = append(commandParts, "--tags", strings.Join(opt.Tags, ","))
+	}
+
+	if opt.Bare {
+		commandParts = append(commandParts, "--bare")
+	}
+
 	return strings.Join(commandParts, " ")
 }
 
@@ -112,6 +150,10 @@ func (m *Manager) getEnvs(opt *Opts) map[string]string {
 		envs["KIND_CLUSTER_NAME"] = opt.LocalKindName
 	}
 
+	if opt.DockerRepo != "" {
+		envs["KO_DOCKER_REPO"] = opt.DockerRepo
+	}
+
 	return envs
 }
 
@@ -128,15 +170,43 @@ func (m *Manager) Install(version string) error {
 // BuildLocal builds container image from the given packagePath and publishes it to a
 // local repository supported by ko. It returns the container image ID within the ctx.
 func (m *Manager) BuildLocal(ctx context.Context, packagePath string, opts ...Option) (context.Context, error) {
+	return m.BuildAndPublish(ctx, packagePath, opts...)
+}
+
+// BuildAndPublish builds container image from the given packagePath and publishes it to
+// the repository configured with WithDockerRepo or WithLocalKindName. It returns the
+// published image reference within the ctx, which can be retrieved with GetLocalImage.
+func (m *Manager) BuildAndPublish(ctx context.Context, packagePath string, opts ...Option) (context.Context, error) {
+	return m.BuildMulti(ctx, []string{packagePath}, opts...)
+}
+
+// BuildMulti builds and publishes container images for all the given packagePaths
+// with a single ko invocation. The published image reference of each package is
+// returned within the ctx, which can be retrieved with GetLocalImage.
+func (m *Manager) BuildMulti(ctx context.Context, packagePaths []string, opts ...Option) (context.Context, error) {
+	if len(packagePaths) == 0 {
+		return ctx, errors.New("at least one package path is required to build images")
+	}
+
 	o := m.processOpts(opts...)
-	o.baseCmdAndOpt = fmt.Sprintf("build %s", packagePath)
+	o.baseCmdAndOpt = fmt.Sprintf("build %s", strings.Join(packagePaths, " "))
 
-	image, err := m.run(o)
+	out, err := m.run(o)
 	if err != nil {
 		return ctx, err
 	}
 
-	return context.WithValue(ctx, localImageContextKey(packagePath), image), nil
+	// ko prints one image reference per package, in the same order as the arguments
+	images := strings.Split(out, "\n")
+	if len(images) != len(packagePaths) {
+		return ctx, fmt.Errorf("expected %d images from ko build, got %d: %s", len(packagePaths), len(images), out)
+	}
+
+	for i, packagePath := range packagePaths {
+		ctx = context.WithValue(ctx, localImageContextKey(packagePath), strings.TrimSpace(images[i]))
+	}
+
+	return ctx, nil
 }
 
 // GetLocalImage returns the previously built container image ID for packagePath from ctx.
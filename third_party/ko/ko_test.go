@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ko
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestManager_getCommandAndEnvs(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		wantCommand string
+		wantEnvs    map[string]string
+	}{
+		{
+			name:        "local kind",
+			opts:        []Option{WithLocalKindName("kind")},
+			wantCommand: "ko build ./cmd/app",
+			wantEnvs:    map[string]string{"KO_DOCKER_REPO": "kind.local", "KIND_CLUSTER_NAME": "kind"},
+		},
+		{
+			name:        "remote repo with tags",
+			opts:        []Option{WithDockerRepo("ghcr.io/org/app"), WithTags("v1", "latest"), WithBare()},
+			wantCommand: "ko build ./cmd/app --tags v1,latest --bare",
+			wantEnvs:    map[string]string{"KO_DOCKER_REPO": "ghcr.io/org/app"},
+		},
+		{
+			name:        "docker repo takes precedence over kind",
+			opts:        []Option{WithLocalKindName("kind"), WithDockerRepo("ghcr.io/org"), WithPlatforms("linux/amd64")},
+			wantCommand: "ko build ./cmd/app --platform linux/amd64",
+			wantEnvs:    map[string]string{"KO_DOCKER_REPO": "ghcr.io/org", "KIND_CLUSTER_NAME": "kind"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New()
+			o := m.processOpts(tt.opts...)
+			o.baseCmdAndOpt = "build ./cmd/app"
+			if got := m.getCommand(o); got != tt.wantCommand {
+				t.Errorf("getCommand() = %q, want %q", got, tt.wantCommand)
+			}
+			if got := m.getEnvs(o); !reflect.DeepEqual(got, tt.wantEnvs) {
+				t.Errorf("getEnvs() = %v, want %v", got, tt.wantEnvs)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	log "k8s.io/klog/v2"
+)
+
+type Opts struct {
+	// Namespace is used to indicate the namespace in which the kubectl
+	// command is run
+	Namespace string
+	// KubeContext is used to indicate the kubeconfig context used by the
+	// kubectl command
+	KubeContext string
+	// Args is used to pass the sub command and arguments of the kubectl
+	// command being run
+	Args []string
+	// Stdin is used to provide the input of the kubectl command, e.g. the
+	// manifests applied with `kubectl apply -f -`
+	Stdin []byte
+}
+
+type Manager struct {
+	kubeConfig string
+	path       string
+}
+
+type Option func(*Opts)
+
+const (
+	missingKubectl = "'kubectl' command is missing. Please ensure the tool exists before using the kubectl manager"
+)
+
+// WithNamespace is used to configure the namespace in which the kubectl command is run
+func WithNamespace(namespace string) Option {
+	return func(opts *Opts) {
+		opts.Namespace = namespace
+	}
+}
+
+// WithKubeContext is used to configure the kubeconfig context used by the kubectl command
+func WithKubeContext(kubeContext string) Option {
+	return func(opts *Opts) {
+		opts.KubeContext = kubeContext
+	}
+}
+
+// WithArgs is used to configure the sub command and arguments of the kubectl command.
+// Each argument is passed as is to kubectl without any shell processing, so values
+// containing spaces such as jsonpath expressions do not need to be quoted.
+func WithArgs(args ...string) Option {
+	return func(opts *Opts) {
+		opts.Args = append(opts.Args, args...)
+	}
+}
+
+// WithStdin is used to configure the data passed to the standard input of the kubectl command
+func WithStdin(data []byte) Option {
+	return func(opts *Opts) {
+		opts.Stdin = data
+	}
+}
+
+// processOpts is used to generate the Opts resource that will be used to generate
+// the actual kubectl command to be run using the getArgs helper
+func (m *Manager) processOpts(opts ...Option) *Opts {
+	option := &Opts{}
+	for _, op := range opts {
+		op(option)
+	}
+	return option
+}
+
+// getArgs is used to convert the Opts into the arguments passed to kubectl. The global flags come
+// first, so that they are not passed to the command run by `kubectl exec ... -- cmd`
+func (m *Manager) getArgs(opt *Opts) []string {
+	var args []string
+	if opt.Namespace != "" {
+		args = append(args, "--namespace", opt.Namespace)
+	}
+	if opt.KubeContext != "" {
+		args = append(args, "--context", opt.KubeContext)
+	}
+	if m.kubeConfig != "" {
+		args = append(args, "--kubeconfig", m.kubeConfig)
+	}
+	return append(args, opt.Args...)
+}
+
+// appendFlags adds the flags to the arguments of the sub command, before the "--" separating
+// them from the arguments of the command run by kubectl, if any
+func appendFlags(args []string, flags ...string) []string {
+	for i, arg := range args {
+		if arg == "--" {
+			return append(append(append([]string{}, args[:i]...), flags...), args[i:]...)
+		}
+	}
+	return append(args, flags...)
+}
+
+// Run invokes kubectl with the configured Opts and returns its standard output. The
+// command is killed if the ctx is cancelled before it completes.
+func (m *Manager) Run(ctx context.Context, opts ...Option) (string, error) {
+	return m.run(ctx, m.processOpts(opts...))
+}
+
+// RunJSON invokes kubectl with `--output json` and decodes the result into out. This can be
+// used to parse the output of commands such as `kubectl get` into typed objects.
+func (m *Manager) RunJSON(ctx context.Context, out interface{}, opts ...Option) error {
+	o := m.processOpts(opts...)
+	o.Args = appendFlags(o.Args, "--output", "json")
+	stdout, err := m.run(ctx, o)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(stdout), out); err != nil {
+		return fmt.Errorf("failed to decode kubectl output: %w", err)
+	}
+	return nil
+}
+
+// Apply invokes `kubectl apply --server-side` for the manifests found at path. Server-side
+// apply is used to avoid the size limit of the last-applied-configuration annotation
+// when applying large bundles such as CRDs.
+func (m *Manager) Apply(ctx context.Context, path string, opts ...Option) error {
+	o := m.processOpts(opts...)
+	o.Args = append([]string{"apply", "--server-side", "--filename", path}, o.Args...)
+	_, err := m.run(ctx, o)
+	return err
+}
+
+// run method is used to invoke a kubectl command to perform a suitable operation.
+// Please make sure to configure the right Opts using the Option helpers
+func (m *Manager) run(ctx context.Context, opts *Opts) (string, error) {
+	log.V(4).InfoS("Determining if kubectl binary is available or not", "executable", m.path)
+	executable, err := exec.LookPath(m.path)
+	if err != nil {
+		return "", errors.New(missingKubectl)
+	}
+	if len(opts.Args) == 0 {
+		return "", errors.New("missing kubectl sub command. Please use the WithArgs option while invoking the run")
+	}
+
+	args := m.getArgs(opts)
+	log.V(4).InfoS("Running Kubectl Operation", "command", m.path+" "+strings.Join(args, " "))
+	cmd := exec.CommandContext(ctx, executable, args...)
+
+	var stderr bytes.Buffer
+	var stdout bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdout = &stdout
+	if opts.Stdin != nil {
+		cmd.Stdin = bytes.NewReader(opts.Stdin)
+	}
+
+	err = cmd.Run()
+	log.V(4).Info("Kubectl Command output \n", stdout.String())
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSuffix(stderr.String(), "\n"), err)
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+// WithPath is used to provide a custom path where the `kubectl` executable command
+// can be found. This is useful in case if your binary is in a non standard location
+// and you want to framework to use that instead of returning an error.
+func (m *Manager) WithPath(path string) *Manager {
+	m.path = path
+	return m
+}
+
+// New creates a kubectl Manager that runs all commands against the cluster
+// identified by the kubeConfig file.
+func New(kubeConfig string) *Manager {
+	return &Manager{
+		kubeConfig: kubeConfig,
+		path:       "kubectl",
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestManager_getArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		kubeConfig string
+		opts       []Option
+		want       []string
+	}{
+		{
+			name: "args only",
+			opts: []Option{WithArgs("get", "pods")},
+			want: []string{"get", "pods"},
+		},
+		{
+			name:       "namespace, context and kubeconfig",
+			kubeConfig: "/tmp/kubeconfig",
+			opts:       []Option{WithArgs("get", "pods"), WithNamespace("default"), WithKubeContext("kind-kind")},
+			want:       []string{"--namespace", "default", "--context", "kind-kind", "--kubeconfig", "/tmp/kubeconfig", "get", "pods"},
+		},
+		{
+			name:       "exec with a remote command",
+			kubeConfig: "/tmp/kubeconfig",
+			opts:       []Option{WithArgs("exec", "web-0", "--", "ls", "-l"), WithNamespace("apps")},
+			want:       []string{"--namespace", "apps", "--kubeconfig", "/tmp/kubeconfig", "exec", "web-0", "--", "ls", "-l"},
+		},
+		{
+			name: "args with spaces are not split",
+			opts: []Option{WithArgs("get", "pods", "-o", "jsonpath={.items[*].metadata.name} ")},
+			want: []string{"get", "pods", "-o", "jsonpath={.items[*].metadata.name} "},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(tt.kubeConfig)
+			if got := m.getArgs(m.processOpts(tt.opts...)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "sub command",
+			args: []string{"get", "pods"},
+			want: []string{"get", "pods", "--output", "json"},
+		},
+		{
+			name: "remote command",
+			args: []string{"exec", "web-0", "--", "cat", "/status.json"},
+			want: []string{"exec", "web-0", "--output", "json", "--", "cat", "/status.json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendFlags(tt.args, "--output", "json"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("appendFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_RunMissingBinary(t *testing.T) {
+	m := New("").WithPath("kubectl-does-not-exist")
+	if _, err := m.Run(context.TODO(), WithArgs("version")); err == nil || err.Error() != missingKubectl {
+		t.Errorf("expected missing kubectl error, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ go 1.19
 
 require (
 	github.com/octago/sflags v0.2.0
+	github.com/spf13/pflag v1.0.5
 	k8s.io/klog/v2 v2.70.0
 	sigs.k8s.io/kubetest2 v0.0.0-20221019023504-d306c412d528
 )
@@ -11,6 +12,5 @@ require (
 require (
 	github.com/go-logr/logr v1.2.0 // indirect
 	github.com/kballard/go-shellquote v0.0.0-20180428030007-95032a82bc51 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/vladimirvivien/gexe v0.2.0 // indirect
 )
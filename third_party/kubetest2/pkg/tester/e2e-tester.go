@@ -17,13 +17,15 @@ limitations under the License.
 package tester
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/exec"
 	"strings"
 
 	"github.com/octago/sflags/gen/gpflag"
-	"github.com/vladimirvivien/gexe"
+	"github.com/spf13/pflag"
 	klog "k8s.io/klog/v2"
 	"sigs.k8s.io/kubetest2/pkg/testers"
 )
@@ -31,20 +33,41 @@ import (
 var GitTag string
 
 type Tester struct {
-	TestFlags               string `desc:"Space-separated flags applied to 'go test' command"`
-	Namespace               string `desc:"A namespace value to use for testing (optional)"`
-	Kubeconfig              string `desc:"Path to a cluster kubeconfig file (optional)"`
-	Feature                 string `desc:"Regular expression to select feature(s) to test"`
-	Assess                  string `desc:"Regular expression to select assessment(s) to run"`
-	Labels                  string `desc:"Comma-separated key=value to filter features by labels"`
-	SkipLabels              string `desc:"Regular expression to skip label(s) to run"`
-	SkipFeatures            string `desc:"Regular expression to skip feature(s) to run"`
-	SkipAssessment          string `desc:"Regular expression to skip assessment(s) to run"`
-	Parallel                bool   `desc:"Run test features in parallel"`
-	DryRun                  bool   `desc:"Run Test suite in dry-run mode. This will list the tests to be executed without actually running them"`
-	FailFast                bool   `desc:"Fail immediately and stop running untested code"`
-	DisableGracefulTeardown bool   `desc:"Ignore panic recovery while running tests. This will prevent test finish steps from getting executed on panic"`
-	Packages                string `desc:"Space-separated packages to test"`
+	TestFlags  string `desc:"Space-separated flags applied to 'go test' command, or to the test binary"`
+	Packages   string `desc:"Space-separated packages to test"`
+	TestBinary string `desc:"Path to a prebuilt test binary, built with 'go test -c', to run instead of 'go test' (optional)"`
+
+	// frameworkArgs are the framework flags passed through to the tests, in the order of frameworkFlags
+	frameworkArgs []string
+}
+
+// frameworkFlag describes a flag of the e2e-framework passed through to the tests.
+// The list of the flags is generated from sigs.k8s.io/e2e-framework/pkg/flags in zz_generated.flags.go
+type frameworkFlag struct {
+	name   string
+	usage  string
+	isBool bool
+}
+
+// frameworkValue holds the value of a framework flag as passed to the tester. The value is
+// forwarded as is to the tests, which validate it
+type frameworkValue struct {
+	value  string
+	isBool bool
+}
+
+func (v *frameworkValue) String() string { return v.value }
+
+func (v *frameworkValue) Set(value string) error {
+	v.value = value
+	return nil
+}
+
+func (v *frameworkValue) Type() string {
+	if v.isBool {
+		return "bool"
+	}
+	return "string"
 }
 
 const usage = `Usage: kubetest2-tester-e2e-framework [e2e-framework-flags]
@@ -53,23 +76,12 @@ e2e-framework flags:
 `
 
 func (t *Tester) Execute(args []string) error {
-	fs, err := gpflag.Parse(t)
+	fs, help, err := t.parse(args)
 	if err != nil {
-		return fmt.Errorf("failed to initialize e2e-framework tester: %s", err)
-	}
-
-	fs.AddGoFlagSet(flag.CommandLine)
-
-	help := fs.BoolP("help", "h", false, "")
-	if err := fs.Parse(args); err != nil {
-		return fmt.Errorf("failed to parse flags: %s", err)
-	}
-
-	fs.Usage = func() {
-		fmt.Print(usage)
+		return err
 	}
 
-	if *help {
+	if help {
 		fs.SetOutput(os.Stdout)
 		fs.Usage()
 		fs.PrintDefaults()
@@ -82,56 +94,71 @@ func (t *Tester) Execute(args []string) error {
 	return t.Test()
 }
 
-func (t *Tester) Test() error {
-	testCmd := t.buildCmd()
-	klog.Info("Running: ", testCmd)
-	p := gexe.NewProc(testCmd)
-	p.SetStdout(os.Stdout)
-	p.SetStderr(os.Stderr)
-	return p.Run().Err()
-}
-
-func (t *Tester) buildCmd() string {
-	var testCmd strings.Builder
-	testCmd.WriteString(fmt.Sprintf("go test %s %s -args", t.TestFlags, t.Packages))
-	if t.Namespace != "" {
-		testCmd.WriteString(" --namespace=" + t.Namespace)
-	}
-	if t.Kubeconfig != "" {
-		testCmd.WriteString(" --kubeconfig=" + t.Kubeconfig)
-	}
-	if t.Feature != "" {
-		testCmd.WriteString(" --kubeconfig=" + t.Feature)
-	}
-	if t.SkipFeatures != "" {
-		testCmd.WriteString(" --skip-features=" + t.SkipFeatures)
-	}
-	if t.Assess != "" {
-		testCmd.WriteString(" --assess=" + t.Assess)
-	}
-	if t.SkipAssessment != "" {
-		testCmd.WriteString(" --skip-assessment=" + t.SkipAssessment)
-	}
-	if t.Labels != "" {
-		testCmd.WriteString(" --labels=" + t.Labels)
+// parse parses the flags of the tester and collects the framework flags set in args
+func (t *Tester) parse(args []string) (*pflag.FlagSet, bool, error) {
+	fs, err := gpflag.Parse(t)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to initialize e2e-framework tester: %s", err)
 	}
-	if t.SkipLabels != "" {
-		testCmd.WriteString(" --skip-labels=" + t.SkipLabels)
+
+	values := make([]*frameworkValue, len(frameworkFlags))
+	for i, f := range frameworkFlags {
+		values[i] = &frameworkValue{isBool: f.isBool}
+		fs.Var(values[i], f.name, f.usage)
+		if f.isBool {
+			fs.Lookup(f.name).NoOptDefVal = "true"
+		}
 	}
-	if t.Parallel {
-		testCmd.WriteString(" --parallel")
+
+	fs.AddGoFlagSet(flag.CommandLine)
+
+	help := fs.BoolP("help", "h", false, "")
+	if err := fs.Parse(args); err != nil {
+		return nil, false, fmt.Errorf("failed to parse flags: %s", err)
 	}
-	if t.DryRun {
-		testCmd.WriteString(" --dry-run")
+
+	fs.Usage = func() {
+		fmt.Print(usage)
 	}
-	if t.FailFast {
-		testCmd.WriteString(" --fail-fast")
+
+	t.frameworkArgs = nil
+	for i, f := range frameworkFlags {
+		if fs.Changed(f.name) {
+			t.frameworkArgs = append(t.frameworkArgs, fmt.Sprintf("--%s=%s", f.name, values[i].value))
+		}
 	}
-	if t.DisableGracefulTeardown {
-		testCmd.WriteString(" --disable-graceful-shutdown")
+	return fs, *help, nil
+}
+
+func (t *Tester) Test() error {
+	testCmd, err := t.buildCmd()
+	if err != nil {
+		return err
 	}
+	klog.Info("Running: ", strings.Join(testCmd, " "))
+	cmd := exec.Command(testCmd[0], testCmd[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
 
-	return testCmd.String()
+// buildCmd returns the command running the tests: 'go test' on the packages, or the prebuilt
+// test binary, followed by the framework flags
+func (t *Tester) buildCmd() ([]string, error) {
+	var testCmd []string
+	if t.TestBinary != "" {
+		if t.Packages != "" {
+			return nil, errors.New("--packages cannot be used with --test-binary")
+		}
+		testCmd = append(testCmd, t.TestBinary)
+		testCmd = append(testCmd, strings.Fields(t.TestFlags)...)
+	} else {
+		testCmd = append(testCmd, "go", "test")
+		testCmd = append(testCmd, strings.Fields(t.TestFlags)...)
+		testCmd = append(testCmd, strings.Fields(t.Packages)...)
+		testCmd = append(testCmd, "-args")
+	}
+	return append(testCmd, t.frameworkArgs...), nil
 }
 
 func Main() {
@@ -17,21 +17,21 @@ limitations under the License.
 package tester
 
 import (
+	"reflect"
 	"testing"
-
-	"github.com/octago/sflags/gen/gpflag"
 )
 
-func TestBuildFlags(t *testing.T) {
+func TestBuildCmd(t *testing.T) {
 	tests := []struct {
-		name   string
-		args   []string
-		tester *Tester
+		name    string
+		args    []string
+		want    []string
+		wantErr bool
 	}{
 		{
-			name:   "empty flags",
-			args:   []string{},
-			tester: &Tester{},
+			name: "empty flags",
+			args: []string{},
+			want: []string{"go", "test", "-args"},
 		},
 		{
 			name: "all flags",
@@ -47,61 +47,72 @@ func TestBuildFlags(t *testing.T) {
 				"--dry-run",
 				"--disable-graceful-teardown",
 			},
-			tester: &Tester{
-				Packages:                ".",
-				Assess:                  "volume test",
-				Feature:                 "beta",
-				Labels:                  "k0=v0, k1=v1, k2=v2",
-				SkipLabels:              "k0=v0, k1=v1",
-				SkipFeatures:            "networking",
-				SkipAssessment:          "volume test",
-				Parallel:                true,
-				DryRun:                  true,
-				DisableGracefulTeardown: true,
+			want: []string{
+				"go", "test", ".", "-args",
+				"--feature=beta",
+				"--assess=volume test",
+				"--labels=k0=v0, k1=v1, k2=v2",
+				"--skip-labels=k0=v0, k1=v1",
+				"--skip-features=networking",
+				"--skip-assessment=volume test",
+				"--parallel=true",
+				"--dry-run=true",
+				"--disable-graceful-teardown=true",
 			},
 		},
+		{
+			name: "test flags and packages",
+			args: []string{"--test-flags", "-v -count=1", "--packages", "./a ./b", "--namespace", "e2e", "--kubeconfig=/tmp/kubeconfig"},
+			want: []string{"go", "test", "-v", "-count=1", "./a", "./b", "-args", "--namespace=e2e", "--kubeconfig=/tmp/kubeconfig"},
+		},
+		{
+			name: "bool flag set to false",
+			args: []string{"--fail-fast=false", "--in-cluster"},
+			want: []string{"go", "test", "-args", "--fail-fast=false", "--in-cluster=true"},
+		},
+		{
+			name: "newer framework flags",
+			args: []string{"--artifacts", "/tmp/artifacts", "--feature-gates", "A=true", "--shards=2", "--shard-index=1"},
+			want: []string{"go", "test", "-args", "--artifacts=/tmp/artifacts", "--shards=2", "--shard-index=1", "--feature-gates=A=true"},
+		},
+		{
+			name: "test binary",
+			args: []string{"--test-binary", "./e2e.test", "--test-flags", "-test.v", "--feature", "beta"},
+			want: []string{"./e2e.test", "-test.v", "--feature=beta"},
+		},
+		{
+			name:    "test binary with packages",
+			args:    []string{"--test-binary", "./e2e.test", "--packages", "."},
+			wantErr: true,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			tester := &Tester{}
-			fs, err := gpflag.Parse(tester)
-			if err != nil {
+			if _, _, err := tester.parse(test.args); err != nil {
 				t.Fatal(err)
 			}
-			if err := fs.Parse(test.args); err != nil {
-				t.Fatalf("failed to parse flags: %s", err)
-			}
-			if tester.Packages != test.tester.Packages {
-				t.Errorf("flag 'Package' not matched: expecting %s, got %s", test.tester.Packages, tester.Packages)
+			got, err := tester.buildCmd()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error: %v", err)
 			}
-			if tester.Assess != test.tester.Assess {
-				t.Errorf("flag 'Assess' not matched: expecting %s, got %s", test.tester.Assess, tester.Assess)
-			}
-			if tester.Feature != test.tester.Feature {
-				t.Errorf("flag 'Feature' not matched: expecting %s, got %s", test.tester.Feature, tester.Feature)
-			}
-			if tester.Labels != test.tester.Labels {
-				t.Errorf("flag 'Labels' not matched: expecting %s, got %s", test.tester.Labels, tester.Labels)
-			}
-			if tester.SkipLabels != test.tester.SkipLabels {
-				t.Errorf("flag 'SkipLabels' not matched: expecting %s, got %s", test.tester.SkipLabels, tester.SkipLabels)
-			}
-			if tester.SkipFeatures != test.tester.SkipFeatures {
-				t.Errorf("flag 'SkipFeatures' not matched: expecting %s, got %s", test.tester.SkipFeatures, tester.SkipFeatures)
-			}
-			if tester.SkipAssessment != test.tester.SkipAssessment {
-				t.Errorf("flag 'SkipAssessment' not matched: expecting %s, got %s", test.tester.SkipAssessment, tester.SkipAssessment)
-			}
-			if tester.Parallel != test.tester.Parallel {
-				t.Errorf("flag 'Parallel' not matched: expecting %t, got %t", test.tester.Parallel, tester.Parallel)
-			}
-			if tester.DryRun != test.tester.DryRun {
-				t.Errorf("flag 'DryRun' not matched: expecting %t, got %t", test.tester.DryRun, tester.DryRun)
-			}
-			if tester.DisableGracefulTeardown != test.tester.DisableGracefulTeardown {
-				t.Errorf("flag 'DisableGracefulTeardown' not matched: expecting %t, got %t", test.tester.DisableGracefulTeardown, tester.DisableGracefulTeardown)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unexpected command:\nwant %q\ngot  %q", test.want, got)
 			}
 		})
 	}
 }
+
+func TestFrameworkFlagsUnique(t *testing.T) {
+	seen := make(map[string]bool, len(frameworkFlags))
+	for _, f := range frameworkFlags {
+		if seen[f.name] {
+			t.Errorf("framework flag %s is defined more than once", f.name)
+		}
+		if f.name == "test-flags" || f.name == "packages" || f.name == "test-binary" {
+			t.Errorf("framework flag %s conflicts with a flag of the tester", f.name)
+		}
+		seen[f.name] = true
+	}
+}
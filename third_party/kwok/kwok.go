@@ -45,7 +45,10 @@ type Cluster struct {
 	rc           *rest.Config
 }
 
-var _ support.E2EClusterProvider = &Cluster{}
+var (
+	_ support.E2EClusterProvider             = &Cluster{}
+	_ support.E2EClusterProviderWithSnapshot = &Cluster{}
+)
 
 func NewCluster(name string) *Cluster {
 	return &Cluster{name: name, waitDuration: 1 * time.Minute}
@@ -199,6 +202,11 @@ func (k *Cluster) Destroy(ctx context.Context) error {
 	return nil
 }
 
+// Capabilities declares the capabilities of the kwok provider that are not inferred by support.CapabilitiesOf
+func (k *Cluster) Capabilities() support.Capabilities {
+	return support.Capabilities{support.CapabilityLogsExport: true}
+}
+
 func (k *Cluster) ExportLogs(ctx context.Context, dest string) error {
 	if err := k.findOrInstallKwokCtl(); err != nil {
 		return err
@@ -280,3 +288,34 @@ func (k *Cluster) WithVersion(version string) support.E2EClusterProvider {
 func (k *Cluster) KubernetesRestConfig() *rest.Config {
 	return k.rc
 }
+
+// Snapshot saves the etcd data of the kwok cluster into the file at path using `kwokctl snapshot save`
+func (k *Cluster) Snapshot(ctx context.Context, path string) error {
+	return k.snapshotOperation("save", path)
+}
+
+// Restore resets the etcd data of the kwok cluster to the snapshot file at path using `kwokctl snapshot restore`
+func (k *Cluster) Restore(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("kwok: snapshot not found at %q: %w", path, err)
+	}
+	return k.snapshotOperation("restore", path)
+}
+
+func (k *Cluster) snapshotOperation(operation, path string) error {
+	if err := k.findOrInstallKwokCtl(); err != nil {
+		return err
+	}
+	command := k.snapshotCommand(operation, path)
+	klog.V(4).InfoS("Performing kwok snapshot operation", "command", command)
+	p := utils.RunCommand(command)
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return fmt.Errorf("kwok: snapshot %s of cluster %q failed: %s: %s", operation, k.name, p.Err(), p.Result())
+	}
+	return nil
+}
+
+// snapshotCommand returns the kwokctl command performing the snapshot operation with the file at path
+func (k *Cluster) snapshotCommand(operation, path string) string {
+	return fmt.Sprintf("%s snapshot %s --name %s --path %s", k.path, operation, k.name, path)
+}
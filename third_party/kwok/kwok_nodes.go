@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kwok
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
+	"sigs.k8s.io/e2e-framework/klient/decoder"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/utils"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+const (
+	// NodeAnnotation is the annotation kwok uses to identify the nodes it simulates
+	NodeAnnotation = "kwok.x-k8s.io/node"
+	// NodeTaintKey is the key of the taint set on simulated nodes to keep regular pods away from them
+	NodeTaintKey = "kwok.x-k8s.io/node"
+)
+
+var _ support.E2EClusterProviderWithNodeScaling = &Cluster{}
+
+// NodeOption is used to customize the simulated nodes created with CreateNodes
+type NodeOption func(*v1.Node) error
+
+// WithNodeCapacity is used to configure the cpu, memory and pods capacity of the simulated nodes, e.g.
+// WithNodeCapacity("32", "256Gi", 110). The allocatable resources of the nodes are set to the same values.
+// CreateNodes returns an error, without creating any node, when cpu or memory is not a valid quantity.
+func WithNodeCapacity(cpu, memory string, pods int64) NodeOption {
+	return func(node *v1.Node) error {
+		cpuQuantity, err := resource.ParseQuantity(cpu)
+		if err != nil {
+			return fmt.Errorf("invalid cpu capacity %q: %w", cpu, err)
+		}
+		memoryQuantity, err := resource.ParseQuantity(memory)
+		if err != nil {
+			return fmt.Errorf("invalid memory capacity %q: %w", memory, err)
+		}
+		capacity := v1.ResourceList{
+			v1.ResourceCPU:    cpuQuantity,
+			v1.ResourceMemory: memoryQuantity,
+			v1.ResourcePods:   *resource.NewQuantity(pods, resource.DecimalSI),
+		}
+		node.Status.Capacity = capacity
+		node.Status.Allocatable = capacity.DeepCopy()
+		return nil
+	}
+}
+
+// WithNodeLabels is used to add labels to the simulated nodes
+func WithNodeLabels(labels map[string]string) NodeOption {
+	return func(node *v1.Node) error {
+		for k, v := range labels {
+			node.Labels[k] = v
+		}
+		return nil
+	}
+}
+
+// WithoutNodeTaint is used to remove the kwok.x-k8s.io/node taint from the simulated nodes, so
+// that pods without the matching toleration can be scheduled on them
+func WithoutNodeTaint() NodeOption {
+	return func(node *v1.Node) error {
+		node.Spec.Taints = nil
+		return nil
+	}
+}
+
+// defaultNodeCapacity is the capacity of the simulated nodes unless WithNodeCapacity is used
+var defaultNodeCapacity = WithNodeCapacity("32", "256Gi", 110)
+
+// newNode returns a simulated node following the node template documented by kwok
+func newNode(name string, opts ...NodeOption) (*v1.Node, error) {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{NodeAnnotation: "fake"},
+			Labels: map[string]string{
+				"kubernetes.io/hostname":        name,
+				"kubernetes.io/os":              "linux",
+				"kubernetes.io/role":            "agent",
+				"node-role.kubernetes.io/agent": "",
+				"type":                          "kwok",
+			},
+		},
+		Spec: v1.NodeSpec{
+			Taints: []v1.Taint{{Key: NodeTaintKey, Value: "fake", Effect: v1.TaintEffectNoSchedule}},
+		},
+	}
+	for _, opt := range append([]NodeOption{defaultNodeCapacity}, opts...) {
+		if err := opt(node); err != nil {
+			return nil, fmt.Errorf("kwok: node %q: %w", name, err)
+		}
+	}
+	return node, nil
+}
+
+// ScaleNodes scales the number of simulated nodes of the cluster to replicas using `kwokctl scale node`
+func (k *Cluster) ScaleNodes(ctx context.Context, replicas int, args ...string) error {
+	if err := k.findOrInstallKwokCtl(); err != nil {
+		return err
+	}
+	command := fmt.Sprintf("%s scale node --name %s --replicas %d", k.path, k.name, replicas)
+	for _, arg := range args {
+		command = fmt.Sprintf("%s %s", command, arg)
+	}
+	klog.V(4).InfoS("Scaling kwok nodes", "command", command)
+	p := utils.RunCommand(command)
+	if p.Err() != nil || (p.Exited() && p.ExitCode() != 0) {
+		return fmt.Errorf("kwok: failed to scale nodes of cluster %q: %s: %s", k.name, p.Err(), p.Result())
+	}
+	return nil
+}
+
+// CreateNodes creates count simulated nodes named <prefix>-<index> through the api server. Unlike ScaleNodes,
+// the nodes can be customized with NodeOption, e.g. to simulate nodes with different capacities.
+func (k *Cluster) CreateNodes(ctx context.Context, prefix string, count int, opts ...NodeOption) error {
+	nodes := make([]*v1.Node, 0, count)
+	for i := 0; i < count; i++ {
+		node, err := newNode(fmt.Sprintf("%s-%d", prefix, i), opts...)
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, node)
+	}
+	r, err := k.resources()
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if err := r.Create(ctx, node); err != nil {
+			return fmt.Errorf("kwok: failed to create node %q: %w", node.Name, err)
+		}
+	}
+	return nil
+}
+
+// ApplyStages creates the Stage objects found in the manifest file at path. The kwok controller
+// of the cluster has to be configured with the Stage CRD enabled for the stages to be taken into account.
+func (k *Cluster) ApplyStages(ctx context.Context, path string) error {
+	r, err := k.resources()
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("kwok: failed to open stages manifest: %w", err)
+	}
+	defer f.Close()
+	if err := decoder.DecodeEach(ctx, f, decoder.CreateIgnoreAlreadyExists(r)); err != nil {
+		return fmt.Errorf("kwok: failed to apply stages from %q: %w", path, err)
+	}
+	return nil
+}
+
+func (k *Cluster) resources() (*resources.Resources, error) {
+	if k.rc == nil {
+		return nil, fmt.Errorf("kwok: cluster %q has not been created", k.name)
+	}
+	return resources.New(k.rc)
+}
+
+// clusterFromContext retrieves the kwok cluster saved in the context using the cluster name
+func clusterFromContext(ctx context.Context, clusterName string) (*Cluster, error) {
+	clusterVal := ctx.Value(support.ClusterNameContextKey(clusterName))
+	if clusterVal == nil {
+		return nil, fmt.Errorf("context cluster is nil")
+	}
+	cluster, ok := clusterVal.(*Cluster)
+	if !ok {
+		return nil, fmt.Errorf("cluster %q is not a kwok cluster", clusterName)
+	}
+	return cluster, nil
+}
+
+// CreateNodes returns an env.Func that creates count simulated nodes in the kwok cluster saved in
+// the context using clusterName
+func CreateNodes(clusterName, prefix string, count int, opts ...NodeOption) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		cluster, err := clusterFromContext(ctx, clusterName)
+		if err != nil {
+			return ctx, fmt.Errorf("create kwok nodes func: %w", err)
+		}
+		return ctx, cluster.CreateNodes(ctx, prefix, count, opts...)
+	}
+}
+
+// ApplyStages returns an env.Func that creates the Stage objects found in the manifest file at path
+// in the kwok cluster saved in the context using clusterName
+func ApplyStages(clusterName, path string) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		cluster, err := clusterFromContext(ctx, clusterName)
+		if err != nil {
+			return ctx, fmt.Errorf("apply kwok stages func: %w", err)
+		}
+		return ctx, cluster.ApplyStages(ctx, path)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kwok
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestNewNode(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []NodeOption
+		wantCPU    string
+		wantMemory string
+		wantTaints int
+		wantErr    bool
+	}{
+		{name: "defaults", wantCPU: "32", wantMemory: "256Gi", wantTaints: 1},
+		{
+			name:       "custom capacity without taint",
+			opts:       []NodeOption{WithNodeCapacity("4", "16Gi", 30), WithoutNodeTaint()},
+			wantCPU:    "4",
+			wantMemory: "16Gi",
+		},
+		{name: "invalid cpu", opts: []NodeOption{WithNodeCapacity("four", "16Gi", 30)}, wantErr: true},
+		{name: "invalid memory", opts: []NodeOption{WithNodeCapacity("4", "16 GB", 30)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := newNode("kwok-0", tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newNode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			cpu, memory := node.Status.Allocatable[v1.ResourceCPU], node.Status.Allocatable[v1.ResourceMemory]
+			if cpu.String() != tt.wantCPU || memory.String() != tt.wantMemory {
+				t.Errorf("newNode() allocatable = %s/%s, want %s/%s", cpu.String(), memory.String(), tt.wantCPU, tt.wantMemory)
+			}
+			if len(node.Spec.Taints) != tt.wantTaints {
+				t.Errorf("newNode() taints = %v, want %d", node.Spec.Taints, tt.wantTaints)
+			}
+		})
+	}
+}
+
+func TestCreateNodesInvalidOption(t *testing.T) {
+	// the options are validated before the cluster is accessed
+	err := NewCluster("kwok").CreateNodes(context.TODO(), "kwok", 2, WithNodeCapacity("4", "lots", 30))
+	if err == nil || !strings.Contains(err.Error(), "invalid memory capacity") {
+		t.Fatalf("expected an error for an invalid memory capacity, got %v", err)
+	}
+}
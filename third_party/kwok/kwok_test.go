@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kwok
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+func TestCluster_snapshotCommand(t *testing.T) {
+	k := NewCluster("test").SetDefaults().(*Cluster)
+	tests := []struct {
+		operation string
+		want      string
+	}{
+		{operation: "save", want: "kwokctl snapshot save --name test --path /tmp/snapshots/test.db"},
+		{operation: "restore", want: "kwokctl snapshot restore --name test --path /tmp/snapshots/test.db"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			if got := k.snapshotCommand(tt.operation, "/tmp/snapshots/test.db"); got != tt.want {
+				t.Errorf("snapshotCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCluster_RestoreMissingSnapshot(t *testing.T) {
+	// the snapshot file is checked before kwokctl is looked up
+	err := NewCluster("test").Restore(context.TODO(), filepath.Join(t.TempDir(), "missing.db"))
+	if err == nil || !strings.Contains(err.Error(), "snapshot not found") {
+		t.Errorf("expected an error for a missing snapshot, got %v", err)
+	}
+}
+
+func TestCluster_Capabilities(t *testing.T) {
+	caps := support.CapabilitiesOf(NewCluster("test"))
+	// the nodes of a kwok cluster do not run containers, images cannot be loaded into them
+	if caps.Has(support.CapabilityImageLoad) {
+		t.Error("expected the kwok provider not to report the image load capability")
+	}
+	for _, capability := range []support.Capability{support.CapabilitySnapshot, support.CapabilityLogsExport} {
+		if !caps.Has(capability) {
+			t.Errorf("expected the kwok provider to report the %s capability", capability)
+		}
+	}
+}
@@ -0,0 +1,468 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minikube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vladimirvivien/gexe"
+	"k8s.io/client-go/rest"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient"
+	"sigs.k8s.io/e2e-framework/klient/conf"
+	"sigs.k8s.io/e2e-framework/pkg/utils"
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+const minikubePath = "minikube"
+
+type Cluster struct {
+	path        string
+	name        string
+	kubecfgFile string
+	version     string
+	driver      string
+	nodes       int
+	addons      []string
+	args        []string
+	rc          *rest.Config
+}
+
+// minikubeProfiles is a subset of the `minikube profile list -o json` output that is used
+// to identify if a cluster already exists
+type minikubeProfiles struct {
+	Valid []struct {
+		Name string `json:"Name"`
+	} `json:"valid"`
+	Invalid []struct {
+		Name string `json:"Name"`
+	} `json:"invalid"`
+}
+
+var (
+	_ support.E2EClusterProviderWithImageLoader = &Cluster{}
+	_ support.E2EClusterProviderWithLifeCycle   = &Cluster{}
+)
+
+func NewCluster(name string) *Cluster {
+	return &Cluster{name: name}
+}
+
+func NewProvider() support.E2EClusterProvider {
+	return &Cluster{}
+}
+
+// WithDriver is used to configure the minikube driver (docker, podman, kvm2, ...) used to run the cluster
+func WithDriver(driver string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		m, ok := c.(*Cluster)
+		if ok {
+			m.driver = driver
+		}
+	}
+}
+
+// WithNodes is used to configure the number of nodes the minikube cluster is created with
+func WithNodes(nodes int) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		m, ok := c.(*Cluster)
+		if ok {
+			m.nodes = nodes
+		}
+	}
+}
+
+// WithAddons is used to configure the minikube addons enabled while creating the cluster
+func WithAddons(addons ...string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		m, ok := c.(*Cluster)
+		if ok {
+			m.addons = append(m.addons, addons...)
+		}
+	}
+}
+
+// WithArgs is used to pass additional arguments to the `minikube start` command
+func WithArgs(args ...string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		m, ok := c.(*Cluster)
+		if ok {
+			m.args = append(m.args, args...)
+		}
+	}
+}
+
+func WithPath(path string) support.ClusterOpts {
+	return func(c support.E2EClusterProvider) {
+		m, ok := c.(*Cluster)
+		if ok {
+			m.path = path
+		}
+	}
+}
+
+func (c *Cluster) WithName(name string) support.E2EClusterProvider {
+	c.name = name
+	return c
+}
+
+// WithVersion is used to configure the kubernetes version of the minikube cluster
+func (c *Cluster) WithVersion(version string) support.E2EClusterProvider {
+	c.version = version
+	return c
+}
+
+func (c *Cluster) WithPath(path string) support.E2EClusterProvider {
+	c.path = path
+	return c
+}
+
+func (c *Cluster) WithOpts(opts ...support.ClusterOpts) support.E2EClusterProvider {
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+func (c *Cluster) SetDefaults() support.E2EClusterProvider {
+	if c.path == "" {
+		c.path = minikubePath
+	}
+	return c
+}
+
+// run invokes a minikube command with the KUBECONFIG environment variable of the minikube process
+// pointing to the kubeconfig file of the cluster, so that the user's default kubeconfig is left
+// untouched. The environment of the test process is not modified.
+func (c *Cluster) run(command string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	p := utils.NewProcWithKubeconfig(command, c.kubecfgFile)
+	p.SetStdout(&stdout)
+	p.SetStderr(&stderr)
+	p.Run()
+	if p.Err() != nil {
+		return "", fmt.Errorf("%w: %s", p.Err(), strings.TrimSpace(stderr.String()))
+	}
+	if p.Exited() && p.ExitCode() != 0 {
+		return "", fmt.Errorf("exit code %d: %s: %s", p.ExitCode(), strings.TrimSpace(stdout.String()), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (c *Cluster) findMinikube() error {
+	if gexe.ProgAvail(c.path) == "" {
+		return fmt.Errorf("'%s' command is missing. Please ensure the tool exists before using the minikube provider", c.path)
+	}
+	return nil
+}
+
+func (c *Cluster) clusterExists(name string) (string, bool) {
+	out, err := c.run(fmt.Sprintf("%s profile list --output json", c.path))
+	if err != nil {
+		return out, false
+	}
+	return out, profileExists(out, name)
+}
+
+// profileExists checks if the output of `minikube profile list --output json` lists the profile name,
+// whether it is valid or not
+func profileExists(out, name string) bool {
+	var profiles minikubeProfiles
+	if err := json.Unmarshal([]byte(out), &profiles); err != nil {
+		return false
+	}
+	for _, p := range profiles.Valid {
+		if p.Name == name {
+			return true
+		}
+	}
+	for _, p := range profiles.Invalid {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cluster) initKubeconfigFile() error {
+	if c.kubecfgFile != "" {
+		return nil
+	}
+	file, err := os.CreateTemp("", fmt.Sprintf("minikube-cluster-%s-kubecfg", c.name))
+	if err != nil {
+		return fmt.Errorf("minikube kubeconfig file: %w", err)
+	}
+	defer file.Close()
+	c.kubecfgFile = file.Name()
+	return nil
+}
+
+func (c *Cluster) initKubernetesAccessClients() error {
+	cfg, err := conf.New(c.kubecfgFile)
+	if err != nil {
+		return err
+	}
+	c.rc = cfg
+	return nil
+}
+
+func (c *Cluster) Create(ctx context.Context, args ...string) (string, error) {
+	log.V(4).InfoS("Creating minikube cluster", "name", c.name)
+	if err := c.findMinikube(); err != nil {
+		return "", err
+	}
+	if err := c.initKubeconfigFile(); err != nil {
+		return "", err
+	}
+
+	// minikube start is idempotent and restarts a stopped cluster, so an existing
+	// cluster only needs its context to be written into the kubeconfig file
+	if _, ok := c.clusterExists(c.name); ok {
+		log.V(4).InfoS("Skipping minikube cluster creation. Cluster already exists", "name", c.name)
+		if _, err := c.run(fmt.Sprintf("%s update-context --profile %s", c.path, c.name)); err != nil {
+			return "", fmt.Errorf("minikube: failed to update context of cluster %q: %w", c.name, err)
+		}
+		return c.kubecfgFile, c.initKubernetesAccessClients()
+	}
+
+	if c.version != "" {
+		args = append(args, "--kubernetes-version", c.version)
+	}
+	if c.driver != "" {
+		args = append(args, "--driver", c.driver)
+	}
+	if c.nodes > 0 {
+		args = append(args, "--nodes", fmt.Sprint(c.nodes))
+	}
+	for _, addon := range c.addons {
+		args = append(args, "--addons", addon)
+	}
+	args = append(args, c.args...)
+
+	command := fmt.Sprintf("%s start --profile %s", c.path, c.name)
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	log.V(4).InfoS("Launching minikube cluster", "command", command)
+	if _, err := c.run(command); err != nil {
+		return "", fmt.Errorf("minikube: failed to create cluster %q: %w", c.name, err)
+	}
+
+	clusters, ok := c.clusterExists(c.name)
+	if !ok {
+		return "", fmt.Errorf("minikube Cluster.Create: cluster %v still not in 'profile list' after creation: %v", c.name, clusters)
+	}
+	return c.kubecfgFile, c.initKubernetesAccessClients()
+}
+
+// CreateWithConfig creates the cluster using the minikube config file provided. minikube does not
+// support a cluster config file, so the file is expected to be a JSON object in the format of
+// ~/.minikube/config/config.json whose keys are passed as flags to `minikube start`.
+func (c *Cluster) CreateWithConfig(ctx context.Context, configFile string) (string, error) {
+	if configFile == "" {
+		return c.Create(ctx)
+	}
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return "", fmt.Errorf("minikube: failed to read config file %q: %w", configFile, err)
+	}
+	args, err := configArgs(data)
+	if err != nil {
+		return "", fmt.Errorf("minikube: failed to parse config file %q: %w", configFile, err)
+	}
+	return c.Create(ctx, args...)
+}
+
+// configArgs converts the settings of a minikube config file into `minikube start` flags, sorted by name
+func configArgs(data []byte) ([]string, error) {
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var args []string
+	for _, k := range keys {
+		args = append(args, fmt.Sprintf("--%s=%v", k, settings[k]))
+	}
+	return args, nil
+}
+
+func (c *Cluster) GetKubeconfig() string {
+	return c.kubecfgFile
+}
+
+func (c *Cluster) GetKubectlContext() string {
+	return c.name
+}
+
+// Capabilities declares the capabilities of the minikube provider that are not inferred by support.CapabilitiesOf
+func (c *Cluster) Capabilities() support.Capabilities {
+	return support.Capabilities{support.CapabilityLogsExport: true}
+}
+
+// ExportLogs writes the output of `minikube logs` into a minikube.log file in the dest directory
+func (c *Cluster) ExportLogs(ctx context.Context, dest string) error {
+	log.V(4).InfoS("Exporting minikube cluster logs", "name", c.name, "dest", dest)
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return fmt.Errorf("minikube: failed to create logs directory %q: %w", dest, err)
+	}
+	logFile := filepath.Join(dest, "minikube.log")
+	if _, err := c.run(fmt.Sprintf("%s logs --profile %s --file %s", c.path, c.name, logFile)); err != nil {
+		return fmt.Errorf("minikube: export cluster %v logs failed: %w", c.name, err)
+	}
+	return nil
+}
+
+func (c *Cluster) Destroy(ctx context.Context) error {
+	log.V(4).InfoS("Destroying minikube cluster", "name", c.name)
+	if err := c.findMinikube(); err != nil {
+		return err
+	}
+
+	if _, err := c.run(fmt.Sprintf("%s delete --profile %s", c.path, c.name)); err != nil {
+		return fmt.Errorf("minikube: failed to delete cluster %q: %w", c.name, err)
+	}
+
+	log.V(4).InfoS("Removing kubeconfig file", "configFile", c.kubecfgFile)
+	if err := os.RemoveAll(c.kubecfgFile); err != nil {
+		return fmt.Errorf("minikube: failed to remove kubeconfig file %q: %w", c.kubecfgFile, err)
+	}
+	return nil
+}
+
+func (c *Cluster) WaitForControlPlane(ctx context.Context, client klient.Client) error {
+	log.V(4).Info("minikube provider doesn't implement a WaitForControlPlane as minikube start waits for the control plane")
+	return nil
+}
+
+func (c *Cluster) KubernetesRestConfig() *rest.Config {
+	return c.rc
+}
+
+// EnableAddon enables a minikube addon such as ingress or metrics-server on a running cluster
+func (c *Cluster) EnableAddon(ctx context.Context, addon string) error {
+	log.V(4).InfoS("Enabling minikube addon", "cluster", c.name, "addon", addon)
+	if _, err := c.run(fmt.Sprintf("%s addons enable %s --profile %s", c.path, addon, c.name)); err != nil {
+		return fmt.Errorf("minikube: failed to enable addon %q: %w", addon, err)
+	}
+	return nil
+}
+
+func (c *Cluster) LoadImage(ctx context.Context, image string, args ...string) error {
+	log.V(4).InfoS("Performing Image load operation", "cluster", c.name, "image", image, "args", args)
+	command := fmt.Sprintf("%s image load %s --profile %s", c.path, image, c.name)
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	if _, err := c.run(command); err != nil {
+		return fmt.Errorf("minikube: load image %v failed: %w", image, err)
+	}
+	return nil
+}
+
+func (c *Cluster) LoadImageArchive(ctx context.Context, imageArchive string, args ...string) error {
+	return c.LoadImage(ctx, imageArchive, args...)
+}
+
+// AddNode adds a new node to the cluster. minikube assigns the name of the node, so only the
+// control-plane role of the node is taken into account.
+func (c *Cluster) AddNode(ctx context.Context, node *support.Node, args ...string) error {
+	command := fmt.Sprintf("%s node add --profile %s", c.path, c.name)
+	if node.Role == "control-plane" || node.Role == "server" {
+		command = fmt.Sprintf("%s --control-plane", command)
+	}
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	log.V(4).InfoS("Adding node to minikube cluster", "command", command)
+	if _, err := c.run(command); err != nil {
+		return fmt.Errorf("minikube: failed to add node to cluster %q: %w", c.name, err)
+	}
+	return nil
+}
+
+func (c *Cluster) RemoveNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodeOperation("delete", node, args...)
+}
+
+func (c *Cluster) StartNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodeOperation("start", node, args...)
+}
+
+func (c *Cluster) StopNode(ctx context.Context, node *support.Node, args ...string) error {
+	return c.nodeOperation("stop", node, args...)
+}
+
+func (c *Cluster) nodeOperation(operation string, node *support.Node, args ...string) error {
+	command := fmt.Sprintf("%s node %s %s --profile %s", c.path, operation, node.Name, c.name)
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	log.V(4).InfoS("Performing node operation on minikube cluster", "command", command)
+	if _, err := c.run(command); err != nil {
+		return fmt.Errorf("minikube: failed to %s node %q in cluster %q: %w", operation, node.Name, c.name, err)
+	}
+	return nil
+}
+
+// ListNode returns the nodes of the cluster using `minikube node list`, which reports the
+// name and IP address of each node
+func (c *Cluster) ListNode(ctx context.Context, args ...string) ([]support.Node, error) {
+	out, err := c.run(c.listNodeCommand(args...))
+	if err != nil {
+		return nil, fmt.Errorf("minikube: failed to list nodes: %w", err)
+	}
+	return parseNodeList(c.name, out), nil
+}
+
+// listNodeCommand returns the minikube command listing the nodes of the cluster, with the args passed to ListNode
+func (c *Cluster) listNodeCommand(args ...string) string {
+	command := fmt.Sprintf("%s node list --profile %s", c.path, c.name)
+	if len(args) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+	}
+	return command
+}
+
+func parseNodeList(cluster, out string) []support.Node {
+	var nodes []support.Node
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		node := support.Node{Name: fields[0], Cluster: cluster}
+		if len(fields) > 1 {
+			node.IP = net.ParseIP(fields[1])
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minikube
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/support"
+)
+
+func TestParseNodeList(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want []support.Node
+	}{
+		{name: "no nodes"},
+		{
+			name: "nodes",
+			out:  "e2e\t192.168.49.2\ne2e-m02\t192.168.49.3\n\n",
+			want: []support.Node{
+				{Name: "e2e", Cluster: "e2e", IP: net.ParseIP("192.168.49.2")},
+				{Name: "e2e-m02", Cluster: "e2e", IP: net.ParseIP("192.168.49.3")},
+			},
+		},
+		{
+			name: "node without address",
+			out:  "e2e",
+			want: []support.Node{{Name: "e2e", Cluster: "e2e"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseNodeList("e2e", tt.out); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNodeList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfileExists(t *testing.T) {
+	out := `{"invalid":[{"Name":"broken"}],"valid":[{"Name":"e2e","Status":"Running"}]}`
+	tests := []struct {
+		name    string
+		out     string
+		profile string
+		want    bool
+	}{
+		{name: "valid profile", out: out, profile: "e2e", want: true},
+		{name: "invalid profile", out: out, profile: "broken", want: true},
+		{name: "missing profile", out: out, profile: "other"},
+		{name: "unexpected output", out: "no profiles", profile: "e2e"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := profileExists(tt.out, tt.profile); got != tt.want {
+				t.Errorf("profileExists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty config", data: "{}"},
+		{
+			name: "settings sorted by name",
+			data: `{"memory": 4096, "driver": "docker", "embed-certs": true}`,
+			want: []string{"--driver=docker", "--embed-certs=true", "--memory=4096"},
+		},
+		{name: "invalid config", data: "driver: docker", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := configArgs([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("configArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("configArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterRunKubeconfig(t *testing.T) {
+	kubecfg := filepath.Join(t.TempDir(), "kubeconfig")
+	t.Setenv("KUBECONFIG", "/home/user/.kube/config")
+	c := &Cluster{kubecfgFile: kubecfg}
+	out, err := c.run("env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "KUBECONFIG="+kubecfg) {
+		t.Errorf("expected the command to use the kubeconfig of the cluster, got environment:\n%s", out)
+	}
+	if got := os.Getenv("KUBECONFIG"); got != "/home/user/.kube/config" {
+		t.Errorf("expected the KUBECONFIG of the test process to be left untouched, got %q", got)
+	}
+}
+
+func TestCluster_listNodeCommand(t *testing.T) {
+	c := &Cluster{path: "minikube", name: "e2e"}
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "no args", want: "minikube node list --profile e2e"},
+		{name: "args", args: []string{"--alsologtostderr"}, want: "minikube node list --profile e2e --alsologtostderr"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.listNodeCommand(tt.args...); got != tt.want {
+				t.Errorf("listNodeCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterRunFailure(t *testing.T) {
+	c := &Cluster{}
+	_, err := c.run("sh -c 'echo failed >&2; exit 3'")
+	if err == nil || strings.Contains(err.Error(), "<nil>") || !strings.HasSuffix(err.Error(), ": failed") {
+		t.Errorf("expected the exit code and stderr of the command, got %v", err)
+	}
+}
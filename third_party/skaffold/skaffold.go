@@ -0,0 +1,309 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package skaffold
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	log "k8s.io/klog/v2"
+)
+
+type Opts struct {
+	// File is the path of the skaffold configuration. Defaults to
+	// skaffold.yaml in the working directory
+	File string
+	// WorkDir is the directory skaffold runs in, which the paths of the
+	// skaffold configuration are relative to
+	WorkDir string
+	// Profiles are the skaffold profiles activated
+	Profiles []string
+	// Namespace is the namespace the manifests are deployed to
+	Namespace string
+	// KubeContext is the kubeconfig context used by skaffold
+	KubeContext string
+	// DefaultRepo is the registry the image names are prefixed with
+	DefaultRepo string
+	// Tag overrides the tag of the built images
+	Tag string
+	// Push sets whether the built images are pushed to their registry. When
+	// unset, skaffold decides based on the kube context
+	Push *bool
+	// Args is used to pass any additional arguments to the skaffold command
+	Args []string
+	// mode is the skaffold sub command being run
+	mode []string
+}
+
+// Artifact is an image built by skaffold, as reported by
+// `skaffold build --file-output`
+type Artifact struct {
+	// ImageName is the name of the image in the skaffold configuration
+	ImageName string `json:"imageName"`
+	// Tag is the full reference of the built image, including its tag and,
+	// once pushed, its digest
+	Tag string `json:"tag"`
+}
+
+// buildOutput is the format of the files written by `skaffold build
+// --file-output` and read by `skaffold deploy --build-artifacts`
+type buildOutput struct {
+	Builds []Artifact `json:"builds"`
+}
+
+type Manager struct {
+	kubeConfig string
+	path       string
+}
+
+type Option func(*Opts)
+
+const (
+	missingSkaffold = "'skaffold' command is missing. Please ensure the tool exists before using the skaffold manager"
+)
+
+// WithFile is used to configure the path of the skaffold configuration
+func WithFile(path string) Option {
+	return func(opts *Opts) {
+		opts.File = path
+	}
+}
+
+// WithWorkDir is used to configure the directory skaffold runs in
+func WithWorkDir(dir string) Option {
+	return func(opts *Opts) {
+		opts.WorkDir = dir
+	}
+}
+
+// WithProfiles is used to activate skaffold profiles
+func WithProfiles(profiles ...string) Option {
+	return func(opts *Opts) {
+		opts.Profiles = append(opts.Profiles, profiles...)
+	}
+}
+
+// WithNamespace is used to configure the namespace the manifests are
+// deployed to
+func WithNamespace(namespace string) Option {
+	return func(opts *Opts) {
+		opts.Namespace = namespace
+	}
+}
+
+// WithKubeContext is used to configure the kubeconfig context used by skaffold
+func WithKubeContext(kubeContext string) Option {
+	return func(opts *Opts) {
+		opts.KubeContext = kubeContext
+	}
+}
+
+// WithDefaultRepo is used to configure the registry the image names are
+// prefixed with
+func WithDefaultRepo(repo string) Option {
+	return func(opts *Opts) {
+		opts.DefaultRepo = repo
+	}
+}
+
+// WithTag is used to override the tag of the built images
+func WithTag(tag string) Option {
+	return func(opts *Opts) {
+		opts.Tag = tag
+	}
+}
+
+// WithPush is used to configure whether the built images are pushed to
+// their registry. Images that are loaded into a kind cluster with
+// LoadArtifactsToCluster do not need to be pushed.
+func WithPush(push bool) Option {
+	return func(opts *Opts) {
+		opts.Push = &push
+	}
+}
+
+// WithArgs is used to pass any additional arguments to the skaffold command.
+// Each argument is passed as is without any shell processing.
+func WithArgs(args ...string) Option {
+	return func(opts *Opts) {
+		opts.Args = append(opts.Args, args...)
+	}
+}
+
+// processOpts is used to generate the Opts resource that will be used to
+// generate the actual skaffold command to be run using the getArgs helper
+func (m *Manager) processOpts(opts ...Option) *Opts {
+	option := &Opts{}
+	for _, op := range opts {
+		op(option)
+	}
+	return option
+}
+
+// getArgs is used to convert the Opts into the arguments passed to skaffold
+func (m *Manager) getArgs(opt *Opts) []string {
+	args := append([]string{}, opt.mode...)
+	if opt.File != "" {
+		args = append(args, "--filename", opt.File)
+	}
+	if len(opt.Profiles) > 0 {
+		args = append(args, "--profile", strings.Join(opt.Profiles, ","))
+	}
+	if opt.Namespace != "" {
+		args = append(args, "--namespace", opt.Namespace)
+	}
+	if opt.KubeContext != "" {
+		args = append(args, "--kube-context", opt.KubeContext)
+	}
+	if opt.DefaultRepo != "" {
+		args = append(args, "--default-repo", opt.DefaultRepo)
+	}
+	if opt.Tag != "" {
+		args = append(args, "--tag", opt.Tag)
+	}
+	if opt.Push != nil {
+		args = append(args, "--push="+strconv.FormatBool(*opt.Push))
+	}
+	args = append(args, opt.Args...)
+	if m.kubeConfig != "" {
+		args = append(args, "--kubeconfig", m.kubeConfig)
+	}
+	return args
+}
+
+// Build builds the artifacts of the skaffold configuration with
+// `skaffold build` and returns the references of the built images
+func (m *Manager) Build(ctx context.Context, opts ...Option) ([]Artifact, error) {
+	dir, err := os.MkdirTemp("", "skaffold")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "build.json")
+
+	o := m.processOpts(opts...)
+	o.mode = []string{"build", "--file-output", output}
+	if _, err := m.run(ctx, o); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skaffold build output: %w", err)
+	}
+	var build buildOutput
+	if err := json.Unmarshal(data, &build); err != nil {
+		return nil, fmt.Errorf("failed to decode skaffold build output: %w", err)
+	}
+	return build.Builds, nil
+}
+
+// Deploy deploys the manifests of the skaffold configuration with
+// `skaffold deploy`, using the given artifacts as images instead of
+// building them again
+func (m *Manager) Deploy(ctx context.Context, artifacts []Artifact, opts ...Option) error {
+	dir, err := os.MkdirTemp("", "skaffold")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	input := filepath.Join(dir, "build.json")
+	data, err := json.Marshal(buildOutput{Builds: artifacts})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(input, data, 0o600); err != nil {
+		return err
+	}
+
+	o := m.processOpts(opts...)
+	o.mode = []string{"deploy", "--build-artifacts", input}
+	_, err = m.run(ctx, o)
+	return err
+}
+
+// Delete deletes the resources deployed by the skaffold configuration with
+// `skaffold delete`
+func (m *Manager) Delete(ctx context.Context, opts ...Option) error {
+	o := m.processOpts(opts...)
+	o.mode = []string{"delete"}
+	o.DefaultRepo, o.Tag, o.Push = "", "", nil
+	_, err := m.run(ctx, o)
+	return err
+}
+
+// Run invokes skaffold with the arguments configured with WithArgs and
+// returns its standard output
+func (m *Manager) Run(ctx context.Context, opts ...Option) (string, error) {
+	return m.run(ctx, m.processOpts(opts...))
+}
+
+// run method is used to invoke a skaffold command to perform a suitable
+// operation. Please make sure to configure the right Opts using the Option
+// helpers
+func (m *Manager) run(ctx context.Context, opts *Opts) (string, error) {
+	log.V(4).InfoS("Determining if skaffold binary is available or not", "executable", m.path)
+	executable, err := exec.LookPath(m.path)
+	if err != nil {
+		return "", errors.New(missingSkaffold)
+	}
+	if len(opts.mode) == 0 && len(opts.Args) == 0 {
+		return "", errors.New("missing skaffold sub command. Please use the WithArgs option while invoking the run")
+	}
+	args := m.getArgs(opts)
+
+	log.V(4).InfoS("Running Skaffold Operation", "command", m.path+" "+strings.Join(args, " "), "dir", opts.WorkDir)
+	cmd := exec.CommandContext(ctx, executable, args...)
+	cmd.Dir = opts.WorkDir
+
+	var stderr bytes.Buffer
+	var stdout bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdout = &stdout
+
+	err = cmd.Run()
+	log.V(4).Info("Skaffold Command output \n", stdout.String())
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSuffix(stderr.String(), "\n"), err)
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+// WithPath is used to provide a custom path where the `skaffold` executable
+// command can be found. This is useful in case if your binary is in a non
+// standard location and you want to framework to use that instead of
+// returning an error.
+func (m *Manager) WithPath(path string) *Manager {
+	m.path = path
+	return m
+}
+
+// New creates a skaffold Manager that deploys to the cluster identified by
+// the kubeConfig file.
+func New(kubeConfig string) *Manager {
+	return &Manager{
+		kubeConfig: kubeConfig,
+		path:       "skaffold",
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package skaffold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/e2e-framework/pkg/ctxutil"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+	"sigs.k8s.io/e2e-framework/pkg/envfuncs"
+)
+
+type artifactsContextKey struct{}
+
+// ArtifactsFromContext returns the artifacts built by Build and stored in
+// the context
+func ArtifactsFromContext(ctx context.Context) ([]Artifact, bool) {
+	return ctxutil.Load[[]Artifact](ctx, artifactsContextKey{})
+}
+
+// ImageFromContext returns the reference of the image built by Build for the
+// image name of the skaffold configuration, to be used in the manifests
+// created by the tests
+func ImageFromContext(ctx context.Context, imageName string) (string, bool) {
+	artifacts, _ := ArtifactsFromContext(ctx)
+	for _, artifact := range artifacts {
+		if artifact.ImageName == imageName {
+			return artifact.Tag, true
+		}
+	}
+	return "", false
+}
+
+// Build returns an env.Func that builds the artifacts of the skaffold
+// configuration and stores them in the context, where they are found by
+// LoadArtifactsToCluster, Deploy and ImageFromContext
+func Build(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		artifacts, err := New(c.KubeconfigFile()).Build(ctx, opts...)
+		if err != nil {
+			return ctx, fmt.Errorf("skaffold build failed: %w", err)
+		}
+		return ctxutil.Store(ctx, artifactsContextKey{}, artifacts), nil
+	}
+}
+
+// LoadArtifactsToCluster returns an env.Func that loads the images built by
+// Build into the cluster identified by clusterName with
+// envfuncs.LoadImageToCluster, so that they do not have to be pushed to a
+// registry. Build should then be used with WithPush(false).
+func LoadArtifactsToCluster(clusterName string, args ...string) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		artifacts, ok := ArtifactsFromContext(ctx)
+		if !ok {
+			return ctx, errors.New("no skaffold artifacts found in context, use skaffold.Build first")
+		}
+		for _, artifact := range artifacts {
+			var err error
+			if ctx, err = envfuncs.LoadImageToCluster(clusterName, artifact.Tag, args...)(ctx, c); err != nil {
+				return ctx, fmt.Errorf("loading skaffold artifact %s failed: %w", artifact.ImageName, err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// Deploy returns an env.Func that deploys the manifests of the skaffold
+// configuration with the artifacts built by Build
+func Deploy(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		artifacts, ok := ArtifactsFromContext(ctx)
+		if !ok {
+			return ctx, errors.New("no skaffold artifacts found in context, use skaffold.Build first")
+		}
+		if err := New(c.KubeconfigFile()).Deploy(ctx, artifacts, opts...); err != nil {
+			return ctx, fmt.Errorf("skaffold deploy failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// Delete returns an env.Func that deletes the resources deployed by the
+// skaffold configuration
+func Delete(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := New(c.KubeconfigFile()).Delete(ctx, opts...); err != nil {
+			return ctx, fmt.Errorf("skaffold delete failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
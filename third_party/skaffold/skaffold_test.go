@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package skaffold
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/e2e-framework/pkg/ctxutil"
+)
+
+func TestManager_getArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		kubeConfig string
+		opts       []Option
+		want       []string
+	}{
+		{
+			name: "args only",
+			opts: []Option{WithArgs("diagnose")},
+			want: []string{"diagnose"},
+		},
+		{
+			name:       "profiles, namespace and kubeconfig",
+			kubeConfig: "/tmp/kubeconfig",
+			opts:       []Option{WithFile("skaffold.yaml"), WithProfiles("e2e", "kind"), WithNamespace("apps"), WithKubeContext("kind-e2e")},
+			want:       []string{"--filename", "skaffold.yaml", "--profile", "e2e,kind", "--namespace", "apps", "--kube-context", "kind-e2e", "--kubeconfig", "/tmp/kubeconfig"},
+		},
+		{
+			name: "image options",
+			opts: []Option{WithDefaultRepo("registry.example.com"), WithTag("e2e"), WithPush(false)},
+			want: []string{"--default-repo", "registry.example.com", "--tag", "e2e", "--push=false"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(tt.kubeConfig)
+			if got := m.getArgs(m.processOpts(tt.opts...)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_BuildAndDeploy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a shell script")
+	}
+	// the fake skaffold writes a build output and records the artifacts it deploys
+	dir := t.TempDir()
+	record := filepath.Join(dir, "deployed")
+	script := `#!/bin/sh
+while [ $# -gt 0 ]; do
+  case "$1" in
+    --file-output) echo '{"builds":[{"imageName":"web","tag":"web:e2e@sha256:abc"}]}' > "$2"; shift ;;
+    --build-artifacts) cp "$2" ` + record + `; shift ;;
+  esac
+  shift
+done
+`
+	path := filepath.Join(dir, "skaffold")
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	m := New("").WithPath(path)
+
+	artifacts, err := m.Build(context.TODO(), WithPush(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Artifact{{ImageName: "web", Tag: "web:e2e@sha256:abc"}}
+	if !reflect.DeepEqual(artifacts, want) {
+		t.Fatalf("expected artifacts %v, got %v", want, artifacts)
+	}
+
+	if err := m.Deploy(context.TODO(), artifacts); err != nil {
+		t.Fatal(err)
+	}
+	deployed, err := os.ReadFile(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(deployed)); got != `{"builds":[{"imageName":"web","tag":"web:e2e@sha256:abc"}]}` {
+		t.Errorf("unexpected deployed artifacts %s", got)
+	}
+}
+
+func TestManager_RunMissingBinary(t *testing.T) {
+	m := New("").WithPath("skaffold-does-not-exist")
+	if _, err := m.Run(context.TODO(), WithArgs("version")); err == nil || err.Error() != missingSkaffold {
+		t.Errorf("expected missing skaffold error, got %v", err)
+	}
+}
+
+func TestImageFromContext(t *testing.T) {
+	ctx := ctxutil.Store(context.TODO(), artifactsContextKey{}, []Artifact{{ImageName: "web", Tag: "web:e2e"}, {ImageName: "worker", Tag: "worker:e2e"}})
+	if image, ok := ImageFromContext(ctx, "worker"); !ok || image != "worker:e2e" {
+		t.Errorf("expected worker:e2e, got %q (found %t)", image, ok)
+	}
+	if _, ok := ImageFromContext(ctx, "db"); ok {
+		t.Error("expected no image for an unknown image name")
+	}
+	if _, ok := ImageFromContext(context.TODO(), "web"); ok {
+		t.Error("expected no image without artifacts in context")
+	}
+}
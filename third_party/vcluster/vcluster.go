@@ -25,7 +25,7 @@ import (
 	"os"
 	"strings"
 
-	"github.com/vladimirvivien/gexe"
+	"github.com/vladimirvivien/gexe/exec"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
@@ -160,12 +160,7 @@ func (c *Cluster) Create(ctx context.Context, args ...string) (string, error) {
 		command = fmt.Sprintf("%s %s", command, strings.Join(args, " "))
 	}
 	log.V(4).Info("Launching:", command)
-	echo := gexe.New()
-	if c.hostKubeCfg != "" {
-		echo.SetEnv("KUBECONFIG", c.hostKubeCfg)
-	}
-
-	p := echo.RunProc(command)
+	p := c.hostProc(command).Run()
 	if p.Err() != nil {
 		outBytes, err := io.ReadAll(p.Out())
 		if err != nil {
@@ -184,7 +179,7 @@ func (c *Cluster) Create(ctx context.Context, args ...string) (string, error) {
 		return "", err
 	}
 
-	return kConfig, nil
+	return kConfig, c.initKubernetesAccessClients()
 }
 
 func (c *Cluster) CreateWithConfig(ctx context.Context, configFile string) (string, error) {
@@ -226,8 +221,8 @@ func (c *Cluster) Destroy(ctx context.Context) error {
 		return err
 	}
 
-	command := fmt.Sprintf("%s delete %s", c.path, c.name)
-	p := utils.RunCommand(command)
+	command := fmt.Sprintf("%s delete %s%s", c.path, c.name, c.hostArgs())
+	p := c.hostProc(command).Run()
 	if p.Err() != nil {
 		outBytes, err := io.ReadAll(p.Out())
 		if err != nil {
@@ -236,10 +231,39 @@ func (c *Cluster) Destroy(ctx context.Context) error {
 		return fmt.Errorf("vcluster: failed to delete cluster %q: %s: %s: %s", c.name, p.Err(), p.Result(), string(outBytes))
 	}
 
+	return c.Disconnect(ctx)
+}
+
+// Connect regenerates the kubeconfig file used to access the vcluster and reinitializes the rest.Config
+// returned by KubernetesRestConfig. This can be used to reconnect to a vcluster that already exists in the
+// host cluster, e.g. one that was created by a previous test run.
+func (c *Cluster) Connect(ctx context.Context) (string, error) {
+	log.V(4).Info("Connecting to vcluster ", c.name)
+	if err := c.findOrInstallVcluster(); err != nil {
+		return "", err
+	}
+	if _, exists := c.clusterExists(c.name); !exists {
+		return "", fmt.Errorf("vcluster: cluster %q does not exist", c.name)
+	}
+	kConfig, err := c.getKubeconfig()
+	if err != nil {
+		return "", err
+	}
+	return kConfig, c.initKubernetesAccessClients()
+}
+
+// Disconnect removes the kubeconfig file generated to access the vcluster without deleting
+// the vcluster from the host cluster.
+func (c *Cluster) Disconnect(ctx context.Context) error {
+	if c.kubecfgFile == "" {
+		return nil
+	}
 	log.V(4).Info("Removing kubeconfig file ", c.kubecfgFile)
-	if err := os.Remove(c.kubecfgFile); err != nil {
+	if err := os.Remove(c.kubecfgFile); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("vcluster: failed to remove kubeconfig file %q: %w", c.kubecfgFile, err)
 	}
+	c.kubecfgFile = ""
+	c.rc = nil
 	return nil
 }
 
@@ -294,7 +318,7 @@ type clusterItem struct {
 }
 
 func (c *Cluster) clusterExists(name string) (string, bool) {
-	raw := utils.FetchCommandOutput(fmt.Sprintf("%s list --output json", c.path))
+	raw := c.hostProc(fmt.Sprintf("%s list --output json%s", c.path, c.hostArgs())).Run().Result()
 	clusters := []clusterItem{}
 	if err := json.Unmarshal([]byte(raw), &clusters); err != nil {
 		return raw, false
@@ -311,8 +335,10 @@ func (c *Cluster) getKubeconfig() (string, error) {
 	kubecfg := fmt.Sprintf("%s-kubecfg", c.name)
 
 	var stdout, stderr bytes.Buffer
-	err := utils.RunCommandWithSeperatedOutput(fmt.Sprintf(`%s connect %s --print`, c.path, c.name), &stdout, &stderr)
-	if err != nil {
+	p := c.hostProc(fmt.Sprintf(`%s connect %s --print%s`, c.path, c.name, c.hostArgs()))
+	p.SetStdout(&stdout)
+	p.SetStderr(&stderr)
+	if err := p.Run().Err(); err != nil {
 		return "", fmt.Errorf("vcluster connect: stderr: %s: %w", stderr.String(), err)
 	}
 	log.V(4).Info("vcluster connect stderr \n", stderr.String())
@@ -331,6 +357,24 @@ func (c *Cluster) getKubeconfig() (string, error) {
 	return file.Name(), nil
 }
 
+// hostProc returns the process of a command that targets the host cluster configured with WithHostKubeConfig.
+// The KUBECONFIG environment variable is only set for the process, the environment of the tests is left untouched.
+func (c *Cluster) hostProc(command string) *exec.Proc {
+	return utils.NewProcWithKubeconfig(command, c.hostKubeCfg)
+}
+
+// hostArgs returns the arguments used to locate the vcluster within the host cluster
+func (c *Cluster) hostArgs() string {
+	var args string
+	if c.namespace != "" {
+		args += " --namespace " + c.namespace
+	}
+	if c.hostKubeContext != "" {
+		args += " --context " + c.hostKubeContext
+	}
+	return args
+}
+
 func (c *Cluster) initKubernetesAccessClients() error {
 	cfg, err := conf.New(c.kubecfgFile)
 	if err != nil {
@@ -1,6 +1,11 @@
 package vcluster
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
 
 func TestCluster_VclusterInstall(t *testing.T) {
 	c := Cluster{}
@@ -9,3 +14,19 @@ func TestCluster_VclusterInstall(t *testing.T) {
 		t.Fatalf("Error installing vcluster: %v", err)
 	}
 }
+
+func TestCluster_HostProc(t *testing.T) {
+	hostKubeCfg := filepath.Join(t.TempDir(), "host-kubeconfig")
+	t.Setenv("KUBECONFIG", "/home/user/.kube/config")
+	c := Cluster{hostKubeCfg: hostKubeCfg}
+	p := c.hostProc("env").Run()
+	if p.Err() != nil {
+		t.Fatal(p.Err())
+	}
+	if !strings.Contains(p.Result(), "KUBECONFIG="+hostKubeCfg) {
+		t.Errorf("expected the command to use the host kubeconfig, got environment:\n%s", p.Result())
+	}
+	if got := os.Getenv("KUBECONFIG"); got != "/home/user/.kube/config" {
+		t.Errorf("expected the KUBECONFIG of the test process to be left untouched, got %q", got)
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package velero
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+var (
+	BackupGVK  = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Backup"}
+	RestoreGVK = schema.GroupVersionKind{Group: "velero.io", Version: "v1", Kind: "Restore"}
+)
+
+// SpecOption customizes the spec of the Backup or Restore it is passed to
+type SpecOption func(spec map[string]interface{})
+
+// WithIncludedNamespaces restricts the Backup or Restore to the given
+// namespaces
+func WithIncludedNamespaces(namespaces ...string) SpecOption {
+	return func(spec map[string]interface{}) {
+		spec["includedNamespaces"] = toSlice(namespaces)
+	}
+}
+
+// WithExcludedResources excludes the given resources, such as events, from
+// the Backup or Restore
+func WithExcludedResources(resources ...string) SpecOption {
+	return func(spec map[string]interface{}) {
+		spec["excludedResources"] = toSlice(resources)
+	}
+}
+
+// WithLabelSelector restricts the Backup or Restore to the objects with the
+// given labels
+func WithLabelSelector(labels map[string]string) SpecOption {
+	return func(spec map[string]interface{}) {
+		matchLabels := map[string]interface{}{}
+		for key, value := range labels {
+			matchLabels[key] = value
+		}
+		spec["labelSelector"] = map[string]interface{}{"matchLabels": matchLabels}
+	}
+}
+
+// WithTTL sets the time after which Velero garbage collects a Backup
+func WithTTL(ttl time.Duration) SpecOption {
+	return func(spec map[string]interface{}) {
+		spec["ttl"] = ttl.String()
+	}
+}
+
+// WithDefaultVolumesToFsBackup makes a Backup copy the content of the
+// volumes of the pods with the node agent, which must be installed with
+// WithNodeAgent
+func WithDefaultVolumesToFsBackup() SpecOption {
+	return func(spec map[string]interface{}) {
+		spec["defaultVolumesToFsBackup"] = true
+	}
+}
+
+// WithNamespaceMapping makes a Restore recreate the objects of a namespace
+// of the backup in another namespace, which allows restoring next to the
+// original objects
+func WithNamespaceMapping(mapping map[string]string) SpecOption {
+	return func(spec map[string]interface{}) {
+		namespaces := map[string]interface{}{}
+		for from, to := range mapping {
+			namespaces[from] = to
+		}
+		spec["namespaceMapping"] = namespaces
+	}
+}
+
+// Backup returns a Backup, in the Velero namespace, usually
+// DefaultNamespace, of the objects selected by the options, or of the whole
+// cluster without options. Volume snapshots are disabled, as MinIO does not
+// provide any.
+func Backup(name, namespace string, opts ...SpecOption) *unstructured.Unstructured {
+	spec := map[string]interface{}{"snapshotVolumes": false}
+	return newObject(BackupGVK, name, namespace, spec, opts)
+}
+
+// Restore returns a Restore, in the Velero namespace, usually
+// DefaultNamespace, of the objects of the Backup identified by backupName
+// selected by the options
+func Restore(name, namespace, backupName string, opts ...SpecOption) *unstructured.Unstructured {
+	spec := map[string]interface{}{"backupName": backupName}
+	return newObject(RestoreGVK, name, namespace, spec, opts)
+}
+
+func newObject(gvk schema.GroupVersionKind, name, namespace string, spec map[string]interface{}, opts []SpecOption) *unstructured.Unstructured {
+	for _, opt := range opts {
+		opt(spec)
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return obj
+}
+
+func toSlice(values []string) []interface{} {
+	items := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		items = append(items, value)
+	}
+	return items
+}
+
+// Create returns an env.Func that creates the given Backups and Restores in
+// order. Velero starts processing them as soon as they are created.
+func Create(objs ...*unstructured.Unstructured) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		for _, obj := range objs {
+			if err := client.Resources().Create(ctx, obj.DeepCopy()); err != nil {
+				return ctx, fmt.Errorf("creation of %s %s failed: %w", obj.GetKind(), obj.GetName(), err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// Delete returns an env.Func that deletes the given Backups and Restores.
+// Deleting a Backup object does not delete its data from the object store,
+// use `velero backup delete` through Manager.Run for that. Objects that do
+// not exist are ignored.
+func Delete(objs ...*unstructured.Unstructured) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		for _, obj := range objs {
+			if err := client.Resources().Delete(ctx, obj.DeepCopy()); err != nil && !apierrors.IsNotFound(err) {
+				return ctx, fmt.Errorf("deletion of %s %s failed: %w", obj.GetKind(), obj.GetName(), err)
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// InstallVelero returns an env.Func that installs the Velero server with the
+// MinIO object store deployed by InstallMinIO as backup storage location
+func InstallVelero(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := New(c.KubeconfigFile()).Install(ctx, opts...); err != nil {
+			return ctx, fmt.Errorf("installation of velero failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
+
+// UninstallVelero returns an env.Func that removes the Velero server, its
+// custom resources and its namespace
+func UninstallVelero(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		if err := New(c.KubeconfigFile()).Uninstall(ctx, opts...); err != nil {
+			return ctx, fmt.Errorf("uninstallation of velero failed: %w", err)
+		}
+		return ctx, nil
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package velero
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s"
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/klient/wait/conditions"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+const (
+	// MinIOImage is the image of the MinIO server deployed by InstallMinIO
+	MinIOImage = "quay.io/minio/minio:RELEASE.2024-12-18T13-15-44Z"
+	// MinIOClientImage is the image of the MinIO client used to create the
+	// bucket of the backup storage location
+	MinIOClientImage = "quay.io/minio/mc:RELEASE.2024-11-21T17-21-54Z"
+
+	minioName      = "minio"
+	minioSetupName = "minio-setup"
+)
+
+// minioObjects returns the objects of a single replica MinIO server storing
+// its data in an emptyDir, and of the job creating the Velero bucket
+func minioObjects(namespace string) []k8s.Object {
+	labels := map[string]string{"app.kubernetes.io/name": minioName}
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}
+	}
+	credentials := []corev1.EnvVar{
+		{Name: "MINIO_ROOT_USER", Value: minioAccessKey},
+		{Name: "MINIO_ROOT_PASSWORD", Value: minioSecretKey},
+	}
+	backoffLimit := int32(10)
+	return []k8s.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+		&appsv1.Deployment{
+			ObjectMeta: meta(minioName),
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  minioName,
+							Image: MinIOImage,
+							Args:  []string{"server", "/data"},
+							Env:   credentials,
+							Ports: []corev1.ContainerPort{{Name: "s3", ContainerPort: 9000}},
+							ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/minio/health/ready", Port: intstr.FromInt32(9000)},
+							}},
+							VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}},
+						}},
+						Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+					},
+				},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: meta(minioName),
+			Spec: corev1.ServiceSpec{
+				Selector: labels,
+				Ports:    []corev1.ServicePort{{Name: "s3", Port: 9000, TargetPort: intstr.FromString("s3")}},
+			},
+		},
+		&batchv1.Job{
+			ObjectMeta: meta(minioSetupName),
+			Spec: batchv1.JobSpec{
+				BackoffLimit: &backoffLimit,
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						RestartPolicy: corev1.RestartPolicyOnFailure,
+						Containers: []corev1.Container{{
+							Name:    "mc",
+							Image:   MinIOClientImage,
+							Command: []string{"/bin/sh", "-c"},
+							Args: []string{fmt.Sprintf("mc alias set velero http://%s:9000 \"$MINIO_ROOT_USER\" \"$MINIO_ROOT_PASSWORD\" && mc mb --ignore-existing velero/%s",
+								minioName, DefaultBucket)},
+							Env: credentials,
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// InstallMinIO returns an env.Func that deploys a MinIO object store in the
+// Velero namespace, selected with WithNamespace, and creates the bucket used
+// by InstallVelero. The data is kept in an emptyDir, which makes the store
+// suitable for tests only. Objects that already exist are left untouched.
+func InstallMinIO(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		o := (&Manager{}).processOpts(opts...)
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		r := client.Resources()
+
+		log.V(4).InfoS("Installing MinIO", "namespace", o.Namespace)
+		for _, obj := range minioObjects(o.Namespace) {
+			if err := r.Create(ctx, obj); err != nil && !apierrors.IsAlreadyExists(err) {
+				return ctx, fmt.Errorf("installation of minio failed: %w", err)
+			}
+		}
+		cond := conditions.New(r)
+		if err := wait.For(cond.DeploymentAvailable(minioName, o.Namespace), wait.WithContext(ctx), wait.WithTimeout(DefaultTimeout)); err != nil {
+			return ctx, fmt.Errorf("minio did not become available: %w", err)
+		}
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: minioSetupName, Namespace: o.Namespace}}
+		if err := wait.For(cond.JobCompleted(job), wait.WithContext(ctx), wait.WithTimeout(DefaultTimeout)); err != nil {
+			return ctx, fmt.Errorf("minio bucket %s was not created: %w", DefaultBucket, err)
+		}
+		return ctx, nil
+	}
+}
+
+// UninstallMinIO returns an env.Func that deletes the MinIO object store
+// deployed by InstallMinIO, along with the backups it stores. The namespace
+// is left in place, as it is shared with Velero.
+func UninstallMinIO(opts ...Option) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		o := (&Manager{}).processOpts(opts...)
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		for _, obj := range minioObjects(o.Namespace)[1:] {
+			err := client.Resources().Delete(ctx, obj, resources.WithDeletePropagation(string(metav1.DeletePropagationBackground)))
+			if err != nil && !apierrors.IsNotFound(err) {
+				return ctx, fmt.Errorf("uninstallation of minio failed: %w", err)
+			}
+		}
+		return ctx, nil
+	}
+}
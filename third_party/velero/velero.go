@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package velero provides a manager for the velero command line, env funcs
+// to install Velero backed by a MinIO object store running in the cluster,
+// and helpers to create Backups and Restores and to wait for them to
+// complete.
+package velero
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "k8s.io/klog/v2"
+)
+
+const (
+	// DefaultNamespace is the namespace Velero and MinIO are installed into
+	DefaultNamespace = "velero"
+	// DefaultBucket is the MinIO bucket created by InstallMinIO and used by
+	// Velero as its backup storage location
+	DefaultBucket = "velero"
+	// DefaultAWSPlugin is the object store plugin used to talk to MinIO
+	DefaultAWSPlugin = "velero/velero-plugin-for-aws:v1.11.0"
+	// DefaultTimeout is the time InstallMinIO waits for the object store to
+	// be ready
+	DefaultTimeout = 5 * time.Minute
+
+	// credentials of the MinIO deployment of the Velero examples
+	minioAccessKey = "minio"
+	minioSecretKey = "minio123"
+
+	missingVelero = "'velero' command is missing. Please ensure the tool exists before using the velero manager"
+)
+
+type Opts struct {
+	// Namespace is the namespace Velero is installed into
+	Namespace string
+	// Plugin is the object store plugin image installed with Velero
+	Plugin string
+	// Image overrides the Velero server image, which defaults to the
+	// version of the velero binary
+	Image string
+	// NodeAgent deploys the node agent used to back up the content of
+	// volumes with the file system backup
+	NodeAgent bool
+	// Args is used to pass any additional arguments to the velero command
+	Args []string
+	// mode is the velero sub command being run
+	mode []string
+}
+
+type Manager struct {
+	kubeConfig string
+	path       string
+}
+
+type Option func(*Opts)
+
+// WithNamespace is used to configure the namespace Velero is installed into
+func WithNamespace(namespace string) Option {
+	return func(opts *Opts) {
+		opts.Namespace = namespace
+	}
+}
+
+// WithPlugin is used to configure the object store plugin image installed
+// with Velero
+func WithPlugin(image string) Option {
+	return func(opts *Opts) {
+		opts.Plugin = image
+	}
+}
+
+// WithImage is used to configure the Velero server image, for instance to
+// pin the version of Velero under test
+func WithImage(image string) Option {
+	return func(opts *Opts) {
+		opts.Image = image
+	}
+}
+
+// WithNodeAgent is used to deploy the node agent, which is required to back
+// up the content of volumes without volume snapshots
+func WithNodeAgent() Option {
+	return func(opts *Opts) {
+		opts.NodeAgent = true
+	}
+}
+
+// WithArgs is used to pass any additional arguments to the velero command.
+// Each argument is passed as is without any shell processing.
+func WithArgs(args ...string) Option {
+	return func(opts *Opts) {
+		opts.Args = append(opts.Args, args...)
+	}
+}
+
+// processOpts is used to generate the Opts resource that will be used to
+// generate the actual velero command to be run using the getArgs helper
+func (m *Manager) processOpts(opts ...Option) *Opts {
+	option := &Opts{Namespace: DefaultNamespace, Plugin: DefaultAWSPlugin}
+	for _, op := range opts {
+		op(option)
+	}
+	return option
+}
+
+// getArgs is used to convert the Opts into the arguments passed to velero
+func (m *Manager) getArgs(opt *Opts) []string {
+	args := append([]string{}, opt.mode...)
+	args = append(args, opt.Args...)
+	if opt.Namespace != "" {
+		args = append(args, "--namespace", opt.Namespace)
+	}
+	if m.kubeConfig != "" {
+		args = append(args, "--kubeconfig", m.kubeConfig)
+	}
+	return args
+}
+
+// installArgs returns the `velero install` arguments configuring the MinIO
+// service of the namespace as backup storage location, with the credentials
+// stored in secretFile
+func installArgs(opt *Opts, secretFile string) []string {
+	args := []string{
+		"install",
+		"--provider", "aws",
+		"--plugins", opt.Plugin,
+		"--bucket", DefaultBucket,
+		"--secret-file", secretFile,
+		"--use-volume-snapshots=false",
+		"--backup-location-config", fmt.Sprintf("region=minio,s3ForcePathStyle=true,s3Url=http://minio.%s.svc:9000", opt.Namespace),
+		"--wait",
+	}
+	if opt.Image != "" {
+		args = append(args, "--image", opt.Image)
+	}
+	if opt.NodeAgent {
+		args = append(args, "--use-node-agent")
+	}
+	return args
+}
+
+// Install installs the Velero server with `velero install`, using the MinIO
+// object store deployed by InstallMinIO in the same namespace as backup
+// storage location. The command waits for the Velero server to be ready.
+func (m *Manager) Install(ctx context.Context, opts ...Option) error {
+	o := m.processOpts(opts...)
+	dir, err := os.MkdirTemp("", "velero")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	secretFile := filepath.Join(dir, "credentials")
+	credentials := fmt.Sprintf("[default]\naws_access_key_id = %s\naws_secret_access_key = %s\n", minioAccessKey, minioSecretKey)
+	if err := os.WriteFile(secretFile, []byte(credentials), 0o600); err != nil {
+		return err
+	}
+	o.mode = installArgs(o, secretFile)
+	_, err = m.run(ctx, o)
+	return err
+}
+
+// Uninstall removes the Velero server, its custom resources and its
+// namespace with `velero uninstall`
+func (m *Manager) Uninstall(ctx context.Context, opts ...Option) error {
+	o := m.processOpts(opts...)
+	o.mode = []string{"uninstall", "--force"}
+	_, err := m.run(ctx, o)
+	return err
+}
+
+// Run invokes velero with the arguments configured with WithArgs and returns
+// its standard output, e.g. to describe a backup with
+// WithArgs("backup", "describe", name, "--details")
+func (m *Manager) Run(ctx context.Context, opts ...Option) (string, error) {
+	return m.run(ctx, m.processOpts(opts...))
+}
+
+// run method is used to invoke a velero command to perform a suitable
+// operation. Please make sure to configure the right Opts using the Option
+// helpers
+func (m *Manager) run(ctx context.Context, opts *Opts) (string, error) {
+	log.V(4).InfoS("Determining if velero binary is available or not", "executable", m.path)
+	executable, err := exec.LookPath(m.path)
+	if err != nil {
+		return "", errors.New(missingVelero)
+	}
+	if len(opts.mode) == 0 && len(opts.Args) == 0 {
+		return "", errors.New("missing velero sub command. Please use the WithArgs option while invoking the run")
+	}
+	args := m.getArgs(opts)
+
+	log.V(4).InfoS("Running Velero Operation", "command", m.path+" "+strings.Join(args, " "))
+	cmd := exec.CommandContext(ctx, executable, args...)
+
+	var stderr bytes.Buffer
+	var stdout bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdout = &stdout
+
+	err = cmd.Run()
+	log.V(4).Info("Velero Command output \n", stdout.String())
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSuffix(stderr.String(), "\n"), err)
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+// WithPath is used to provide a custom path where the `velero` executable
+// command can be found. This is useful in case if your binary is in a non
+// standard location and you want to framework to use that instead of
+// returning an error.
+func (m *Manager) WithPath(path string) *Manager {
+	m.path = path
+	return m
+}
+
+// New creates a velero Manager that runs all commands against the cluster
+// identified by the kubeConfig file.
+func New(kubeConfig string) *Manager {
+	return &Manager{
+		kubeConfig: kubeConfig,
+		path:       "velero",
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package velero
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestInstallArgs(t *testing.T) {
+	m := New("/tmp/kubeconfig")
+	o := m.processOpts(WithNamespace("backup"), WithImage("velero/velero:v1.15.0"), WithNodeAgent())
+	o.mode = installArgs(o, "/tmp/credentials")
+	want := []string{
+		"install", "--provider", "aws", "--plugins", DefaultAWSPlugin, "--bucket", DefaultBucket,
+		"--secret-file", "/tmp/credentials", "--use-volume-snapshots=false",
+		"--backup-location-config", "region=minio,s3ForcePathStyle=true,s3Url=http://minio.backup.svc:9000",
+		"--wait", "--image", "velero/velero:v1.15.0", "--use-node-agent",
+		"--namespace", "backup", "--kubeconfig", "/tmp/kubeconfig",
+	}
+	if got := m.getArgs(o); !reflect.DeepEqual(got, want) {
+		t.Errorf("getArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestMinIOObjects(t *testing.T) {
+	objs := minioObjects("backup")
+	if len(objs) != 4 || objs[0].GetName() != "backup" {
+		t.Fatalf("unexpected objects %v", objs)
+	}
+	for _, obj := range objs[1:] {
+		if obj.GetNamespace() != "backup" {
+			t.Errorf("expected %s to be in the backup namespace, got %q", obj.GetName(), obj.GetNamespace())
+		}
+	}
+	if _, ok := objs[1].(*appsv1.Deployment); !ok {
+		t.Errorf("expected a deployment, got %T", objs[1])
+	}
+	job, ok := objs[3].(*batchv1.Job)
+	if !ok {
+		t.Fatalf("expected a job, got %T", objs[3])
+	}
+	if args := job.Spec.Template.Spec.Containers[0].Args; len(args) != 1 || !strings.Contains(args[0], "mc mb --ignore-existing velero/"+DefaultBucket) {
+		t.Errorf("unexpected bucket creation command %v", args)
+	}
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  *unstructured.Unstructured
+		gvk  string
+		spec map[string]interface{}
+	}{
+		{
+			name: "cluster backup",
+			obj:  Backup("all", DefaultNamespace),
+			gvk:  "velero.io/v1, Kind=Backup",
+			spec: map[string]interface{}{"snapshotVolumes": false},
+		},
+		{
+			name: "namespace backup",
+			obj:  Backup("apps", DefaultNamespace, WithIncludedNamespaces("apps"), WithExcludedResources("events"), WithTTL(time.Hour), WithDefaultVolumesToFsBackup()),
+			gvk:  "velero.io/v1, Kind=Backup",
+			spec: map[string]interface{}{
+				"snapshotVolumes":          false,
+				"includedNamespaces":       []interface{}{"apps"},
+				"excludedResources":        []interface{}{"events"},
+				"ttl":                      "1h0m0s",
+				"defaultVolumesToFsBackup": true,
+			},
+		},
+		{
+			name: "restore",
+			obj:  Restore("apps", DefaultNamespace, "apps", WithNamespaceMapping(map[string]string{"apps": "apps-restored"}), WithLabelSelector(map[string]string{"app": "web"})),
+			gvk:  "velero.io/v1, Kind=Restore",
+			spec: map[string]interface{}{
+				"backupName":       "apps",
+				"namespaceMapping": map[string]interface{}{"apps": "apps-restored"},
+				"labelSelector":    map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.obj.GroupVersionKind().String(); got != tc.gvk {
+				t.Errorf("expected %s, got %s", tc.gvk, got)
+			}
+			if tc.obj.GetNamespace() != DefaultNamespace {
+				t.Errorf("expected namespace %s, got %s", DefaultNamespace, tc.obj.GetNamespace())
+			}
+			if !reflect.DeepEqual(tc.obj.Object["spec"], tc.spec) {
+				t.Errorf("expected spec %v, got %v", tc.spec, tc.obj.Object["spec"])
+			}
+		})
+	}
+}
+
+func TestCheckPhase(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  map[string]interface{}
+		done    bool
+		wantErr string
+	}{
+		{name: "no status"},
+		{name: "in progress", status: map[string]interface{}{"phase": PhaseInProgress}},
+		{name: "completed", status: map[string]interface{}{"phase": PhaseCompleted}, done: true},
+		{name: "partially failed", status: map[string]interface{}{"phase": PhasePartiallyFailed, "errors": int64(2)}, wantErr: "Backup apps is PartiallyFailed with 2 errors"},
+		{name: "failed", status: map[string]interface{}{"phase": PhaseFailed, "failureReason": "bucket not found"}, wantErr: "Backup apps is Failed: bucket not found"},
+		{name: "failed validation", status: map[string]interface{}{"phase": PhaseFailedValidation, "validationErrors": []interface{}{"invalid ttl"}}, wantErr: "Backup apps is FailedValidation: invalid ttl"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			backup := Backup("apps", DefaultNamespace)
+			if tc.status != nil {
+				backup.Object["status"] = tc.status
+			}
+			done, err := checkPhase(backup)
+			if done != tc.done {
+				t.Errorf("expected done to be %t, got %t", tc.done, done)
+			}
+			if tc.wantErr == "" && err != nil || tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr) {
+				t.Errorf("expected error %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
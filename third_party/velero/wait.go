@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package velero
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinerywait "k8s.io/apimachinery/pkg/util/wait"
+	log "k8s.io/klog/v2"
+
+	"sigs.k8s.io/e2e-framework/klient/k8s/resources"
+	"sigs.k8s.io/e2e-framework/klient/wait"
+	"sigs.k8s.io/e2e-framework/pkg/env"
+	"sigs.k8s.io/e2e-framework/pkg/envconf"
+)
+
+// Phases reported by Velero for Backups and Restores
+const (
+	PhaseNew              = "New"
+	PhaseInProgress       = "InProgress"
+	PhaseCompleted        = "Completed"
+	PhasePartiallyFailed  = "PartiallyFailed"
+	PhaseFailed           = "Failed"
+	PhaseFailedValidation = "FailedValidation"
+)
+
+// BackupCompleted returns a condition that is met once the Backup identified
+// by name and namespace has completed. It returns an error as soon as the
+// Backup reaches a failed phase, which aborts the wait.
+func BackupCompleted(r *resources.Resources, name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return phaseCompleted(r, BackupGVK, name, namespace)
+}
+
+// RestoreCompleted returns a condition that is met once the Restore
+// identified by name and namespace has completed. It returns an error as
+// soon as the Restore reaches a failed phase, which aborts the wait.
+func RestoreCompleted(r *resources.Resources, name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return phaseCompleted(r, RestoreGVK, name, namespace)
+}
+
+// WaitForBackupCompleted returns an env.Func that blocks until the Backup
+// identified by name and namespace has completed. The wait is aborted with
+// an error once the timeout expires or the Backup fails.
+func WaitForBackupCompleted(name, namespace string, timeout time.Duration) env.Func {
+	return waitForCompleted(BackupGVK, name, namespace, timeout)
+}
+
+// WaitForRestoreCompleted returns an env.Func that blocks until the Restore
+// identified by name and namespace has completed. The wait is aborted with
+// an error once the timeout expires or the Restore fails.
+func WaitForRestoreCompleted(name, namespace string, timeout time.Duration) env.Func {
+	return waitForCompleted(RestoreGVK, name, namespace, timeout)
+}
+
+func waitForCompleted(gvk schema.GroupVersionKind, name, namespace string, timeout time.Duration) env.Func {
+	return func(ctx context.Context, c *envconf.Config) (context.Context, error) {
+		client, err := c.NewClient()
+		if err != nil {
+			return ctx, err
+		}
+		err = wait.For(phaseCompleted(client.Resources(), gvk, name, namespace), wait.WithContext(ctx), wait.WithTimeout(timeout), wait.WithImmediate())
+		if err != nil {
+			return ctx, fmt.Errorf("%s %s did not complete: %w", gvk.Kind, name, err)
+		}
+		return ctx, nil
+	}
+}
+
+func phaseCompleted(r *resources.Resources, gvk schema.GroupVersionKind, name, namespace string) apimachinerywait.ConditionWithContextFunc {
+	return func(ctx context.Context) (done bool, err error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		if err := r.Get(ctx, name, namespace, obj); err != nil {
+			return false, nil
+		}
+		return checkPhase(obj)
+	}
+}
+
+// checkPhase reports whether the Backup or Restore has completed, and
+// returns an error describing the failure when it has reached a failed phase
+func checkPhase(obj *unstructured.Unstructured) (bool, error) {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	log.V(4).InfoS("Velero object phase", "kind", obj.GetKind(), "name", obj.GetName(), "phase", phase)
+	switch phase {
+	case PhaseCompleted:
+		return true, nil
+	case PhasePartiallyFailed, PhaseFailed, PhaseFailedValidation:
+		errs, _, _ := unstructured.NestedInt64(obj.Object, "status", "errors")
+		reason, _, _ := unstructured.NestedString(obj.Object, "status", "failureReason")
+		validationErrors, _, _ := unstructured.NestedStringSlice(obj.Object, "status", "validationErrors")
+		msg := fmt.Sprintf("%s %s is %s", obj.GetKind(), obj.GetName(), phase)
+		if errs > 0 {
+			msg += fmt.Sprintf(" with %d errors", errs)
+		}
+		if reason != "" {
+			msg += ": " + reason
+		}
+		if len(validationErrors) > 0 {
+			msg += ": " + strings.Join(validationErrors, "; ")
+		}
+		return false, errors.New(msg)
+	default:
+		return false, nil
+	}
+}